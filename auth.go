@@ -79,18 +79,27 @@ func New(baseConfig *models.Config) *Auth {
 
 	apiKey := os.Getenv("GO_BETTER_AUTH_ADMIN_API_KEY")
 	adminAuth := func() func(http.Handler) http.Handler {
-		return middleware.AdminAuth(apiKey)
+		return middleware.AdminAuth(apiKey, auth.Config, auth.Service)
+	}
+	clientAuth := func() func(http.Handler) http.Handler {
+		return middleware.ClientAuth(apiKey, auth.Service)
 	}
 	apiMiddleware := &models.ApiMiddleware{
 		// Admin
-		AdminAuth: adminAuth,
+		AdminAuth:  adminAuth,
+		ClientAuth: clientAuth,
 		// Auth
 		Auth:          auth.AuthMiddleware,
 		OptionalAuth:  auth.OptionalAuthMiddleware,
 		CorsAuth:      auth.CorsAuthMiddleware,
 		CSRF:          auth.CSRFMiddleware,
+		ForwardAuth:   auth.ForwardAuthMiddleware,
 		RateLimit:     auth.RateLimitMiddleware,
 		EndpointHooks: auth.EndpointHooksMiddleware,
+		// RBAC
+		RequirePermission: auth.RequirePermissionMiddleware,
+		// Organizations
+		RequireOrganizationMember: auth.RequireOrganizationMemberMiddleware,
 	}
 	auth.middleware = apiMiddleware
 
@@ -136,6 +145,9 @@ func (auth *Auth) watchForConfigChanges() {
 			*auth.Config = *updatedConfig
 			auth.logger.Debug("Configuration updated via watcher")
 
+			// Providers are only built from the config at startup and on change
+			auth.Service.OAuth2ProviderRegistry.RefreshOAuth2Providers()
+
 			if restartRequired {
 				auth.logger.Info("Configuration change requires server restart")
 				if auth.OnRestartRequired != nil {
@@ -167,8 +179,21 @@ func (auth *Auth) RunMigrations() {
 func (auth *Auth) DropMigrations() {
 	models := []any{
 		// Auth
+		&models.Invitation{},
+		&models.Member{},
+		&models.Organization{},
+		&models.UserRole{},
+		&models.RolePermission{},
+		&models.Permission{},
+		&models.Role{},
 		&models.KeyValueStore{},
+		&models.OAuthConsent{},
+		&models.OAuthClient{},
+		&models.SigningKey{},
 		&models.Verification{},
+		&models.Passkey{},
+		&models.TwoFactorBackupCode{},
+		&models.TwoFactor{},
 		&models.Session{},
 		&models.Account{},
 		&models.User{},
@@ -203,15 +228,15 @@ func (auth *Auth) DropMigrations() {
 
 func (auth *Auth) AuthMiddleware() func(http.Handler) http.Handler {
 	return middleware.AuthMiddleware(
+		auth.Config,
 		auth.Service,
-		auth.Config.Session.CookieName,
 	)
 }
 
 func (auth *Auth) OptionalAuthMiddleware() func(http.Handler) http.Handler {
 	return middleware.OptionalAuthMiddleware(
+		auth.Config,
 		auth.Service,
-		auth.Config.Session.CookieName,
 	)
 }
 
@@ -222,7 +247,7 @@ func (auth *Auth) CorsAuthMiddleware() func(http.Handler) http.Handler {
 }
 
 func (auth *Auth) CSRFMiddleware() func(http.Handler) http.Handler {
-	return middleware.CSRFMiddleware(auth.Config.CSRF)
+	return middleware.CSRFMiddleware(auth.Config)
 }
 
 func (auth *Auth) RateLimitMiddleware() func(http.Handler) http.Handler {
@@ -233,8 +258,20 @@ func (auth *Auth) EndpointHooksMiddleware() func(http.Handler) http.Handler {
 	return middleware.EndpointHooksMiddleware(auth.Config, auth.Service)
 }
 
+func (auth *Auth) ForwardAuthMiddleware() func(http.Handler) http.Handler {
+	return middleware.ForwardAuthMiddleware(auth.Config, auth.Service)
+}
+
+func (auth *Auth) RequirePermissionMiddleware(permission string) func(http.Handler) http.Handler {
+	return middleware.RequirePermission(auth.Config, auth.Service, permission)
+}
+
+func (auth *Auth) RequireOrganizationMemberMiddleware(roles ...models.OrganizationRole) func(http.Handler) http.Handler {
+	return middleware.RequireOrganizationMember(auth.Config, auth.Service, roles...)
+}
+
 func (auth *Auth) RedirectAuthMiddleware(redirectURL string, status int) func(http.Handler) http.Handler {
-	return middleware.RedirectAuthMiddleware(auth.Service, auth.Config.Session.CookieName, redirectURL, status)
+	return middleware.RedirectAuthMiddleware(auth.Config, auth.Service, redirectURL, status)
 }
 
 func (auth *Auth) GetUserIDFromContext(ctx context.Context) (string, bool) {
@@ -251,6 +288,13 @@ func (auth *Auth) GetUserIDFromRequest(req *http.Request) (string, bool) {
 	return auth.GetUserIDFromContext(req.Context())
 }
 
+// GetOrganizationIDFromContext returns the organization a request was authorized for by the
+// RequireOrganizationMember middleware.
+func (auth *Auth) GetOrganizationIDFromContext(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(middleware.ContextOrganizationID).(string)
+	return id, ok && id != ""
+}
+
 func (auth *Auth) RegisterRoute(route models.CustomRoute) {
 	originalHandler := route.Handler
 	route.Handler = func(config *models.Config) http.Handler {
@@ -348,6 +392,16 @@ func (auth *Auth) registerPluginRoutes(basePath string) {
 	}
 }
 
+// Close stops the background jobs of the services, such as the periodic purge of expired sessions.
+// Call it on shutdown, and before a new instance replaces this one on a restart.
+func (auth *Auth) Close() error {
+	if auth.Service == nil {
+		return nil
+	}
+
+	return auth.Service.Close()
+}
+
 // ClosePlugins calls Close for all registered plugins
 func (auth *Auth) ClosePlugins() error {
 	if auth.pluginRegistry == nil {
@@ -143,8 +143,21 @@ func RunCoreMigrations(db *gorm.DB) {
 		&models.User{},
 		&models.Account{},
 		&models.Session{},
+		&models.TwoFactor{},
+		&models.TwoFactorBackupCode{},
+		&models.Passkey{},
 		&models.Verification{},
 		&models.KeyValueStore{},
+		&models.SigningKey{},
+		&models.OAuthClient{},
+		&models.OAuthConsent{},
+		&models.Role{},
+		&models.Permission{},
+		&models.RolePermission{},
+		&models.UserRole{},
+		&models.Organization{},
+		&models.Member{},
+		&models.Invitation{},
 	}
 
 	// Auto-migrate core models
@@ -262,11 +275,19 @@ func InitServices(config *models.Config, configManager models.ConfigManager, eve
 	userService := services.NewUserServiceImpl(config, config.DB)
 	accountService := services.NewAccountServiceImpl(config, config.DB)
 	sessionService := services.NewSessionServiceImpl(config, config.DB)
+	sessionService.StartCleanup()
+	twoFactorService := services.NewTwoFactorServiceImpl(config, config.DB)
+	passkeyService := services.NewPasskeyServiceImpl(config, config.DB)
+	emailOTPService := services.NewEmailOTPServiceImpl(config)
 	verificationService := services.NewVerificationServiceImpl(config, config.DB)
 	passwordService := services.NewArgon2PasswordService()
-	tokenService := services.NewTokenServiceImpl(config)
+	signingKeyService := services.NewSigningKeyServiceImpl(config, config.DB)
+	tokenService := services.NewTokenServiceImpl(config, signingKeyService)
+	oidcProviderService := services.NewOIDCProviderServiceImpl(config, config.DB)
 	rateLimitService := services.NewRateLimitServiceImpl(config, config.Logger.Logger, pluginRateLimits)
 	mailerService := services.NewSMTPMailerService(config)
+	rbacService := services.NewRBACServiceImpl(config, config.DB)
+	organizationService := services.NewOrganizationServiceImpl(config, config.DB)
 	webhookExecutor := internalevents.NewWebhookExecutor(config.Logger.Logger)
 	eventEmitter := internalevents.NewEventEmitter(config, config.Logger.Logger, eventBus, webhookExecutor)
 	oauth2ProviderRegistry := oauth2providers.NewOAuth2ProviderRegistry(config)
@@ -279,11 +300,18 @@ func InitServices(config *models.Config, configManager models.ConfigManager, eve
 		userService,
 		accountService,
 		sessionService,
+		twoFactorService,
+		passkeyService,
+		emailOTPService,
 		verificationService,
 		passwordService,
 		tokenService,
+		signingKeyService,
+		oidcProviderService,
 		rateLimitService,
 		mailerService,
+		rbacService,
+		organizationService,
 		oauth2ProviderRegistry,
 	)
 
@@ -86,10 +86,20 @@ func runServer(port string, restartChan chan struct{}, shutdownChan chan os.Sign
 		gobetterauthconfig.WithSecondaryStorage(tomlConfig.SecondaryStorage),
 		gobetterauthconfig.WithEmailPassword(tomlConfig.EmailPassword),
 		gobetterauthconfig.WithEmailVerification(tomlConfig.EmailVerification),
+		gobetterauthconfig.WithMagicLink(tomlConfig.MagicLink),
+		gobetterauthconfig.WithEmailOTP(tomlConfig.EmailOTP),
 		gobetterauthconfig.WithUser(tomlConfig.User),
 		gobetterauthconfig.WithSession(tomlConfig.Session),
+		gobetterauthconfig.WithTwoFactor(tomlConfig.TwoFactor),
+		gobetterauthconfig.WithPasskey(tomlConfig.Passkey),
+		gobetterauthconfig.WithJWT(tomlConfig.JWT),
+		gobetterauthconfig.WithOIDCProvider(tomlConfig.OIDCProvider),
+		gobetterauthconfig.WithForwardAuth(tomlConfig.ForwardAuth),
 		gobetterauthconfig.WithCSRF(tomlConfig.CSRF),
+		gobetterauthconfig.WithRBAC(tomlConfig.RBAC),
+		gobetterauthconfig.WithOrganization(tomlConfig.Organization),
 		gobetterauthconfig.WithSocialProviders(tomlConfig.SocialProviders),
+		gobetterauthconfig.WithAccountLinking(tomlConfig.AccountLinking),
 		gobetterauthconfig.WithTrustedOrigins(tomlConfig.TrustedOrigins),
 		gobetterauthconfig.WithRateLimit(tomlConfig.RateLimit),
 		gobetterauthconfig.WithEventBus(tomlConfig.EventBus),
@@ -155,6 +165,9 @@ func runServer(port string, restartChan chan struct{}, shutdownChan chan os.Sign
 		if err := auth.ClosePlugins(); err != nil {
 			logger.Error("Failed to close plugins", "error", err)
 		}
+		if err := auth.Close(); err != nil {
+			logger.Error("Failed to close services", "error", err)
+		}
 		return nil
 
 	case sig := <-shutdownChan:
@@ -167,6 +180,9 @@ func runServer(port string, restartChan chan struct{}, shutdownChan chan os.Sign
 		if err := auth.ClosePlugins(); err != nil {
 			logger.Error("Failed to close plugins", "error", err)
 		}
+		if err := auth.Close(); err != nil {
+			logger.Error("Failed to close services", "error", err)
+		}
 		os.Exit(0)
 	}
 
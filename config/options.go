@@ -1,6 +1,7 @@
 package config
 
 import (
+	"net/http"
 	"os"
 	"time"
 
@@ -36,13 +37,64 @@ func NewConfig(options ...models.ConfigOption) *models.Config {
 			SendOnSignIn: false,
 			ExpiresIn:    1 * time.Hour,
 		},
+		MagicLink: models.MagicLinkConfig{
+			Enabled:   false,
+			ExpiresIn: 15 * time.Minute,
+		},
+		EmailOTP: models.EmailOTPConfig{
+			Enabled:     false,
+			Length:      6,
+			ExpiresIn:   5 * time.Minute,
+			MaxAttempts: 3,
+		},
 		User: models.UserConfig{
 			ChangeEmail: models.ChangeEmailConfig{},
 		},
 		Session: models.SessionConfig{
-			CookieName: "gobetterauth.session_token",
-			ExpiresIn:  7 * 24 * time.Hour,
-			UpdateAge:  24 * time.Hour,
+			CookieName:      "gobetterauth.session_token",
+			ExpiresIn:       7 * 24 * time.Hour,
+			UpdateAge:       24 * time.Hour,
+			CleanupInterval: 1 * time.Hour,
+			Cache: models.SessionCacheConfig{
+				Enabled: false,
+				TTL:     5 * time.Minute,
+			},
+			TokenSources: []models.SessionTokenSource{
+				models.SessionTokenSourceCookie,
+				models.SessionTokenSourceBearer,
+			},
+			ImpersonationExpiresIn: 1 * time.Hour,
+		},
+		TwoFactor: models.TwoFactorConfig{
+			Enabled:            false,
+			Issuer:             "GoBetterAuth",
+			Digits:             6,
+			Period:             30 * time.Second,
+			Skew:               1,
+			ChallengeExpiresIn: 5 * time.Minute,
+			BackupCodesCount:   10,
+		},
+		Passkey: models.PasskeyConfig{
+			Enabled:          false,
+			Timeout:          5 * time.Minute,
+			UserVerification: "preferred",
+		},
+		JWT: models.JWTConfig{
+			Enabled:             false,
+			Algorithm:           "EdDSA",
+			ExpiresIn:           15 * time.Minute,
+			KeyRotationInterval: 30 * 24 * time.Hour,
+		},
+		OIDCProvider: models.OIDCProviderConfig{
+			Enabled:              false,
+			Scopes:               []string{models.ScopeOpenID, models.ScopeProfile, models.ScopeEmail},
+			CodeExpiresIn:        10 * time.Minute,
+			AccessTokenExpiresIn: 1 * time.Hour,
+		},
+		ForwardAuth: models.ForwardAuthConfig{
+			Enabled:        false,
+			RedirectStatus: http.StatusFound,
+			Hosts:          map[string]models.ForwardAuthHostConfig{},
 		},
 		CSRF: models.CSRFConfig{
 			Enabled:    false,
@@ -50,6 +102,10 @@ func NewConfig(options ...models.ConfigOption) *models.Config {
 			HeaderName: "X-GOBETTERAUTH-CSRF-TOKEN",
 			ExpiresIn:  7 * 24 * time.Hour,
 		},
+		Organization: models.OrganizationConfig{
+			Enabled:             false,
+			InvitationExpiresIn: 48 * time.Hour,
+		},
 		TrustedOrigins: models.TrustedOriginsConfig{},
 		SecondaryStorage: models.SecondaryStorageConfig{
 			Type: models.SecondaryStorageTypeMemory,
@@ -247,6 +303,54 @@ func WithEmailVerification(config models.EmailVerificationConfig) models.ConfigO
 	}
 }
 
+func WithMagicLink(magicLinkConfig models.MagicLinkConfig) models.ConfigOption {
+	return func(c *models.Config) {
+		defaults := c.MagicLink
+
+		if magicLinkConfig.Enabled {
+			defaults.Enabled = magicLinkConfig.Enabled
+		}
+		if magicLinkConfig.ExpiresIn != 0 {
+			defaults.ExpiresIn = magicLinkConfig.ExpiresIn
+		}
+		if magicLinkConfig.DisableSignUp {
+			defaults.DisableSignUp = magicLinkConfig.DisableSignUp
+		}
+		if magicLinkConfig.SendMagicLinkEmail != nil {
+			defaults.SendMagicLinkEmail = magicLinkConfig.SendMagicLinkEmail
+		}
+
+		c.MagicLink = defaults
+	}
+}
+
+func WithEmailOTP(emailOTPConfig models.EmailOTPConfig) models.ConfigOption {
+	return func(c *models.Config) {
+		defaults := c.EmailOTP
+
+		if emailOTPConfig.Enabled {
+			defaults.Enabled = emailOTPConfig.Enabled
+		}
+		if emailOTPConfig.Length != 0 {
+			defaults.Length = emailOTPConfig.Length
+		}
+		if emailOTPConfig.ExpiresIn != 0 {
+			defaults.ExpiresIn = emailOTPConfig.ExpiresIn
+		}
+		if emailOTPConfig.MaxAttempts != 0 {
+			defaults.MaxAttempts = emailOTPConfig.MaxAttempts
+		}
+		if emailOTPConfig.DisableSignUp {
+			defaults.DisableSignUp = emailOTPConfig.DisableSignUp
+		}
+		if emailOTPConfig.SendEmailOTP != nil {
+			defaults.SendEmailOTP = emailOTPConfig.SendEmailOTP
+		}
+
+		c.EmailOTP = defaults
+	}
+}
+
 func WithUser(userConfig models.UserConfig) models.ConfigOption {
 	return func(c *models.Config) {
 		c.User = userConfig
@@ -264,10 +368,157 @@ func WithSession(sessionConfig models.SessionConfig) models.ConfigOption {
 		if sessionConfig.UpdateAge == 0 {
 			sessionConfig.UpdateAge = c.Session.UpdateAge
 		}
+		if sessionConfig.CleanupInterval == 0 {
+			sessionConfig.CleanupInterval = c.Session.CleanupInterval
+		}
+		if sessionConfig.Cache.TTL == 0 {
+			sessionConfig.Cache.TTL = c.Session.Cache.TTL
+		}
+		if len(sessionConfig.TokenSources) == 0 {
+			sessionConfig.TokenSources = c.Session.TokenSources
+		}
+		if sessionConfig.ImpersonationExpiresIn == 0 {
+			sessionConfig.ImpersonationExpiresIn = c.Session.ImpersonationExpiresIn
+		}
 		c.Session = sessionConfig
 	}
 }
 
+func WithTwoFactor(twoFactorConfig models.TwoFactorConfig) models.ConfigOption {
+	return func(c *models.Config) {
+		defaults := c.TwoFactor
+
+		if twoFactorConfig.Enabled {
+			defaults.Enabled = twoFactorConfig.Enabled
+		}
+		if twoFactorConfig.Issuer != "" {
+			defaults.Issuer = twoFactorConfig.Issuer
+		}
+		if twoFactorConfig.Digits != 0 {
+			defaults.Digits = twoFactorConfig.Digits
+		}
+		if twoFactorConfig.Period != 0 {
+			defaults.Period = twoFactorConfig.Period
+		}
+		if twoFactorConfig.Skew != 0 {
+			defaults.Skew = twoFactorConfig.Skew
+		}
+		if twoFactorConfig.ChallengeExpiresIn != 0 {
+			defaults.ChallengeExpiresIn = twoFactorConfig.ChallengeExpiresIn
+		}
+		if twoFactorConfig.BackupCodesCount != 0 {
+			defaults.BackupCodesCount = twoFactorConfig.BackupCodesCount
+		}
+
+		c.TwoFactor = defaults
+	}
+}
+
+func WithPasskey(passkeyConfig models.PasskeyConfig) models.ConfigOption {
+	return func(c *models.Config) {
+		defaults := c.Passkey
+
+		if passkeyConfig.Enabled {
+			defaults.Enabled = passkeyConfig.Enabled
+		}
+		if passkeyConfig.RPID != "" {
+			defaults.RPID = passkeyConfig.RPID
+		}
+		if passkeyConfig.RPName != "" {
+			defaults.RPName = passkeyConfig.RPName
+		}
+		if len(passkeyConfig.Origins) > 0 {
+			defaults.Origins = passkeyConfig.Origins
+		}
+		if passkeyConfig.Timeout != 0 {
+			defaults.Timeout = passkeyConfig.Timeout
+		}
+		if passkeyConfig.UserVerification != "" {
+			defaults.UserVerification = passkeyConfig.UserVerification
+		}
+
+		c.Passkey = defaults
+	}
+}
+
+func WithJWT(jwtConfig models.JWTConfig) models.ConfigOption {
+	return func(c *models.Config) {
+		defaults := c.JWT
+
+		if jwtConfig.Enabled {
+			defaults.Enabled = jwtConfig.Enabled
+		}
+		if jwtConfig.Algorithm != "" {
+			defaults.Algorithm = jwtConfig.Algorithm
+		}
+		if jwtConfig.Issuer != "" {
+			defaults.Issuer = jwtConfig.Issuer
+		}
+		if jwtConfig.Audience != "" {
+			defaults.Audience = jwtConfig.Audience
+		}
+		if jwtConfig.ExpiresIn != 0 {
+			defaults.ExpiresIn = jwtConfig.ExpiresIn
+		}
+		if jwtConfig.KeyRotationInterval != 0 {
+			defaults.KeyRotationInterval = jwtConfig.KeyRotationInterval
+		}
+		if jwtConfig.DefinePayload != nil {
+			defaults.DefinePayload = jwtConfig.DefinePayload
+		}
+
+		c.JWT = defaults
+	}
+}
+
+func WithOIDCProvider(oidcProviderConfig models.OIDCProviderConfig) models.ConfigOption {
+	return func(c *models.Config) {
+		defaults := c.OIDCProvider
+
+		if oidcProviderConfig.Enabled {
+			defaults.Enabled = oidcProviderConfig.Enabled
+		}
+		if oidcProviderConfig.LoginPage != "" {
+			defaults.LoginPage = oidcProviderConfig.LoginPage
+		}
+		if oidcProviderConfig.ConsentPage != "" {
+			defaults.ConsentPage = oidcProviderConfig.ConsentPage
+		}
+		if len(oidcProviderConfig.Scopes) > 0 {
+			defaults.Scopes = oidcProviderConfig.Scopes
+		}
+		if oidcProviderConfig.CodeExpiresIn != 0 {
+			defaults.CodeExpiresIn = oidcProviderConfig.CodeExpiresIn
+		}
+		if oidcProviderConfig.AccessTokenExpiresIn != 0 {
+			defaults.AccessTokenExpiresIn = oidcProviderConfig.AccessTokenExpiresIn
+		}
+
+		c.OIDCProvider = defaults
+	}
+}
+
+func WithForwardAuth(forwardAuthConfig models.ForwardAuthConfig) models.ConfigOption {
+	return func(c *models.Config) {
+		defaults := c.ForwardAuth
+
+		if forwardAuthConfig.Enabled {
+			defaults.Enabled = forwardAuthConfig.Enabled
+		}
+		if forwardAuthConfig.LoginURL != "" {
+			defaults.LoginURL = forwardAuthConfig.LoginURL
+		}
+		if forwardAuthConfig.RedirectStatus != 0 {
+			defaults.RedirectStatus = forwardAuthConfig.RedirectStatus
+		}
+		if len(forwardAuthConfig.Hosts) > 0 {
+			defaults.Hosts = forwardAuthConfig.Hosts
+		}
+
+		c.ForwardAuth = defaults
+	}
+}
+
 func WithCSRF(csrfConfig models.CSRFConfig) models.ConfigOption {
 	return func(c *models.Config) {
 		if csrfConfig.CookieName == "" {
@@ -283,12 +534,57 @@ func WithCSRF(csrfConfig models.CSRFConfig) models.ConfigOption {
 	}
 }
 
+func WithRBAC(rbacConfig models.RBACConfig) models.ConfigOption {
+	return func(c *models.Config) {
+		c.RBAC = rbacConfig
+	}
+}
+
+func WithOrganization(organizationConfig models.OrganizationConfig) models.ConfigOption {
+	return func(c *models.Config) {
+		defaults := c.Organization
+
+		if organizationConfig.Enabled {
+			defaults.Enabled = organizationConfig.Enabled
+		}
+		if organizationConfig.InvitationExpiresIn != 0 {
+			defaults.InvitationExpiresIn = organizationConfig.InvitationExpiresIn
+		}
+		if organizationConfig.InvitationPage != "" {
+			defaults.InvitationPage = organizationConfig.InvitationPage
+		}
+		if organizationConfig.SendInvitationEmail != nil {
+			defaults.SendInvitationEmail = organizationConfig.SendInvitationEmail
+		}
+
+		c.Organization = defaults
+	}
+}
+
 func WithSocialProviders(socialProvidersConfig models.SocialProvidersConfig) models.ConfigOption {
 	return func(c *models.Config) {
 		c.SocialProviders = socialProvidersConfig
 	}
 }
 
+func WithAccountLinking(accountLinkingConfig models.AccountLinkingConfig) models.ConfigOption {
+	return func(c *models.Config) {
+		defaults := c.AccountLinking
+
+		if accountLinkingConfig.Enabled {
+			defaults.Enabled = accountLinkingConfig.Enabled
+		}
+		if len(accountLinkingConfig.TrustedProviders) > 0 {
+			defaults.TrustedProviders = accountLinkingConfig.TrustedProviders
+		}
+		if accountLinkingConfig.AllowDifferentEmails {
+			defaults.AllowDifferentEmails = accountLinkingConfig.AllowDifferentEmails
+		}
+
+		c.AccountLinking = defaults
+	}
+}
+
 func WithTrustedOrigins(trustedOriginsConfig models.TrustedOriginsConfig) models.ConfigOption {
 	return func(c *models.Config) {
 		c.TrustedOrigins = trustedOriginsConfig
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	admin "github.com/GoBetterAuth/go-better-auth/internal/auth/admin"
+	"github.com/GoBetterAuth/go-better-auth/internal/common"
+	"github.com/GoBetterAuth/go-better-auth/internal/constants"
+	"github.com/GoBetterAuth/go-better-auth/internal/middleware"
+	"github.com/GoBetterAuth/go-better-auth/internal/util"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+// impersonatorCookieName holds the admin's own session token while they act as another user
+func impersonatorCookieName(config *models.Config) string {
+	return config.Session.CookieName + "_impersonator"
+}
+
+// POST /admin/users/{id}/impersonate
+
+type AdminImpersonateUserHandler struct {
+	Config  *models.Config
+	UseCase admin.AdminUseCase
+}
+
+func (h *AdminImpersonateUserHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := r.Context().Value(middleware.ContextSessionID).(string)
+	if !ok || sessionID == "" {
+		util.JSONResponse(w, http.StatusUnauthorized, map[string]any{"message": "unauthorized"})
+		return
+	}
+
+	result, err := h.UseCase.ImpersonateUser(r.Context(), sessionID, r.PathValue("id"))
+	if err != nil {
+		status := adminUserErrorStatus(err)
+		if errors.Is(err, constants.ErrImpersonateSelf) || errors.Is(err, constants.ErrAlreadyImpersonating) || errors.Is(err, constants.ErrUserBanned) {
+			status = http.StatusBadRequest
+		}
+		util.JSONResponse(w, status, map[string]any{"message": err.Error()})
+		return
+	}
+
+	isSecure, sameSite := util.GetCookieOptions(h.Config)
+
+	// Keep the admin's session aside so that stopping the impersonation can restore it
+	if token, source := util.GetSessionToken(h.Config, r); source == models.SessionTokenSourceCookie {
+		http.SetCookie(w, &http.Cookie{
+			Name:     impersonatorCookieName(h.Config),
+			Value:    token,
+			Path:     "/",
+			HttpOnly: true,
+			Secure:   isSecure,
+			SameSite: sameSite,
+			MaxAge:   int(h.Config.Session.ExpiresIn.Seconds()),
+		})
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     h.Config.Session.CookieName,
+		Value:    result.Token,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   isSecure,
+		SameSite: sameSite,
+		MaxAge:   int(h.Config.Session.ImpersonationExpiresIn.Seconds()),
+	})
+
+	util.JSONResponse(w, http.StatusOK, result)
+}
+
+func (h *AdminImpersonateUserHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// POST /admin/impersonation/stop
+
+type AdminStopImpersonatingHandler struct {
+	Config  *models.Config
+	UseCase admin.AdminUseCase
+}
+
+func (h *AdminStopImpersonatingHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := r.Context().Value(middleware.ContextSessionID).(string)
+	if !ok || sessionID == "" {
+		util.JSONResponse(w, http.StatusUnauthorized, map[string]any{"message": "unauthorized"})
+		return
+	}
+
+	var adminSessionToken string
+	if cookie, err := r.Cookie(impersonatorCookieName(h.Config)); err == nil {
+		adminSessionToken = cookie.Value
+	}
+
+	result, err := h.UseCase.StopImpersonating(r.Context(), sessionID, adminSessionToken)
+	if err != nil {
+		status := adminUserErrorStatus(err)
+		if errors.Is(err, constants.ErrNotImpersonating) {
+			status = http.StatusBadRequest
+		}
+		util.JSONResponse(w, status, map[string]any{"message": err.Error()})
+		return
+	}
+
+	isSecure, sameSite := util.GetCookieOptions(h.Config)
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     impersonatorCookieName(h.Config),
+		Value:    "",
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   isSecure,
+		SameSite: sameSite,
+		MaxAge:   -1,
+	})
+
+	// Sign the admin back in, or out when their own session is gone
+	sessionCookie := &http.Cookie{
+		Name:     h.Config.Session.CookieName,
+		Value:    result.Token,
+		Path:     "/",
+		HttpOnly: true,
+		Secure:   isSecure,
+		SameSite: sameSite,
+		MaxAge:   int(h.Config.Session.ExpiresIn.Seconds()),
+	}
+	if result.Token == "" {
+		sessionCookie.MaxAge = -1
+	}
+	http.SetCookie(w, sessionCookie)
+
+	util.JSONResponse(w, http.StatusOK, map[string]any{
+		"message":          "Impersonation stopped",
+		"session_restored": result.Token != "",
+	})
+}
+
+func (h *AdminStopImpersonatingHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	rbac "github.com/GoBetterAuth/go-better-auth/internal/auth/rbac"
+	"github.com/GoBetterAuth/go-better-auth/internal/common"
+	"github.com/GoBetterAuth/go-better-auth/internal/util"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+// GET /admin/permissions
+
+type AdminListPermissionsHandler struct {
+	UseCase rbac.RBACUseCase
+}
+
+func (h *AdminListPermissionsHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	permissions, err := h.UseCase.ListPermissions(r.Context())
+	if err != nil {
+		util.JSONResponse(w, http.StatusInternalServerError, map[string]any{"message": err.Error()})
+		return
+	}
+
+	util.JSONResponse(w, http.StatusOK, map[string]any{"permissions": permissions})
+}
+
+func (h *AdminListPermissionsHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// POST /admin/permissions
+
+type AdminCreatePermissionHandler struct {
+	UseCase rbac.RBACUseCase
+}
+
+func (h *AdminCreatePermissionHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	var payload models.CreatePermissionRequest
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.JSONResponse(w, http.StatusBadRequest, map[string]any{"message": "invalid request"})
+		return
+	}
+	if err := util.Validate.Struct(payload); err != nil {
+		util.JSONResponse(w, http.StatusUnprocessableEntity, map[string]any{"message": err.Error()})
+		return
+	}
+
+	permission, err := h.UseCase.CreatePermission(r.Context(), payload)
+	if err != nil {
+		util.JSONResponse(w, adminRBACErrorStatus(err), map[string]any{"message": err.Error()})
+		return
+	}
+
+	util.JSONResponse(w, http.StatusCreated, map[string]any{"permission": permission})
+}
+
+func (h *AdminCreatePermissionHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// DELETE /admin/permissions/{id}
+
+type AdminDeletePermissionHandler struct {
+	UseCase rbac.RBACUseCase
+}
+
+func (h *AdminDeletePermissionHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	if err := h.UseCase.DeletePermission(r.Context(), r.PathValue("id")); err != nil {
+		util.JSONResponse(w, adminRBACErrorStatus(err), map[string]any{"message": err.Error()})
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *AdminDeletePermissionHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
@@ -0,0 +1,270 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	rbac "github.com/GoBetterAuth/go-better-auth/internal/auth/rbac"
+	"github.com/GoBetterAuth/go-better-auth/internal/common"
+	"github.com/GoBetterAuth/go-better-auth/internal/constants"
+	"github.com/GoBetterAuth/go-better-auth/internal/util"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+// GET /admin/roles
+
+type AdminListRolesHandler struct {
+	UseCase rbac.RBACUseCase
+}
+
+func (h *AdminListRolesHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	roles, err := h.UseCase.ListRoles(r.Context())
+	if err != nil {
+		util.JSONResponse(w, http.StatusInternalServerError, map[string]any{"message": err.Error()})
+		return
+	}
+
+	util.JSONResponse(w, http.StatusOK, map[string]any{"roles": roles})
+}
+
+func (h *AdminListRolesHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// GET /admin/roles/{id}
+
+type AdminGetRoleHandler struct {
+	UseCase rbac.RBACUseCase
+}
+
+func (h *AdminGetRoleHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	role, err := h.UseCase.GetRole(r.Context(), r.PathValue("id"))
+	if err != nil {
+		util.JSONResponse(w, adminRBACErrorStatus(err), map[string]any{"message": err.Error()})
+		return
+	}
+
+	util.JSONResponse(w, http.StatusOK, map[string]any{"role": role})
+}
+
+func (h *AdminGetRoleHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// POST /admin/roles
+
+type AdminCreateRoleHandler struct {
+	UseCase rbac.RBACUseCase
+}
+
+func (h *AdminCreateRoleHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	var payload models.CreateRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.JSONResponse(w, http.StatusBadRequest, map[string]any{"message": "invalid request"})
+		return
+	}
+	if err := util.Validate.Struct(payload); err != nil {
+		util.JSONResponse(w, http.StatusUnprocessableEntity, map[string]any{"message": err.Error()})
+		return
+	}
+
+	role, err := h.UseCase.CreateRole(r.Context(), payload)
+	if err != nil {
+		util.JSONResponse(w, adminRBACErrorStatus(err), map[string]any{"message": err.Error()})
+		return
+	}
+
+	util.JSONResponse(w, http.StatusCreated, map[string]any{"role": role})
+}
+
+func (h *AdminCreateRoleHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// PATCH /admin/roles/{id}
+
+type AdminUpdateRoleHandler struct {
+	UseCase rbac.RBACUseCase
+}
+
+func (h *AdminUpdateRoleHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	var payload models.UpdateRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.JSONResponse(w, http.StatusBadRequest, map[string]any{"message": "invalid request"})
+		return
+	}
+	if err := util.Validate.Struct(payload); err != nil {
+		util.JSONResponse(w, http.StatusUnprocessableEntity, map[string]any{"message": err.Error()})
+		return
+	}
+
+	role, err := h.UseCase.UpdateRole(r.Context(), r.PathValue("id"), payload)
+	if err != nil {
+		util.JSONResponse(w, adminRBACErrorStatus(err), map[string]any{"message": err.Error()})
+		return
+	}
+
+	util.JSONResponse(w, http.StatusOK, map[string]any{"role": role})
+}
+
+func (h *AdminUpdateRoleHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// DELETE /admin/roles/{id}
+
+type AdminDeleteRoleHandler struct {
+	UseCase rbac.RBACUseCase
+}
+
+func (h *AdminDeleteRoleHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	if err := h.UseCase.DeleteRole(r.Context(), r.PathValue("id")); err != nil {
+		util.JSONResponse(w, adminRBACErrorStatus(err), map[string]any{"message": err.Error()})
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *AdminDeleteRoleHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// POST /admin/roles/{id}/permissions
+
+type AdminAddRolePermissionPayload struct {
+	PermissionID string `json:"permission_id" validate:"required"`
+}
+
+type AdminAddRolePermissionHandler struct {
+	UseCase rbac.RBACUseCase
+}
+
+func (h *AdminAddRolePermissionHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	var payload AdminAddRolePermissionPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.JSONResponse(w, http.StatusBadRequest, map[string]any{"message": "invalid request"})
+		return
+	}
+	if err := util.Validate.Struct(payload); err != nil {
+		util.JSONResponse(w, http.StatusUnprocessableEntity, map[string]any{"message": err.Error()})
+		return
+	}
+
+	if err := h.UseCase.AddRolePermission(r.Context(), r.PathValue("id"), payload.PermissionID); err != nil {
+		util.JSONResponse(w, adminRBACErrorStatus(err), map[string]any{"message": err.Error()})
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *AdminAddRolePermissionHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// DELETE /admin/roles/{id}/permissions/{permissionId}
+
+type AdminRemoveRolePermissionHandler struct {
+	UseCase rbac.RBACUseCase
+}
+
+func (h *AdminRemoveRolePermissionHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	if err := h.UseCase.RemoveRolePermission(r.Context(), r.PathValue("id"), r.PathValue("permissionId")); err != nil {
+		util.JSONResponse(w, adminRBACErrorStatus(err), map[string]any{"message": err.Error()})
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *AdminRemoveRolePermissionHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// GET /admin/users/{id}/roles
+
+type AdminListUserRolesHandler struct {
+	UseCase rbac.RBACUseCase
+}
+
+func (h *AdminListUserRolesHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	roles, err := h.UseCase.ListUserRoles(r.Context(), r.PathValue("id"))
+	if err != nil {
+		util.JSONResponse(w, adminRBACErrorStatus(err), map[string]any{"message": err.Error()})
+		return
+	}
+
+	util.JSONResponse(w, http.StatusOK, map[string]any{"roles": roles})
+}
+
+func (h *AdminListUserRolesHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// POST /admin/users/{id}/roles
+
+type AdminAssignUserRolePayload struct {
+	RoleID string `json:"role_id" validate:"required"`
+}
+
+type AdminAssignUserRoleHandler struct {
+	UseCase rbac.RBACUseCase
+}
+
+func (h *AdminAssignUserRoleHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	var payload AdminAssignUserRolePayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.JSONResponse(w, http.StatusBadRequest, map[string]any{"message": "invalid request"})
+		return
+	}
+	if err := util.Validate.Struct(payload); err != nil {
+		util.JSONResponse(w, http.StatusUnprocessableEntity, map[string]any{"message": err.Error()})
+		return
+	}
+
+	if err := h.UseCase.AssignUserRole(r.Context(), r.PathValue("id"), payload.RoleID); err != nil {
+		util.JSONResponse(w, adminRBACErrorStatus(err), map[string]any{"message": err.Error()})
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *AdminAssignUserRoleHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// DELETE /admin/users/{id}/roles/{roleId}
+
+type AdminRemoveUserRoleHandler struct {
+	UseCase rbac.RBACUseCase
+}
+
+func (h *AdminRemoveUserRoleHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	if err := h.UseCase.RemoveUserRole(r.Context(), r.PathValue("id"), r.PathValue("roleId")); err != nil {
+		util.JSONResponse(w, adminRBACErrorStatus(err), map[string]any{"message": err.Error()})
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *AdminRemoveUserRoleHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// adminRBACErrorStatus maps the errors of the RBAC use case to HTTP status codes
+func adminRBACErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, constants.ErrRoleNotFound), errors.Is(err, constants.ErrPermissionNotFound), errors.Is(err, constants.ErrUserNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, constants.ErrRoleAlreadyExists), errors.Is(err, constants.ErrPermissionAlreadyExists):
+		return http.StatusConflict
+	case errors.Is(err, constants.ErrPermissionInvalid):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"net/http"
+
+	admin "github.com/GoBetterAuth/go-better-auth/internal/auth/admin"
+	"github.com/GoBetterAuth/go-better-auth/internal/common"
+	"github.com/GoBetterAuth/go-better-auth/internal/util"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+// GET /admin/users/{id}/sessions
+
+type AdminListUserSessionsHandler struct {
+	UseCase admin.AdminUseCase
+}
+
+func (h *AdminListUserSessionsHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	sessions, err := h.UseCase.ListUserSessions(r.Context(), r.PathValue("id"))
+	if err != nil {
+		util.JSONResponse(w, adminUserErrorStatus(err), map[string]any{"message": err.Error()})
+		return
+	}
+
+	util.JSONResponse(w, http.StatusOK, map[string]any{"sessions": sessions})
+}
+
+func (h *AdminListUserSessionsHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// DELETE /admin/users/{id}/sessions/{sessionId}
+
+type AdminRevokeUserSessionHandler struct {
+	UseCase admin.AdminUseCase
+}
+
+func (h *AdminRevokeUserSessionHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	if err := h.UseCase.RevokeUserSession(r.Context(), r.PathValue("id"), r.PathValue("sessionId")); err != nil {
+		util.JSONResponse(w, adminUserErrorStatus(err), map[string]any{"message": err.Error()})
+		return
+	}
+
+	util.JSONResponse(w, http.StatusOK, map[string]any{"message": "Session revoked"})
+}
+
+func (h *AdminRevokeUserSessionHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// DELETE /admin/users/{id}/sessions
+
+type AdminRevokeUserSessionsHandler struct {
+	UseCase admin.AdminUseCase
+}
+
+func (h *AdminRevokeUserSessionsHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	if err := h.UseCase.RevokeUserSessions(r.Context(), r.PathValue("id")); err != nil {
+		util.JSONResponse(w, adminUserErrorStatus(err), map[string]any{"message": err.Error()})
+		return
+	}
+
+	util.JSONResponse(w, http.StatusOK, map[string]any{"message": "Sessions revoked"})
+}
+
+func (h *AdminRevokeUserSessionsHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
@@ -0,0 +1,281 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	admin "github.com/GoBetterAuth/go-better-auth/internal/auth/admin"
+	"github.com/GoBetterAuth/go-better-auth/internal/common"
+	"github.com/GoBetterAuth/go-better-auth/internal/constants"
+	"github.com/GoBetterAuth/go-better-auth/internal/util"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+// GET /admin/users
+
+type AdminListUsersHandler struct {
+	UseCase admin.AdminUseCase
+}
+
+func (h *AdminListUsersHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	query := models.UserListQuery{Search: params.Get("search")}
+
+	var err error
+	if value := params.Get("limit"); value != "" {
+		if query.Limit, err = strconv.Atoi(value); err != nil {
+			util.JSONResponse(w, http.StatusBadRequest, map[string]any{"message": "invalid limit"})
+			return
+		}
+	}
+	if value := params.Get("offset"); value != "" {
+		if query.Offset, err = strconv.Atoi(value); err != nil {
+			util.JSONResponse(w, http.StatusBadRequest, map[string]any{"message": "invalid offset"})
+			return
+		}
+	}
+	if value := params.Get("email_verified"); value != "" {
+		emailVerified, err := strconv.ParseBool(value)
+		if err != nil {
+			util.JSONResponse(w, http.StatusBadRequest, map[string]any{"message": "invalid email_verified"})
+			return
+		}
+		query.EmailVerified = &emailVerified
+	}
+	if value := params.Get("banned"); value != "" {
+		banned, err := strconv.ParseBool(value)
+		if err != nil {
+			util.JSONResponse(w, http.StatusBadRequest, map[string]any{"message": "invalid banned"})
+			return
+		}
+		query.Banned = &banned
+	}
+
+	result, err := h.UseCase.ListUsers(r.Context(), query)
+	if err != nil {
+		util.JSONResponse(w, http.StatusInternalServerError, map[string]any{"message": err.Error()})
+		return
+	}
+
+	util.JSONResponse(w, http.StatusOK, result)
+}
+
+func (h *AdminListUsersHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// GET /admin/users/{id}
+
+type AdminGetUserHandler struct {
+	UseCase admin.AdminUseCase
+}
+
+func (h *AdminGetUserHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	user, err := h.UseCase.GetUser(r.Context(), r.PathValue("id"))
+	if err != nil {
+		util.JSONResponse(w, adminUserErrorStatus(err), map[string]any{"message": err.Error()})
+		return
+	}
+
+	util.JSONResponse(w, http.StatusOK, map[string]any{"user": user})
+}
+
+func (h *AdminGetUserHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// POST /admin/users
+
+type AdminCreateUserHandler struct {
+	UseCase admin.AdminUseCase
+}
+
+func (h *AdminCreateUserHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	var payload models.AdminCreateUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.JSONResponse(w, http.StatusBadRequest, map[string]any{"message": "invalid request"})
+		return
+	}
+	if err := util.Validate.Struct(payload); err != nil {
+		util.JSONResponse(w, http.StatusUnprocessableEntity, map[string]any{"message": err.Error()})
+		return
+	}
+
+	user, err := h.UseCase.CreateUser(r.Context(), payload)
+	if err != nil {
+		util.JSONResponse(w, adminUserErrorStatus(err), map[string]any{"message": err.Error()})
+		return
+	}
+
+	util.JSONResponse(w, http.StatusCreated, map[string]any{"user": user})
+}
+
+func (h *AdminCreateUserHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// PATCH /admin/users/{id}
+
+type AdminUpdateUserHandler struct {
+	UseCase admin.AdminUseCase
+}
+
+func (h *AdminUpdateUserHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	var payload models.AdminUpdateUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.JSONResponse(w, http.StatusBadRequest, map[string]any{"message": "invalid request"})
+		return
+	}
+	if err := util.Validate.Struct(payload); err != nil {
+		util.JSONResponse(w, http.StatusUnprocessableEntity, map[string]any{"message": err.Error()})
+		return
+	}
+
+	user, err := h.UseCase.UpdateUser(r.Context(), r.PathValue("id"), payload)
+	if err != nil {
+		util.JSONResponse(w, adminUserErrorStatus(err), map[string]any{"message": err.Error()})
+		return
+	}
+
+	util.JSONResponse(w, http.StatusOK, map[string]any{"user": user})
+}
+
+func (h *AdminUpdateUserHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// DELETE /admin/users/{id}
+
+type AdminDeleteUserHandler struct {
+	UseCase admin.AdminUseCase
+}
+
+func (h *AdminDeleteUserHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	if err := h.UseCase.DeleteUser(r.Context(), r.PathValue("id")); err != nil {
+		util.JSONResponse(w, adminUserErrorStatus(err), map[string]any{"message": err.Error()})
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *AdminDeleteUserHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// POST /admin/users/{id}/verify-email
+
+type AdminVerifyUserEmailHandler struct {
+	UseCase admin.AdminUseCase
+}
+
+func (h *AdminVerifyUserEmailHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	user, err := h.UseCase.VerifyUserEmail(r.Context(), r.PathValue("id"))
+	if err != nil {
+		util.JSONResponse(w, adminUserErrorStatus(err), map[string]any{"message": err.Error()})
+		return
+	}
+
+	util.JSONResponse(w, http.StatusOK, map[string]any{"user": user})
+}
+
+func (h *AdminVerifyUserEmailHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// POST /admin/users/{id}/reset-password
+
+type AdminResetPasswordPayload struct {
+	CallbackURL *string `json:"callback_url,omitempty"`
+}
+
+type AdminResetPasswordHandler struct {
+	UseCase admin.AdminUseCase
+}
+
+func (h *AdminResetPasswordHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	// The body is optional, it only carries the callback URL
+	var payload AdminResetPasswordPayload
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			util.JSONResponse(w, http.StatusBadRequest, map[string]any{"message": "invalid request"})
+			return
+		}
+	}
+
+	if err := h.UseCase.SendPasswordReset(r.Context(), r.PathValue("id"), payload.CallbackURL); err != nil {
+		util.JSONResponse(w, adminUserErrorStatus(err), map[string]any{"message": err.Error()})
+		return
+	}
+
+	util.JSONResponse(w, http.StatusOK, map[string]any{"message": "Password reset email sent"})
+}
+
+func (h *AdminResetPasswordHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// POST /admin/users/{id}/ban
+
+type AdminBanUserHandler struct {
+	UseCase admin.AdminUseCase
+}
+
+func (h *AdminBanUserHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	// The body is optional, a ban without reason or expiry is permanent
+	var payload models.AdminBanUserRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			util.JSONResponse(w, http.StatusBadRequest, map[string]any{"message": "invalid request"})
+			return
+		}
+	}
+
+	user, err := h.UseCase.BanUser(r.Context(), r.PathValue("id"), payload)
+	if err != nil {
+		util.JSONResponse(w, adminUserErrorStatus(err), map[string]any{"message": err.Error()})
+		return
+	}
+
+	util.JSONResponse(w, http.StatusOK, map[string]any{"user": user})
+}
+
+func (h *AdminBanUserHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// POST /admin/users/{id}/unban
+
+type AdminUnbanUserHandler struct {
+	UseCase admin.AdminUseCase
+}
+
+func (h *AdminUnbanUserHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	user, err := h.UseCase.UnbanUser(r.Context(), r.PathValue("id"))
+	if err != nil {
+		util.JSONResponse(w, adminUserErrorStatus(err), map[string]any{"message": err.Error()})
+		return
+	}
+
+	util.JSONResponse(w, http.StatusOK, map[string]any{"user": user})
+}
+
+func (h *AdminUnbanUserHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// adminUserErrorStatus maps the errors of the admin use case to HTTP status codes
+func adminUserErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, constants.ErrUserNotFound), errors.Is(err, constants.ErrSessionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, constants.ErrUserAlreadyExists), errors.Is(err, constants.ErrEmailAlreadyExists):
+		return http.StatusConflict
+	case errors.Is(err, constants.ErrBanExpiryInPast):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -15,6 +15,118 @@ func GetRoutes(config *models.Config, configManager models.ConfigManager, authSe
 		ConfigManager: configManager,
 	}
 
+	useCases := auth.NewUseCases(config, authService)
+
+	listUsersHandler := &adminhandlers.AdminListUsersHandler{
+		UseCase: useCases.AdminUseCase,
+	}
+
+	createUserHandler := &adminhandlers.AdminCreateUserHandler{
+		UseCase: useCases.AdminUseCase,
+	}
+
+	getUserHandler := &adminhandlers.AdminGetUserHandler{
+		UseCase: useCases.AdminUseCase,
+	}
+
+	updateUserHandler := &adminhandlers.AdminUpdateUserHandler{
+		UseCase: useCases.AdminUseCase,
+	}
+
+	deleteUserHandler := &adminhandlers.AdminDeleteUserHandler{
+		UseCase: useCases.AdminUseCase,
+	}
+
+	verifyUserEmailHandler := &adminhandlers.AdminVerifyUserEmailHandler{
+		UseCase: useCases.AdminUseCase,
+	}
+
+	resetPasswordHandler := &adminhandlers.AdminResetPasswordHandler{
+		UseCase: useCases.AdminUseCase,
+	}
+
+	listUserSessionsHandler := &adminhandlers.AdminListUserSessionsHandler{
+		UseCase: useCases.AdminUseCase,
+	}
+
+	revokeUserSessionsHandler := &adminhandlers.AdminRevokeUserSessionsHandler{
+		UseCase: useCases.AdminUseCase,
+	}
+
+	revokeUserSessionHandler := &adminhandlers.AdminRevokeUserSessionHandler{
+		UseCase: useCases.AdminUseCase,
+	}
+
+	banUserHandler := &adminhandlers.AdminBanUserHandler{
+		UseCase: useCases.AdminUseCase,
+	}
+
+	unbanUserHandler := &adminhandlers.AdminUnbanUserHandler{
+		UseCase: useCases.AdminUseCase,
+	}
+
+	impersonateUserHandler := &adminhandlers.AdminImpersonateUserHandler{
+		Config:  config,
+		UseCase: useCases.AdminUseCase,
+	}
+
+	stopImpersonatingHandler := &adminhandlers.AdminStopImpersonatingHandler{
+		Config:  config,
+		UseCase: useCases.AdminUseCase,
+	}
+
+	listRolesHandler := &adminhandlers.AdminListRolesHandler{
+		UseCase: useCases.RBACUseCase,
+	}
+
+	createRoleHandler := &adminhandlers.AdminCreateRoleHandler{
+		UseCase: useCases.RBACUseCase,
+	}
+
+	getRoleHandler := &adminhandlers.AdminGetRoleHandler{
+		UseCase: useCases.RBACUseCase,
+	}
+
+	updateRoleHandler := &adminhandlers.AdminUpdateRoleHandler{
+		UseCase: useCases.RBACUseCase,
+	}
+
+	deleteRoleHandler := &adminhandlers.AdminDeleteRoleHandler{
+		UseCase: useCases.RBACUseCase,
+	}
+
+	addRolePermissionHandler := &adminhandlers.AdminAddRolePermissionHandler{
+		UseCase: useCases.RBACUseCase,
+	}
+
+	removeRolePermissionHandler := &adminhandlers.AdminRemoveRolePermissionHandler{
+		UseCase: useCases.RBACUseCase,
+	}
+
+	listPermissionsHandler := &adminhandlers.AdminListPermissionsHandler{
+		UseCase: useCases.RBACUseCase,
+	}
+
+	createPermissionHandler := &adminhandlers.AdminCreatePermissionHandler{
+		UseCase: useCases.RBACUseCase,
+	}
+
+	deletePermissionHandler := &adminhandlers.AdminDeletePermissionHandler{
+		UseCase: useCases.RBACUseCase,
+	}
+
+	listUserRolesHandler := &adminhandlers.AdminListUserRolesHandler{
+		UseCase: useCases.RBACUseCase,
+	}
+
+	assignUserRoleHandler := &adminhandlers.AdminAssignUserRoleHandler{
+		UseCase: useCases.RBACUseCase,
+	}
+
+	removeUserRoleHandler := &adminhandlers.AdminRemoveUserRoleHandler{
+		UseCase: useCases.RBACUseCase,
+	}
+
 	return []models.CustomRoute{
 		{
 			Method: "GET",
@@ -32,5 +144,226 @@ func GetRoutes(config *models.Config, configManager models.ConfigManager, authSe
 			},
 			Handler: updateConfigHandler.Handler(),
 		},
+		{
+			Method: "GET",
+			Path:   "/admin/users",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.AdminAuth(),
+			},
+			Handler: listUsersHandler.Handler(),
+		},
+		{
+			Method: "POST",
+			Path:   "/admin/users",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.AdminAuth(),
+			},
+			Handler: createUserHandler.Handler(),
+		},
+		{
+			Method: "GET",
+			Path:   "/admin/users/{id}",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.AdminAuth(),
+			},
+			Handler: getUserHandler.Handler(),
+		},
+		{
+			Method: "PATCH",
+			Path:   "/admin/users/{id}",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.AdminAuth(),
+			},
+			Handler: updateUserHandler.Handler(),
+		},
+		{
+			Method: "DELETE",
+			Path:   "/admin/users/{id}",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.AdminAuth(),
+			},
+			Handler: deleteUserHandler.Handler(),
+		},
+		{
+			Method: "POST",
+			Path:   "/admin/users/{id}/verify-email",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.AdminAuth(),
+			},
+			Handler: verifyUserEmailHandler.Handler(),
+		},
+		{
+			Method: "POST",
+			Path:   "/admin/users/{id}/reset-password",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.AdminAuth(),
+			},
+			Handler: resetPasswordHandler.Handler(),
+		},
+		{
+			Method: "POST",
+			Path:   "/admin/users/{id}/ban",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.AdminAuth(),
+			},
+			Handler: banUserHandler.Handler(),
+		},
+		{
+			Method: "POST",
+			Path:   "/admin/users/{id}/unban",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.AdminAuth(),
+			},
+			Handler: unbanUserHandler.Handler(),
+		},
+		{
+			// Impersonation acts on behalf of the admin's own session
+			Method: "POST",
+			Path:   "/admin/users/{id}/impersonate",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.AdminAuth(),
+				middleware.Auth(),
+			},
+			Handler: impersonateUserHandler.Handler(),
+		},
+		{
+			// The current session is the impersonated user's, who lacks the admin permission. The use case
+			// checks that the session was started by an admin instead
+			Method: "POST",
+			Path:   "/admin/impersonation/stop",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.Auth(),
+				middleware.CSRF(),
+			},
+			Handler: stopImpersonatingHandler.Handler(),
+		},
+		{
+			Method: "GET",
+			Path:   "/admin/users/{id}/sessions",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.AdminAuth(),
+			},
+			Handler: listUserSessionsHandler.Handler(),
+		},
+		{
+			Method: "DELETE",
+			Path:   "/admin/users/{id}/sessions",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.AdminAuth(),
+			},
+			Handler: revokeUserSessionsHandler.Handler(),
+		},
+		{
+			Method: "DELETE",
+			Path:   "/admin/users/{id}/sessions/{sessionId}",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.AdminAuth(),
+			},
+			Handler: revokeUserSessionHandler.Handler(),
+		},
+		{
+			Method: "GET",
+			Path:   "/admin/roles",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.AdminAuth(),
+			},
+			Handler: listRolesHandler.Handler(),
+		},
+		{
+			Method: "POST",
+			Path:   "/admin/roles",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.AdminAuth(),
+			},
+			Handler: createRoleHandler.Handler(),
+		},
+		{
+			Method: "GET",
+			Path:   "/admin/roles/{id}",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.AdminAuth(),
+			},
+			Handler: getRoleHandler.Handler(),
+		},
+		{
+			Method: "PATCH",
+			Path:   "/admin/roles/{id}",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.AdminAuth(),
+			},
+			Handler: updateRoleHandler.Handler(),
+		},
+		{
+			Method: "DELETE",
+			Path:   "/admin/roles/{id}",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.AdminAuth(),
+			},
+			Handler: deleteRoleHandler.Handler(),
+		},
+		{
+			Method: "POST",
+			Path:   "/admin/roles/{id}/permissions",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.AdminAuth(),
+			},
+			Handler: addRolePermissionHandler.Handler(),
+		},
+		{
+			Method: "DELETE",
+			Path:   "/admin/roles/{id}/permissions/{permissionId}",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.AdminAuth(),
+			},
+			Handler: removeRolePermissionHandler.Handler(),
+		},
+		{
+			Method: "GET",
+			Path:   "/admin/permissions",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.AdminAuth(),
+			},
+			Handler: listPermissionsHandler.Handler(),
+		},
+		{
+			Method: "POST",
+			Path:   "/admin/permissions",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.AdminAuth(),
+			},
+			Handler: createPermissionHandler.Handler(),
+		},
+		{
+			Method: "DELETE",
+			Path:   "/admin/permissions/{id}",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.AdminAuth(),
+			},
+			Handler: deletePermissionHandler.Handler(),
+		},
+		{
+			Method: "GET",
+			Path:   "/admin/users/{id}/roles",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.AdminAuth(),
+			},
+			Handler: listUserRolesHandler.Handler(),
+		},
+		{
+			Method: "POST",
+			Path:   "/admin/users/{id}/roles",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.AdminAuth(),
+			},
+			Handler: assignUserRoleHandler.Handler(),
+		},
+		{
+			Method: "DELETE",
+			Path:   "/admin/users/{id}/roles/{roleId}",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.AdminAuth(),
+			},
+			Handler: removeUserRoleHandler.Handler(),
+		},
 	}
 }
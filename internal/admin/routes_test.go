@@ -0,0 +1,337 @@
+package admin
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/GoBetterAuth/go-better-auth/config"
+	"github.com/GoBetterAuth/go-better-auth/internal/auth"
+	"github.com/GoBetterAuth/go-better-auth/internal/events"
+	"github.com/GoBetterAuth/go-better-auth/internal/middleware"
+	"github.com/GoBetterAuth/go-better-auth/internal/services"
+	"github.com/GoBetterAuth/go-better-auth/internal/testutil"
+	"github.com/GoBetterAuth/go-better-auth/internal/util"
+	"github.com/GoBetterAuth/go-better-auth/models"
+	"github.com/GoBetterAuth/go-better-auth/storage"
+)
+
+const testCSRFToken = "csrf"
+
+// newTestHandler serves the admin routes the way the Auth handler mounts them, with RBAC admins allowed
+// through the admin:access permission.
+func newTestHandler(t *testing.T, options ...models.ConfigOption) (http.Handler, *auth.Service, *models.Config) {
+	t.Helper()
+
+	util.InitValidator()
+	cfg := config.NewConfig(append([]models.ConfigOption{
+		config.WithLogger(models.LoggerConfig{Logger: testutil.NewLogger()}),
+		config.WithSecondaryStorage(models.SecondaryStorageConfig{
+			Storage: storage.NewMemorySecondaryStorage(models.SecondaryStorageMemoryOptions{}),
+		}),
+		config.WithRBAC(models.RBACConfig{AdminPermission: "admin:access"}),
+		config.WithCSRF(models.CSRFConfig{Enabled: true}),
+	}, options...)...)
+	db := testutil.NewDB(t,
+		&models.User{}, &models.Account{}, &models.Session{}, &models.Verification{}, &models.TwoFactor{},
+		&models.TwoFactorBackupCode{}, &models.Passkey{}, &models.OAuthConsent{}, &models.Role{}, &models.Permission{},
+		&models.RolePermission{}, &models.UserRole{}, &models.Organization{}, &models.Member{}, &models.Invitation{},
+	)
+	authService := &auth.Service{
+		EventEmitter:        events.NewEventEmitter(cfg, cfg.Logger.Logger, nil, nil),
+		UserService:         services.NewUserServiceImpl(cfg, db),
+		AccountService:      services.NewAccountServiceImpl(cfg, db),
+		SessionService:      services.NewSessionServiceImpl(cfg, db),
+		VerificationService: services.NewVerificationServiceImpl(cfg, db),
+		PasswordService:     services.NewArgon2PasswordService(),
+		TokenService:        services.NewTokenServiceImpl(cfg, nil),
+		OIDCProviderService: services.NewOIDCProviderServiceImpl(cfg, db),
+		RBACService:         services.NewRBACServiceImpl(cfg, db),
+	}
+
+	apiMiddleware := &models.ApiMiddleware{
+		AdminAuth: func() func(http.Handler) http.Handler {
+			return middleware.AdminAuth("admin-key", cfg, authService)
+		},
+		Auth: func() func(http.Handler) http.Handler {
+			return middleware.AuthMiddleware(cfg, authService)
+		},
+		CSRF: func() func(http.Handler) http.Handler {
+			return middleware.CSRFMiddleware(cfg)
+		},
+	}
+
+	mux := http.NewServeMux()
+	for _, route := range GetRoutes(cfg, nil, authService, "", apiMiddleware) {
+		handler := route.Handler(cfg)
+		for i := len(route.Middleware) - 1; i >= 0; i-- {
+			handler = route.Middleware[i](handler)
+		}
+		mux.Handle(route.Method+" "+route.Path, handler)
+	}
+	return mux, authService, cfg
+}
+
+func createUser(t *testing.T, authService *auth.Service, email string) *models.User {
+	t.Helper()
+
+	user := &models.User{Email: email, EmailVerified: true}
+	if err := authService.UserService.CreateUser(user); err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+	return user
+}
+
+func grantAdminPermission(t *testing.T, authService *auth.Service, userID string) {
+	t.Helper()
+
+	role := &models.Role{Name: "admin"}
+	assert.NoError(t, authService.RBACService.CreateRole(role))
+	permission := &models.Permission{Name: "admin:access"}
+	assert.NoError(t, authService.RBACService.CreatePermission(permission))
+	assert.NoError(t, authService.RBACService.AddPermissionToRole(role.ID, permission.ID))
+	assert.NoError(t, authService.RBACService.AssignRoleToUser(userID, role.ID))
+}
+
+// post sends a browser request carrying the cookies and the matching CSRF header.
+func post(handler http.Handler, cfg *models.Config, path string, cookies ...*http.Cookie) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, nil)
+	for _, cookie := range cookies {
+		req.AddCookie(cookie)
+	}
+	req.AddCookie(&http.Cookie{Name: cfg.CSRF.CookieName, Value: testCSRFToken})
+	req.Header.Set(cfg.CSRF.HeaderName, testCSRFToken)
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+// adminRequest sends a request authenticated with the admin API key, with body encoded as JSON when given.
+func adminRequest(handler http.Handler, method string, path string, body any) *httptest.ResponseRecorder {
+	var data []byte
+	if body != nil {
+		data, _ = json.Marshal(body)
+	}
+	req := httptest.NewRequest(method, path, bytes.NewReader(data))
+	req.Header.Set("X-API-KEY", "admin-key")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func responseCookie(rec *httptest.ResponseRecorder, name string) *http.Cookie {
+	for _, cookie := range rec.Result().Cookies() {
+		if cookie.Name == name {
+			return cookie
+		}
+	}
+	return nil
+}
+
+func TestImpersonation_RBACAdminCanStop(t *testing.T) {
+	handler, authService, cfg := newTestHandler(t)
+
+	admin := createUser(t, authService, "admin@example.com")
+	grantAdminPermission(t, authService, admin.ID)
+	user := createUser(t, authService, "user@example.com")
+	_, err := authService.SessionService.CreateSession(admin.ID, authService.TokenService.HashToken("admin-token"))
+	assert.NoError(t, err)
+	adminCookie := &http.Cookie{Name: cfg.Session.CookieName, Value: "admin-token"}
+
+	rec := post(handler, cfg, fmt.Sprintf("/admin/users/%s/impersonate", user.ID), adminCookie)
+	if !assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String()) {
+		return
+	}
+	sessionCookie := responseCookie(rec, cfg.Session.CookieName)
+	impersonatorCookie := responseCookie(rec, cfg.Session.CookieName+"_impersonator")
+	if !assert.NotNil(t, sessionCookie) || !assert.NotNil(t, impersonatorCookie) {
+		return
+	}
+
+	// The impersonated user holds no admin permission, which must not keep the admin from stopping
+	rec = post(handler, cfg, "/admin/impersonation/stop", sessionCookie, impersonatorCookie)
+	if !assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String()) {
+		return
+	}
+
+	var body struct {
+		SessionRestored bool `json:"session_restored"`
+	}
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&body))
+	assert.True(t, body.SessionRestored)
+	if restored := responseCookie(rec, cfg.Session.CookieName); assert.NotNil(t, restored) {
+		assert.Equal(t, "admin-token", restored.Value)
+	}
+
+	session, err := authService.SessionService.GetSessionByToken(authService.TokenService.HashToken(sessionCookie.Value))
+	assert.NoError(t, err)
+	assert.Nil(t, session, "the impersonation session must be revoked")
+}
+
+func TestImpersonation_StopRequiresAnImpersonationSession(t *testing.T) {
+	handler, authService, cfg := newTestHandler(t)
+
+	user := createUser(t, authService, "user@example.com")
+	_, err := authService.SessionService.CreateSession(user.ID, authService.TokenService.HashToken("user-token"))
+	assert.NoError(t, err)
+	userCookie := &http.Cookie{Name: cfg.Session.CookieName, Value: "user-token"}
+
+	rec := post(handler, cfg, "/admin/impersonation/stop", userCookie)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	// The route answers to the session alone, never to a forged request
+	req := httptest.NewRequest(http.MethodPost, "/admin/impersonation/stop", nil)
+	req.AddCookie(userCookie)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestAdminUsers_List(t *testing.T) {
+	handler, authService, _ := newTestHandler(t)
+
+	createUser(t, authService, "alice@example.com")
+	bob := createUser(t, authService, "bob@example.com")
+	bob.EmailVerified = false
+	assert.NoError(t, authService.UserService.UpdateUser(bob))
+	carol := createUser(t, authService, "carol@example.com")
+	carol.Banned = true
+	assert.NoError(t, authService.UserService.UpdateUser(carol))
+
+	list := func(query string) models.UserList {
+		t.Helper()
+		rec := adminRequest(handler, http.MethodGet, "/admin/users"+query, nil)
+		assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var result models.UserList
+		assert.NoError(t, json.NewDecoder(rec.Body).Decode(&result))
+		return result
+	}
+
+	page := list("?limit=2")
+	assert.Equal(t, int64(3), page.Total)
+	assert.Len(t, page.Users, 2)
+	assert.Equal(t, 2, page.Limit)
+	page = list("?limit=2&offset=2")
+	assert.Len(t, page.Users, 1)
+
+	for query, email := range map[string]string{
+		"?search=ALICE":         "alice@example.com",
+		"?email_verified=false": "bob@example.com",
+		"?banned=true":          "carol@example.com",
+	} {
+		page := list(query)
+		if assert.Len(t, page.Users, 1, query) {
+			assert.Equal(t, email, page.Users[0].Email, query)
+		}
+	}
+	assert.Equal(t, int64(2), list("?banned=false").Total)
+
+	rec := adminRequest(handler, http.MethodGet, "/admin/users?limit=ten", nil)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin/users", nil)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestAdminUsers_Update(t *testing.T) {
+	handler, authService, _ := newTestHandler(t)
+
+	user := createUser(t, authService, "user@example.com")
+	createUser(t, authService, "taken@example.com")
+
+	rec := adminRequest(handler, http.MethodPatch, "/admin/users/"+user.ID, map[string]any{
+		"name":           "Renamed",
+		"email":          "renamed@example.com",
+		"email_verified": false,
+	})
+	if !assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String()) {
+		return
+	}
+	updated, err := authService.UserService.GetUserByID(user.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, "Renamed", updated.Name)
+	assert.Equal(t, "renamed@example.com", updated.Email)
+	assert.False(t, updated.EmailVerified)
+
+	rec = adminRequest(handler, http.MethodPatch, "/admin/users/"+user.ID, map[string]any{"email": "taken@example.com"})
+	assert.Equal(t, http.StatusConflict, rec.Code)
+	rec = adminRequest(handler, http.MethodPatch, "/admin/users/"+user.ID, map[string]any{"email": "not-an-email"})
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	rec = adminRequest(handler, http.MethodPatch, "/admin/users/unknown", map[string]any{"name": "Nobody"})
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = adminRequest(handler, http.MethodPost, "/admin/users/"+user.ID+"/verify-email", nil)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	updated, err = authService.UserService.GetUserByID(user.ID)
+	assert.NoError(t, err)
+	assert.True(t, updated.EmailVerified)
+}
+
+func TestAdminUsers_Delete(t *testing.T) {
+	handler, authService, _ := newTestHandler(t)
+
+	user := createUser(t, authService, "user@example.com")
+	_, err := authService.SessionService.CreateSession(user.ID, authService.TokenService.HashToken("user-token"))
+	assert.NoError(t, err)
+
+	rec := adminRequest(handler, http.MethodDelete, "/admin/users/"+user.ID, nil)
+	if !assert.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String()) {
+		return
+	}
+
+	rec = adminRequest(handler, http.MethodGet, "/admin/users/"+user.ID, nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	session, err := authService.SessionService.GetSessionByToken(authService.TokenService.HashToken("user-token"))
+	assert.NoError(t, err)
+	assert.Nil(t, session, "the sessions of a deleted user must be revoked")
+
+	rec = adminRequest(handler, http.MethodDelete, "/admin/users/"+user.ID, nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestAdminUsers_SetPassword(t *testing.T) {
+	var resetEmails []string
+	handler, authService, _ := newTestHandler(t, config.WithEmailPassword(models.EmailPasswordConfig{
+		SendResetPasswordEmail: func(user models.User, url string, token string) error {
+			resetEmails = append(resetEmails, user.Email)
+			return nil
+		},
+	}))
+
+	rec := adminRequest(handler, http.MethodPost, "/admin/users", map[string]any{
+		"name":     "User",
+		"email":    "user@example.com",
+		"password": "password123",
+	})
+	if !assert.Equal(t, http.StatusCreated, rec.Code, rec.Body.String()) {
+		return
+	}
+	var created struct {
+		User models.User `json:"user"`
+	}
+	assert.NoError(t, json.NewDecoder(rec.Body).Decode(&created))
+
+	account, err := authService.AccountService.GetCredentialAccount(created.User.ID)
+	if assert.NoError(t, err) && assert.NotNil(t, account) && assert.NotNil(t, account.Password) {
+		valid, err := authService.PasswordService.VerifyPassword("password123", *account.Password)
+		assert.NoError(t, err)
+		assert.True(t, valid, "the password set by the admin must be stored hashed on the credential account")
+	}
+
+	rec = adminRequest(handler, http.MethodPost, "/admin/users", map[string]any{"name": "User", "email": "user@example.com"})
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	rec = adminRequest(handler, http.MethodPost, "/admin/users/"+created.User.ID+"/reset-password", nil)
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, []string{"user@example.com"}, resetEmails)
+
+	rec = adminRequest(handler, http.MethodPost, "/admin/users/unknown/reset-password", nil)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -0,0 +1,62 @@
+package accounts
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/GoBetterAuth/go-better-auth/internal/constants"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+type service struct {
+	config         *models.Config
+	logger         models.Logger
+	accountService models.AccountService
+}
+
+func New(
+	config *models.Config,
+	logger models.Logger,
+	accountService models.AccountService,
+) *service {
+	return &service{
+		config:         config,
+		logger:         logger,
+		accountService: accountService,
+	}
+}
+
+func (s *service) ListAccounts(ctx context.Context, userID string) ([]models.LinkedAccount, error) {
+	accounts, err := s.accountService.ListAccountsByUserID(userID)
+	if err != nil {
+		s.logger.Error("failed to list accounts", "user_id", userID, "error", err)
+		return nil, fmt.Errorf("failed to list accounts: %w", err)
+	}
+
+	result := make([]models.LinkedAccount, 0, len(accounts))
+	for _, account := range accounts {
+		result = append(result, models.LinkedAccount{
+			ID:         account.ID,
+			ProviderID: account.ProviderID,
+			AccountID:  account.AccountID,
+			Scope:      account.Scope,
+			CreatedAt:  account.CreatedAt,
+			UpdatedAt:  account.UpdatedAt,
+		})
+	}
+
+	return result, nil
+}
+
+func (s *service) UnlinkAccount(ctx context.Context, userID string, accountID string) error {
+	if err := s.accountService.UnlinkAccount(userID, accountID); err != nil {
+		if errors.Is(err, constants.ErrAccountNotFound) || errors.Is(err, constants.ErrLastAccount) {
+			return err
+		}
+		s.logger.Error("failed to unlink account", "user_id", userID, "account_id", accountID, "error", err)
+		return fmt.Errorf("failed to unlink account: %w", err)
+	}
+
+	return nil
+}
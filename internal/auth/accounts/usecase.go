@@ -0,0 +1,14 @@
+package accounts
+
+import (
+	"context"
+
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+type AccountsUseCase interface {
+	// ListAccounts returns the credential and OAuth2 accounts the user can sign in with.
+	ListAccounts(ctx context.Context, userID string) ([]models.LinkedAccount, error)
+	// UnlinkAccount removes one of the user's accounts. The last remaining account cannot be removed.
+	UnlinkAccount(ctx context.Context, userID string, accountID string) error
+}
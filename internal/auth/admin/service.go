@@ -0,0 +1,425 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	resetpassword "github.com/GoBetterAuth/go-better-auth/internal/auth/reset-password"
+	"github.com/GoBetterAuth/go-better-auth/internal/constants"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+)
+
+type service struct {
+	config               *models.Config
+	logger               models.Logger
+	userService          models.UserService
+	accountService       models.AccountService
+	sessionService       models.SessionService
+	tokenService         models.TokenService
+	passwordService      models.PasswordService
+	oidcProviderService  models.OIDCProviderService
+	eventEmitter         models.EventEmitter
+	resetPasswordUseCase resetpassword.ResetPasswordUseCase
+}
+
+func New(
+	config *models.Config,
+	logger models.Logger,
+	userService models.UserService,
+	accountService models.AccountService,
+	sessionService models.SessionService,
+	tokenService models.TokenService,
+	passwordService models.PasswordService,
+	oidcProviderService models.OIDCProviderService,
+	eventEmitter models.EventEmitter,
+	resetPasswordUseCase resetpassword.ResetPasswordUseCase,
+) *service {
+	return &service{
+		config:               config,
+		logger:               logger,
+		userService:          userService,
+		accountService:       accountService,
+		sessionService:       sessionService,
+		tokenService:         tokenService,
+		passwordService:      passwordService,
+		oidcProviderService:  oidcProviderService,
+		eventEmitter:         eventEmitter,
+		resetPasswordUseCase: resetPasswordUseCase,
+	}
+}
+
+func (s *service) ListUsers(ctx context.Context, query models.UserListQuery) (*models.UserList, error) {
+	if query.Limit <= 0 {
+		query.Limit = defaultListLimit
+	}
+	query.Limit = min(query.Limit, maxListLimit)
+	query.Offset = max(query.Offset, 0)
+	query.Search = strings.TrimSpace(query.Search)
+
+	users, total, err := s.userService.ListUsers(query)
+	if err != nil {
+		s.logger.Error("failed to list users", "error", err)
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+
+	return &models.UserList{
+		Users:  users,
+		Total:  total,
+		Limit:  query.Limit,
+		Offset: query.Offset,
+	}, nil
+}
+
+func (s *service) GetUser(ctx context.Context, userID string) (*models.User, error) {
+	user, err := s.userService.GetUserByID(userID)
+	if err != nil {
+		s.logger.Error("failed to get user", "user_id", userID, "error", err)
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil {
+		return nil, constants.ErrUserNotFound
+	}
+
+	return user, nil
+}
+
+func (s *service) CreateUser(ctx context.Context, request models.AdminCreateUserRequest) (*models.User, error) {
+	existingUser, err := s.userService.GetUserByEmail(request.Email)
+	if err != nil {
+		s.logger.Error("failed to check existing user", "email", request.Email, "error", err)
+		return nil, fmt.Errorf("failed to check existing user: %w", err)
+	}
+	if existingUser != nil {
+		return nil, constants.ErrUserAlreadyExists
+	}
+
+	// Hash before creating the user so that a failure does not leave a user without its account
+	var hashedPassword *string
+	if request.Password != nil && *request.Password != "" {
+		hashed, err := s.hashPassword(*request.Password)
+		if err != nil {
+			s.logger.Error("failed to hash password", "error", err)
+			return nil, fmt.Errorf("%w: %w", constants.ErrPasswordHashingFailed, err)
+		}
+		hashedPassword = &hashed
+	}
+
+	newUser := &models.User{
+		Name:          request.Name,
+		Email:         request.Email,
+		EmailVerified: request.EmailVerified,
+		Image:         request.Image,
+		CreatedAt:     time.Now().UTC(),
+		UpdatedAt:     time.Now().UTC(),
+	}
+	if err := s.userService.CreateUser(newUser); err != nil {
+		s.logger.Error("failed to create user", "email", request.Email, "error", err)
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	if hashedPassword != nil {
+		newAccount := &models.Account{
+			UserID:     newUser.ID,
+			ProviderID: models.ProviderEmail,
+			Password:   hashedPassword,
+			CreatedAt:  time.Now().UTC(),
+			UpdatedAt:  time.Now().UTC(),
+		}
+		if err := s.accountService.CreateAccount(newAccount); err != nil {
+			s.logger.Error("failed to create account", "user_id", newUser.ID, "error", err)
+			return nil, fmt.Errorf("%w: %w", constants.ErrAccountCreationFailed, err)
+		}
+	}
+
+	return newUser, nil
+}
+
+func (s *service) UpdateUser(ctx context.Context, userID string, request models.AdminUpdateUserRequest) (*models.User, error) {
+	user, err := s.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Email != nil && !strings.EqualFold(*request.Email, user.Email) {
+		existingUser, err := s.userService.GetUserByEmail(*request.Email)
+		if err != nil {
+			s.logger.Error("failed to check existing user", "email", *request.Email, "error", err)
+			return nil, fmt.Errorf("failed to check existing user: %w", err)
+		}
+		if existingUser != nil {
+			return nil, constants.ErrEmailAlreadyExists
+		}
+		user.Email = *request.Email
+	}
+	if request.Name != nil {
+		user.Name = *request.Name
+	}
+	if request.EmailVerified != nil {
+		user.EmailVerified = *request.EmailVerified
+	}
+	if request.Image != nil {
+		user.Image = request.Image
+		if *request.Image == "" {
+			user.Image = nil
+		}
+	}
+	user.UpdatedAt = time.Now().UTC()
+
+	if err := s.userService.UpdateUser(user); err != nil {
+		s.logger.Error("failed to update user", "user_id", userID, "error", err)
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+
+	return user, nil
+}
+
+func (s *service) DeleteUser(ctx context.Context, userID string) error {
+	if _, err := s.GetUser(ctx, userID); err != nil {
+		return err
+	}
+
+	if err := s.userService.DeleteUser(userID); err != nil {
+		s.logger.Error("failed to delete user", "user_id", userID, "error", err)
+		return fmt.Errorf("failed to delete user: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) VerifyUserEmail(ctx context.Context, userID string) (*models.User, error) {
+	verified := true
+	return s.UpdateUser(ctx, userID, models.AdminUpdateUserRequest{EmailVerified: &verified})
+}
+
+func (s *service) SendPasswordReset(ctx context.Context, userID string, callbackURL *string) error {
+	user, err := s.GetUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	return s.resetPasswordUseCase.ResetPassword(ctx, user.Email, callbackURL)
+}
+
+func (s *service) ListUserSessions(ctx context.Context, userID string) ([]models.ActiveSession, error) {
+	if _, err := s.GetUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	sessions, err := s.sessionService.ListSessionsByUserID(userID)
+	if err != nil {
+		s.logger.Error("failed to list sessions", "user_id", userID, "error", err)
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	result := make([]models.ActiveSession, 0, len(sessions))
+	for _, sess := range sessions {
+		result = append(result, models.ActiveSession{
+			ID:        sess.ID,
+			IPAddress: sess.IPAddress,
+			UserAgent: sess.UserAgent,
+			ExpiresAt: sess.ExpiresAt,
+			CreatedAt: sess.CreatedAt,
+			UpdatedAt: sess.UpdatedAt,
+		})
+	}
+
+	return result, nil
+}
+
+func (s *service) RevokeUserSession(ctx context.Context, userID string, sessionID string) error {
+	if err := s.sessionService.RevokeSession(userID, sessionID); err != nil {
+		if errors.Is(err, constants.ErrSessionNotFound) {
+			return err
+		}
+		s.logger.Error("failed to revoke session", "user_id", userID, "session_id", sessionID, "error", err)
+		return fmt.Errorf("%w: %w", constants.ErrSessionDeletionFailed, err)
+	}
+
+	return nil
+}
+
+func (s *service) RevokeUserSessions(ctx context.Context, userID string) error {
+	if _, err := s.GetUser(ctx, userID); err != nil {
+		return err
+	}
+
+	if err := s.sessionService.RevokeUserSessions(userID); err != nil {
+		s.logger.Error("failed to revoke sessions", "user_id", userID, "error", err)
+		return fmt.Errorf("%w: %w", constants.ErrSessionDeletionFailed, err)
+	}
+
+	return nil
+}
+
+func (s *service) BanUser(ctx context.Context, userID string, request models.AdminBanUserRequest) (*models.User, error) {
+	if request.ExpiresAt != nil && !request.ExpiresAt.After(time.Now()) {
+		return nil, constants.ErrBanExpiryInPast
+	}
+
+	user, err := s.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Banned = true
+	user.BanReason = request.Reason
+	user.BanExpiresAt = nil
+	if request.ExpiresAt != nil {
+		expiresAt := request.ExpiresAt.UTC()
+		user.BanExpiresAt = &expiresAt
+	}
+	user.UpdatedAt = time.Now().UTC()
+
+	if err := s.userService.UpdateUser(user); err != nil {
+		s.logger.Error("failed to ban user", "user_id", userID, "error", err)
+		return nil, fmt.Errorf("failed to ban user: %w", err)
+	}
+
+	if err := s.sessionService.RevokeUserSessions(userID); err != nil {
+		s.logger.Error("failed to revoke sessions of banned user", "user_id", userID, "error", err)
+		return nil, fmt.Errorf("%w: %w", constants.ErrSessionDeletionFailed, err)
+	}
+
+	// Clients of the OIDC provider would otherwise keep acting for the user until their tokens expire
+	if err := s.oidcProviderService.RevokeUserGrants(ctx, userID); err != nil {
+		s.logger.Error("failed to revoke oidc grants of banned user", "user_id", userID, "error", err)
+		return nil, fmt.Errorf("failed to revoke oidc grants: %w", err)
+	}
+
+	s.eventEmitter.OnUserBanned(*user)
+
+	return user, nil
+}
+
+func (s *service) UnbanUser(ctx context.Context, userID string) (*models.User, error) {
+	user, err := s.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Banned = false
+	user.BanReason = nil
+	user.BanExpiresAt = nil
+	user.UpdatedAt = time.Now().UTC()
+
+	if err := s.userService.UpdateUser(user); err != nil {
+		s.logger.Error("failed to unban user", "user_id", userID, "error", err)
+		return nil, fmt.Errorf("failed to unban user: %w", err)
+	}
+
+	s.eventEmitter.OnUserUnbanned(*user)
+
+	return user, nil
+}
+
+func (s *service) ImpersonateUser(ctx context.Context, adminSessionID string, userID string) (*models.ImpersonationResult, error) {
+	adminSession, err := s.sessionService.GetSessionByID(adminSessionID)
+	if err != nil {
+		s.logger.Error("failed to get admin session", "session_id", adminSessionID, "error", err)
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	if adminSession == nil {
+		return nil, constants.ErrSessionNotFound
+	}
+	// Chained impersonation would lose track of the admin behind it
+	if adminSession.ImpersonatedBy != nil {
+		return nil, constants.ErrAlreadyImpersonating
+	}
+	if adminSession.UserID == userID {
+		return nil, constants.ErrImpersonateSelf
+	}
+
+	user, err := s.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.IsBanned() {
+		return nil, constants.ErrUserBanned
+	}
+
+	token, err := s.tokenService.GenerateToken()
+	if err != nil {
+		s.logger.Error("failed to generate session token", "error", err)
+		return nil, fmt.Errorf("%w: %w", constants.ErrTokenGenerationFailed, err)
+	}
+
+	session, err := s.sessionService.CreateImpersonationSession(user.ID, s.tokenService.HashToken(token), adminSession.UserID)
+	if err != nil {
+		s.logger.Error("failed to create impersonation session", "user_id", user.ID, "error", err)
+		return nil, fmt.Errorf("%w: %w", constants.ErrSessionCreationFailed, err)
+	}
+
+	s.logger.Info("impersonation started",
+		"audit", true,
+		"admin_id", adminSession.UserID,
+		"user_id", user.ID,
+		"session_id", session.ID,
+		"expires_at", session.ExpiresAt,
+	)
+	s.eventEmitter.OnImpersonationStarted(*session)
+
+	return &models.ImpersonationResult{
+		Token:   token,
+		User:    user,
+		Session: session,
+	}, nil
+}
+
+func (s *service) StopImpersonating(ctx context.Context, sessionID string, adminSessionToken string) (*models.StopImpersonationResult, error) {
+	session, err := s.sessionService.GetSessionByID(sessionID)
+	if err != nil {
+		s.logger.Error("failed to get session", "session_id", sessionID, "error", err)
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	if session == nil {
+		return nil, constants.ErrSessionNotFound
+	}
+	if session.ImpersonatedBy == nil {
+		return nil, constants.ErrNotImpersonating
+	}
+
+	if err := s.sessionService.RevokeSession(session.UserID, session.ID); err != nil && !errors.Is(err, constants.ErrSessionNotFound) {
+		s.logger.Error("failed to revoke impersonation session", "session_id", session.ID, "error", err)
+		return nil, fmt.Errorf("%w: %w", constants.ErrSessionDeletionFailed, err)
+	}
+
+	s.logger.Info("impersonation stopped",
+		"audit", true,
+		"admin_id", *session.ImpersonatedBy,
+		"user_id", session.UserID,
+		"session_id", session.ID,
+	)
+	s.eventEmitter.OnImpersonationStopped(*session)
+
+	result := &models.StopImpersonationResult{}
+	if adminSessionToken == "" {
+		return result, nil
+	}
+
+	// Only hand back a session that still belongs to the admin who started the impersonation
+	adminSession, err := s.sessionService.GetSessionByToken(s.tokenService.HashToken(adminSessionToken))
+	if err != nil {
+		s.logger.Warn("failed to get admin session", "error", err)
+		return result, nil
+	}
+	if adminSession != nil && adminSession.UserID == *session.ImpersonatedBy && !s.sessionService.IsExpired(adminSession) {
+		result.Token = adminSessionToken
+	}
+
+	return result, nil
+}
+
+func (s *service) hashPassword(password string) (string, error) {
+	if s.config.EmailPassword.Password.Hash != nil {
+		return s.config.EmailPassword.Password.Hash(password)
+	}
+	return s.passwordService.HashPassword(password)
+}
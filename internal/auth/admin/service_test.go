@@ -0,0 +1,226 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/GoBetterAuth/go-better-auth/config"
+	"github.com/GoBetterAuth/go-better-auth/internal/constants"
+	"github.com/GoBetterAuth/go-better-auth/internal/events"
+	"github.com/GoBetterAuth/go-better-auth/internal/services"
+	"github.com/GoBetterAuth/go-better-auth/internal/testutil"
+	"github.com/GoBetterAuth/go-better-auth/models"
+	"github.com/GoBetterAuth/go-better-auth/storage"
+)
+
+type testEnv struct {
+	userService         *services.UserServiceImpl
+	sessionService      *services.SessionServiceImpl
+	tokenService        *services.TokenServiceImpl
+	oidcProviderService *services.OIDCProviderServiceImpl
+	useCase             *service
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+
+	db := testutil.NewDB(t, &models.User{}, &models.Account{}, &models.Session{})
+	cfg := config.NewConfig(
+		config.WithSecondaryStorage(models.SecondaryStorageConfig{
+			Storage: storage.NewMemorySecondaryStorage(models.SecondaryStorageMemoryOptions{}),
+		}),
+		config.WithSession(models.SessionConfig{ExpiresIn: time.Hour, ImpersonationExpiresIn: time.Hour}),
+	)
+	logger := testutil.NewLogger()
+
+	env := &testEnv{
+		userService:         services.NewUserServiceImpl(cfg, db),
+		sessionService:      services.NewSessionServiceImpl(cfg, db),
+		tokenService:        services.NewTokenServiceImpl(cfg, nil),
+		oidcProviderService: services.NewOIDCProviderServiceImpl(cfg, db),
+	}
+	env.useCase = New(
+		cfg,
+		logger,
+		env.userService,
+		services.NewAccountServiceImpl(cfg, db),
+		env.sessionService,
+		env.tokenService,
+		services.NewArgon2PasswordService(),
+		env.oidcProviderService,
+		events.NewEventEmitter(cfg, logger, nil, nil),
+		nil,
+	)
+	return env
+}
+
+func (env *testEnv) createUser(t *testing.T, email string) *models.User {
+	t.Helper()
+
+	user := &models.User{Email: email, EmailVerified: true}
+	if err := env.userService.CreateUser(user); err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+	return user
+}
+
+func (env *testEnv) createSession(t *testing.T, userID string) (*models.Session, string) {
+	t.Helper()
+
+	token, err := env.tokenService.GenerateToken()
+	if err != nil {
+		t.Fatalf("GenerateToken failed: %v", err)
+	}
+	session, err := env.sessionService.CreateSession(userID, env.tokenService.HashToken(token))
+	if err != nil {
+		t.Fatalf("CreateSession failed: %v", err)
+	}
+	return session, token
+}
+
+func TestBanUser(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+
+	user := env.createUser(t, "user@example.com")
+	session, _ := env.createSession(t, user.ID)
+	accessToken, err := env.oidcProviderService.CreateGrant(ctx, models.OAuthGrantKindAccessToken, &models.OAuthGrant{
+		ClientID:  "client-1",
+		UserID:    user.ID,
+		ExpiresAt: time.Now().UTC().Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("CreateGrant failed: %v", err)
+	}
+
+	past := time.Now().Add(-time.Minute)
+	if _, err := env.useCase.BanUser(ctx, user.ID, models.AdminBanUserRequest{ExpiresAt: &past}); !errors.Is(err, constants.ErrBanExpiryInPast) {
+		t.Fatalf("expected ErrBanExpiryInPast, got %v", err)
+	}
+
+	reason := "spam"
+	banned, err := env.useCase.BanUser(ctx, user.ID, models.AdminBanUserRequest{Reason: &reason})
+	if err != nil {
+		t.Fatalf("BanUser failed: %v", err)
+	}
+	if !banned.IsBanned() || banned.BanReason == nil || *banned.BanReason != reason {
+		t.Fatalf("unexpected banned user: %+v", banned)
+	}
+
+	if found, _ := env.sessionService.GetSessionByID(session.ID); found != nil {
+		t.Fatal("expected the sessions of a banned user to be revoked")
+	}
+	if grant, _ := env.oidcProviderService.GetGrant(ctx, models.OAuthGrantKindAccessToken, accessToken); grant != nil {
+		t.Fatal("expected the oidc grants of a banned user to be revoked")
+	}
+
+	// Banned users cannot be impersonated either
+	adminUser := env.createUser(t, "admin@example.com")
+	adminSession, _ := env.createSession(t, adminUser.ID)
+	if _, err := env.useCase.ImpersonateUser(ctx, adminSession.ID, user.ID); !errors.Is(err, constants.ErrUserBanned) {
+		t.Fatalf("expected ErrUserBanned, got %v", err)
+	}
+
+	unbanned, err := env.useCase.UnbanUser(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("UnbanUser failed: %v", err)
+	}
+	if unbanned.IsBanned() || unbanned.BanReason != nil {
+		t.Fatalf("unexpected unbanned user: %+v", unbanned)
+	}
+}
+
+func TestBanUser_Expiry(t *testing.T) {
+	env := newTestEnv(t)
+
+	user := env.createUser(t, "user@example.com")
+	expiresAt := time.Now().Add(time.Hour)
+	banned, err := env.useCase.BanUser(context.Background(), user.ID, models.AdminBanUserRequest{ExpiresAt: &expiresAt})
+	if err != nil {
+		t.Fatalf("BanUser failed: %v", err)
+	}
+	if !banned.IsBanned() {
+		t.Fatal("expected the user to be banned until the expiry")
+	}
+
+	// The ban is lifted once it expires
+	lifted := time.Now().Add(-time.Second)
+	banned.BanExpiresAt = &lifted
+	if banned.IsBanned() {
+		t.Fatal("expected an expired ban to be lifted")
+	}
+}
+
+func TestImpersonation(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+
+	adminUser := env.createUser(t, "admin@example.com")
+	adminSession, adminToken := env.createSession(t, adminUser.ID)
+	user := env.createUser(t, "user@example.com")
+
+	if _, err := env.useCase.ImpersonateUser(ctx, adminSession.ID, adminUser.ID); !errors.Is(err, constants.ErrImpersonateSelf) {
+		t.Fatalf("expected ErrImpersonateSelf, got %v", err)
+	}
+	if _, err := env.useCase.StopImpersonating(ctx, adminSession.ID, adminToken); !errors.Is(err, constants.ErrNotImpersonating) {
+		t.Fatalf("expected ErrNotImpersonating, got %v", err)
+	}
+
+	result, err := env.useCase.ImpersonateUser(ctx, adminSession.ID, user.ID)
+	if err != nil {
+		t.Fatalf("ImpersonateUser failed: %v", err)
+	}
+	if result.Session.UserID != user.ID || result.Session.ImpersonatedBy == nil || *result.Session.ImpersonatedBy != adminUser.ID {
+		t.Fatalf("unexpected impersonation session: %+v", result.Session)
+	}
+	session, _ := env.sessionService.GetSessionByToken(env.tokenService.HashToken(result.Token))
+	if session == nil || session.ID != result.Session.ID {
+		t.Fatal("expected the impersonation token to resolve to the impersonation session")
+	}
+
+	// Impersonation cannot be chained
+	other := env.createUser(t, "other@example.com")
+	if _, err := env.useCase.ImpersonateUser(ctx, result.Session.ID, other.ID); !errors.Is(err, constants.ErrAlreadyImpersonating) {
+		t.Fatalf("expected ErrAlreadyImpersonating, got %v", err)
+	}
+
+	stopped, err := env.useCase.StopImpersonating(ctx, result.Session.ID, adminToken)
+	if err != nil {
+		t.Fatalf("StopImpersonating failed: %v", err)
+	}
+	if stopped.Token != adminToken {
+		t.Fatal("expected the admin session to be handed back")
+	}
+	if found, _ := env.sessionService.GetSessionByID(result.Session.ID); found != nil {
+		t.Fatal("expected the impersonation session to be revoked")
+	}
+	if found, _ := env.sessionService.GetSessionByID(adminSession.ID); found == nil {
+		t.Fatal("expected the admin session to be kept")
+	}
+}
+
+func TestStopImpersonating_OnlyHandsBackTheAdminSession(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+
+	adminUser := env.createUser(t, "admin@example.com")
+	adminSession, _ := env.createSession(t, adminUser.ID)
+	user := env.createUser(t, "user@example.com")
+	_, otherToken := env.createSession(t, user.ID)
+
+	result, err := env.useCase.ImpersonateUser(ctx, adminSession.ID, user.ID)
+	if err != nil {
+		t.Fatalf("ImpersonateUser failed: %v", err)
+	}
+
+	// A token of anyone but the impersonating admin is not handed back
+	stopped, err := env.useCase.StopImpersonating(ctx, result.Session.ID, otherToken)
+	if err != nil {
+		t.Fatalf("StopImpersonating failed: %v", err)
+	}
+	if stopped.Token != "" {
+		t.Fatal("expected no session to be handed back")
+	}
+}
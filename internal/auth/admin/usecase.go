@@ -0,0 +1,40 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+type AdminUseCase interface {
+	// ListUsers returns a page of users matching the query, newest first.
+	ListUsers(ctx context.Context, query models.UserListQuery) (*models.UserList, error)
+	// GetUser returns a user by ID.
+	GetUser(ctx context.Context, userID string) (*models.User, error)
+	// CreateUser creates a user, with a credential account when a password is given.
+	CreateUser(ctx context.Context, request models.AdminCreateUserRequest) (*models.User, error)
+	// UpdateUser changes the given fields of a user.
+	UpdateUser(ctx context.Context, userID string, request models.AdminUpdateUserRequest) (*models.User, error)
+	// DeleteUser deletes a user along with their accounts, sessions, the organizations they are the only owner of
+	// and other related records.
+	DeleteUser(ctx context.Context, userID string) error
+	// VerifyUserEmail marks the email of a user as verified without sending a verification email.
+	VerifyUserEmail(ctx context.Context, userID string) (*models.User, error)
+	// SendPasswordReset sends the user a password reset email.
+	SendPasswordReset(ctx context.Context, userID string, callbackURL *string) error
+	// ListUserSessions returns the active sessions of a user.
+	ListUserSessions(ctx context.Context, userID string) ([]models.ActiveSession, error)
+	// RevokeUserSession signs a single session of a user out.
+	RevokeUserSession(ctx context.Context, userID string, sessionID string) error
+	// RevokeUserSessions signs a user out of every session.
+	RevokeUserSessions(ctx context.Context, userID string) error
+	// BanUser locks a user out and revokes all of their sessions.
+	BanUser(ctx context.Context, userID string, request models.AdminBanUserRequest) (*models.User, error)
+	// UnbanUser lifts the ban of a user.
+	UnbanUser(ctx context.Context, userID string) (*models.User, error)
+	// ImpersonateUser creates a time boxed session for a user on behalf of the admin signed in with adminSessionID.
+	ImpersonateUser(ctx context.Context, adminSessionID string, userID string) (*models.ImpersonationResult, error)
+	// StopImpersonating ends an impersonation session. The admin's own session token, when given and still
+	// valid, is returned so that the admin can be signed back in.
+	StopImpersonating(ctx context.Context, sessionID string, adminSessionToken string) (*models.StopImpersonationResult, error)
+}
@@ -23,11 +23,18 @@ func (a *AuthApiImpl) Services() *models.AuthServices {
 		Users:         a.authService.UserService,
 		Accounts:      a.authService.AccountService,
 		Sessions:      a.authService.SessionService,
+		TwoFactors:    a.authService.TwoFactorService,
+		Passkeys:      a.authService.PasskeyService,
+		EmailOTPs:     a.authService.EmailOTPService,
 		Verifications: a.authService.VerificationService,
 		Passwords:     a.authService.PasswordService,
 		Tokens:        a.authService.TokenService,
+		SigningKeys:   a.authService.SigningKeyService,
+		OIDCProvider:  a.authService.OIDCProviderService,
 		RateLimits:    a.authService.RateLimitService,
 		Mailers:       a.authService.MailerService,
+		RBAC:          a.authService.RBACService,
+		Organizations: a.authService.OrganizationService,
 	}
 }
 
@@ -43,6 +50,18 @@ func (a *AuthApiImpl) SignOut(ctx context.Context, sessionToken string) error {
 	return a.useCases.SignOutUseCase.SignOut(ctx, sessionToken)
 }
 
+func (a *AuthApiImpl) ListSessions(ctx context.Context, userID string, currentSessionID string) ([]models.ActiveSession, error) {
+	return a.useCases.SessionsUseCase.ListSessions(ctx, userID, currentSessionID)
+}
+
+func (a *AuthApiImpl) RevokeSession(ctx context.Context, userID string, sessionID string) error {
+	return a.useCases.SessionsUseCase.RevokeSession(ctx, userID, sessionID)
+}
+
+func (a *AuthApiImpl) RevokeOtherSessions(ctx context.Context, userID string, currentSessionID string) error {
+	return a.useCases.SessionsUseCase.RevokeOtherSessions(ctx, userID, currentSessionID)
+}
+
 func (a *AuthApiImpl) VerifyEmail(ctx context.Context, rawToken string) (*models.VerifyEmailResult, error) {
 	return a.useCases.VerifyEmailUseCase.VerifyEmail(ctx, rawToken)
 }
@@ -55,6 +74,26 @@ func (a *AuthApiImpl) ResetPassword(ctx context.Context, email string, callbackU
 	return a.useCases.ResetPasswordUseCase.ResetPassword(ctx, email, callbackURL)
 }
 
+func (a *AuthApiImpl) SendMagicLink(ctx context.Context, email string, callbackURL *string) error {
+	return a.useCases.MagicLinkUseCase.SendMagicLink(ctx, email, callbackURL)
+}
+
+func (a *AuthApiImpl) SendEmailOTP(ctx context.Context, email string, otpType models.EmailOTPType) error {
+	return a.useCases.EmailOTPUseCase.SendEmailOTP(ctx, email, otpType)
+}
+
+func (a *AuthApiImpl) SignInWithEmailOTP(ctx context.Context, email string, otp string) (*models.SignInResult, error) {
+	return a.useCases.EmailOTPUseCase.SignInWithEmailOTP(ctx, email, otp)
+}
+
+func (a *AuthApiImpl) VerifyEmailWithOTP(ctx context.Context, email string, otp string) (*models.VerifyEmailResult, error) {
+	return a.useCases.EmailOTPUseCase.VerifyEmailWithOTP(ctx, email, otp)
+}
+
+func (a *AuthApiImpl) ResetPasswordWithOTP(ctx context.Context, email string, otp string, newPassword string) error {
+	return a.useCases.EmailOTPUseCase.ResetPasswordWithOTP(ctx, email, otp, newPassword)
+}
+
 func (a *AuthApiImpl) ChangePassword(ctx context.Context, rawToken string, newPassword string) error {
 	return a.useCases.ChangePasswordUseCase.ChangePassword(ctx, rawToken, newPassword)
 }
@@ -63,14 +102,270 @@ func (a *AuthApiImpl) EmailChange(ctx context.Context, userID string, newEmail s
 	return a.useCases.EmailChangeUseCase.EmailChange(ctx, userID, newEmail, callbackURL)
 }
 
-func (a *AuthApiImpl) GetMe(ctx context.Context, userID string) (*models.MeResult, error) {
-	return a.useCases.MeUseCase.GetMe(ctx, userID)
+func (a *AuthApiImpl) GetMe(ctx context.Context, userID string, sessionID string) (*models.MeResult, error) {
+	return a.useCases.MeUseCase.GetMe(ctx, userID, sessionID)
+}
+
+func (a *AuthApiImpl) PrepareOAuth2Login(ctx context.Context, providerName string, options models.OAuth2LoginOptions) (*models.OAuth2LoginResult, error) {
+	return a.useCases.OAuth2UseCase.PrepareOAuth2Login(ctx, providerName, options)
+}
+
+func (a *AuthApiImpl) SignInWithOAuth2(ctx context.Context, providerName string, code string, state string) (*models.SignInResult, error) {
+	return a.useCases.OAuth2UseCase.SignInWithOAuth2(ctx, providerName, code, state)
+}
+
+func (a *AuthApiImpl) SignInWithIDToken(ctx context.Context, providerName string, idToken string, nonce string) (*models.SignInResult, error) {
+	return a.useCases.OAuth2UseCase.SignInWithIDToken(ctx, providerName, idToken, nonce)
+}
+
+func (a *AuthApiImpl) EnrollTwoFactor(ctx context.Context, userID string) (*models.TwoFactorEnrollResult, error) {
+	return a.useCases.TwoFactorUseCase.EnrollTwoFactor(ctx, userID)
+}
+
+func (a *AuthApiImpl) EnableTwoFactor(ctx context.Context, userID string, code string) error {
+	return a.useCases.TwoFactorUseCase.EnableTwoFactor(ctx, userID, code)
+}
+
+func (a *AuthApiImpl) DisableTwoFactor(ctx context.Context, userID string, code string) error {
+	return a.useCases.TwoFactorUseCase.DisableTwoFactor(ctx, userID, code)
+}
+
+func (a *AuthApiImpl) VerifyTwoFactor(ctx context.Context, challengeToken string, code string) (*models.SignInResult, error) {
+	return a.useCases.TwoFactorUseCase.VerifyTwoFactor(ctx, challengeToken, code)
+}
+
+func (a *AuthApiImpl) RegenerateBackupCodes(ctx context.Context, userID string) ([]string, error) {
+	return a.useCases.TwoFactorUseCase.RegenerateBackupCodes(ctx, userID)
+}
+
+func (a *AuthApiImpl) BeginPasskeyRegistration(ctx context.Context, userID string) (*models.PasskeyRegistrationOptions, error) {
+	return a.useCases.PasskeyUseCase.BeginPasskeyRegistration(ctx, userID)
+}
+
+func (a *AuthApiImpl) FinishPasskeyRegistration(ctx context.Context, userID string, name string, credential models.PasskeyRegistrationCredential) (*models.Passkey, error) {
+	return a.useCases.PasskeyUseCase.FinishPasskeyRegistration(ctx, userID, name, credential)
+}
+
+func (a *AuthApiImpl) BeginPasskeyAuthentication(ctx context.Context, email *string) (*models.PasskeyAuthenticationOptions, error) {
+	return a.useCases.PasskeyUseCase.BeginPasskeyAuthentication(ctx, email)
+}
+
+func (a *AuthApiImpl) FinishPasskeyAuthentication(ctx context.Context, credential models.PasskeyAuthenticationCredential) (*models.SignInResult, error) {
+	return a.useCases.PasskeyUseCase.FinishPasskeyAuthentication(ctx, credential)
+}
+
+func (a *AuthApiImpl) GetJWKS(ctx context.Context) (*models.JSONWebKeySet, error) {
+	return a.useCases.JWTUseCase.GetJWKS(ctx)
+}
+
+func (a *AuthApiImpl) IssueToken(ctx context.Context, userID string, sessionID string) (*models.TokenResult, error) {
+	return a.useCases.JWTUseCase.IssueToken(ctx, userID, sessionID)
+}
+
+func (a *AuthApiImpl) RegisterOAuthClient(ctx context.Context, registration models.OAuthClientRegistration) (*models.OAuthClientRegistrationResult, error) {
+	return a.useCases.OIDCProviderUseCase.RegisterClient(ctx, registration)
+}
+
+func (a *AuthApiImpl) AuthorizeOIDC(ctx context.Context, userID string, sessionID string, request models.OIDCAuthorizeRequest) (*models.OIDCAuthorizeResult, error) {
+	return a.useCases.OIDCProviderUseCase.Authorize(ctx, userID, sessionID, request)
+}
+
+func (a *AuthApiImpl) ConsentOIDC(ctx context.Context, userID string, consentCode string, accept bool) (*models.OIDCAuthorizeResult, error) {
+	return a.useCases.OIDCProviderUseCase.Consent(ctx, userID, consentCode, accept)
+}
+
+func (a *AuthApiImpl) ExchangeOIDCToken(ctx context.Context, request models.OIDCTokenRequest) (*models.OIDCTokenResponse, error) {
+	return a.useCases.OIDCProviderUseCase.ExchangeToken(ctx, request)
+}
+
+func (a *AuthApiImpl) GetOIDCUserInfo(ctx context.Context, accessToken string) (map[string]any, error) {
+	return a.useCases.OIDCProviderUseCase.GetUserInfo(ctx, accessToken)
+}
+
+func (a *AuthApiImpl) IntrospectToken(ctx context.Context, token string, tokenTypeHint string) (*models.IntrospectionResult, error) {
+	return a.useCases.IntrospectionUseCase.Introspect(ctx, "", token, tokenTypeHint)
+}
+
+func (a *AuthApiImpl) RevokeToken(ctx context.Context, token string, tokenTypeHint string) error {
+	return a.useCases.IntrospectionUseCase.Revoke(ctx, "", token, tokenTypeHint)
+}
+
+func (a *AuthApiImpl) VerifyForwardedRequest(ctx context.Context, userID string) (*models.User, error) {
+	return a.useCases.ForwardAuthUseCase.VerifyRequest(ctx, userID)
+}
+
+func (a *AuthApiImpl) PrepareOAuth2Link(ctx context.Context, providerName string, userID string, options models.OAuth2LoginOptions) (*models.OAuth2LoginResult, error) {
+	return a.useCases.OAuth2UseCase.PrepareOAuth2Link(ctx, providerName, userID, options)
+}
+
+func (a *AuthApiImpl) PrepareOAuth2Reauthorize(ctx context.Context, providerName string, userID string, options models.OAuth2LoginOptions) (*models.OAuth2LoginResult, error) {
+	return a.useCases.OAuth2UseCase.PrepareOAuth2Reauthorize(ctx, providerName, userID, options)
+}
+
+func (a *AuthApiImpl) GetAccessToken(ctx context.Context, userID string, providerName string) (*models.OAuth2AccessToken, error) {
+	return a.useCases.OAuth2UseCase.GetAccessToken(ctx, userID, providerName)
+}
+
+func (a *AuthApiImpl) ListAccounts(ctx context.Context, userID string) ([]models.LinkedAccount, error) {
+	return a.useCases.AccountsUseCase.ListAccounts(ctx, userID)
+}
+
+func (a *AuthApiImpl) UnlinkAccount(ctx context.Context, userID string, accountID string) error {
+	return a.useCases.AccountsUseCase.UnlinkAccount(ctx, userID, accountID)
+}
+
+func (a *AuthApiImpl) AdminListUsers(ctx context.Context, query models.UserListQuery) (*models.UserList, error) {
+	return a.useCases.AdminUseCase.ListUsers(ctx, query)
+}
+
+func (a *AuthApiImpl) AdminGetUser(ctx context.Context, userID string) (*models.User, error) {
+	return a.useCases.AdminUseCase.GetUser(ctx, userID)
+}
+
+func (a *AuthApiImpl) AdminCreateUser(ctx context.Context, request models.AdminCreateUserRequest) (*models.User, error) {
+	return a.useCases.AdminUseCase.CreateUser(ctx, request)
+}
+
+func (a *AuthApiImpl) AdminUpdateUser(ctx context.Context, userID string, request models.AdminUpdateUserRequest) (*models.User, error) {
+	return a.useCases.AdminUseCase.UpdateUser(ctx, userID, request)
+}
+
+func (a *AuthApiImpl) AdminDeleteUser(ctx context.Context, userID string) error {
+	return a.useCases.AdminUseCase.DeleteUser(ctx, userID)
+}
+
+func (a *AuthApiImpl) AdminVerifyUserEmail(ctx context.Context, userID string) (*models.User, error) {
+	return a.useCases.AdminUseCase.VerifyUserEmail(ctx, userID)
+}
+
+func (a *AuthApiImpl) AdminSendPasswordReset(ctx context.Context, userID string, callbackURL *string) error {
+	return a.useCases.AdminUseCase.SendPasswordReset(ctx, userID, callbackURL)
+}
+
+func (a *AuthApiImpl) AdminListUserSessions(ctx context.Context, userID string) ([]models.ActiveSession, error) {
+	return a.useCases.AdminUseCase.ListUserSessions(ctx, userID)
+}
+
+func (a *AuthApiImpl) AdminRevokeUserSession(ctx context.Context, userID string, sessionID string) error {
+	return a.useCases.AdminUseCase.RevokeUserSession(ctx, userID, sessionID)
+}
+
+func (a *AuthApiImpl) AdminRevokeUserSessions(ctx context.Context, userID string) error {
+	return a.useCases.AdminUseCase.RevokeUserSessions(ctx, userID)
+}
+
+func (a *AuthApiImpl) AdminBanUser(ctx context.Context, userID string, request models.AdminBanUserRequest) (*models.User, error) {
+	return a.useCases.AdminUseCase.BanUser(ctx, userID, request)
+}
+
+func (a *AuthApiImpl) AdminUnbanUser(ctx context.Context, userID string) (*models.User, error) {
+	return a.useCases.AdminUseCase.UnbanUser(ctx, userID)
+}
+
+func (a *AuthApiImpl) AdminImpersonateUser(ctx context.Context, adminSessionID string, userID string) (*models.ImpersonationResult, error) {
+	return a.useCases.AdminUseCase.ImpersonateUser(ctx, adminSessionID, userID)
+}
+
+func (a *AuthApiImpl) AdminStopImpersonating(ctx context.Context, sessionID string, adminSessionToken string) (*models.StopImpersonationResult, error) {
+	return a.useCases.AdminUseCase.StopImpersonating(ctx, sessionID, adminSessionToken)
+}
+
+func (a *AuthApiImpl) AdminListRoles(ctx context.Context) ([]models.Role, error) {
+	return a.useCases.RBACUseCase.ListRoles(ctx)
+}
+
+func (a *AuthApiImpl) AdminGetRole(ctx context.Context, roleID string) (*models.RoleWithPermissions, error) {
+	return a.useCases.RBACUseCase.GetRole(ctx, roleID)
+}
+
+func (a *AuthApiImpl) AdminCreateRole(ctx context.Context, request models.CreateRoleRequest) (*models.Role, error) {
+	return a.useCases.RBACUseCase.CreateRole(ctx, request)
+}
+
+func (a *AuthApiImpl) AdminUpdateRole(ctx context.Context, roleID string, request models.UpdateRoleRequest) (*models.Role, error) {
+	return a.useCases.RBACUseCase.UpdateRole(ctx, roleID, request)
+}
+
+func (a *AuthApiImpl) AdminDeleteRole(ctx context.Context, roleID string) error {
+	return a.useCases.RBACUseCase.DeleteRole(ctx, roleID)
+}
+
+func (a *AuthApiImpl) AdminListPermissions(ctx context.Context) ([]models.Permission, error) {
+	return a.useCases.RBACUseCase.ListPermissions(ctx)
+}
+
+func (a *AuthApiImpl) AdminCreatePermission(ctx context.Context, request models.CreatePermissionRequest) (*models.Permission, error) {
+	return a.useCases.RBACUseCase.CreatePermission(ctx, request)
+}
+
+func (a *AuthApiImpl) AdminDeletePermission(ctx context.Context, permissionID string) error {
+	return a.useCases.RBACUseCase.DeletePermission(ctx, permissionID)
+}
+
+func (a *AuthApiImpl) AdminAddRolePermission(ctx context.Context, roleID string, permissionID string) error {
+	return a.useCases.RBACUseCase.AddRolePermission(ctx, roleID, permissionID)
+}
+
+func (a *AuthApiImpl) AdminRemoveRolePermission(ctx context.Context, roleID string, permissionID string) error {
+	return a.useCases.RBACUseCase.RemoveRolePermission(ctx, roleID, permissionID)
+}
+
+func (a *AuthApiImpl) AdminListUserRoles(ctx context.Context, userID string) ([]models.Role, error) {
+	return a.useCases.RBACUseCase.ListUserRoles(ctx, userID)
+}
+
+func (a *AuthApiImpl) AdminAssignUserRole(ctx context.Context, userID string, roleID string) error {
+	return a.useCases.RBACUseCase.AssignUserRole(ctx, userID, roleID)
+}
+
+func (a *AuthApiImpl) AdminRemoveUserRole(ctx context.Context, userID string, roleID string) error {
+	return a.useCases.RBACUseCase.RemoveUserRole(ctx, userID, roleID)
+}
+
+func (a *AuthApiImpl) HasPermission(ctx context.Context, userID string, permission string) (bool, error) {
+	return a.useCases.RBACUseCase.HasPermission(ctx, userID, permission)
+}
+
+func (a *AuthApiImpl) CreateOrganization(ctx context.Context, userID string, sessionID string, request models.CreateOrganizationRequest) (*models.UserOrganization, error) {
+	return a.useCases.OrganizationUseCase.CreateOrganization(ctx, userID, sessionID, request)
+}
+
+func (a *AuthApiImpl) ListOrganizations(ctx context.Context, userID string) ([]models.UserOrganization, error) {
+	return a.useCases.OrganizationUseCase.ListOrganizations(ctx, userID)
+}
+
+func (a *AuthApiImpl) SetActiveOrganization(ctx context.Context, userID string, sessionID string, organizationID *string) (*models.Session, error) {
+	return a.useCases.OrganizationUseCase.SetActiveOrganization(ctx, userID, sessionID, organizationID)
+}
+
+func (a *AuthApiImpl) ListOrganizationMembers(ctx context.Context, userID string, organizationID string) ([]models.OrganizationMember, error) {
+	return a.useCases.OrganizationUseCase.ListMembers(ctx, userID, organizationID)
+}
+
+func (a *AuthApiImpl) DeleteOrganization(ctx context.Context, userID string, organizationID string) error {
+	return a.useCases.OrganizationUseCase.DeleteOrganization(ctx, userID, organizationID)
+}
+
+func (a *AuthApiImpl) UpdateOrganizationMemberRole(ctx context.Context, userID string, organizationID string, memberUserID string, role models.OrganizationRole) (*models.Member, error) {
+	return a.useCases.OrganizationUseCase.UpdateMemberRole(ctx, userID, organizationID, memberUserID, role)
+}
+
+func (a *AuthApiImpl) RemoveOrganizationMember(ctx context.Context, userID string, organizationID string, memberUserID string) error {
+	return a.useCases.OrganizationUseCase.RemoveMember(ctx, userID, organizationID, memberUserID)
+}
+
+func (a *AuthApiImpl) CreateOrganizationInvitation(ctx context.Context, inviterID string, organizationID string, request models.CreateInvitationRequest) (*models.Invitation, error) {
+	return a.useCases.OrganizationUseCase.CreateInvitation(ctx, inviterID, organizationID, request)
+}
+
+func (a *AuthApiImpl) ListOrganizationInvitations(ctx context.Context, userID string, organizationID string) ([]models.Invitation, error) {
+	return a.useCases.OrganizationUseCase.ListInvitations(ctx, userID, organizationID)
 }
 
-func (a *AuthApiImpl) PrepareOAuth2Login(ctx context.Context, providerName string) (*models.OAuth2LoginResult, error) {
-	return a.useCases.OAuth2UseCase.PrepareOAuth2Login(ctx, providerName)
+func (a *AuthApiImpl) AcceptOrganizationInvitation(ctx context.Context, userID string, token string) (*models.InvitationResult, error) {
+	return a.useCases.OrganizationUseCase.AcceptInvitation(ctx, userID, token)
 }
 
-func (a *AuthApiImpl) SignInWithOAuth2(ctx context.Context, providerName string, code string, state string, verifier *string) (*models.SignInResult, error) {
-	return a.useCases.OAuth2UseCase.SignInWithOAuth2(ctx, providerName, code, state, verifier)
+func (a *AuthApiImpl) DeclineOrganizationInvitation(ctx context.Context, userID string, token string) (*models.InvitationResult, error) {
+	return a.useCases.OrganizationUseCase.DeclineInvitation(ctx, userID, token)
 }
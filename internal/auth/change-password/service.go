@@ -13,6 +13,7 @@ type service struct {
 	logger              models.Logger
 	userService         models.UserService
 	accountService      models.AccountService
+	sessionService      models.SessionService
 	verificationService models.VerificationService
 	tokenService        models.TokenService
 	passwordService     models.PasswordService
@@ -24,6 +25,7 @@ func New(
 	logger models.Logger,
 	userService models.UserService,
 	accountService models.AccountService,
+	sessionService models.SessionService,
 	verificationService models.VerificationService,
 	tokenService models.TokenService,
 	passwordService models.PasswordService,
@@ -34,6 +36,7 @@ func New(
 		logger:              logger,
 		userService:         userService,
 		accountService:      accountService,
+		sessionService:      sessionService,
 		verificationService: verificationService,
 		tokenService:        tokenService,
 		passwordService:     passwordService,
@@ -75,9 +78,9 @@ func (s *service) ChangePassword(ctx context.Context, rawToken string, newPasswo
 		return constants.ErrUserNotFound
 	}
 
-	acc, err := s.accountService.GetAccountByUserID(user.ID)
+	acc, err := s.accountService.GetCredentialAccount(user.ID)
 	if err != nil {
-		s.logger.Error("failed to get account", "user_id", user.ID, "error", err)
+		s.logger.Error("failed to get credential account", "user_id", user.ID, "error", err)
 		return fmt.Errorf("%w: %w", constants.ErrAccountNotFound, err)
 	}
 	if acc == nil {
@@ -96,6 +99,10 @@ func (s *service) ChangePassword(ctx context.Context, rawToken string, newPasswo
 		return fmt.Errorf("failed to update account: %w", err)
 	}
 
+	if err := s.sessionService.InvalidateCachedSessions(user.ID); err != nil {
+		s.logger.Warn("failed to invalidate cached sessions", "user_id", user.ID, "error", err)
+	}
+
 	if err := s.verificationService.DeleteVerification(ver.ID); err != nil {
 		s.logger.Warn("failed to delete verification", "verification_id", ver.ID, "error", err)
 	}
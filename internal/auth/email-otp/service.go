@@ -0,0 +1,336 @@
+package emailotp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/GoBetterAuth/go-better-auth/internal/constants"
+	"github.com/GoBetterAuth/go-better-auth/internal/util"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+type service struct {
+	config           *models.Config
+	logger           models.Logger
+	userService      models.UserService
+	accountService   models.AccountService
+	sessionService   models.SessionService
+	tokenService     models.TokenService
+	emailOTPService  models.EmailOTPService
+	mailerService    models.MailerService
+	passwordService  models.PasswordService
+	twoFactorService models.TwoFactorService
+	eventEmitter     models.EventEmitter
+}
+
+func New(
+	config *models.Config,
+	logger models.Logger,
+	userService models.UserService,
+	accountService models.AccountService,
+	sessionService models.SessionService,
+	tokenService models.TokenService,
+	emailOTPService models.EmailOTPService,
+	mailerService models.MailerService,
+	passwordService models.PasswordService,
+	twoFactorService models.TwoFactorService,
+	eventEmitter models.EventEmitter,
+) *service {
+	return &service{
+		config:           config,
+		logger:           logger,
+		userService:      userService,
+		accountService:   accountService,
+		sessionService:   sessionService,
+		tokenService:     tokenService,
+		emailOTPService:  emailOTPService,
+		mailerService:    mailerService,
+		passwordService:  passwordService,
+		twoFactorService: twoFactorService,
+		eventEmitter:     eventEmitter,
+	}
+}
+
+func (s *service) SendEmailOTP(ctx context.Context, email string, otpType models.EmailOTPType) error {
+	if !s.config.EmailOTP.Enabled {
+		return constants.ErrEmailOTPDisabled
+	}
+	if email == "" {
+		return fmt.Errorf("email is required")
+	}
+	if !otpType.IsValid() {
+		return constants.ErrEmailOTPTypeInvalid
+	}
+
+	user, err := s.userService.GetUserByEmail(email)
+	if err != nil {
+		s.logger.Error("failed to get user by email", "email", email, "error", err)
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	// Respond the same way whether or not a code was sent to avoid leaking which emails are registered
+	if user == nil && (otpType != models.EmailOTPTypeSignIn || s.config.EmailOTP.DisableSignUp) {
+		s.logger.Info("email code requested for non-existent email", "email", email, "type", otpType)
+		return nil
+	}
+	if user != nil && otpType == models.EmailOTPTypeEmailVerification && user.EmailVerified {
+		return nil
+	}
+
+	otp, err := s.emailOTPService.CreateOTP(ctx, email, otpType)
+	if err != nil {
+		s.logger.Error("failed to create email code", "email", email, "error", err)
+		return fmt.Errorf("%w: %w", constants.ErrTokenGenerationFailed, err)
+	}
+
+	if s.config.EmailOTP.SendEmailOTP != nil {
+		if err := s.config.EmailOTP.SendEmailOTP(email, otp, otpType); err != nil {
+			s.logger.Error("failed to send email code", "email", email, "error", err)
+		}
+	} else {
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			s.mailerService.Send(
+				ctx,
+				email,
+				"Your Verification Code",
+				fmt.Sprintf("Your verification code is %s", otp),
+				util.CreateEmailOTPEmailBody(otp, otpType),
+			)
+		}()
+	}
+
+	return nil
+}
+
+func (s *service) SignInWithEmailOTP(ctx context.Context, email string, otp string) (*models.SignInResult, error) {
+	if !s.config.EmailOTP.Enabled {
+		return nil, constants.ErrEmailOTPDisabled
+	}
+
+	if err := s.verifyOTP(ctx, email, models.EmailOTPTypeSignIn, otp); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userService.GetUserByEmail(email)
+	if err != nil {
+		s.logger.Error("failed to get user by email", "email", email, "error", err)
+		return nil, fmt.Errorf("%w: %w", constants.ErrUserNotFound, err)
+	}
+
+	if user == nil {
+		if s.config.EmailOTP.DisableSignUp {
+			return nil, constants.ErrEmailOTPSignUpDisabled
+		}
+
+		name, _, _ := strings.Cut(email, "@")
+		user = &models.User{
+			Name:          name,
+			Email:         email,
+			EmailVerified: true,
+			CreatedAt:     time.Now().UTC(),
+			UpdatedAt:     time.Now().UTC(),
+		}
+		if err := s.userService.CreateUser(user); err != nil {
+			s.logger.Error("failed to create user", "email", email, "error", err)
+			return nil, fmt.Errorf("failed to create user: %w", err)
+		}
+		s.eventEmitter.OnUserSignedUp(*user)
+	} else if !user.EmailVerified {
+		if err := s.claimUnverifiedUser(user); err != nil {
+			return nil, err
+		}
+	}
+
+	if user.IsBanned() {
+		return nil, constants.ErrUserBanned
+	}
+
+	// Users with two factor enabled get a challenge instead of a session
+	twoFactorRequired, err := s.twoFactorService.IsEnabledForUser(user.ID)
+	if err != nil {
+		s.logger.Error("failed to check two factor", "user_id", user.ID, "error", err)
+		return nil, err
+	}
+	if twoFactorRequired {
+		challengeToken, err := s.twoFactorService.CreateChallenge(ctx, user.ID)
+		if err != nil {
+			s.logger.Error("failed to create two factor challenge", "user_id", user.ID, "error", err)
+			return nil, fmt.Errorf("%w: %w", constants.ErrTokenGenerationFailed, err)
+		}
+		return &models.SignInResult{
+			User:              user,
+			TwoFactorRequired: true,
+			ChallengeToken:    &challengeToken,
+		}, nil
+	}
+
+	token, err := s.tokenService.GenerateToken()
+	if err != nil {
+		s.logger.Error("failed to generate token", "error", err)
+		return nil, fmt.Errorf("%w: %w", constants.ErrTokenGenerationFailed, err)
+	}
+
+	_, err = s.sessionService.CreateSession(user.ID, s.tokenService.HashToken(token))
+	if err != nil {
+		s.logger.Error("failed to create session", "user_id", user.ID, "error", err)
+		return nil, fmt.Errorf("%w: %w", constants.ErrSessionCreationFailed, err)
+	}
+
+	s.eventEmitter.OnUserLoggedIn(*user)
+
+	var csrfToken *string
+	if s.config.CSRF.Enabled {
+		csrf, err := s.tokenService.GenerateToken()
+		if err != nil {
+			s.logger.Error("failed to generate csrf token", "error", err)
+			return nil, fmt.Errorf("%w: %w", constants.ErrTokenGenerationFailed, err)
+		}
+		csrfToken = &csrf
+	}
+
+	return &models.SignInResult{
+		Token:     token,
+		User:      user,
+		CSRFToken: csrfToken,
+	}, nil
+}
+
+func (s *service) VerifyEmailWithOTP(ctx context.Context, email string, otp string) (*models.VerifyEmailResult, error) {
+	if !s.config.EmailOTP.Enabled {
+		return nil, constants.ErrEmailOTPDisabled
+	}
+
+	if err := s.verifyOTP(ctx, email, models.EmailOTPTypeEmailVerification, otp); err != nil {
+		return nil, err
+	}
+
+	user, err := s.getUserByEmail(email)
+	if err != nil {
+		return nil, err
+	}
+
+	user.EmailVerified = true
+	if err := s.userService.UpdateUser(user); err != nil {
+		s.logger.Error("failed to update user", "user_id", user.ID, "error", err)
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+
+	s.eventEmitter.OnEmailVerified(*user)
+
+	return &models.VerifyEmailResult{
+		Message: "Email verified successfully",
+		User:    user,
+	}, nil
+}
+
+func (s *service) ResetPasswordWithOTP(ctx context.Context, email string, otp string, newPassword string) error {
+	if !s.config.EmailOTP.Enabled {
+		return constants.ErrEmailOTPDisabled
+	}
+	if newPassword == "" {
+		return fmt.Errorf("new password is required")
+	}
+
+	if err := s.verifyOTP(ctx, email, models.EmailOTPTypePasswordReset, otp); err != nil {
+		return err
+	}
+
+	user, err := s.getUserByEmail(email)
+	if err != nil {
+		return err
+	}
+
+	acc, err := s.accountService.GetCredentialAccount(user.ID)
+	if err != nil {
+		s.logger.Error("failed to get credential account", "user_id", user.ID, "error", err)
+		return fmt.Errorf("%w: %w", constants.ErrAccountNotFound, err)
+	}
+	if acc == nil {
+		return constants.ErrAccountNotFound
+	}
+
+	hashedPassword, err := s.hashPassword(newPassword)
+	if err != nil {
+		s.logger.Error("failed to hash password", "error", err)
+		return fmt.Errorf("%w: %w", constants.ErrPasswordHashingFailed, err)
+	}
+
+	acc.Password = &hashedPassword
+	if err := s.accountService.UpdateAccount(acc); err != nil {
+		s.logger.Error("failed to update account", "account_id", acc.ID, "error", err)
+		return fmt.Errorf("failed to update account: %w", err)
+	}
+
+	if err := s.sessionService.InvalidateCachedSessions(user.ID); err != nil {
+		s.logger.Warn("failed to invalidate cached sessions", "user_id", user.ID, "error", err)
+	}
+
+	s.eventEmitter.OnPasswordChanged(*user)
+
+	return nil
+}
+
+func (s *service) verifyOTP(ctx context.Context, email string, otpType models.EmailOTPType, otp string) error {
+	if err := s.emailOTPService.VerifyOTP(ctx, email, otpType, otp); err != nil {
+		if errors.Is(err, constants.ErrEmailOTPInvalid) || errors.Is(err, constants.ErrEmailOTPAttempts) {
+			return err
+		}
+		s.logger.Error("failed to verify email code", "email", email, "type", otpType, "error", err)
+		return fmt.Errorf("%w: %w", constants.ErrEmailOTPInvalid, err)
+	}
+	return nil
+}
+
+func (s *service) getUserByEmail(email string) (*models.User, error) {
+	user, err := s.userService.GetUserByEmail(email)
+	if err != nil {
+		s.logger.Error("failed to get user by email", "email", email, "error", err)
+		return nil, fmt.Errorf("%w: %w", constants.ErrUserNotFound, err)
+	}
+	if user == nil {
+		return nil, constants.ErrUserNotFound
+	}
+	return user, nil
+}
+
+func (s *service) hashPassword(password string) (string, error) {
+	if s.config.EmailPassword.Password.Hash != nil {
+		return s.config.EmailPassword.Password.Hash(password)
+	}
+	return s.passwordService.HashPassword(password)
+}
+
+// claimUnverifiedUser verifies the email of a user who signed in with a code sent to it. A password chosen
+// before the address was verified may belong to whoever registered it first, so it is deleted and the
+// sessions signed in with it are revoked.
+func (s *service) claimUnverifiedUser(user *models.User) error {
+	account, err := s.accountService.GetCredentialAccount(user.ID)
+	if err != nil {
+		s.logger.Error("failed to get credential account", "user_id", user.ID, "error", err)
+		return err
+	}
+	if account != nil {
+		if err := s.accountService.DeleteAccount(account.ID); err != nil {
+			s.logger.Error("failed to delete credential account", "user_id", user.ID, "error", err)
+			return err
+		}
+		if err := s.sessionService.RevokeUserSessions(user.ID); err != nil {
+			s.logger.Error("failed to revoke sessions", "user_id", user.ID, "error", err)
+			return err
+		}
+	}
+
+	user.EmailVerified = true
+	if err := s.userService.UpdateUser(user); err != nil {
+		s.logger.Error("failed to update user", "user_id", user.ID, "error", err)
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+	s.eventEmitter.OnEmailVerified(*user)
+
+	return nil
+}
@@ -0,0 +1,18 @@
+package emailotp
+
+import (
+	"context"
+
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+type EmailOTPUseCase interface {
+	// SendEmailOTP emails a one-time code for the given purpose.
+	SendEmailOTP(ctx context.Context, email string, otpType models.EmailOTPType) error
+	// SignInWithEmailOTP signs the user in with a sign in code, creating the user on first use unless sign up is disabled.
+	SignInWithEmailOTP(ctx context.Context, email string, otp string) (*models.SignInResult, error)
+	// VerifyEmailWithOTP marks the email address as verified.
+	VerifyEmailWithOTP(ctx context.Context, email string, otp string) (*models.VerifyEmailResult, error)
+	// ResetPasswordWithOTP sets a new password for the user.
+	ResetPasswordWithOTP(ctx context.Context, email string, otp string, newPassword string) error
+}
@@ -0,0 +1,41 @@
+package forwardauth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GoBetterAuth/go-better-auth/internal/constants"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+type service struct {
+	config      *models.Config
+	logger      models.Logger
+	userService models.UserService
+}
+
+func New(
+	config *models.Config,
+	logger models.Logger,
+	userService models.UserService,
+) *service {
+	return &service{
+		config:      config,
+		logger:      logger,
+		userService: userService,
+	}
+}
+
+func (s *service) VerifyRequest(ctx context.Context, userID string) (*models.User, error) {
+	if !s.config.ForwardAuth.Enabled {
+		return nil, constants.ErrForwardAuthDisabled
+	}
+
+	user, err := s.userService.GetUserByID(userID)
+	if err != nil {
+		s.logger.Error("failed to get user", "user_id", userID, "error", err)
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return user, nil
+}
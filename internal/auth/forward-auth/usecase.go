@@ -0,0 +1,12 @@
+package forwardauth
+
+import (
+	"context"
+
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+type ForwardAuthUseCase interface {
+	// VerifyRequest returns the user a reverse proxy should pass upstream. Returns nil if the user no longer exists.
+	VerifyRequest(ctx context.Context, userID string) (*models.User, error)
+}
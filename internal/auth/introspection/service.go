@@ -0,0 +1,189 @@
+package introspection
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+type service struct {
+	config              *models.Config
+	logger              models.Logger
+	eventEmitter        models.EventEmitter
+	sessionService      models.SessionService
+	tokenService        models.TokenService
+	oidcProviderService models.OIDCProviderService
+}
+
+func New(
+	config *models.Config,
+	logger models.Logger,
+	eventEmitter models.EventEmitter,
+	sessionService models.SessionService,
+	tokenService models.TokenService,
+	oidcProviderService models.OIDCProviderService,
+) *service {
+	return &service{
+		config:              config,
+		logger:              logger,
+		eventEmitter:        eventEmitter,
+		sessionService:      sessionService,
+		tokenService:        tokenService,
+		oidcProviderService: oidcProviderService,
+	}
+}
+
+func (s *service) Introspect(ctx context.Context, clientID string, token string, tokenTypeHint string) (*models.IntrospectionResult, error) {
+	if token == "" {
+		return &models.IntrospectionResult{Active: false}, nil
+	}
+
+	lookups := []func(context.Context, string, string) (*models.IntrospectionResult, error){
+		s.introspectSession,
+		s.introspectAccessToken,
+	}
+	if tokenTypeHint == models.TokenTypeHintAccessToken {
+		lookups[0], lookups[1] = lookups[1], lookups[0]
+	}
+
+	for _, lookup := range lookups {
+		result, err := lookup(ctx, clientID, token)
+		if err != nil {
+			return nil, err
+		}
+		if result != nil {
+			return result, nil
+		}
+	}
+
+	return &models.IntrospectionResult{Active: false}, nil
+}
+
+func (s *service) Revoke(ctx context.Context, clientID string, token string, tokenTypeHint string) error {
+	if token == "" {
+		return nil
+	}
+
+	revocations := []func(context.Context, string, string) (bool, error){
+		s.revokeSession,
+		s.revokeAccessToken,
+	}
+	if tokenTypeHint == models.TokenTypeHintAccessToken {
+		revocations[0], revocations[1] = revocations[1], revocations[0]
+	}
+
+	for _, revoke := range revocations {
+		revoked, err := revoke(ctx, clientID, token)
+		if err != nil {
+			return err
+		}
+		if revoked {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func (s *service) introspectSession(ctx context.Context, clientID string, token string) (*models.IntrospectionResult, error) {
+	// Session tokens are not issued to OAuth clients
+	if clientID != "" {
+		return nil, nil
+	}
+
+	session, err := s.sessionService.GetSessionByToken(s.tokenService.HashToken(token))
+	if err != nil {
+		s.logger.Error("failed to get session", "error", err)
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	if session == nil {
+		return nil, nil
+	}
+	if s.sessionService.IsExpired(session) {
+		return &models.IntrospectionResult{Active: false}, nil
+	}
+
+	return &models.IntrospectionResult{
+		Active:    true,
+		Subject:   session.UserID,
+		TokenType: models.TokenTypeHintSession,
+		SessionID: session.ID,
+		ExpiresAt: session.ExpiresAt.Unix(),
+		IssuedAt:  session.CreatedAt.Unix(),
+	}, nil
+}
+
+func (s *service) introspectAccessToken(ctx context.Context, clientID string, token string) (*models.IntrospectionResult, error) {
+	if !s.config.OIDCProvider.Enabled {
+		return nil, nil
+	}
+
+	grant, err := s.oidcProviderService.GetGrant(ctx, models.OAuthGrantKindAccessToken, token)
+	if err != nil {
+		s.logger.Error("failed to get access token", "error", err)
+		return nil, fmt.Errorf("failed to get access token: %w", err)
+	}
+	// Clients cannot learn about the tokens issued to other clients
+	if grant == nil || (clientID != "" && grant.ClientID != clientID) {
+		return nil, nil
+	}
+
+	return &models.IntrospectionResult{
+		Active:    true,
+		Subject:   grant.UserID,
+		ClientID:  grant.ClientID,
+		Scope:     strings.Join(grant.Scopes, " "),
+		TokenType: "Bearer",
+		SessionID: grant.SessionID,
+		ExpiresAt: grant.ExpiresAt.Unix(),
+		IssuedAt:  grant.IssuedAt.Unix(),
+	}, nil
+}
+
+func (s *service) revokeSession(ctx context.Context, clientID string, token string) (bool, error) {
+	if clientID != "" {
+		return false, nil
+	}
+
+	session, err := s.sessionService.GetSessionByToken(s.tokenService.HashToken(token))
+	if err != nil {
+		s.logger.Error("failed to get session", "error", err)
+		return false, fmt.Errorf("failed to get session: %w", err)
+	}
+	if session == nil {
+		return false, nil
+	}
+
+	if err := s.sessionService.DeleteSessionByID(session.ID); err != nil {
+		s.logger.Error("failed to revoke session", "session_id", session.ID, "error", err)
+		return false, fmt.Errorf("failed to revoke session: %w", err)
+	}
+
+	s.eventEmitter.OnSessionRevoked(*session)
+
+	return true, nil
+}
+
+func (s *service) revokeAccessToken(ctx context.Context, clientID string, token string) (bool, error) {
+	if !s.config.OIDCProvider.Enabled {
+		return false, nil
+	}
+
+	grant, err := s.oidcProviderService.GetGrant(ctx, models.OAuthGrantKindAccessToken, token)
+	if err != nil {
+		s.logger.Error("failed to get access token", "error", err)
+		return false, fmt.Errorf("failed to get access token: %w", err)
+	}
+	if grant == nil || (clientID != "" && grant.ClientID != clientID) {
+		return false, nil
+	}
+
+	if err := s.oidcProviderService.DeleteGrant(ctx, models.OAuthGrantKindAccessToken, token); err != nil {
+		s.logger.Error("failed to revoke access token", "client_id", grant.ClientID, "error", err)
+		return false, fmt.Errorf("failed to revoke access token: %w", err)
+	}
+
+	return true, nil
+}
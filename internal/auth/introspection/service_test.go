@@ -0,0 +1,163 @@
+package introspection
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/GoBetterAuth/go-better-auth/config"
+	"github.com/GoBetterAuth/go-better-auth/internal/events"
+	"github.com/GoBetterAuth/go-better-auth/internal/services"
+	"github.com/GoBetterAuth/go-better-auth/internal/testutil"
+	"github.com/GoBetterAuth/go-better-auth/models"
+	"github.com/GoBetterAuth/go-better-auth/storage"
+)
+
+type testEnv struct {
+	sessionService      *services.SessionServiceImpl
+	tokenService        *services.TokenServiceImpl
+	oidcProviderService *services.OIDCProviderServiceImpl
+	useCase             *service
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+
+	db := testutil.NewDB(t, &models.User{}, &models.Session{})
+	cfg := config.NewConfig(
+		config.WithLogger(models.LoggerConfig{Logger: testutil.NewLogger()}),
+		config.WithSecondaryStorage(models.SecondaryStorageConfig{
+			Storage: storage.NewMemorySecondaryStorage(models.SecondaryStorageMemoryOptions{}),
+		}),
+		config.WithOIDCProvider(models.OIDCProviderConfig{Enabled: true}),
+	)
+
+	env := &testEnv{
+		sessionService:      services.NewSessionServiceImpl(cfg, db),
+		tokenService:        services.NewTokenServiceImpl(cfg, nil),
+		oidcProviderService: services.NewOIDCProviderServiceImpl(cfg, db),
+	}
+	env.useCase = New(
+		cfg,
+		cfg.Logger.Logger,
+		events.NewEventEmitter(cfg, cfg.Logger.Logger, nil, nil),
+		env.sessionService,
+		env.tokenService,
+		env.oidcProviderService,
+	)
+	return env
+}
+
+func (env *testEnv) createAccessToken(t *testing.T, clientID string) (string, *models.OAuthGrant) {
+	t.Helper()
+
+	grant := &models.OAuthGrant{
+		ClientID:  clientID,
+		UserID:    "user-1",
+		Scopes:    []string{models.ScopeOpenID},
+		ExpiresAt: time.Now().UTC().Add(time.Minute),
+	}
+	token, err := env.oidcProviderService.CreateGrant(context.Background(), models.OAuthGrantKindAccessToken, grant)
+	if err != nil {
+		t.Fatalf("CreateGrant failed: %v", err)
+	}
+	return token, grant
+}
+
+func (env *testEnv) createSession(t *testing.T, token string) {
+	t.Helper()
+
+	if _, err := env.sessionService.CreateSession("user-1", env.tokenService.HashToken(token)); err != nil {
+		t.Fatalf("CreateSession failed: %v", err)
+	}
+}
+
+func TestIntrospect_AccessTokenOwnedByClient(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	token, grant := env.createAccessToken(t, "client-1")
+
+	result, err := env.useCase.Introspect(ctx, "client-1", token, models.TokenTypeHintAccessToken)
+	if err != nil {
+		t.Fatalf("Introspect failed: %v", err)
+	}
+	if !result.Active || result.ClientID != "client-1" || result.Subject != "user-1" {
+		t.Fatalf("expected the owning client to see the token, got %+v", result)
+	}
+	if result.IssuedAt != grant.IssuedAt.Unix() {
+		t.Fatalf("expected iat %d, got %d", grant.IssuedAt.Unix(), result.IssuedAt)
+	}
+
+	result, err = env.useCase.Introspect(ctx, "client-2", token, models.TokenTypeHintAccessToken)
+	if err != nil {
+		t.Fatalf("Introspect failed: %v", err)
+	}
+	if result.Active {
+		t.Fatalf("expected another client to see an inactive token, got %+v", result)
+	}
+
+	result, err = env.useCase.Introspect(ctx, "", token, "")
+	if err != nil {
+		t.Fatalf("Introspect failed: %v", err)
+	}
+	if !result.Active {
+		t.Fatalf("expected the admin key to see the token, got %+v", result)
+	}
+}
+
+func TestIntrospect_SessionsRequireAdminKey(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	env.createSession(t, "session-token")
+
+	result, err := env.useCase.Introspect(ctx, "client-1", "session-token", "")
+	if err != nil {
+		t.Fatalf("Introspect failed: %v", err)
+	}
+	if result.Active {
+		t.Fatalf("expected a client to see an inactive session, got %+v", result)
+	}
+
+	result, err = env.useCase.Introspect(ctx, "", "session-token", "")
+	if err != nil {
+		t.Fatalf("Introspect failed: %v", err)
+	}
+	if !result.Active || result.TokenType != models.TokenTypeHintSession {
+		t.Fatalf("expected the admin key to see the session, got %+v", result)
+	}
+}
+
+func TestRevoke_OnlyOwnTokens(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	token, _ := env.createAccessToken(t, "client-1")
+	env.createSession(t, "session-token")
+
+	// Other clients are answered as if the tokens were unknown
+	if err := env.useCase.Revoke(ctx, "client-2", token, ""); err != nil {
+		t.Fatalf("Revoke failed: %v", err)
+	}
+	if err := env.useCase.Revoke(ctx, "client-2", "session-token", ""); err != nil {
+		t.Fatalf("Revoke failed: %v", err)
+	}
+	if grant, _ := env.oidcProviderService.GetGrant(ctx, models.OAuthGrantKindAccessToken, token); grant == nil {
+		t.Fatal("expected the access token to survive another client's revocation")
+	}
+	if session, _ := env.sessionService.GetSessionByToken(env.tokenService.HashToken("session-token")); session == nil {
+		t.Fatal("expected the session to survive a client's revocation")
+	}
+
+	if err := env.useCase.Revoke(ctx, "client-1", token, models.TokenTypeHintAccessToken); err != nil {
+		t.Fatalf("Revoke failed: %v", err)
+	}
+	if grant, _ := env.oidcProviderService.GetGrant(ctx, models.OAuthGrantKindAccessToken, token); grant != nil {
+		t.Fatal("expected the owning client to revoke the access token")
+	}
+
+	if err := env.useCase.Revoke(ctx, "", "session-token", ""); err != nil {
+		t.Fatalf("Revoke failed: %v", err)
+	}
+	if session, _ := env.sessionService.GetSessionByToken(env.tokenService.HashToken("session-token")); session != nil {
+		t.Fatal("expected the admin key to revoke the session")
+	}
+}
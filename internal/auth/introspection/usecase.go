@@ -0,0 +1,19 @@
+package introspection
+
+import (
+	"context"
+
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+// IntrospectionUseCase answers the callers authenticated by ClientAuth. clientID is the OAuth client making the
+// request, or empty for the admin API key. Clients only see and revoke the access tokens issued to them, while
+// session tokens are reserved to the admin API key.
+type IntrospectionUseCase interface {
+	// Introspect reports whether a session token or OIDC access token is active, and who it belongs to.
+	// tokenTypeHint is optional and only changes the order in which token types are looked up.
+	Introspect(ctx context.Context, clientID string, token string, tokenTypeHint string) (*models.IntrospectionResult, error)
+	// Revoke invalidates a session token or OIDC access token. Unknown tokens, and the tokens the caller may not
+	// revoke, are ignored as required by RFC 7009.
+	Revoke(ctx context.Context, clientID string, token string, tokenTypeHint string) error
+}
@@ -0,0 +1,123 @@
+package jwt
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/GoBetterAuth/go-better-auth/internal/constants"
+	"github.com/GoBetterAuth/go-better-auth/internal/jose"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+type service struct {
+	config            *models.Config
+	logger            models.Logger
+	userService       models.UserService
+	sessionService    models.SessionService
+	tokenService      models.TokenService
+	signingKeyService models.SigningKeyService
+}
+
+func New(
+	config *models.Config,
+	logger models.Logger,
+	userService models.UserService,
+	sessionService models.SessionService,
+	tokenService models.TokenService,
+	signingKeyService models.SigningKeyService,
+) *service {
+	return &service{
+		config:            config,
+		logger:            logger,
+		userService:       userService,
+		sessionService:    sessionService,
+		tokenService:      tokenService,
+		signingKeyService: signingKeyService,
+	}
+}
+
+func (s *service) GetJWKS(ctx context.Context) (*models.JSONWebKeySet, error) {
+	// The OIDC provider signs its ID tokens with the same keys
+	if !s.config.JWT.Enabled && !s.config.OIDCProvider.Enabled {
+		return nil, constants.ErrJWTDisabled
+	}
+
+	// Make sure a key exists before the first token is requested
+	if _, err := s.signingKeyService.GetActiveSigningKey(); err != nil {
+		s.logger.Error("failed to get signing key", "error", err)
+		return nil, fmt.Errorf("failed to get signing key: %w", err)
+	}
+
+	keys, err := s.signingKeyService.ListVerificationKeys()
+	if err != nil {
+		s.logger.Error("failed to list signing keys", "error", err)
+		return nil, fmt.Errorf("failed to list signing keys: %w", err)
+	}
+
+	result := &models.JSONWebKeySet{Keys: make([]models.JSONWebKey, 0, len(keys))}
+	for _, key := range keys {
+		publicKey, err := jose.ParsePublicKey(key.PublicKey)
+		if err != nil {
+			s.logger.Error("failed to parse signing key", "kid", key.ID, "error", err)
+			continue
+		}
+		jwk, err := jose.PublicJWK(key.ID, key.Algorithm, publicKey)
+		if err != nil {
+			s.logger.Error("failed to encode signing key", "kid", key.ID, "error", err)
+			continue
+		}
+		result.Keys = append(result.Keys, jwk)
+	}
+
+	return result, nil
+}
+
+func (s *service) IssueToken(ctx context.Context, userID string, sessionID string) (*models.TokenResult, error) {
+	if !s.config.JWT.Enabled {
+		return nil, constants.ErrJWTDisabled
+	}
+
+	user, err := s.userService.GetUserByID(userID)
+	if err != nil {
+		s.logger.Error("failed to get user", "user_id", userID, "error", err)
+		return nil, fmt.Errorf("%w: %w", constants.ErrUserNotFound, err)
+	}
+	if user == nil {
+		return nil, constants.ErrUserNotFound
+	}
+
+	session, err := s.sessionService.GetSessionByID(sessionID)
+	if err != nil {
+		s.logger.Error("failed to get session", "session_id", sessionID, "error", err)
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	if session == nil || session.UserID != user.ID || s.sessionService.IsExpired(session) {
+		return nil, constants.ErrSessionNotFound
+	}
+
+	claims := map[string]any{
+		"sub":            user.ID,
+		"sid":            session.ID,
+		"email":          user.Email,
+		"email_verified": user.EmailVerified,
+		"name":           user.Name,
+	}
+	if s.config.JWT.DefinePayload != nil {
+		custom, err := s.config.JWT.DefinePayload(ctx, user, session)
+		if err != nil {
+			s.logger.Error("failed to define token payload", "user_id", user.ID, "error", err)
+			return nil, fmt.Errorf("failed to define token payload: %w", err)
+		}
+		for name, value := range custom {
+			claims[name] = value
+		}
+	}
+
+	token, err := s.tokenService.SignJWT(claims)
+	if err != nil {
+		s.logger.Error("failed to sign token", "user_id", user.ID, "error", err)
+		return nil, fmt.Errorf("%w: %w", constants.ErrTokenGenerationFailed, err)
+	}
+
+	return &models.TokenResult{Token: token}, nil
+}
@@ -0,0 +1,14 @@
+package jwt
+
+import (
+	"context"
+
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+type JWTUseCase interface {
+	// GetJWKS returns the public keys tokens can be verified with, including recently rotated ones.
+	GetJWKS(ctx context.Context) (*models.JSONWebKeySet, error)
+	// IssueToken exchanges a valid session for a short-lived signed JWT.
+	IssueToken(ctx context.Context, userID string, sessionID string) (*models.TokenResult, error)
+}
@@ -0,0 +1,109 @@
+package magiclink
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/GoBetterAuth/go-better-auth/internal/constants"
+	"github.com/GoBetterAuth/go-better-auth/internal/util"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+type service struct {
+	config              *models.Config
+	logger              models.Logger
+	userService         models.UserService
+	tokenService        models.TokenService
+	verificationService models.VerificationService
+	mailerService       models.MailerService
+}
+
+func New(
+	config *models.Config,
+	logger models.Logger,
+	userService models.UserService,
+	tokenService models.TokenService,
+	verificationService models.VerificationService,
+	mailerService models.MailerService,
+) *service {
+	return &service{
+		config:              config,
+		logger:              logger,
+		userService:         userService,
+		tokenService:        tokenService,
+		verificationService: verificationService,
+		mailerService:       mailerService,
+	}
+}
+
+func (s *service) SendMagicLink(ctx context.Context, email string, callbackURL *string) error {
+	if !s.config.MagicLink.Enabled {
+		return constants.ErrMagicLinkDisabled
+	}
+	if email == "" {
+		return fmt.Errorf("email is required")
+	}
+	// Following the link signs the user in and redirects to the callback, so only trusted targets are allowed
+	if callbackURL != nil && *callbackURL != "" && !util.IsTrustedRedirect(*callbackURL, s.config.TrustedOrigins.Origins) {
+		return constants.ErrUntrustedRedirect
+	}
+
+	user, err := s.userService.GetUserByEmail(email)
+	if err != nil {
+		s.logger.Error("failed to get user by email", "email", email, "error", err)
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil && s.config.MagicLink.DisableSignUp {
+		// Respond the same way as for existing users to avoid leaking which emails are registered
+		s.logger.Info("magic link requested for non-existent email", "email", email)
+		return nil
+	}
+
+	token, err := s.tokenService.GenerateToken()
+	if err != nil {
+		s.logger.Error("failed to generate token", "error", err)
+		return fmt.Errorf("%w: %w", constants.ErrTokenGenerationFailed, err)
+	}
+
+	ver := &models.Verification{
+		Identifier: email,
+		Token:      s.tokenService.HashToken(token),
+		Type:       models.TypeMagicLink,
+		ExpiresAt:  time.Now().UTC().Add(s.config.MagicLink.ExpiresIn),
+	}
+	if user != nil {
+		ver.UserID = &user.ID
+	}
+	if err := s.verificationService.CreateVerification(ver); err != nil {
+		s.logger.Error("failed to create verification", "email", email, "error", err)
+		return fmt.Errorf("failed to create verification: %w", err)
+	}
+
+	url := util.BuildVerificationURL(
+		s.config.BaseURL,
+		s.config.BasePath,
+		token,
+		callbackURL,
+	)
+
+	if s.config.MagicLink.SendMagicLinkEmail != nil {
+		if err := s.config.MagicLink.SendMagicLinkEmail(email, url, token); err != nil {
+			s.logger.Error("failed to send magic link email", "email", email, "error", err)
+		}
+	} else {
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			s.mailerService.Send(
+				ctx,
+				email,
+				"Sign In",
+				"Your sign in link",
+				util.CreateMagicLinkEmailBody(email, url),
+			)
+		}()
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package magiclink
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/GoBetterAuth/go-better-auth/config"
+	"github.com/GoBetterAuth/go-better-auth/internal/constants"
+	"github.com/GoBetterAuth/go-better-auth/internal/services"
+	"github.com/GoBetterAuth/go-better-auth/internal/testutil"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+func TestSendMagicLink(t *testing.T) {
+	db := testutil.NewDB(t, &models.User{}, &models.Verification{})
+
+	var sentURL, sentToken string
+	cfg := config.NewConfig(
+		config.WithMagicLink(models.MagicLinkConfig{
+			Enabled:   true,
+			ExpiresIn: 10 * time.Minute,
+			SendMagicLinkEmail: func(email string, url string, token string) error {
+				sentURL, sentToken = url, token
+				return nil
+			},
+		}),
+		config.WithTrustedOrigins(models.TrustedOriginsConfig{Origins: []string{"https://app.example.com"}}),
+	)
+	tokenService := services.NewTokenServiceImpl(cfg, nil)
+	verificationService := services.NewVerificationServiceImpl(cfg, db)
+	useCase := New(cfg, testutil.NewLogger(), services.NewUserServiceImpl(cfg, db), tokenService, verificationService, nil)
+
+	// The link signs the user in before redirecting, so the callback must be trusted
+	untrusted := "https://evil.example.com/"
+	if err := useCase.SendMagicLink(context.Background(), "user@example.com", &untrusted); !errors.Is(err, constants.ErrUntrustedRedirect) {
+		t.Fatalf("expected untrusted callback to be rejected, got %v", err)
+	}
+	if sentToken != "" {
+		t.Fatal("expected no link to be sent for an untrusted callback")
+	}
+
+	callbackURL := "https://app.example.com/dashboard"
+	if err := useCase.SendMagicLink(context.Background(), "user@example.com", &callbackURL); err != nil {
+		t.Fatalf("SendMagicLink failed: %v", err)
+	}
+	if !strings.Contains(sentURL, "callback_url=https%3A%2F%2Fapp.example.com%2Fdashboard") {
+		t.Fatalf("expected the callback in the link, got %s", sentURL)
+	}
+
+	ver, err := verificationService.GetVerificationByToken(tokenService.HashToken(sentToken))
+	if err != nil || ver == nil {
+		t.Fatalf("expected the link to be stored, got %v", err)
+	}
+	if ver.Type != models.TypeMagicLink || ver.Identifier != "user@example.com" {
+		t.Fatalf("unexpected verification: %+v", ver)
+	}
+	if time.Until(ver.ExpiresAt) > 10*time.Minute {
+		t.Fatalf("expected the link to expire after the configured 10 minutes, expires at %s", ver.ExpiresAt)
+	}
+}
@@ -0,0 +1,8 @@
+package magiclink
+
+import "context"
+
+type MagicLinkUseCase interface {
+	// SendMagicLink emails a single-use sign in link. The link is consumed by VerifyEmail.
+	SendMagicLink(ctx context.Context, email string, callbackURL *string) error
+}
@@ -7,10 +7,12 @@ import (
 )
 
 type service struct {
-	config         *models.Config
-	logger         models.Logger
-	userService    models.UserService
-	sessionService models.SessionService
+	config           *models.Config
+	logger           models.Logger
+	userService      models.UserService
+	sessionService   models.SessionService
+	twoFactorService models.TwoFactorService
+	rbacService      models.RBACService
 }
 
 func New(
@@ -18,17 +20,21 @@ func New(
 	logger models.Logger,
 	userService models.UserService,
 	sessionService models.SessionService,
+	twoFactorService models.TwoFactorService,
+	rbacService models.RBACService,
 ) *service {
 	return &service{
-		config:         config,
-		logger:         logger,
-		userService:    userService,
-		sessionService: sessionService,
+		config:           config,
+		logger:           logger,
+		userService:      userService,
+		sessionService:   sessionService,
+		twoFactorService: twoFactorService,
+		rbacService:      rbacService,
 	}
 }
 
 // GetMe retrieves the current user and their session
-func (s *service) GetMe(ctx context.Context, userID string) (*models.MeResult, error) {
+func (s *service) GetMe(ctx context.Context, userID string, sessionID string) (*models.MeResult, error) {
 	user, err := s.userService.GetUserByID(userID)
 	if err != nil {
 		return nil, err
@@ -37,16 +43,56 @@ func (s *service) GetMe(ctx context.Context, userID string) (*models.MeResult, e
 		return nil, nil
 	}
 
-	session, err := s.sessionService.GetSessionByUserID(userID)
+	var session *models.Session
+	if sessionID != "" {
+		session, err = s.sessionService.GetSessionByID(sessionID)
+	} else {
+		session, err = s.sessionService.GetSessionByUserID(userID)
+	}
 	if err != nil {
 		return nil, err
 	}
-	if session == nil {
+	if session == nil || session.UserID != userID {
 		return nil, nil
 	}
 
+	twoFactorEnabled, err := s.twoFactorService.IsEnabledForUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	backupCodesRemaining := 0
+	if twoFactorEnabled {
+		backupCodesRemaining, err = s.twoFactorService.CountBackupCodes(userID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	userRoles, err := s.rbacService.ListUserRoles(userID)
+	if err != nil {
+		return nil, err
+	}
+	roles := make([]string, 0, len(userRoles))
+	for _, role := range userRoles {
+		roles = append(roles, role.Name)
+	}
+
+	permissions, err := s.rbacService.ListUserPermissions(userID)
+	if err != nil {
+		return nil, err
+	}
+	if permissions == nil {
+		permissions = []string{}
+	}
+
 	return &models.MeResult{
-		User:    user,
-		Session: session,
+		User:                 user,
+		Session:              session,
+		TwoFactorEnabled:     twoFactorEnabled,
+		BackupCodesRemaining: backupCodesRemaining,
+		ImpersonatedBy:       session.ImpersonatedBy,
+		Roles:                roles,
+		Permissions:          permissions,
 	}, nil
 }
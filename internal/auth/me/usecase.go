@@ -7,5 +7,6 @@ import (
 )
 
 type MeUseCase interface {
-	GetMe(ctx context.Context, userID string) (*models.MeResult, error)
+	// GetMe returns the user with the session of the request, or any of their sessions when sessionID is empty.
+	GetMe(ctx context.Context, userID string, sessionID string) (*models.MeResult, error)
 }
@@ -2,7 +2,11 @@ package oauth2
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/GoBetterAuth/go-better-auth/internal/constants"
@@ -12,6 +16,19 @@ import (
 	"golang.org/x/oauth2"
 )
 
+const (
+	oauth2StatePrefix = "oauth2_state:"
+	// oauth2StateExpiresIn is how long users have to complete the flow at the provider
+	oauth2StateExpiresIn = 10 * time.Minute
+	// oauth2StateConsumedSuffix marks the guard counting the callbacks that tried to consume a state
+	oauth2StateConsumedSuffix = ":consumed"
+	oauth2RefreshLockPrefix   = "oauth2_refresh_lock:"
+	// oauth2RefreshLockExpiresIn releases the refresh lock of an account if its holder never does, such as when it crashes
+	oauth2RefreshLockExpiresIn = 30 * time.Second
+	// oauth2RefreshLockPollInterval is how often requests waiting for a refresh check whether it is done
+	oauth2RefreshLockPollInterval = 100 * time.Millisecond
+)
+
 type service struct {
 	config                 *models.Config
 	logger                 models.Logger
@@ -19,6 +36,7 @@ type service struct {
 	accountService         models.AccountService
 	sessionService         models.SessionService
 	tokenService           models.TokenService
+	twoFactorService       models.TwoFactorService
 	oauth2ProviderRegistry *oauth2providers.OAuth2ProviderRegistry
 }
 
@@ -29,6 +47,7 @@ func New(
 	accountService models.AccountService,
 	sessionService models.SessionService,
 	tokenService models.TokenService,
+	twoFactorService models.TwoFactorService,
 	oauth2ProviderRegistry *oauth2providers.OAuth2ProviderRegistry,
 ) *service {
 	return &service{
@@ -38,33 +57,57 @@ func New(
 		accountService:         accountService,
 		sessionService:         sessionService,
 		tokenService:           tokenService,
+		twoFactorService:       twoFactorService,
 		oauth2ProviderRegistry: oauth2ProviderRegistry,
 	}
 }
 
-func (s *service) PrepareOAuth2Login(ctx context.Context, providerName string) (*models.OAuth2LoginResult, error) {
+func (s *service) PrepareOAuth2Login(ctx context.Context, providerName string, options models.OAuth2LoginOptions) (*models.OAuth2LoginResult, error) {
+	return s.prepareOAuth2Flow(ctx, providerName, "", options)
+}
+
+func (s *service) PrepareOAuth2Link(ctx context.Context, providerName string, userID string, options models.OAuth2LoginOptions) (*models.OAuth2LoginResult, error) {
+	if !s.config.AccountLinking.Enabled {
+		return nil, constants.ErrAccountLinkingDisabled
+	}
+
+	// The callback is shared with sign in, the state tells it which user to link the account to
+	return s.prepareOAuth2Flow(ctx, providerName, userID, options)
+}
+
+// prepareOAuth2Flow stores the state of a new flow and builds the provider's authorization URL.
+func (s *service) prepareOAuth2Flow(ctx context.Context, providerName string, linkUserID string, options models.OAuth2LoginOptions) (*models.OAuth2LoginResult, error) {
 	// Get the provider to verify it exists and check if it requires PKCE
 	provider, err := s.oauth2ProviderRegistry.Get(providerName)
 	if err != nil {
 		return nil, err
 	}
 
+	if options.RedirectTo != "" && !util.IsTrustedRedirect(options.RedirectTo, s.config.TrustedOrigins.Origins) {
+		return nil, constants.ErrUntrustedRedirect
+	}
+
 	// Generate state token for CSRF protection
 	state, err := s.tokenService.GenerateToken()
 	if err != nil {
 		return nil, err
 	}
 
+	record := &models.OAuth2State{
+		Provider:   providerName,
+		RedirectTo: options.RedirectTo,
+		LinkUserID: linkUserID,
+		Scopes:     options.Scopes,
+	}
 	var opts []oauth2.AuthCodeOption
-	var verifier *string
 
 	// Generate PKCE verifier and challenge if the provider requires PKCE
 	if provider.RequiresPKCE() {
-		pkceVerifier, challenge, err := util.GeneratePKCE()
+		verifier, challenge, err := util.GeneratePKCE()
 		if err != nil {
 			return nil, err
 		}
-		verifier = &pkceVerifier
+		record.Verifier = verifier
 
 		opts = append(opts,
 			oauth2.SetAuthURLParam("code_challenge", challenge),
@@ -72,19 +115,50 @@ func (s *service) PrepareOAuth2Login(ctx context.Context, providerName string) (
 		)
 	}
 
+	// OpenID Connect providers echo the nonce in the ID token, binding it to this login
+	if _, ok := provider.(oauth2providers.IDTokenVerifier); ok {
+		nonce, err := s.tokenService.GenerateToken()
+		if err != nil {
+			return nil, err
+		}
+		record.Nonce = nonce
+
+		opts = append(opts, oauth2.SetAuthURLParam("nonce", nonce))
+	}
+
+	if len(options.Scopes) > 0 {
+		scopes := provider.GetConfig().Scopes
+		for _, scope := range options.Scopes {
+			if !slices.Contains(scopes, scope) {
+				scopes = append(scopes, scope)
+			}
+		}
+		opts = append(opts, oauth2.SetAuthURLParam("scope", strings.Join(scopes, " ")))
+	}
+
+	if err := s.storeState(ctx, state, record); err != nil {
+		return nil, err
+	}
+
 	// Get the authorization URL
 	authURL := provider.GetAuthURL(state, opts...)
 
 	return &models.OAuth2LoginResult{
-		AuthURL:  authURL,
-		State:    state,
-		Verifier: verifier,
+		AuthURL: authURL,
+		State:   state,
 	}, nil
 }
 
-func (s *service) SignInWithOAuth2(ctx context.Context, providerName string, code string, state string, verifier *string) (*models.SignInResult, error) {
-	// Store the state and verifier from the request for use when exchanging the code
-	// The state is validated by the handler, but we include the full logic here for completeness
+func (s *service) SignInWithOAuth2(ctx context.Context, providerName string, code string, state string) (*models.SignInResult, error) {
+	// Each flow can only complete once, replaying a callback finds no state
+	record, err := s.consumeState(ctx, state)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil || record.Provider != providerName {
+		return nil, constants.ErrOAuth2StateInvalid
+	}
+
 	provider, err := s.oauth2ProviderRegistry.Get(providerName)
 	if err != nil {
 		return nil, err
@@ -92,9 +166,9 @@ func (s *service) SignInWithOAuth2(ctx context.Context, providerName string, cod
 
 	// Build the options for the code exchange
 	var opts []oauth2.AuthCodeOption
-	if verifier != nil && *verifier != "" {
+	if record.Verifier != "" {
 		// Add the PKCE code verifier if provided
-		opts = append(opts, oauth2.SetAuthURLParam("code_verifier", *verifier))
+		opts = append(opts, oauth2.SetAuthURLParam("code_verifier", record.Verifier))
 	}
 
 	// Exchange the authorization code for tokens
@@ -105,12 +179,52 @@ func (s *service) SignInWithOAuth2(ctx context.Context, providerName string, cod
 	}
 
 	// Get user info from the OAuth2 provider
-	userInfo, err := provider.GetUserInfo(ctx, oauthToken)
+	userInfo, err := s.getUserInfo(ctx, provider, oauthToken, record.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *models.SignInResult
+	if record.LinkUserID != "" {
+		result, err = s.linkAccount(record.LinkUserID, providerName, oauthToken, userInfo)
+	} else {
+		result, err = s.signIn(ctx, providerName, userInfo, oauthToken)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result.RedirectTo = record.RedirectTo
+	return result, nil
+}
+
+func (s *service) SignInWithIDToken(ctx context.Context, providerName string, idToken string, nonce string) (*models.SignInResult, error) {
+	provider, err := s.oauth2ProviderRegistry.Get(providerName)
 	if err != nil {
-		s.logger.Error("failed to get oauth2 user info", "provider", providerName, "error", err)
-		return nil, constants.ErrOAuth2UserInfoFailed
+		return nil, err
 	}
 
+	verifier, ok := provider.(oauth2providers.IDTokenVerifier)
+	if !ok {
+		return nil, constants.ErrOAuth2IDTokenNotSupported
+	}
+
+	userInfo, err := verifier.VerifyIDToken(ctx, idToken, nonce)
+	if err != nil {
+		s.logger.Error("failed to verify oauth2 id token", "provider", providerName, "error", err)
+		return nil, constants.ErrOAuth2IDTokenInvalid
+	}
+	if userInfo.ID == "" || userInfo.Email == "" {
+		s.logger.Error("oauth2 id token has no subject or email", "provider", providerName)
+		return nil, constants.ErrOAuth2IDTokenInvalid
+	}
+
+	// There are no provider tokens to store, the app keeps those obtained by the platform SDK
+	return s.signIn(ctx, providerName, userInfo, nil)
+}
+
+// signIn finds or creates the user of a provider account and starts a session for them.
+func (s *service) signIn(ctx context.Context, providerName string, userInfo *models.OAuth2UserInfo, oauthToken *oauth2.Token) (*models.SignInResult, error) {
 	// Check if an account already exists for this provider and user
 	account, err := s.accountService.GetAccountByProviderAndAccountID(models.ProviderType(providerName), userInfo.ID)
 	if err != nil {
@@ -125,40 +239,15 @@ func (s *service) SignInWithOAuth2(ctx context.Context, providerName string, cod
 		if err != nil {
 			return nil, err
 		}
-
-		// Encrypt and store the new access token
-		encryptedAccessToken, err := s.tokenService.EncryptToken(oauthToken.AccessToken)
-		if err != nil {
-			s.logger.Error("failed to encrypt access token", "error", err)
-			return nil, err
-		}
-		account.AccessToken = &encryptedAccessToken
-
-		// Handle refresh token if provided
-		if oauthToken.RefreshToken != "" {
-			encryptedRefreshToken, err := s.tokenService.EncryptToken(oauthToken.RefreshToken)
-			if err != nil {
-				s.logger.Error("failed to encrypt refresh token", "error", err)
-				return nil, err
-			}
-			account.RefreshToken = &encryptedRefreshToken
-			account.RefreshTokenExpiresAt = extractRefreshTokenExpiry(oauthToken)
-		} else {
-			account.RefreshToken = nil
-			account.RefreshTokenExpiresAt = nil
+		if user == nil {
+			return nil, constants.ErrUserNotFound
 		}
-
-		// Handle ID token if provided
-		if value, ok := oauthToken.Extra("id_token").(string); ok {
-			account.IDToken = &value
-		} else {
-			account.IDToken = nil
+		if user.IsBanned() {
+			return nil, constants.ErrUserBanned
 		}
-		account.AccessTokenExpiresAt = &oauthToken.Expiry
 
-		// Update the account with new tokens
-		if err := s.accountService.UpdateAccount(account); err != nil {
-			s.logger.Error("failed to update account tokens", "account_id", account.ID, "error", err)
+		if err := s.updateAccountTokens(account, oauthToken); err != nil {
+			return nil, err
 		}
 	} else {
 		// Account doesn't exist, create a new user and account
@@ -178,46 +267,34 @@ func (s *service) SignInWithOAuth2(ctx context.Context, providerName string, cod
 			if err := s.userService.CreateUser(user); err != nil {
 				return nil, err
 			}
-		} else {
+		} else if user.IsBanned() {
+			return nil, constants.ErrUserBanned
+		} else if !s.isTrustedForAutoLinking(providerName, userInfo) {
 			// User exists with this email but no OAuth2 account
-			// Return error to prevent automatic account linking
-			// TODO: users must use the account linking feature instead
+			// Untrusted providers must be linked explicitly by the signed in user
 			return nil, constants.ErrAccountLinkingRequired
 		}
 
-		// Encrypt the access token
-		encryptedAccessToken, err := s.tokenService.EncryptToken(oauthToken.AccessToken)
-		if err != nil {
-			s.logger.Error("failed to encrypt access token", "error", err)
+		if err := s.createAccount(user.ID, providerName, userInfo, oauthToken); err != nil {
 			return nil, err
 		}
+	}
 
-		// Handle refresh token if provided
-		var refreshToken *string
-		var refreshTokenExpiresAt *time.Time
-		if oauthToken.RefreshToken != "" {
-			encrypted, err := s.tokenService.EncryptToken(oauthToken.RefreshToken)
-			if err != nil {
-				s.logger.Error("failed to encrypt refresh token", "error", err)
-				return nil, err
-			}
-			refreshToken = &encrypted
-			refreshTokenExpiresAt = extractRefreshTokenExpiry(oauthToken)
-		}
-
-		// Create the new account
-		account = &models.Account{
-			UserID:                user.ID,
-			AccountID:             userInfo.ID,
-			ProviderID:            models.ProviderType(providerName),
-			AccessToken:           &encryptedAccessToken,
-			RefreshToken:          refreshToken,
-			AccessTokenExpiresAt:  &oauthToken.Expiry,
-			RefreshTokenExpiresAt: refreshTokenExpiresAt,
-		}
-		if err := s.accountService.CreateAccount(account); err != nil {
+	// Users with two factor enabled get a challenge instead of a session
+	twoFactorRequired, err := s.twoFactorService.IsEnabledForUser(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if twoFactorRequired {
+		challengeToken, err := s.twoFactorService.CreateChallenge(ctx, user.ID)
+		if err != nil {
 			return nil, err
 		}
+		return &models.SignInResult{
+			User:              user,
+			TwoFactorRequired: true,
+			ChallengeToken:    &challengeToken,
+		}, nil
 	}
 
 	// Generate session token
@@ -249,30 +326,411 @@ func (s *service) SignInWithOAuth2(ctx context.Context, providerName string, cod
 	}, nil
 }
 
-// GetValidAccessToken ensures the access token is valid and refreshes it if expired or near expiry.
-func (s *service) GetValidAccessToken(ctx context.Context, account *models.Account, providerName string) (string, error) {
-	// Consider token "expired" if less than 1 minute remains
-	const refreshBefore = 1 * time.Minute
-	now := time.Now()
+// getUserInfo returns the user info of the provider account. OpenID Connect providers read it from the
+// verified ID token and only fall back to their user info endpoint for claims the token lacks.
+func (s *service) getUserInfo(ctx context.Context, provider oauth2providers.OAuth2Provider, oauthToken *oauth2.Token, nonce string) (*models.OAuth2UserInfo, error) {
+	verifier, ok := provider.(oauth2providers.IDTokenVerifier)
+	if !ok {
+		userInfo, err := provider.GetUserInfo(ctx, oauthToken)
+		if err != nil {
+			s.logger.Error("failed to get oauth2 user info", "provider", provider.GetName(), "error", err)
+			return nil, constants.ErrOAuth2UserInfoFailed
+		}
+		return userInfo, nil
+	}
 
-	if account.AccessToken == nil || account.AccessTokenExpiresAt == nil || now.After(account.AccessTokenExpiresAt.Add(-refreshBefore)) {
-		newToken, err := s.refreshOAuth2AccessToken(ctx, account, providerName)
+	rawIDToken, _ := oauthToken.Extra("id_token").(string)
+	if rawIDToken == "" {
+		// Without the openid scope there is no ID token, the user info endpoint is used instead
+		userInfo, err := provider.GetUserInfo(ctx, oauthToken)
 		if err != nil {
-			return "", err
+			s.logger.Error("failed to get oauth2 user info", "provider", provider.GetName(), "error", err)
+			return nil, constants.ErrOAuth2UserInfoFailed
+		}
+		return userInfo, nil
+	}
+
+	userInfo, err := verifier.VerifyIDToken(ctx, rawIDToken, nonce)
+	if err != nil {
+		s.logger.Error("failed to verify oauth2 id token", "provider", provider.GetName(), "error", err)
+		return nil, constants.ErrOAuth2IDTokenInvalid
+	}
+
+	if userInfo.Email == "" {
+		fetched, err := provider.GetUserInfo(ctx, oauthToken)
+		if err != nil {
+			s.logger.Error("failed to get oauth2 user info", "provider", provider.GetName(), "error", err)
+			return nil, constants.ErrOAuth2UserInfoFailed
+		}
+		// The user info response must be about the subject of the ID token (OpenID Connect Core section 5.3.2)
+		if fetched.ID != userInfo.ID {
+			s.logger.Error("oauth2 user info subject does not match id token", "provider", provider.GetName())
+			return nil, constants.ErrOAuth2UserInfoFailed
+		}
+		userInfo = fetched
+	}
+
+	return userInfo, nil
+}
+
+// linkAccount connects the provider account to a signed in user, or refreshes its tokens if it already is.
+func (s *service) linkAccount(userID string, providerName string, oauthToken *oauth2.Token, userInfo *models.OAuth2UserInfo) (*models.SignInResult, error) {
+	user, err := s.userService.GetUserByID(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, constants.ErrUserNotFound
+	}
+
+	account, err := s.accountService.GetAccountByProviderAndAccountID(models.ProviderType(providerName), userInfo.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if account != nil {
+		// Authorizing an account that is already linked again, for example to grant more scopes
+		if account.UserID != user.ID {
+			return nil, constants.ErrAccountAlreadyLinked
+		}
+		if err := s.updateAccountTokens(account, oauthToken); err != nil {
+			return nil, err
+		}
+	} else {
+		if !s.config.AccountLinking.Enabled {
+			return nil, constants.ErrAccountLinkingDisabled
+		}
+		if !s.config.AccountLinking.AllowDifferentEmails && !strings.EqualFold(userInfo.Email, user.Email) {
+			return nil, constants.ErrAccountEmailMismatch
+		}
+		if err := s.createAccount(user.ID, providerName, userInfo, oauthToken); err != nil {
+			return nil, err
+		}
+	}
+
+	return &models.SignInResult{
+		User:          user,
+		AccountLinked: true,
+	}, nil
+}
+
+func (s *service) storeState(ctx context.Context, state string, record *models.OAuth2State) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode oauth2 state: %w", err)
+	}
+
+	ttl := oauth2StateExpiresIn
+	if err := s.config.SecondaryStorage.Storage.Set(ctx, s.stateKey(state), string(data), &ttl); err != nil {
+		s.logger.Error("failed to store oauth2 state", "provider", record.Provider, "error", err)
+		return fmt.Errorf("failed to store oauth2 state: %w", err)
+	}
+
+	return nil
+}
+
+// consumeState returns the record of the flow started with state and deletes it. Returns nil if there is none.
+func (s *service) consumeState(ctx context.Context, state string) (*models.OAuth2State, error) {
+	if state == "" {
+		return nil, nil
+	}
+
+	key := s.stateKey(state)
+	value, err := s.config.SecondaryStorage.Storage.Get(ctx, key)
+	if err != nil {
+		s.logger.Error("failed to get oauth2 state", "error", err)
+		return nil, fmt.Errorf("failed to get oauth2 state: %w", err)
+	}
+	data, ok := value.(string)
+	if !ok || data == "" {
+		return nil, nil
+	}
+
+	// Concurrent callbacks may all read the state before it is deleted. Incr is atomic in the secondary
+	// storage, so only the first one to increment the guard consumes it.
+	ttl := oauth2StateExpiresIn
+	count, err := s.config.SecondaryStorage.Storage.Incr(ctx, key+oauth2StateConsumedSuffix, &ttl)
+	if err != nil {
+		s.logger.Error("failed to consume oauth2 state", "error", err)
+		return nil, fmt.Errorf("failed to consume oauth2 state: %w", err)
+	}
+	if count > 1 {
+		return nil, nil
+	}
+
+	if err := s.config.SecondaryStorage.Storage.Delete(ctx, key); err != nil {
+		s.logger.Error("failed to delete oauth2 state", "error", err)
+		return nil, fmt.Errorf("failed to delete oauth2 state: %w", err)
+	}
+
+	var record models.OAuth2State
+	if err := json.Unmarshal([]byte(data), &record); err != nil {
+		return nil, fmt.Errorf("failed to decode oauth2 state: %w", err)
+	}
+
+	return &record, nil
+}
+
+func (s *service) stateKey(state string) string {
+	return oauth2StatePrefix + util.HashTokenWithSecret(state, s.config.Secret)
+}
+
+// isTrustedForAutoLinking reports whether a provider account may be linked to the existing user with the
+// same email without the user asking for it.
+func (s *service) isTrustedForAutoLinking(providerName string, userInfo *models.OAuth2UserInfo) bool {
+	return s.config.AccountLinking.Enabled &&
+		userInfo.Verified &&
+		slices.Contains(s.config.AccountLinking.TrustedProviders, providerName)
+}
+
+// createAccount stores a new provider account of a user along with its encrypted tokens, if any.
+func (s *service) createAccount(userID string, providerName string, userInfo *models.OAuth2UserInfo, oauthToken *oauth2.Token) error {
+	if oauthToken == nil {
+		return s.accountService.CreateAccount(&models.Account{
+			UserID:     userID,
+			AccountID:  userInfo.ID,
+			ProviderID: models.ProviderType(providerName),
+		})
+	}
+
+	// Encrypt the access token
+	encryptedAccessToken, err := s.tokenService.EncryptToken(oauthToken.AccessToken)
+	if err != nil {
+		s.logger.Error("failed to encrypt access token", "error", err)
+		return err
+	}
+
+	// Handle refresh token if provided
+	var refreshToken *string
+	var refreshTokenExpiresAt *time.Time
+	if oauthToken.RefreshToken != "" {
+		encrypted, err := s.tokenService.EncryptToken(oauthToken.RefreshToken)
+		if err != nil {
+			s.logger.Error("failed to encrypt refresh token", "error", err)
+			return err
+		}
+		refreshToken = &encrypted
+		refreshTokenExpiresAt = extractRefreshTokenExpiry(oauthToken)
+	}
+
+	// Create the new account
+	account := &models.Account{
+		UserID:                userID,
+		AccountID:             userInfo.ID,
+		ProviderID:            models.ProviderType(providerName),
+		AccessToken:           &encryptedAccessToken,
+		RefreshToken:          refreshToken,
+		AccessTokenExpiresAt:  &oauthToken.Expiry,
+		RefreshTokenExpiresAt: refreshTokenExpiresAt,
+		Scope:                 grantedScope(oauthToken),
+	}
+	return s.accountService.CreateAccount(account)
+}
+
+// updateAccountTokens stores the tokens of a new sign in on an existing provider account.
+// Failing to save them is logged but does not fail the sign in. Sign ins without tokens keep the
+// ones stored by earlier sign ins, and providers only return a refresh token on the first consent,
+// so the stored one is kept when none is returned.
+func (s *service) updateAccountTokens(account *models.Account, oauthToken *oauth2.Token) error {
+	if oauthToken == nil {
+		return nil
+	}
+
+	// Encrypt and store the new access token
+	encryptedAccessToken, err := s.tokenService.EncryptToken(oauthToken.AccessToken)
+	if err != nil {
+		s.logger.Error("failed to encrypt access token", "error", err)
+		return err
+	}
+	account.AccessToken = &encryptedAccessToken
+
+	// Handle refresh token if provided
+	if oauthToken.RefreshToken != "" {
+		encryptedRefreshToken, err := s.tokenService.EncryptToken(oauthToken.RefreshToken)
+		if err != nil {
+			s.logger.Error("failed to encrypt refresh token", "error", err)
+			return err
+		}
+		account.RefreshToken = &encryptedRefreshToken
+		account.RefreshTokenExpiresAt = extractRefreshTokenExpiry(oauthToken)
+	}
+
+	// Handle ID token if provided
+	if value, ok := oauthToken.Extra("id_token").(string); ok {
+		account.IDToken = &value
+	} else {
+		account.IDToken = nil
+	}
+	account.AccessTokenExpiresAt = &oauthToken.Expiry
+	if scope := grantedScope(oauthToken); scope != nil {
+		account.Scope = scope
+	}
+
+	// Update the account with new tokens
+	if err := s.accountService.UpdateAccount(account); err != nil {
+		s.logger.Error("failed to update account tokens", "account_id", account.ID, "error", err)
+	}
+
+	return nil
+}
+
+func (s *service) GetAccessToken(ctx context.Context, userID string, providerName string) (*models.OAuth2AccessToken, error) {
+	account, err := s.accountService.GetAccountByUserIDAndProvider(userID, models.ProviderType(providerName))
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, constants.ErrAccountNotFound
+	}
+
+	if needsRefresh(account) {
+		// Providers rotating refresh tokens invalidate the old one on use, so only one request may refresh
+		release, err := s.acquireRefreshLock(ctx, account.ID)
+		if err != nil {
+			return nil, err
+		}
+		defer release()
+
+		// Another request may have refreshed the token while this one was waiting
+		account, err = s.accountService.GetAccountByUserIDAndProvider(userID, models.ProviderType(providerName))
+		if err != nil {
+			return nil, err
+		}
+		if account == nil {
+			return nil, constants.ErrAccountNotFound
+		}
+
+		if needsRefresh(account) {
+			if _, err := s.refreshOAuth2AccessToken(ctx, account, providerName); err != nil {
+				return nil, err
+			}
 		}
-		return newToken, nil
 	}
 
 	accessToken, err := s.tokenService.DecryptToken(*account.AccessToken)
 	if err != nil {
 		s.logger.Error("failed to decrypt access token", "account_id", account.ID, "error", err)
-		return "", err
+		return nil, err
+	}
+
+	result := &models.OAuth2AccessToken{
+		AccessToken: accessToken,
+		Scopes:      []string{},
+	}
+	if account.AccessTokenExpiresAt != nil && !account.AccessTokenExpiresAt.IsZero() {
+		result.ExpiresAt = account.AccessTokenExpiresAt
+	}
+	if account.Scope != nil {
+		// GitHub separates scopes with commas, other providers with spaces
+		result.Scopes = strings.FieldsFunc(*account.Scope, func(r rune) bool { return r == ' ' || r == ',' })
 	}
 
-	return accessToken, nil
+	return result, nil
 }
 
-// RefreshOAuth2AccessToken refreshes the access token for a given account if a valid refresh token exists.
+func (s *service) PrepareOAuth2Reauthorize(ctx context.Context, providerName string, userID string, options models.OAuth2LoginOptions) (*models.OAuth2LoginResult, error) {
+	account, err := s.accountService.GetAccountByUserIDAndProvider(userID, models.ProviderType(providerName))
+	if err != nil {
+		return nil, err
+	}
+	if account == nil {
+		return nil, constants.ErrAccountNotFound
+	}
+
+	// The callback refreshes the tokens of the linked account, as it does when linking it again
+	return s.prepareOAuth2Flow(ctx, providerName, userID, options)
+}
+
+// needsRefresh reports whether the access token of an account is missing or expires within a minute.
+// Tokens without expiry, such as those of GitHub OAuth apps, never need to be refreshed.
+func needsRefresh(account *models.Account) bool {
+	const refreshBefore = 1 * time.Minute
+
+	if account.AccessToken == nil {
+		return true
+	}
+	if account.AccessTokenExpiresAt == nil || account.AccessTokenExpiresAt.IsZero() {
+		return false
+	}
+	return time.Now().After(account.AccessTokenExpiresAt.Add(-refreshBefore))
+}
+
+// acquireRefreshLock waits until no other request, in this process or another, refreshes the tokens of an
+// account, and locks them. The returned function releases the lock.
+func (s *service) acquireRefreshLock(ctx context.Context, accountID string) (func(), error) {
+	storage := s.config.SecondaryStorage.Storage
+	key := oauth2RefreshLockPrefix + accountID
+	ownerKey := key + ":owner"
+	ttl := oauth2RefreshLockExpiresIn
+
+	owner, err := util.GenerateToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to lock token refresh: %w", err)
+	}
+
+	for {
+		// Incr is atomic in the secondary storage, so only one request sees the first increment
+		count, err := storage.Incr(ctx, key, &ttl)
+		if err != nil {
+			s.logger.Error("failed to lock token refresh", "account_id", accountID, "error", err)
+			return nil, fmt.Errorf("failed to lock token refresh: %w", err)
+		}
+		if count == 1 {
+			if err := storage.Set(ctx, ownerKey, owner, &ttl); err != nil {
+				_ = storage.Delete(context.WithoutCancel(ctx), key)
+				s.logger.Error("failed to lock token refresh", "account_id", accountID, "error", err)
+				return nil, fmt.Errorf("failed to lock token refresh: %w", err)
+			}
+			return func() { s.releaseRefreshLock(context.WithoutCancel(ctx), accountID, owner) }, nil
+		}
+
+		// Incrementing extends the lock, so waiting requests only try again once it is released
+		for {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(oauth2RefreshLockPollInterval):
+			}
+
+			value, err := storage.Get(ctx, key)
+			if err != nil {
+				s.logger.Error("failed to check token refresh lock", "account_id", accountID, "error", err)
+				return nil, fmt.Errorf("failed to check token refresh lock: %w", err)
+			}
+			if value == nil {
+				break
+			}
+		}
+	}
+}
+
+// releaseRefreshLock releases the refresh lock of an account if it is still held by owner. A refresh outliving
+// the lock lets another request take it, which must not be released on its behalf. The secondary storage has no
+// compare-and-delete, so a lock expiring between the check and the delete can still be released early.
+func (s *service) releaseRefreshLock(ctx context.Context, accountID string, owner string) {
+	storage := s.config.SecondaryStorage.Storage
+	key := oauth2RefreshLockPrefix + accountID
+
+	value, err := storage.Get(ctx, key+":owner")
+	if err != nil {
+		s.logger.Error("failed to unlock token refresh", "account_id", accountID, "error", err)
+		return
+	}
+	if current, ok := value.(string); !ok || current != owner {
+		s.logger.Warn("token refresh lock expired before being released", "account_id", accountID)
+		return
+	}
+
+	if err := storage.Delete(ctx, key); err != nil {
+		s.logger.Error("failed to unlock token refresh", "account_id", accountID, "error", err)
+		return
+	}
+	if err := storage.Delete(ctx, key+":owner"); err != nil {
+		s.logger.Error("failed to unlock token refresh", "account_id", accountID, "error", err)
+	}
+}
+
+// refreshOAuth2AccessToken refreshes the access token for a given account if a valid refresh token exists.
 func (s *service) refreshOAuth2AccessToken(ctx context.Context, account *models.Account, providerName string) (string, error) {
 	if account.RefreshToken == nil {
 		return "", constants.ErrNoRefreshToken
@@ -298,7 +756,7 @@ func (s *service) refreshOAuth2AccessToken(ctx context.Context, account *models.
 	newToken, err := tokenSource.Token()
 	if err != nil {
 		s.logger.Error("failed to refresh access token", "account_id", account.ID, "error", err)
-		return "", err
+		return "", constants.ErrOAuth2RefreshFailed
 	}
 
 	encryptedAccessToken, err := s.tokenService.EncryptToken(newToken.AccessToken)
@@ -308,6 +766,9 @@ func (s *service) refreshOAuth2AccessToken(ctx context.Context, account *models.
 	}
 	account.AccessToken = &encryptedAccessToken
 	account.AccessTokenExpiresAt = &newToken.Expiry
+	if scope := grantedScope(newToken); scope != nil {
+		account.Scope = scope
+	}
 
 	if newToken.RefreshToken != "" && newToken.RefreshToken != refreshToken {
 		encryptedRefreshToken, err := s.tokenService.EncryptToken(newToken.RefreshToken)
@@ -348,3 +809,11 @@ func extractRefreshTokenExpiry(token *oauth2.Token) *time.Time {
 
 	return nil
 }
+
+// grantedScope returns the scopes the provider reports to have granted, if any.
+func grantedScope(token *oauth2.Token) *string {
+	if scope, ok := token.Extra("scope").(string); ok && scope != "" {
+		return &scope
+	}
+	return nil
+}
@@ -0,0 +1,216 @@
+package oauth2
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/GoBetterAuth/go-better-auth/config"
+	"github.com/GoBetterAuth/go-better-auth/internal/services"
+	"github.com/GoBetterAuth/go-better-auth/internal/testutil"
+	"github.com/GoBetterAuth/go-better-auth/models"
+	oauth2providers "github.com/GoBetterAuth/go-better-auth/oauth2-providers"
+	"github.com/GoBetterAuth/go-better-auth/storage"
+	"golang.org/x/oauth2"
+)
+
+func newTestService(t *testing.T) *service {
+	t.Helper()
+
+	cfg := config.NewConfig(
+		config.WithSecondaryStorage(models.SecondaryStorageConfig{
+			Storage: storage.NewMemorySecondaryStorage(models.SecondaryStorageMemoryOptions{}),
+		}),
+	)
+	return New(cfg, testutil.NewLogger(), nil, nil, nil, nil, nil, oauth2providers.NewOAuth2ProviderRegistry(cfg))
+}
+
+func TestConsumeState_OnlyOnce(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	if err := s.storeState(ctx, "state-1", &models.OAuth2State{Provider: "github", Verifier: "verifier-1"}); err != nil {
+		t.Fatalf("storeState failed: %v", err)
+	}
+
+	record, err := s.consumeState(ctx, "state-1")
+	if err != nil {
+		t.Fatalf("consumeState failed: %v", err)
+	}
+	if record == nil || record.Provider != "github" || record.Verifier != "verifier-1" {
+		t.Fatalf("unexpected state: %+v", record)
+	}
+
+	if record, err := s.consumeState(ctx, "state-1"); err != nil || record != nil {
+		t.Fatalf("expected a consumed state to be gone, got %+v, %v", record, err)
+	}
+	if record, err := s.consumeState(ctx, "unknown"); err != nil || record != nil {
+		t.Fatalf("expected an unknown state to be rejected, got %+v, %v", record, err)
+	}
+	if record, err := s.consumeState(ctx, ""); err != nil || record != nil {
+		t.Fatalf("expected an empty state to be rejected, got %+v, %v", record, err)
+	}
+}
+
+func TestConsumeState_Concurrently(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	if err := s.storeState(ctx, "state-1", &models.OAuth2State{Provider: "github"}); err != nil {
+		t.Fatalf("storeState failed: %v", err)
+	}
+
+	var consumed atomic.Int32
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if record, err := s.consumeState(ctx, "state-1"); err == nil && record != nil {
+				consumed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := consumed.Load(); got != 1 {
+		t.Fatalf("expected the state to be consumed exactly once, got %d", got)
+	}
+}
+
+func TestAcquireRefreshLock(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	release, err := s.acquireRefreshLock(ctx, "account-1")
+	if err != nil {
+		t.Fatalf("acquireRefreshLock failed: %v", err)
+	}
+
+	// Refreshes of other accounts are not blocked
+	releaseOther, err := s.acquireRefreshLock(ctx, "account-2")
+	if err != nil {
+		t.Fatalf("expected another account to be locked independently, got %v", err)
+	}
+	releaseOther()
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 3*oauth2RefreshLockPollInterval)
+	defer cancel()
+	if _, err := s.acquireRefreshLock(timeoutCtx, "account-1"); err == nil {
+		t.Fatal("expected the lock to be held")
+	}
+
+	acquired := make(chan func())
+	go func() {
+		waitingRelease, err := s.acquireRefreshLock(ctx, "account-1")
+		if err != nil {
+			t.Errorf("acquireRefreshLock failed: %v", err)
+			close(acquired)
+			return
+		}
+		acquired <- waitingRelease
+	}()
+
+	release()
+
+	select {
+	case waitingRelease := <-acquired:
+		if waitingRelease != nil {
+			waitingRelease()
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected a waiting request to acquire the lock once it is released")
+	}
+}
+
+func TestAcquireRefreshLock_ExpiredHolderKeepsTheNextLock(t *testing.T) {
+	s := newTestService(t)
+	ctx := context.Background()
+
+	release, err := s.acquireRefreshLock(ctx, "account-1")
+	if err != nil {
+		t.Fatalf("acquireRefreshLock failed: %v", err)
+	}
+
+	// The refresh outlives the lock, which another request then takes
+	secondaryStorage := s.config.SecondaryStorage.Storage
+	key := oauth2RefreshLockPrefix + "account-1"
+	if err := secondaryStorage.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	if err := secondaryStorage.Delete(ctx, key+":owner"); err != nil {
+		t.Fatalf("Delete failed: %v", err)
+	}
+	releaseNext, err := s.acquireRefreshLock(ctx, "account-1")
+	if err != nil {
+		t.Fatalf("acquireRefreshLock failed: %v", err)
+	}
+
+	release()
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 3*oauth2RefreshLockPollInterval)
+	defer cancel()
+	if _, err := s.acquireRefreshLock(timeoutCtx, "account-1"); err == nil {
+		t.Fatal("expected the lock of the next request to be kept")
+	}
+
+	releaseNext()
+	releaseLast, err := s.acquireRefreshLock(ctx, "account-1")
+	if err != nil {
+		t.Fatalf("expected the lock to be released by its owner, got %v", err)
+	}
+	releaseLast()
+}
+
+func TestUpdateAccountTokens_KeepsRefreshTokenWhenNoneReturned(t *testing.T) {
+	cfg := config.NewConfig(
+		config.WithSecret("test-secret"),
+		config.WithSecondaryStorage(models.SecondaryStorageConfig{
+			Storage: storage.NewMemorySecondaryStorage(models.SecondaryStorageMemoryOptions{}),
+		}),
+	)
+	db := testutil.NewDB(t, &models.User{}, &models.Account{})
+	accountService := services.NewAccountServiceImpl(cfg, db)
+	tokenService := services.NewTokenServiceImpl(cfg, nil)
+	s := New(cfg, testutil.NewLogger(), nil, accountService, nil, tokenService, nil, oauth2providers.NewOAuth2ProviderRegistry(cfg))
+
+	refreshToken, err := tokenService.EncryptToken("refresh-1")
+	if err != nil {
+		t.Fatalf("EncryptToken failed: %v", err)
+	}
+	refreshTokenExpiresAt := time.Now().UTC().Add(24 * time.Hour).Truncate(time.Second)
+	account := &models.Account{
+		UserID:                "user-1",
+		AccountID:             "provider-user-1",
+		ProviderID:            models.ProviderType("google"),
+		RefreshToken:          &refreshToken,
+		RefreshTokenExpiresAt: &refreshTokenExpiresAt,
+	}
+	if err := accountService.CreateAccount(account); err != nil {
+		t.Fatalf("CreateAccount failed: %v", err)
+	}
+
+	// Providers such as Google only return a refresh token on the first consent
+	if err := s.updateAccountTokens(account, &oauth2.Token{AccessToken: "access-2", Expiry: time.Now().Add(time.Hour)}); err != nil {
+		t.Fatalf("updateAccountTokens failed: %v", err)
+	}
+
+	stored, err := accountService.GetAccountByUserIDAndProvider("user-1", models.ProviderType("google"))
+	if err != nil || stored == nil {
+		t.Fatalf("GetAccountByUserIDAndProvider failed: %v", err)
+	}
+	if stored.RefreshToken == nil {
+		t.Fatal("expected the refresh token to be kept")
+	}
+	if decrypted, err := tokenService.DecryptToken(*stored.RefreshToken); err != nil || decrypted != "refresh-1" {
+		t.Fatalf("expected the stored refresh token, got %q, %v", decrypted, err)
+	}
+	if stored.RefreshTokenExpiresAt == nil || !stored.RefreshTokenExpiresAt.Equal(refreshTokenExpiresAt) {
+		t.Fatalf("expected the refresh token expiry to be kept, got %v", stored.RefreshTokenExpiresAt)
+	}
+	if decrypted, err := tokenService.DecryptToken(*stored.AccessToken); err != nil || decrypted != "access-2" {
+		t.Fatalf("expected the new access token, got %q, %v", decrypted, err)
+	}
+}
@@ -7,10 +7,27 @@ import (
 )
 
 type OAuth2UseCase interface {
-	// PrepareOAuth2Login generates the authorization URL and state for the OAuth2 login flow
-	PrepareOAuth2Login(ctx context.Context, providerName string) (*models.OAuth2LoginResult, error)
+	// PrepareOAuth2Login generates the authorization URL and state for the OAuth2 login flow, and stores
+	// the state along with the PKCE verifier, nonce and redirect target of the flow
+	PrepareOAuth2Login(ctx context.Context, providerName string, options models.OAuth2LoginOptions) (*models.OAuth2LoginResult, error)
 
-	// SignInWithOAuth2 handles the OAuth2 callback, validates the state, exchanges the code for tokens,
+	// SignInWithOAuth2 handles the OAuth2 callback, consumes the stored state, exchanges the code for tokens,
 	// and either creates a new user or updates an existing one's OAuth2 credentials
-	SignInWithOAuth2(ctx context.Context, providerName string, code string, state string, verifier *string) (*models.SignInResult, error)
+	SignInWithOAuth2(ctx context.Context, providerName string, code string, state string) (*models.SignInResult, error)
+
+	// SignInWithIDToken signs in with an ID token obtained by a native app from the provider's SDK,
+	// creating the user and account the same way SignInWithOAuth2 does
+	SignInWithIDToken(ctx context.Context, providerName string, idToken string, nonce string) (*models.SignInResult, error)
+
+	// PrepareOAuth2Link starts the same flow as PrepareOAuth2Login, but the callback links the provider
+	// account to the signed in user instead of signing in
+	PrepareOAuth2Link(ctx context.Context, providerName string, userID string, options models.OAuth2LoginOptions) (*models.OAuth2LoginResult, error)
+
+	// PrepareOAuth2Reauthorize starts the flow again for a provider the user already linked, typically to
+	// request more scopes. The callback stores the new tokens on the linked account
+	PrepareOAuth2Reauthorize(ctx context.Context, providerName string, userID string, options models.OAuth2LoginOptions) (*models.OAuth2LoginResult, error)
+
+	// GetAccessToken returns a valid access token of the account the user linked with a provider,
+	// refreshing it if it expired or is about to
+	GetAccessToken(ctx context.Context, userID string, providerName string) (*models.OAuth2AccessToken, error)
 }
@@ -0,0 +1,444 @@
+package oidcprovider
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/GoBetterAuth/go-better-auth/internal/constants"
+	"github.com/GoBetterAuth/go-better-auth/internal/util"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+type service struct {
+	config              *models.Config
+	logger              models.Logger
+	userService         models.UserService
+	sessionService      models.SessionService
+	tokenService        models.TokenService
+	oidcProviderService models.OIDCProviderService
+}
+
+func New(
+	config *models.Config,
+	logger models.Logger,
+	userService models.UserService,
+	sessionService models.SessionService,
+	tokenService models.TokenService,
+	oidcProviderService models.OIDCProviderService,
+) *service {
+	return &service{
+		config:              config,
+		logger:              logger,
+		userService:         userService,
+		sessionService:      sessionService,
+		tokenService:        tokenService,
+		oidcProviderService: oidcProviderService,
+	}
+}
+
+func (s *service) GetDiscoveryDocument(ctx context.Context) (*models.OIDCDiscoveryDocument, error) {
+	if !s.config.OIDCProvider.Enabled {
+		return nil, constants.ErrOIDCProviderDisabled
+	}
+
+	issuer := s.issuer()
+	return &models.OIDCDiscoveryDocument{
+		Issuer:                            issuer,
+		AuthorizationEndpoint:             issuer + "/oidc/authorize",
+		TokenEndpoint:                     issuer + "/oidc/token",
+		UserInfoEndpoint:                  issuer + "/oidc/userinfo",
+		JWKSURI:                           issuer + "/jwks.json",
+		ScopesSupported:                   s.config.OIDCProvider.Scopes,
+		ResponseTypesSupported:            []string{"code"},
+		GrantTypesSupported:               []string{"authorization_code"},
+		SubjectTypesSupported:             []string{"public"},
+		IDTokenSigningAlgValuesSupported:  []string{s.config.JWT.Algorithm},
+		TokenEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post", "none"},
+		CodeChallengeMethodsSupported:     []string{"S256"},
+		ClaimsSupported:                   []string{"sub", "iss", "aud", "exp", "iat", "auth_time", "nonce", "name", "picture", "email", "email_verified"},
+	}, nil
+}
+
+func (s *service) RegisterClient(ctx context.Context, registration models.OAuthClientRegistration) (*models.OAuthClientRegistrationResult, error) {
+	if !s.config.OIDCProvider.Enabled {
+		return nil, constants.ErrOIDCProviderDisabled
+	}
+
+	for _, scope := range registration.Scopes {
+		if !slices.Contains(s.config.OIDCProvider.Scopes, scope) {
+			return nil, models.NewOAuthError("invalid_client_metadata", fmt.Sprintf("scope %q is not supported", scope))
+		}
+	}
+
+	clientID, err := util.GenerateRandomTokenHex(16)
+	if err != nil {
+		s.logger.Error("failed to generate client id", "error", err)
+		return nil, fmt.Errorf("%w: %w", constants.ErrTokenGenerationFailed, err)
+	}
+
+	client := &models.OAuthClient{
+		ClientID:     clientID,
+		Name:         registration.Name,
+		RedirectURIs: registration.RedirectURIs,
+		Scopes:       registration.Scopes,
+		Public:       registration.Public,
+		SkipConsent:  registration.SkipConsent,
+	}
+
+	var clientSecret string
+	if !client.Public {
+		clientSecret, err = s.tokenService.GenerateToken()
+		if err != nil {
+			s.logger.Error("failed to generate client secret", "error", err)
+			return nil, fmt.Errorf("%w: %w", constants.ErrTokenGenerationFailed, err)
+		}
+		hashedSecret := s.tokenService.HashToken(clientSecret)
+		client.ClientSecret = &hashedSecret
+	}
+
+	if err := s.oidcProviderService.CreateClient(client); err != nil {
+		s.logger.Error("failed to create client", "name", client.Name, "error", err)
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+
+	return &models.OAuthClientRegistrationResult{Client: client, ClientSecret: clientSecret}, nil
+}
+
+func (s *service) Authorize(ctx context.Context, userID string, sessionID string, request models.OIDCAuthorizeRequest) (*models.OIDCAuthorizeResult, error) {
+	if !s.config.OIDCProvider.Enabled {
+		return nil, constants.ErrOIDCProviderDisabled
+	}
+
+	client, err := s.oidcProviderService.GetClientByClientID(request.ClientID)
+	if err != nil {
+		s.logger.Error("failed to get client", "client_id", request.ClientID, "error", err)
+		return nil, fmt.Errorf("failed to get client: %w", err)
+	}
+	// Without a trusted redirect URI errors cannot be sent back to the client
+	if client == nil {
+		return nil, models.NewOAuthError("invalid_client", "unknown client")
+	}
+	if !client.HasRedirectURI(request.RedirectURI) {
+		return nil, models.NewOAuthError("invalid_request", "redirect_uri is not registered for this client")
+	}
+
+	if request.ResponseType != "code" {
+		return s.redirectError(request.RedirectURI, request.State, "unsupported_response_type", "only the code response type is supported"), nil
+	}
+
+	scopes := strings.Fields(request.Scope)
+	if !slices.Contains(scopes, models.ScopeOpenID) {
+		return s.redirectError(request.RedirectURI, request.State, "invalid_scope", "the openid scope is required"), nil
+	}
+	for _, scope := range scopes {
+		if !s.isAllowedScope(client, scope) {
+			return s.redirectError(request.RedirectURI, request.State, "invalid_scope", fmt.Sprintf("scope %q is not allowed", scope)), nil
+		}
+	}
+
+	if request.CodeChallenge == "" && client.Public {
+		return s.redirectError(request.RedirectURI, request.State, "invalid_request", "public clients must use PKCE"), nil
+	}
+	if request.CodeChallenge != "" && request.CodeChallengeMethod != "S256" {
+		return s.redirectError(request.RedirectURI, request.State, "invalid_request", "only the S256 code challenge method is supported"), nil
+	}
+
+	if userID == "" {
+		if s.config.OIDCProvider.LoginPage == "" {
+			return s.redirectError(request.RedirectURI, request.State, "login_required", "the user is not signed in"), nil
+		}
+		return &models.OIDCAuthorizeResult{
+			RedirectURL: util.AppendQueryParam(s.config.OIDCProvider.LoginPage, "callback_url", s.authorizeURL(request)),
+		}, nil
+	}
+
+	session, err := s.sessionService.GetSessionByID(sessionID)
+	if err != nil {
+		s.logger.Error("failed to get session", "session_id", sessionID, "error", err)
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	if session == nil || session.UserID != userID {
+		return nil, constants.ErrSessionNotFound
+	}
+
+	grant := &models.OAuthGrant{
+		ClientID:            client.ClientID,
+		UserID:              userID,
+		SessionID:           session.ID,
+		RedirectURI:         request.RedirectURI,
+		Scopes:              scopes,
+		State:               request.State,
+		Nonce:               request.Nonce,
+		CodeChallenge:       request.CodeChallenge,
+		CodeChallengeMethod: request.CodeChallengeMethod,
+		AuthTime:            session.CreatedAt,
+	}
+
+	if !client.SkipConsent {
+		consent, err := s.oidcProviderService.GetConsent(userID, client.ClientID)
+		if err != nil {
+			s.logger.Error("failed to get consent", "user_id", userID, "client_id", client.ClientID, "error", err)
+			return nil, fmt.Errorf("failed to get consent: %w", err)
+		}
+		if consent == nil || !consent.Covers(scopes) {
+			return s.requestConsent(ctx, client, grant)
+		}
+	}
+
+	return s.issueCode(ctx, grant)
+}
+
+func (s *service) Consent(ctx context.Context, userID string, consentCode string, accept bool) (*models.OIDCAuthorizeResult, error) {
+	if !s.config.OIDCProvider.Enabled {
+		return nil, constants.ErrOIDCProviderDisabled
+	}
+
+	grant, err := s.oidcProviderService.ConsumeGrant(ctx, models.OAuthGrantKindConsent, consentCode)
+	if err != nil {
+		s.logger.Error("failed to get consent request", "error", err)
+		return nil, fmt.Errorf("%w: %w", constants.ErrOIDCConsentInvalid, err)
+	}
+	if grant == nil || grant.UserID != userID {
+		return nil, constants.ErrOIDCConsentInvalid
+	}
+
+	if !accept {
+		return s.redirectError(grant.RedirectURI, grant.State, "access_denied", "the user denied the request"), nil
+	}
+
+	if err := s.oidcProviderService.SaveConsent(userID, grant.ClientID, grant.Scopes); err != nil {
+		s.logger.Error("failed to save consent", "user_id", userID, "client_id", grant.ClientID, "error", err)
+		return nil, fmt.Errorf("failed to save consent: %w", err)
+	}
+
+	return s.issueCode(ctx, grant)
+}
+
+func (s *service) ExchangeToken(ctx context.Context, request models.OIDCTokenRequest) (*models.OIDCTokenResponse, error) {
+	if !s.config.OIDCProvider.Enabled {
+		return nil, constants.ErrOIDCProviderDisabled
+	}
+
+	if request.GrantType != "authorization_code" {
+		return nil, models.NewOAuthError("unsupported_grant_type", "only the authorization_code grant is supported")
+	}
+
+	client, err := s.authenticateClient(request.ClientID, request.ClientSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	grant, err := s.oidcProviderService.ConsumeGrant(ctx, models.OAuthGrantKindAuthorizationCode, request.Code)
+	if err != nil {
+		s.logger.Error("failed to get authorization code", "error", err)
+		return nil, fmt.Errorf("failed to get authorization code: %w", err)
+	}
+	if grant == nil || grant.ClientID != client.ClientID || grant.RedirectURI != request.RedirectURI {
+		return nil, models.NewOAuthError("invalid_grant", "invalid or expired authorization code")
+	}
+	if grant.CodeChallenge != "" && util.PKCEChallengeS256(request.CodeVerifier) != grant.CodeChallenge {
+		return nil, models.NewOAuthError("invalid_grant", "invalid code verifier")
+	}
+
+	user, err := s.userService.GetUserByID(grant.UserID)
+	if err != nil {
+		s.logger.Error("failed to get user", "user_id", grant.UserID, "error", err)
+		return nil, fmt.Errorf("%w: %w", constants.ErrUserNotFound, err)
+	}
+	if user == nil {
+		return nil, models.NewOAuthError("invalid_grant", "the user no longer exists")
+	}
+	if user.IsBanned() {
+		return nil, models.NewOAuthError("invalid_grant", "the user is banned")
+	}
+
+	now := time.Now().UTC()
+	expiresIn := s.config.OIDCProvider.AccessTokenExpiresIn
+
+	grant.CodeChallenge = ""
+	grant.CodeChallengeMethod = ""
+	grant.ExpiresAt = now.Add(expiresIn)
+	accessToken, err := s.oidcProviderService.CreateGrant(ctx, models.OAuthGrantKindAccessToken, grant)
+	if err != nil {
+		s.logger.Error("failed to create access token", "client_id", client.ClientID, "error", err)
+		return nil, fmt.Errorf("%w: %w", constants.ErrTokenGenerationFailed, err)
+	}
+
+	claims := userClaims(user, grant.Scopes)
+	claims["iss"] = s.issuer()
+	claims["aud"] = client.ClientID
+	claims["azp"] = client.ClientID
+	claims["iat"] = now.Unix()
+	claims["exp"] = now.Add(expiresIn).Unix()
+	claims["auth_time"] = grant.AuthTime.Unix()
+	claims["sid"] = grant.SessionID
+	if grant.Nonce != "" {
+		claims["nonce"] = grant.Nonce
+	}
+
+	idToken, err := s.tokenService.SignJWT(claims)
+	if err != nil {
+		s.logger.Error("failed to sign id token", "client_id", client.ClientID, "error", err)
+		return nil, fmt.Errorf("%w: %w", constants.ErrTokenGenerationFailed, err)
+	}
+
+	return &models.OIDCTokenResponse{
+		AccessToken: accessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int(expiresIn.Seconds()),
+		IDToken:     idToken,
+		Scope:       strings.Join(grant.Scopes, " "),
+	}, nil
+}
+
+func (s *service) GetUserInfo(ctx context.Context, accessToken string) (map[string]any, error) {
+	if !s.config.OIDCProvider.Enabled {
+		return nil, constants.ErrOIDCProviderDisabled
+	}
+
+	grant, err := s.oidcProviderService.GetGrant(ctx, models.OAuthGrantKindAccessToken, accessToken)
+	if err != nil {
+		s.logger.Error("failed to get access token", "error", err)
+		return nil, fmt.Errorf("failed to get access token: %w", err)
+	}
+	if grant == nil {
+		return nil, constants.ErrInvalidToken
+	}
+
+	user, err := s.userService.GetUserByID(grant.UserID)
+	if err != nil {
+		s.logger.Error("failed to get user", "user_id", grant.UserID, "error", err)
+		return nil, fmt.Errorf("%w: %w", constants.ErrUserNotFound, err)
+	}
+	// Tokens of banned users are treated as invalid, as those issued before the ban are revoked
+	if user == nil || user.IsBanned() {
+		return nil, constants.ErrInvalidToken
+	}
+
+	return userClaims(user, grant.Scopes), nil
+}
+
+// authenticateClient checks the client credentials of a token request. Public clients have no secret
+// and are instead bound to the code through PKCE.
+func (s *service) authenticateClient(clientID string, clientSecret string) (*models.OAuthClient, error) {
+	client, err := s.oidcProviderService.GetClientByClientID(clientID)
+	if err != nil {
+		s.logger.Error("failed to get client", "client_id", clientID, "error", err)
+		return nil, fmt.Errorf("failed to get client: %w", err)
+	}
+	if client == nil {
+		return nil, models.NewOAuthError("invalid_client", "unknown client")
+	}
+	if client.Public {
+		return client, nil
+	}
+
+	if client.ClientSecret == nil || clientSecret == "" ||
+		subtle.ConstantTimeCompare([]byte(s.tokenService.HashToken(clientSecret)), []byte(*client.ClientSecret)) != 1 {
+		return nil, models.NewOAuthError("invalid_client", "invalid client credentials")
+	}
+
+	return client, nil
+}
+
+// requestConsent parks the grant until the user decides on the consent page.
+func (s *service) requestConsent(ctx context.Context, client *models.OAuthClient, grant *models.OAuthGrant) (*models.OIDCAuthorizeResult, error) {
+	if s.config.OIDCProvider.ConsentPage == "" {
+		return s.redirectError(grant.RedirectURI, grant.State, "consent_required", "the user has not approved this client"), nil
+	}
+
+	grant.ExpiresAt = time.Now().UTC().Add(s.config.OIDCProvider.CodeExpiresIn)
+	consentCode, err := s.oidcProviderService.CreateGrant(ctx, models.OAuthGrantKindConsent, grant)
+	if err != nil {
+		s.logger.Error("failed to create consent request", "client_id", client.ClientID, "error", err)
+		return nil, fmt.Errorf("%w: %w", constants.ErrTokenGenerationFailed, err)
+	}
+
+	redirectURL := util.AppendQueryParam(s.config.OIDCProvider.ConsentPage, "consent_code", consentCode)
+	redirectURL = util.AppendQueryParam(redirectURL, "client_id", client.ClientID)
+	redirectURL = util.AppendQueryParam(redirectURL, "scope", strings.Join(grant.Scopes, " "))
+	return &models.OIDCAuthorizeResult{RedirectURL: redirectURL}, nil
+}
+
+// issueCode creates an authorization code for the grant and redirects back to the client with it.
+func (s *service) issueCode(ctx context.Context, grant *models.OAuthGrant) (*models.OIDCAuthorizeResult, error) {
+	grant.ExpiresAt = time.Now().UTC().Add(s.config.OIDCProvider.CodeExpiresIn)
+	code, err := s.oidcProviderService.CreateGrant(ctx, models.OAuthGrantKindAuthorizationCode, grant)
+	if err != nil {
+		s.logger.Error("failed to create authorization code", "client_id", grant.ClientID, "error", err)
+		return nil, fmt.Errorf("%w: %w", constants.ErrTokenGenerationFailed, err)
+	}
+
+	redirectURL := util.AppendQueryParam(grant.RedirectURI, "code", code)
+	if grant.State != "" {
+		redirectURL = util.AppendQueryParam(redirectURL, "state", grant.State)
+	}
+	return &models.OIDCAuthorizeResult{RedirectURL: redirectURL}, nil
+}
+
+func (s *service) redirectError(redirectURI string, state string, code string, description string) *models.OIDCAuthorizeResult {
+	redirectURL := util.AppendQueryParam(redirectURI, "error", code)
+	redirectURL = util.AppendQueryParam(redirectURL, "error_description", description)
+	if state != "" {
+		redirectURL = util.AppendQueryParam(redirectURL, "state", state)
+	}
+	return &models.OIDCAuthorizeResult{RedirectURL: redirectURL}
+}
+
+func (s *service) isAllowedScope(client *models.OAuthClient, scope string) bool {
+	if !slices.Contains(s.config.OIDCProvider.Scopes, scope) {
+		return false
+	}
+	return len(client.Scopes) == 0 || slices.Contains(client.Scopes, scope)
+}
+
+// issuer is the URL the provider is served from, which the discovery document is relative to.
+func (s *service) issuer() string {
+	basePath := strings.TrimSuffix(s.config.BasePath, "/")
+	if basePath != "" && !strings.HasPrefix(basePath, "/") {
+		basePath = "/" + basePath
+	}
+	return strings.TrimSuffix(s.config.BaseURL, "/") + basePath
+}
+
+// authorizeURL rebuilds the authorization request so it can be resumed after signing in.
+func (s *service) authorizeURL(request models.OIDCAuthorizeRequest) string {
+	query := url.Values{}
+	query.Set("response_type", request.ResponseType)
+	query.Set("client_id", request.ClientID)
+	query.Set("redirect_uri", request.RedirectURI)
+	query.Set("scope", request.Scope)
+	for name, value := range map[string]string{
+		"state":                 request.State,
+		"nonce":                 request.Nonce,
+		"code_challenge":        request.CodeChallenge,
+		"code_challenge_method": request.CodeChallengeMethod,
+	} {
+		if value != "" {
+			query.Set(name, value)
+		}
+	}
+	return s.issuer() + "/oidc/authorize?" + query.Encode()
+}
+
+// userClaims returns the standard claims of a user released for the granted scopes.
+func userClaims(user *models.User, scopes []string) map[string]any {
+	claims := map[string]any{"sub": user.ID}
+	if slices.Contains(scopes, models.ScopeProfile) {
+		claims["name"] = user.Name
+		claims["updated_at"] = user.UpdatedAt.Unix()
+		if user.Image != nil {
+			claims["picture"] = *user.Image
+		}
+	}
+	if slices.Contains(scopes, models.ScopeEmail) {
+		claims["email"] = user.Email
+		claims["email_verified"] = user.EmailVerified
+	}
+	return claims
+}
@@ -0,0 +1,150 @@
+package oidcprovider
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/GoBetterAuth/go-better-auth/config"
+	"github.com/GoBetterAuth/go-better-auth/internal/constants"
+	"github.com/GoBetterAuth/go-better-auth/internal/services"
+	"github.com/GoBetterAuth/go-better-auth/internal/testutil"
+	"github.com/GoBetterAuth/go-better-auth/models"
+	"github.com/GoBetterAuth/go-better-auth/storage"
+)
+
+func TestBannedUserIsRejected(t *testing.T) {
+	db := testutil.NewDB(t, &models.User{}, &models.Session{}, &models.OAuthClient{})
+	cfg := config.NewConfig(
+		config.WithSecondaryStorage(models.SecondaryStorageConfig{
+			Storage: storage.NewMemorySecondaryStorage(models.SecondaryStorageMemoryOptions{}),
+		}),
+		config.WithOIDCProvider(models.OIDCProviderConfig{Enabled: true}),
+	)
+	userService := services.NewUserServiceImpl(cfg, db)
+	oidcProviderService := services.NewOIDCProviderServiceImpl(cfg, db)
+	useCase := New(cfg, testutil.NewLogger(), userService, services.NewSessionServiceImpl(cfg, db), services.NewTokenServiceImpl(cfg, nil), oidcProviderService)
+	ctx := context.Background()
+
+	user := &models.User{Email: "user@example.com", Banned: true}
+	if err := userService.CreateUser(user); err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+	if err := oidcProviderService.CreateClient(&models.OAuthClient{
+		ClientID:     "client-1",
+		RedirectURIs: []string{"https://app.example.com/callback"},
+		Public:       true,
+	}); err != nil {
+		t.Fatalf("CreateClient failed: %v", err)
+	}
+
+	grant := func(kind models.OAuthGrantKind) string {
+		t.Helper()
+		token, err := oidcProviderService.CreateGrant(ctx, kind, &models.OAuthGrant{
+			ClientID:    "client-1",
+			UserID:      user.ID,
+			RedirectURI: "https://app.example.com/callback",
+			Scopes:      []string{models.ScopeOpenID},
+			ExpiresAt:   time.Now().UTC().Add(time.Minute),
+		})
+		if err != nil {
+			t.Fatalf("CreateGrant failed: %v", err)
+		}
+		return token
+	}
+
+	_, err := useCase.ExchangeToken(ctx, models.OIDCTokenRequest{
+		GrantType:   "authorization_code",
+		Code:        grant(models.OAuthGrantKindAuthorizationCode),
+		RedirectURI: "https://app.example.com/callback",
+		ClientID:    "client-1",
+	})
+	var oauthErr *models.OAuthError
+	if !errors.As(err, &oauthErr) || oauthErr.Code != "invalid_grant" {
+		t.Fatalf("expected invalid_grant for a banned user, got %v", err)
+	}
+
+	if _, err := useCase.GetUserInfo(ctx, grant(models.OAuthGrantKindAccessToken)); !errors.Is(err, constants.ErrInvalidToken) {
+		t.Fatalf("expected ErrInvalidToken for a banned user, got %v", err)
+	}
+}
+
+// slowReadStorage delays reads, so that concurrent requests all read a record before any of them deletes it.
+type slowReadStorage struct {
+	models.SecondaryStorage
+}
+
+func (s slowReadStorage) Get(ctx context.Context, key string) (any, error) {
+	value, err := s.SecondaryStorage.Get(ctx, key)
+	time.Sleep(20 * time.Millisecond)
+	return value, err
+}
+
+func TestExchangeToken_CodeRedeemedOnceConcurrently(t *testing.T) {
+	db := testutil.NewDB(t, &models.User{}, &models.Session{}, &models.OAuthClient{}, &models.SigningKey{})
+	cfg := config.NewConfig(
+		config.WithSecret("test_secret"),
+		config.WithSecondaryStorage(models.SecondaryStorageConfig{
+			Storage: slowReadStorage{storage.NewMemorySecondaryStorage(models.SecondaryStorageMemoryOptions{})},
+		}),
+		config.WithOIDCProvider(models.OIDCProviderConfig{Enabled: true}),
+	)
+	userService := services.NewUserServiceImpl(cfg, db)
+	oidcProviderService := services.NewOIDCProviderServiceImpl(cfg, db)
+	tokenService := services.NewTokenServiceImpl(cfg, services.NewSigningKeyServiceImpl(cfg, db))
+	useCase := New(cfg, testutil.NewLogger(), userService, services.NewSessionServiceImpl(cfg, db), tokenService, oidcProviderService)
+	ctx := context.Background()
+
+	user := &models.User{Email: "user@example.com"}
+	if err := userService.CreateUser(user); err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+	if err := oidcProviderService.CreateClient(&models.OAuthClient{
+		ClientID:     "client-1",
+		RedirectURIs: []string{"https://app.example.com/callback"},
+		Public:       true,
+	}); err != nil {
+		t.Fatalf("CreateClient failed: %v", err)
+	}
+	// Generate the signing key up front, so that the exchanges only race on the code
+	if _, err := tokenService.SignJWT(map[string]any{}); err != nil {
+		t.Fatalf("SignJWT failed: %v", err)
+	}
+
+	code, err := oidcProviderService.CreateGrant(ctx, models.OAuthGrantKindAuthorizationCode, &models.OAuthGrant{
+		ClientID:    "client-1",
+		UserID:      user.ID,
+		RedirectURI: "https://app.example.com/callback",
+		Scopes:      []string{models.ScopeOpenID},
+		ExpiresAt:   time.Now().UTC().Add(time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("CreateGrant failed: %v", err)
+	}
+
+	var issued atomic.Int32
+	var wg sync.WaitGroup
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			response, err := useCase.ExchangeToken(ctx, models.OIDCTokenRequest{
+				GrantType:   "authorization_code",
+				Code:        code,
+				RedirectURI: "https://app.example.com/callback",
+				ClientID:    "client-1",
+			})
+			if err == nil && response.AccessToken != "" {
+				issued.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := issued.Load(); got != 1 {
+		t.Fatalf("expected the authorization code to be redeemed exactly once, got %d", got)
+	}
+}
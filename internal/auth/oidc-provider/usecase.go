@@ -0,0 +1,23 @@
+package oidcprovider
+
+import (
+	"context"
+
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+type OIDCProviderUseCase interface {
+	// GetDiscoveryDocument returns the provider metadata served from /.well-known/openid-configuration.
+	GetDiscoveryDocument(ctx context.Context) (*models.OIDCDiscoveryDocument, error)
+	// RegisterClient registers an application that signs its users in through the provider.
+	RegisterClient(ctx context.Context, registration models.OAuthClientRegistration) (*models.OAuthClientRegistrationResult, error)
+	// Authorize handles an authorization request for the signed in user, who may be empty.
+	// The result redirects to the login page, the consent page or back to the client.
+	Authorize(ctx context.Context, userID string, sessionID string, request models.OIDCAuthorizeRequest) (*models.OIDCAuthorizeResult, error)
+	// Consent records the user's decision on a pending consent request and redirects back to the client.
+	Consent(ctx context.Context, userID string, consentCode string, accept bool) (*models.OIDCAuthorizeResult, error)
+	// ExchangeToken redeems an authorization code for an access token and ID token.
+	ExchangeToken(ctx context.Context, request models.OIDCTokenRequest) (*models.OIDCTokenResponse, error)
+	// GetUserInfo returns the claims of the user an access token was issued for, limited to its scopes.
+	GetUserInfo(ctx context.Context, accessToken string) (map[string]any, error)
+}
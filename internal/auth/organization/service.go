@@ -0,0 +1,507 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/GoBetterAuth/go-better-auth/internal/constants"
+	"github.com/GoBetterAuth/go-better-auth/internal/util"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+type service struct {
+	config              *models.Config
+	logger              models.Logger
+	userService         models.UserService
+	sessionService      models.SessionService
+	tokenService        models.TokenService
+	mailerService       models.MailerService
+	organizationService models.OrganizationService
+}
+
+func New(
+	config *models.Config,
+	logger models.Logger,
+	userService models.UserService,
+	sessionService models.SessionService,
+	tokenService models.TokenService,
+	mailerService models.MailerService,
+	organizationService models.OrganizationService,
+) *service {
+	return &service{
+		config:              config,
+		logger:              logger,
+		userService:         userService,
+		sessionService:      sessionService,
+		tokenService:        tokenService,
+		mailerService:       mailerService,
+		organizationService: organizationService,
+	}
+}
+
+func (s *service) CreateOrganization(ctx context.Context, userID string, sessionID string, request models.CreateOrganizationRequest) (*models.UserOrganization, error) {
+	if !s.config.Organization.Enabled {
+		return nil, constants.ErrOrganizationsDisabled
+	}
+
+	name := strings.TrimSpace(request.Name)
+	slug := request.Slug
+	if slug == "" {
+		slug = util.Slugify(name)
+	}
+	if !util.IsValidSlug(slug) {
+		return nil, constants.ErrOrganizationSlugInvalid
+	}
+
+	existing, err := s.organizationService.GetOrganizationBySlug(slug)
+	if err != nil {
+		s.logger.Error("failed to check existing organization", "slug", slug, "error", err)
+		return nil, fmt.Errorf("failed to check existing organization: %w", err)
+	}
+	if existing != nil {
+		return nil, constants.ErrOrganizationSlugTaken
+	}
+
+	organization := &models.Organization{Name: name, Slug: slug, Logo: request.Logo}
+	if err := s.organizationService.CreateOrganization(organization, userID); err != nil {
+		s.logger.Error("failed to create organization", "slug", slug, "error", err)
+		return nil, fmt.Errorf("failed to create organization: %w", err)
+	}
+
+	// The organization exists at this point, failing to switch to it only leaves the session where it was
+	if session, err := s.sessionService.GetSessionByID(sessionID); err != nil || session == nil {
+		s.logger.Warn("failed to get session to activate organization", "session_id", sessionID, "error", err)
+	} else if err := s.sessionService.SetActiveOrganization(session, &organization.ID); err != nil {
+		s.logger.Warn("failed to activate organization", "session_id", sessionID, "error", err)
+	}
+
+	return &models.UserOrganization{Organization: *organization, Role: models.OrganizationRoleOwner}, nil
+}
+
+func (s *service) ListOrganizations(ctx context.Context, userID string) ([]models.UserOrganization, error) {
+	if !s.config.Organization.Enabled {
+		return nil, constants.ErrOrganizationsDisabled
+	}
+
+	organizations, err := s.organizationService.ListUserOrganizations(userID)
+	if err != nil {
+		s.logger.Error("failed to list organizations", "user_id", userID, "error", err)
+		return nil, fmt.Errorf("failed to list organizations: %w", err)
+	}
+	return organizations, nil
+}
+
+func (s *service) SetActiveOrganization(ctx context.Context, userID string, sessionID string, organizationID *string) (*models.Session, error) {
+	if !s.config.Organization.Enabled {
+		return nil, constants.ErrOrganizationsDisabled
+	}
+
+	if organizationID != nil {
+		if _, err := s.getMembership(*organizationID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	session, err := s.sessionService.GetSessionByID(sessionID)
+	if err != nil {
+		s.logger.Error("failed to get session", "session_id", sessionID, "error", err)
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	if session == nil || session.UserID != userID {
+		return nil, constants.ErrSessionNotFound
+	}
+
+	if err := s.sessionService.SetActiveOrganization(session, organizationID); err != nil {
+		s.logger.Error("failed to set active organization", "session_id", sessionID, "error", err)
+		return nil, fmt.Errorf("failed to set active organization: %w", err)
+	}
+
+	return session, nil
+}
+
+func (s *service) DeleteOrganization(ctx context.Context, userID string, organizationID string) error {
+	if !s.config.Organization.Enabled {
+		return constants.ErrOrganizationsDisabled
+	}
+
+	member, err := s.getMembership(organizationID, userID)
+	if err != nil {
+		return err
+	}
+	if member.Role != models.OrganizationRoleOwner {
+		return constants.ErrOrganizationForbidden
+	}
+
+	if err := s.organizationService.DeleteOrganization(organizationID); err != nil {
+		s.logger.Error("failed to delete organization", "organization_id", organizationID, "error", err)
+		return fmt.Errorf("failed to delete organization: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) ListMembers(ctx context.Context, userID string, organizationID string) ([]models.OrganizationMember, error) {
+	if !s.config.Organization.Enabled {
+		return nil, constants.ErrOrganizationsDisabled
+	}
+
+	if _, err := s.getMembership(organizationID, userID); err != nil {
+		return nil, err
+	}
+
+	members, err := s.organizationService.ListMembers(organizationID)
+	if err != nil {
+		s.logger.Error("failed to list members", "organization_id", organizationID, "error", err)
+		return nil, fmt.Errorf("failed to list members: %w", err)
+	}
+	return members, nil
+}
+
+func (s *service) UpdateMemberRole(ctx context.Context, userID string, organizationID string, memberUserID string, role models.OrganizationRole) (*models.Member, error) {
+	if !s.config.Organization.Enabled {
+		return nil, constants.ErrOrganizationsDisabled
+	}
+
+	if role != models.OrganizationRoleOwner && role != models.OrganizationRoleAdmin && role != models.OrganizationRoleMember {
+		return nil, constants.ErrOrganizationRoleInvalid
+	}
+
+	actor, err := s.getMembership(organizationID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canManageMembers(actor.Role) {
+		return nil, constants.ErrOrganizationForbidden
+	}
+
+	target, err := s.getMembership(organizationID, memberUserID)
+	if err != nil {
+		return nil, err
+	}
+	if (role == models.OrganizationRoleOwner || target.Role == models.OrganizationRoleOwner) && actor.Role != models.OrganizationRoleOwner {
+		return nil, constants.ErrOrganizationForbidden
+	}
+	if target.Role == role {
+		return target, nil
+	}
+
+	if target.Role == models.OrganizationRoleOwner {
+		owners, err := s.organizationService.CountMembersWithRole(organizationID, models.OrganizationRoleOwner)
+		if err != nil {
+			s.logger.Error("failed to count owners", "organization_id", organizationID, "error", err)
+			return nil, fmt.Errorf("failed to count owners: %w", err)
+		}
+		if owners <= 1 {
+			return nil, constants.ErrLastOrganizationOwner
+		}
+	}
+
+	if err := s.organizationService.UpdateMemberRole(organizationID, memberUserID, role); err != nil {
+		s.logger.Error("failed to update member role", "organization_id", organizationID, "user_id", memberUserID, "error", err)
+		return nil, fmt.Errorf("failed to update member role: %w", err)
+	}
+
+	target.Role = role
+	return target, nil
+}
+
+func (s *service) RemoveMember(ctx context.Context, userID string, organizationID string, memberUserID string) error {
+	if !s.config.Organization.Enabled {
+		return constants.ErrOrganizationsDisabled
+	}
+
+	actor, err := s.getMembership(organizationID, userID)
+	if err != nil {
+		return err
+	}
+
+	target := actor
+	if memberUserID != userID {
+		if !canManageMembers(actor.Role) {
+			return constants.ErrOrganizationForbidden
+		}
+		if target, err = s.getMembership(organizationID, memberUserID); err != nil {
+			return err
+		}
+		if target.Role == models.OrganizationRoleOwner && actor.Role != models.OrganizationRoleOwner {
+			return constants.ErrOrganizationForbidden
+		}
+	}
+
+	if target.Role == models.OrganizationRoleOwner {
+		owners, err := s.organizationService.CountMembersWithRole(organizationID, models.OrganizationRoleOwner)
+		if err != nil {
+			s.logger.Error("failed to count owners", "organization_id", organizationID, "error", err)
+			return fmt.Errorf("failed to count owners: %w", err)
+		}
+		if owners <= 1 {
+			return constants.ErrLastOrganizationOwner
+		}
+	}
+
+	if err := s.organizationService.RemoveMember(organizationID, target.UserID); err != nil {
+		s.logger.Error("failed to remove member", "organization_id", organizationID, "user_id", target.UserID, "error", err)
+		return fmt.Errorf("failed to remove member: %w", err)
+	}
+
+	if err := s.sessionService.ClearActiveOrganization(target.UserID, organizationID); err != nil {
+		s.logger.Warn("failed to clear active organization", "organization_id", organizationID, "user_id", target.UserID, "error", err)
+	}
+
+	return nil
+}
+
+func (s *service) CreateInvitation(ctx context.Context, inviterID string, organizationID string, request models.CreateInvitationRequest) (*models.Invitation, error) {
+	if !s.config.Organization.Enabled {
+		return nil, constants.ErrOrganizationsDisabled
+	}
+	if s.config.Organization.InvitationPage == "" {
+		return nil, constants.ErrInvitationPageMissing
+	}
+
+	// Owners are only made through the organization itself, never through an invitation
+	if request.Role != models.OrganizationRoleAdmin && request.Role != models.OrganizationRoleMember {
+		return nil, constants.ErrInvitationRoleInvalid
+	}
+
+	if request.CallbackURL != nil && *request.CallbackURL != "" && !util.IsTrustedRedirect(*request.CallbackURL, s.config.TrustedOrigins.Origins) {
+		return nil, constants.ErrUntrustedRedirect
+	}
+
+	inviter, err := s.getMembership(organizationID, inviterID)
+	if err != nil {
+		return nil, err
+	}
+	if !canManageMembers(inviter.Role) {
+		return nil, constants.ErrOrganizationForbidden
+	}
+
+	organization, err := s.getOrganization(organizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	inviterUser, err := s.userService.GetUserByID(inviterID)
+	if err != nil {
+		s.logger.Error("failed to get inviter", "user_id", inviterID, "error", err)
+		return nil, fmt.Errorf("failed to get inviter: %w", err)
+	}
+	if inviterUser == nil {
+		return nil, constants.ErrUserNotFound
+	}
+
+	email := strings.TrimSpace(request.Email)
+	invitee, err := s.userService.GetUserByEmail(email)
+	if err != nil {
+		s.logger.Error("failed to get user by email", "email", email, "error", err)
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if invitee != nil {
+		member, err := s.organizationService.GetMember(organizationID, invitee.ID)
+		if err != nil {
+			s.logger.Error("failed to get member", "organization_id", organizationID, "user_id", invitee.ID, "error", err)
+			return nil, fmt.Errorf("failed to get member: %w", err)
+		}
+		if member != nil {
+			return nil, constants.ErrAlreadyOrganizationMember
+		}
+	}
+
+	pending, err := s.organizationService.GetPendingInvitation(organizationID, email)
+	if err != nil {
+		s.logger.Error("failed to check pending invitations", "organization_id", organizationID, "error", err)
+		return nil, fmt.Errorf("failed to check pending invitations: %w", err)
+	}
+	if pending != nil {
+		return nil, constants.ErrInvitationAlreadyExists
+	}
+
+	token, err := s.tokenService.GenerateToken()
+	if err != nil {
+		s.logger.Error("failed to generate token", "error", err)
+		return nil, fmt.Errorf("%w: %w", constants.ErrTokenGenerationFailed, err)
+	}
+
+	invitation := &models.Invitation{
+		OrganizationID: organizationID,
+		Email:          email,
+		Role:           request.Role,
+		InviterID:      inviterID,
+		Token:          s.tokenService.HashToken(token),
+		ExpiresAt:      time.Now().UTC().Add(s.config.Organization.InvitationExpiresIn),
+	}
+	if err := s.organizationService.CreateInvitation(invitation); err != nil {
+		s.logger.Error("failed to create invitation", "organization_id", organizationID, "error", err)
+		return nil, fmt.Errorf("failed to create invitation: %w", err)
+	}
+
+	s.sendInvitationEmail(*invitation, *organization, *inviterUser, token, request.CallbackURL)
+
+	return invitation, nil
+}
+
+func (s *service) sendInvitationEmail(invitation models.Invitation, organization models.Organization, inviter models.User, token string, callbackURL *string) {
+	acceptURL := util.BuildInvitationURL(s.config.Organization.InvitationPage, "accept", token, callbackURL)
+	declineURL := util.BuildInvitationURL(s.config.Organization.InvitationPage, "decline", token, callbackURL)
+
+	if s.config.Organization.SendInvitationEmail != nil {
+		if err := s.config.Organization.SendInvitationEmail(invitation, organization, inviter, acceptURL, declineURL); err != nil {
+			s.logger.Error("failed to send invitation email", "invitation_id", invitation.ID, "error", err)
+		}
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		s.mailerService.Send(
+			ctx,
+			invitation.Email,
+			"You're invited to join "+organization.Name,
+			"You're invited to join "+organization.Name,
+			util.CreateInvitationEmailBody(organization, inviter, acceptURL, declineURL),
+		)
+	}()
+}
+
+func (s *service) ListInvitations(ctx context.Context, userID string, organizationID string) ([]models.Invitation, error) {
+	if !s.config.Organization.Enabled {
+		return nil, constants.ErrOrganizationsDisabled
+	}
+
+	member, err := s.getMembership(organizationID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canManageMembers(member.Role) {
+		return nil, constants.ErrOrganizationForbidden
+	}
+
+	invitations, err := s.organizationService.ListPendingInvitations(organizationID)
+	if err != nil {
+		s.logger.Error("failed to list invitations", "organization_id", organizationID, "error", err)
+		return nil, fmt.Errorf("failed to list invitations: %w", err)
+	}
+	return invitations, nil
+}
+
+func (s *service) AcceptInvitation(ctx context.Context, userID string, token string) (*models.InvitationResult, error) {
+	invitation, organization, err := s.getPendingInvitation(token)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.getInvitee(invitation, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.organizationService.AcceptInvitation(invitation, user.ID); err != nil {
+		if errors.Is(err, constants.ErrInvitationNotFound) {
+			return nil, err
+		}
+		s.logger.Error("failed to accept invitation", "invitation_id", invitation.ID, "error", err)
+		return nil, fmt.Errorf("failed to accept invitation: %w", err)
+	}
+
+	return &models.InvitationResult{Invitation: invitation, Organization: organization}, nil
+}
+
+func (s *service) DeclineInvitation(ctx context.Context, userID string, token string) (*models.InvitationResult, error) {
+	invitation, organization, err := s.getPendingInvitation(token)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := s.getInvitee(invitation, userID); err != nil {
+		return nil, err
+	}
+
+	if err := s.organizationService.DeclineInvitation(invitation); err != nil {
+		if errors.Is(err, constants.ErrInvitationNotFound) {
+			return nil, err
+		}
+		s.logger.Error("failed to decline invitation", "invitation_id", invitation.ID, "error", err)
+		return nil, fmt.Errorf("failed to decline invitation: %w", err)
+	}
+
+	return &models.InvitationResult{Invitation: invitation, Organization: organization}, nil
+}
+
+// getPendingInvitation looks up an unanswered and unexpired invitation by its emailed token.
+func (s *service) getPendingInvitation(token string) (*models.Invitation, *models.Organization, error) {
+	if !s.config.Organization.Enabled {
+		return nil, nil, constants.ErrOrganizationsDisabled
+	}
+
+	invitation, err := s.organizationService.GetInvitationByToken(s.tokenService.HashToken(token))
+	if err != nil {
+		s.logger.Error("failed to get invitation", "error", err)
+		return nil, nil, fmt.Errorf("failed to get invitation: %w", err)
+	}
+	if invitation == nil || invitation.Status != models.InvitationStatusPending {
+		return nil, nil, constants.ErrInvitationNotFound
+	}
+	if time.Now().UTC().After(invitation.ExpiresAt) {
+		return nil, nil, constants.ErrInvitationExpired
+	}
+
+	organization, err := s.getOrganization(invitation.OrganizationID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return invitation, organization, nil
+}
+
+// getInvitee returns the signed in user answering an invitation, failing unless the invitation was sent to their
+// verified email. Tokens travel through mailboxes and links, so holding one is not enough.
+func (s *service) getInvitee(invitation *models.Invitation, userID string) (*models.User, error) {
+	user, err := s.userService.GetUserByID(userID)
+	if err != nil {
+		s.logger.Error("failed to get user", "user_id", userID, "error", err)
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil {
+		return nil, constants.ErrUserNotFound
+	}
+	if user.IsBanned() {
+		return nil, constants.ErrUserBanned
+	}
+	if !user.EmailVerified || !strings.EqualFold(user.Email, invitation.Email) {
+		return nil, constants.ErrInvitationEmailMismatch
+	}
+	return user, nil
+}
+
+func (s *service) getOrganization(organizationID string) (*models.Organization, error) {
+	organization, err := s.organizationService.GetOrganizationByID(organizationID)
+	if err != nil {
+		s.logger.Error("failed to get organization", "organization_id", organizationID, "error", err)
+		return nil, fmt.Errorf("failed to get organization: %w", err)
+	}
+	if organization == nil {
+		return nil, constants.ErrOrganizationNotFound
+	}
+	return organization, nil
+}
+
+// getMembership returns the membership of a user, failing when they do not belong to the organization.
+func (s *service) getMembership(organizationID string, userID string) (*models.Member, error) {
+	member, err := s.organizationService.GetMember(organizationID, userID)
+	if err != nil {
+		s.logger.Error("failed to get member", "organization_id", organizationID, "user_id", userID, "error", err)
+		return nil, fmt.Errorf("failed to get member: %w", err)
+	}
+	if member == nil {
+		return nil, constants.ErrNotOrganizationMember
+	}
+	return member, nil
+}
+
+func canManageMembers(role models.OrganizationRole) bool {
+	return role == models.OrganizationRoleOwner || role == models.OrganizationRoleAdmin
+}
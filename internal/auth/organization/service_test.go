@@ -0,0 +1,287 @@
+package organization
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/GoBetterAuth/go-better-auth/config"
+	"github.com/GoBetterAuth/go-better-auth/internal/constants"
+	"github.com/GoBetterAuth/go-better-auth/internal/services"
+	"github.com/GoBetterAuth/go-better-auth/internal/testutil"
+	"github.com/GoBetterAuth/go-better-auth/models"
+	"github.com/GoBetterAuth/go-better-auth/storage"
+)
+
+type testEnv struct {
+	userService         *services.UserServiceImpl
+	organizationService *services.OrganizationServiceImpl
+	useCase             *service
+	// acceptURL is the accept link of the last invitation sent
+	acceptURL string
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+
+	env := &testEnv{}
+	db := testutil.NewDB(t, &models.User{}, &models.Session{}, &models.Organization{}, &models.Member{}, &models.Invitation{})
+	cfg := config.NewConfig(
+		config.WithSecondaryStorage(models.SecondaryStorageConfig{
+			Storage: storage.NewMemorySecondaryStorage(models.SecondaryStorageMemoryOptions{}),
+		}),
+		config.WithOrganization(models.OrganizationConfig{
+			Enabled:             true,
+			InvitationExpiresIn: time.Hour,
+			InvitationPage:      "https://app.com/invitations",
+			SendInvitationEmail: func(invitation models.Invitation, organization models.Organization, inviter models.User, acceptURL string, declineURL string) error {
+				env.acceptURL = acceptURL
+				return nil
+			},
+		}),
+	)
+
+	env.userService = services.NewUserServiceImpl(cfg, db)
+	env.organizationService = services.NewOrganizationServiceImpl(cfg, db)
+	env.useCase = New(
+		cfg,
+		testutil.NewLogger(),
+		env.userService,
+		services.NewSessionServiceImpl(cfg, db),
+		services.NewTokenServiceImpl(cfg, nil),
+		nil,
+		env.organizationService,
+	)
+	return env
+}
+
+func (env *testEnv) createUser(t *testing.T, email string, emailVerified bool) *models.User {
+	t.Helper()
+
+	user := &models.User{Email: email, EmailVerified: emailVerified}
+	if err := env.userService.CreateUser(user); err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+	return user
+}
+
+func (env *testEnv) createOrganization(t *testing.T, owner *models.User) *models.UserOrganization {
+	t.Helper()
+
+	organization, err := env.useCase.CreateOrganization(context.Background(), owner.ID, "", models.CreateOrganizationRequest{Name: "Acme"})
+	if err != nil {
+		t.Fatalf("CreateOrganization failed: %v", err)
+	}
+	return organization
+}
+
+// invite invites email to the organization on behalf of the inviter, returning the emailed token.
+func (env *testEnv) invite(t *testing.T, inviterID string, organizationID string, email string, role models.OrganizationRole) string {
+	t.Helper()
+
+	request := models.CreateInvitationRequest{Email: email, Role: role}
+	if _, err := env.useCase.CreateInvitation(context.Background(), inviterID, organizationID, request); err != nil {
+		t.Fatalf("CreateInvitation failed: %v", err)
+	}
+
+	acceptURL, err := url.Parse(env.acceptURL)
+	if err != nil {
+		t.Fatalf("failed to parse accept URL %q: %v", env.acceptURL, err)
+	}
+	if acceptURL.Query().Get("action") != "accept" {
+		t.Fatalf("expected the accept link to lead to the invitation page, got %q", env.acceptURL)
+	}
+	return acceptURL.Query().Get("token")
+}
+
+// addMember signs up a user with a verified email and has them accept an invitation to the organization.
+func (env *testEnv) addMember(t *testing.T, inviterID string, organizationID string, email string, role models.OrganizationRole) *models.User {
+	t.Helper()
+
+	token := env.invite(t, inviterID, organizationID, email, role)
+	user := env.createUser(t, email, true)
+	if _, err := env.useCase.AcceptInvitation(context.Background(), user.ID, token); err != nil {
+		t.Fatalf("AcceptInvitation failed: %v", err)
+	}
+	return user
+}
+
+func TestCreateInvitation_RejectsRolesOutsideTheAllowlist(t *testing.T) {
+	env := newTestEnv(t)
+	owner := env.createUser(t, "owner@example.com", true)
+	organization := env.createOrganization(t, owner)
+
+	for _, role := range []models.OrganizationRole{models.OrganizationRoleOwner, "superuser", ""} {
+		request := models.CreateInvitationRequest{Email: "invitee@example.com", Role: role}
+		if _, err := env.useCase.CreateInvitation(context.Background(), owner.ID, organization.ID, request); !errors.Is(err, constants.ErrInvitationRoleInvalid) {
+			t.Errorf("role %q: expected ErrInvitationRoleInvalid, got %v", role, err)
+		}
+	}
+}
+
+func TestAcceptInvitation(t *testing.T) {
+	env := newTestEnv(t)
+	owner := env.createUser(t, "owner@example.com", true)
+	organization := env.createOrganization(t, owner)
+	token := env.invite(t, owner.ID, organization.ID, "invitee@example.com", models.OrganizationRoleMember)
+	invitee := env.createUser(t, "Invitee@Example.com", true)
+
+	result, err := env.useCase.AcceptInvitation(context.Background(), invitee.ID, token)
+	if err != nil {
+		t.Fatalf("AcceptInvitation failed: %v", err)
+	}
+	if result.Invitation.Status != models.InvitationStatusAccepted {
+		t.Errorf("expected the invitation to be accepted, got %q", result.Invitation.Status)
+	}
+
+	member, err := env.organizationService.GetMember(organization.ID, invitee.ID)
+	if err != nil {
+		t.Fatalf("GetMember failed: %v", err)
+	}
+	if member == nil || member.Role != models.OrganizationRoleMember {
+		t.Errorf("expected the invitee to be a member, got %+v", member)
+	}
+}
+
+func TestAcceptInvitation_RequiresTheInvitedVerifiedEmail(t *testing.T) {
+	tests := []struct {
+		name          string
+		email         string
+		emailVerified bool
+	}{
+		{"another user", "someone@example.com", true},
+		{"unverified email", "invitee@example.com", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			env := newTestEnv(t)
+			owner := env.createUser(t, "owner@example.com", true)
+			organization := env.createOrganization(t, owner)
+			token := env.invite(t, owner.ID, organization.ID, "invitee@example.com", models.OrganizationRoleMember)
+			user := env.createUser(t, tt.email, tt.emailVerified)
+
+			if _, err := env.useCase.AcceptInvitation(context.Background(), user.ID, token); !errors.Is(err, constants.ErrInvitationEmailMismatch) {
+				t.Fatalf("expected ErrInvitationEmailMismatch, got %v", err)
+			}
+			if _, err := env.useCase.DeclineInvitation(context.Background(), user.ID, token); !errors.Is(err, constants.ErrInvitationEmailMismatch) {
+				t.Fatalf("expected ErrInvitationEmailMismatch when declining, got %v", err)
+			}
+
+			member, err := env.organizationService.GetMember(organization.ID, user.ID)
+			if err != nil {
+				t.Fatalf("GetMember failed: %v", err)
+			}
+			if member != nil {
+				t.Error("expected the user not to join the organization")
+			}
+		})
+	}
+}
+
+func TestUpdateMemberRole_TransfersOwnership(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	owner := env.createUser(t, "owner@example.com", true)
+	organization := env.createOrganization(t, owner)
+	member := env.addMember(t, owner.ID, organization.ID, "member@example.com", models.OrganizationRoleMember)
+
+	// The only owner can neither step down nor leave
+	if _, err := env.useCase.UpdateMemberRole(ctx, owner.ID, organization.ID, owner.ID, models.OrganizationRoleMember); !errors.Is(err, constants.ErrLastOrganizationOwner) {
+		t.Fatalf("expected ErrLastOrganizationOwner, got %v", err)
+	}
+	if err := env.useCase.RemoveMember(ctx, owner.ID, organization.ID, owner.ID); !errors.Is(err, constants.ErrLastOrganizationOwner) {
+		t.Fatalf("expected ErrLastOrganizationOwner, got %v", err)
+	}
+
+	promoted, err := env.useCase.UpdateMemberRole(ctx, owner.ID, organization.ID, member.ID, models.OrganizationRoleOwner)
+	if err != nil {
+		t.Fatalf("UpdateMemberRole failed: %v", err)
+	}
+	if promoted.Role != models.OrganizationRoleOwner {
+		t.Errorf("expected the member to be promoted to owner, got %q", promoted.Role)
+	}
+
+	if _, err := env.useCase.UpdateMemberRole(ctx, owner.ID, organization.ID, owner.ID, models.OrganizationRoleMember); err != nil {
+		t.Fatalf("expected the former owner to step down once another owner exists, got %v", err)
+	}
+	if err := env.useCase.RemoveMember(ctx, owner.ID, organization.ID, owner.ID); err != nil {
+		t.Fatalf("expected the former owner to leave, got %v", err)
+	}
+
+	members, err := env.useCase.ListMembers(ctx, member.ID, organization.ID)
+	if err != nil {
+		t.Fatalf("ListMembers failed: %v", err)
+	}
+	if len(members) != 1 || members[0].UserID != member.ID || members[0].Role != models.OrganizationRoleOwner {
+		t.Errorf("expected the new owner to be the only member left, got %+v", members)
+	}
+}
+
+func TestUpdateMemberRole_Permissions(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	owner := env.createUser(t, "owner@example.com", true)
+	organization := env.createOrganization(t, owner)
+	admin := env.addMember(t, owner.ID, organization.ID, "admin@example.com", models.OrganizationRoleAdmin)
+	member := env.addMember(t, owner.ID, organization.ID, "member@example.com", models.OrganizationRoleMember)
+
+	tests := []struct {
+		name     string
+		actorID  string
+		targetID string
+		role     models.OrganizationRole
+		expected error
+	}{
+		{"members cannot change roles", member.ID, member.ID, models.OrganizationRoleAdmin, constants.ErrOrganizationForbidden},
+		{"admins cannot grant ownership", admin.ID, member.ID, models.OrganizationRoleOwner, constants.ErrOrganizationForbidden},
+		{"admins cannot demote owners", admin.ID, owner.ID, models.OrganizationRoleMember, constants.ErrOrganizationForbidden},
+		{"unknown role", owner.ID, member.ID, "superuser", constants.ErrOrganizationRoleInvalid},
+		{"not a member", owner.ID, "missing", models.OrganizationRoleAdmin, constants.ErrNotOrganizationMember},
+		{"admins manage members", admin.ID, member.ID, models.OrganizationRoleAdmin, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := env.useCase.UpdateMemberRole(ctx, tt.actorID, organization.ID, tt.targetID, tt.role)
+			if !errors.Is(err, tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+func TestDeleteOrganization(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	owner := env.createUser(t, "owner@example.com", true)
+	organization := env.createOrganization(t, owner)
+	admin := env.addMember(t, owner.ID, organization.ID, "admin@example.com", models.OrganizationRoleAdmin)
+	token := env.invite(t, owner.ID, organization.ID, "invitee@example.com", models.OrganizationRoleMember)
+
+	if err := env.useCase.DeleteOrganization(ctx, admin.ID, organization.ID); !errors.Is(err, constants.ErrOrganizationForbidden) {
+		t.Fatalf("expected admins not to delete the organization, got %v", err)
+	}
+
+	if err := env.useCase.DeleteOrganization(ctx, owner.ID, organization.ID); err != nil {
+		t.Fatalf("DeleteOrganization failed: %v", err)
+	}
+
+	if found, err := env.organizationService.GetOrganizationByID(organization.ID); err != nil || found != nil {
+		t.Errorf("expected the organization to be deleted, got %+v, %v", found, err)
+	}
+	if member, err := env.organizationService.GetMember(organization.ID, admin.ID); err != nil || member != nil {
+		t.Errorf("expected the members to be removed, got %+v, %v", member, err)
+	}
+	invitee := env.createUser(t, "invitee@example.com", true)
+	if _, err := env.useCase.AcceptInvitation(ctx, invitee.ID, token); !errors.Is(err, constants.ErrInvitationNotFound) {
+		t.Errorf("expected the invitations to be removed, got %v", err)
+	}
+	organizations, err := env.useCase.ListOrganizations(ctx, owner.ID)
+	if err != nil {
+		t.Fatalf("ListOrganizations failed: %v", err)
+	}
+	if len(organizations) != 0 {
+		t.Errorf("expected the owner to have no organization left, got %+v", organizations)
+	}
+}
@@ -0,0 +1,33 @@
+package organization
+
+import (
+	"context"
+
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+type OrganizationUseCase interface {
+	// CreateOrganization creates an organization owned by the user and makes it the active organization of the session.
+	CreateOrganization(ctx context.Context, userID string, sessionID string, request models.CreateOrganizationRequest) (*models.UserOrganization, error)
+	// ListOrganizations returns the organizations the user is a member of.
+	ListOrganizations(ctx context.Context, userID string) ([]models.UserOrganization, error)
+	// SetActiveOrganization switches the organization the session works in, nil clears it.
+	SetActiveOrganization(ctx context.Context, userID string, sessionID string, organizationID *string) (*models.Session, error)
+	// DeleteOrganization deletes an organization along with its members and invitations. Only owners may delete it.
+	DeleteOrganization(ctx context.Context, userID string, organizationID string) error
+	// ListMembers returns the members of an organization the user belongs to.
+	ListMembers(ctx context.Context, userID string, organizationID string) ([]models.OrganizationMember, error)
+	// UpdateMemberRole changes the role of a member. Owners and admins may change roles, only owners may grant or
+	// take away the owner role. Ownership is transferred by promoting another member to owner, then stepping down.
+	UpdateMemberRole(ctx context.Context, userID string, organizationID string, memberUserID string, role models.OrganizationRole) (*models.Member, error)
+	// RemoveMember removes a member from an organization. Members may remove themselves, owners and admins anyone but owners.
+	RemoveMember(ctx context.Context, userID string, organizationID string, memberUserID string) error
+	// CreateInvitation invites an email address to an organization and emails it the accept and decline links.
+	CreateInvitation(ctx context.Context, inviterID string, organizationID string, request models.CreateInvitationRequest) (*models.Invitation, error)
+	// ListInvitations returns the pending invitations of an organization.
+	ListInvitations(ctx context.Context, userID string, organizationID string) ([]models.Invitation, error)
+	// AcceptInvitation adds the user to the organization, provided their verified email is the invited one.
+	AcceptInvitation(ctx context.Context, userID string, token string) (*models.InvitationResult, error)
+	// DeclineInvitation declines an invitation on behalf of the user it was sent to.
+	DeclineInvitation(ctx context.Context, userID string, token string) (*models.InvitationResult, error)
+}
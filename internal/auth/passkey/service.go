@@ -0,0 +1,399 @@
+package passkey
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/GoBetterAuth/go-better-auth/internal/constants"
+	"github.com/GoBetterAuth/go-better-auth/internal/webauthn"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+const (
+	ceremonyRegistration   = "registration"
+	ceremonyAuthentication = "authentication"
+	defaultPasskeyName     = "Passkey"
+)
+
+type service struct {
+	config         *models.Config
+	logger         models.Logger
+	userService    models.UserService
+	sessionService models.SessionService
+	tokenService   models.TokenService
+	passkeyService models.PasskeyService
+	eventEmitter   models.EventEmitter
+}
+
+func New(
+	config *models.Config,
+	logger models.Logger,
+	userService models.UserService,
+	sessionService models.SessionService,
+	tokenService models.TokenService,
+	passkeyService models.PasskeyService,
+	eventEmitter models.EventEmitter,
+) *service {
+	return &service{
+		config:         config,
+		logger:         logger,
+		userService:    userService,
+		sessionService: sessionService,
+		tokenService:   tokenService,
+		passkeyService: passkeyService,
+		eventEmitter:   eventEmitter,
+	}
+}
+
+func (s *service) BeginPasskeyRegistration(ctx context.Context, userID string) (*models.PasskeyRegistrationOptions, error) {
+	if !s.config.Passkey.Enabled {
+		return nil, constants.ErrPasskeyDisabled
+	}
+
+	rp, err := s.relyingParty()
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userService.GetUserByID(userID)
+	if err != nil {
+		s.logger.Error("failed to get user", "user_id", userID, "error", err)
+		return nil, fmt.Errorf("%w: %w", constants.ErrUserNotFound, err)
+	}
+	if user == nil {
+		return nil, constants.ErrUserNotFound
+	}
+
+	passkeys, err := s.passkeyService.GetPasskeysByUserID(user.ID)
+	if err != nil {
+		s.logger.Error("failed to get passkeys", "user_id", user.ID, "error", err)
+		return nil, err
+	}
+
+	challenge, err := s.passkeyService.CreateChallenge(ctx, ceremonyRegistration, user.ID)
+	if err != nil {
+		s.logger.Error("failed to create passkey challenge", "user_id", user.ID, "error", err)
+		return nil, fmt.Errorf("%w: %w", constants.ErrTokenGenerationFailed, err)
+	}
+
+	params := make([]models.PasskeyCredentialParameter, 0, len(webauthn.SupportedAlgorithms))
+	for _, alg := range webauthn.SupportedAlgorithms {
+		params = append(params, models.PasskeyCredentialParameter{Type: "public-key", Alg: alg})
+	}
+
+	rpName := s.config.Passkey.RPName
+	if rpName == "" {
+		rpName = s.config.AppName
+	}
+
+	return &models.PasskeyRegistrationOptions{
+		Challenge: challenge,
+		RP: models.PasskeyRelyingParty{
+			ID:   rp.ID,
+			Name: rpName,
+		},
+		User: models.PasskeyUserEntity{
+			ID:          webauthn.Base64URL.EncodeToString([]byte(user.ID)),
+			Name:        user.Email,
+			DisplayName: user.Name,
+		},
+		PubKeyCredParams:   params,
+		Timeout:            s.config.Passkey.Timeout.Milliseconds(),
+		Attestation:        "none",
+		ExcludeCredentials: credentialDescriptors(passkeys),
+		AuthenticatorSelection: models.PasskeyAuthenticatorSelection{
+			ResidentKey:      "preferred",
+			UserVerification: s.config.Passkey.UserVerification,
+		},
+	}, nil
+}
+
+func (s *service) FinishPasskeyRegistration(ctx context.Context, userID string, name string, credential models.PasskeyRegistrationCredential) (*models.Passkey, error) {
+	if !s.config.Passkey.Enabled {
+		return nil, constants.ErrPasskeyDisabled
+	}
+
+	rp, err := s.relyingParty()
+	if err != nil {
+		return nil, err
+	}
+
+	clientDataJSON, err := webauthn.Base64URL.DecodeString(credential.Response.ClientDataJSON)
+	if err != nil {
+		return nil, constants.ErrPasskeyVerificationFailed
+	}
+	attestationObject, err := webauthn.Base64URL.DecodeString(credential.Response.AttestationObject)
+	if err != nil {
+		return nil, constants.ErrPasskeyVerificationFailed
+	}
+
+	challenge, err := s.consumeChallenge(ctx, ceremonyRegistration, clientDataJSON)
+	if err != nil {
+		return nil, err
+	}
+	if challenge.userID != userID {
+		return nil, constants.ErrPasskeyChallengeInvalid
+	}
+
+	verified, err := webauthn.VerifyRegistration(rp, challenge.value, clientDataJSON, attestationObject)
+	if err != nil {
+		s.logger.Warn("passkey registration verification failed", "user_id", userID, "error", err)
+		return nil, fmt.Errorf("%w: %w", constants.ErrPasskeyVerificationFailed, err)
+	}
+
+	credentialID := webauthn.Base64URL.EncodeToString(verified.ID)
+	existing, err := s.passkeyService.GetPasskeyByCredentialID(credentialID)
+	if err != nil {
+		s.logger.Error("failed to get passkey", "error", err)
+		return nil, err
+	}
+	if existing != nil {
+		return nil, constants.ErrPasskeyAlreadyRegistered
+	}
+
+	if strings.TrimSpace(name) == "" {
+		name = defaultPasskeyName
+	}
+
+	passkey := &models.Passkey{
+		UserID:       userID,
+		Name:         strings.TrimSpace(name),
+		CredentialID: credentialID,
+		PublicKey:    verified.PublicKey,
+		Algorithm:    verified.Algorithm,
+		SignCount:    verified.SignCount,
+		AAGUID:       verified.AAGUID,
+		Transports:   strings.Join(credential.Response.Transports, ","),
+		BackedUp:     verified.BackedUp,
+	}
+	if err := s.passkeyService.CreatePasskey(passkey); err != nil {
+		s.logger.Error("failed to create passkey", "user_id", userID, "error", err)
+		return nil, err
+	}
+
+	return passkey, nil
+}
+
+func (s *service) BeginPasskeyAuthentication(ctx context.Context, email *string) (*models.PasskeyAuthenticationOptions, error) {
+	if !s.config.Passkey.Enabled {
+		return nil, constants.ErrPasskeyDisabled
+	}
+
+	rp, err := s.relyingParty()
+	if err != nil {
+		return nil, err
+	}
+
+	// Without an email the browser offers the user's discoverable credentials
+	userID := ""
+	allowCredentials := []models.PasskeyCredentialDescriptor{}
+	if email != nil && *email != "" {
+		user, err := s.userService.GetUserByEmail(*email)
+		if err != nil {
+			s.logger.Error("failed to get user by email", "email", *email, "error", err)
+			return nil, fmt.Errorf("%w: %w", constants.ErrUserNotFound, err)
+		}
+		if user != nil {
+			passkeys, err := s.passkeyService.GetPasskeysByUserID(user.ID)
+			if err != nil {
+				s.logger.Error("failed to get passkeys", "user_id", user.ID, "error", err)
+				return nil, err
+			}
+			userID = user.ID
+			allowCredentials = credentialDescriptors(passkeys)
+		}
+	}
+
+	challenge, err := s.passkeyService.CreateChallenge(ctx, ceremonyAuthentication, userID)
+	if err != nil {
+		s.logger.Error("failed to create passkey challenge", "error", err)
+		return nil, fmt.Errorf("%w: %w", constants.ErrTokenGenerationFailed, err)
+	}
+
+	return &models.PasskeyAuthenticationOptions{
+		Challenge:        challenge,
+		RPID:             rp.ID,
+		Timeout:          s.config.Passkey.Timeout.Milliseconds(),
+		UserVerification: s.config.Passkey.UserVerification,
+		AllowCredentials: allowCredentials,
+	}, nil
+}
+
+func (s *service) FinishPasskeyAuthentication(ctx context.Context, credential models.PasskeyAuthenticationCredential) (*models.SignInResult, error) {
+	if !s.config.Passkey.Enabled {
+		return nil, constants.ErrPasskeyDisabled
+	}
+
+	rp, err := s.relyingParty()
+	if err != nil {
+		return nil, err
+	}
+
+	clientDataJSON, err := webauthn.Base64URL.DecodeString(credential.Response.ClientDataJSON)
+	if err != nil {
+		return nil, constants.ErrPasskeyVerificationFailed
+	}
+	authenticatorData, err := webauthn.Base64URL.DecodeString(credential.Response.AuthenticatorData)
+	if err != nil {
+		return nil, constants.ErrPasskeyVerificationFailed
+	}
+	signature, err := webauthn.Base64URL.DecodeString(credential.Response.Signature)
+	if err != nil {
+		return nil, constants.ErrPasskeyVerificationFailed
+	}
+	rawCredentialID, err := webauthn.Base64URL.DecodeString(credential.ID)
+	if err != nil {
+		return nil, constants.ErrPasskeyVerificationFailed
+	}
+
+	challenge, err := s.consumeChallenge(ctx, ceremonyAuthentication, clientDataJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	passkey, err := s.passkeyService.GetPasskeyByCredentialID(webauthn.Base64URL.EncodeToString(rawCredentialID))
+	if err != nil {
+		s.logger.Error("failed to get passkey", "error", err)
+		return nil, err
+	}
+	if passkey == nil {
+		return nil, constants.ErrPasskeyNotFound
+	}
+
+	// The challenge may have been issued for a specific user
+	if challenge.userID != "" && challenge.userID != passkey.UserID {
+		return nil, constants.ErrPasskeyVerificationFailed
+	}
+	if credential.Response.UserHandle != nil && *credential.Response.UserHandle != "" {
+		userHandle, err := webauthn.Base64URL.DecodeString(*credential.Response.UserHandle)
+		if err != nil || string(userHandle) != passkey.UserID {
+			return nil, constants.ErrPasskeyVerificationFailed
+		}
+	}
+
+	result, err := webauthn.VerifyAssertion(
+		rp,
+		challenge.value,
+		passkey.PublicKey,
+		passkey.SignCount,
+		clientDataJSON,
+		authenticatorData,
+		signature,
+	)
+	if err != nil {
+		s.logger.Warn("passkey authentication verification failed", "passkey_id", passkey.ID, "error", err)
+		return nil, fmt.Errorf("%w: %w", constants.ErrPasskeyVerificationFailed, err)
+	}
+
+	now := time.Now().UTC()
+	passkey.SignCount = result.SignCount
+	passkey.BackedUp = result.BackedUp
+	passkey.LastUsedAt = &now
+	if err := s.passkeyService.UpdatePasskey(passkey); err != nil {
+		s.logger.Error("failed to update passkey", "passkey_id", passkey.ID, "error", err)
+		return nil, err
+	}
+
+	user, err := s.userService.GetUserByID(passkey.UserID)
+	if err != nil {
+		s.logger.Error("failed to get user", "user_id", passkey.UserID, "error", err)
+		return nil, fmt.Errorf("%w: %w", constants.ErrUserNotFound, err)
+	}
+	if user == nil {
+		return nil, constants.ErrUserNotFound
+	}
+	if user.IsBanned() {
+		return nil, constants.ErrUserBanned
+	}
+
+	token, err := s.tokenService.GenerateToken()
+	if err != nil {
+		s.logger.Error("failed to generate token", "error", err)
+		return nil, fmt.Errorf("%w: %w", constants.ErrTokenGenerationFailed, err)
+	}
+
+	_, err = s.sessionService.CreateSession(user.ID, s.tokenService.HashToken(token))
+	if err != nil {
+		s.logger.Error("failed to create session", "user_id", user.ID, "error", err)
+		return nil, fmt.Errorf("%w: %w", constants.ErrSessionCreationFailed, err)
+	}
+
+	s.eventEmitter.OnUserLoggedIn(*user)
+
+	var csrfToken *string = nil
+	if s.config.CSRF.Enabled {
+		csrfTokenGenerated, err := s.tokenService.GenerateToken()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", constants.ErrTokenGenerationFailed, err)
+		}
+		csrfToken = &csrfTokenGenerated
+	}
+
+	return &models.SignInResult{
+		User:      user,
+		Token:     token,
+		CSRFToken: csrfToken,
+	}, nil
+}
+
+type storedChallenge struct {
+	value  string
+	userID string
+}
+
+// consumeChallenge extracts the challenge from the client data and consumes it from storage
+func (s *service) consumeChallenge(ctx context.Context, ceremony string, clientDataJSON []byte) (*storedChallenge, error) {
+	clientData, err := webauthn.ParseClientData(clientDataJSON)
+	if err != nil {
+		return nil, constants.ErrPasskeyVerificationFailed
+	}
+
+	userID, err := s.passkeyService.ConsumeChallenge(ctx, ceremony, clientData.Challenge)
+	if err != nil {
+		return nil, err
+	}
+
+	return &storedChallenge{value: clientData.Challenge, userID: userID}, nil
+}
+
+// relyingParty resolves the relying party id and allowed origins from the config
+func (s *service) relyingParty() (webauthn.RelyingParty, error) {
+	baseURL, err := url.Parse(s.config.BaseURL)
+	if err != nil {
+		return webauthn.RelyingParty{}, fmt.Errorf("%w: invalid base url: %w", constants.ErrConfigInvalid, err)
+	}
+
+	rpID := s.config.Passkey.RPID
+	if rpID == "" {
+		rpID = baseURL.Hostname()
+	}
+
+	origins := s.config.Passkey.Origins
+	if len(origins) == 0 {
+		origins = append([]string{baseURL.Scheme + "://" + baseURL.Host}, s.config.TrustedOrigins.Origins...)
+	}
+
+	return webauthn.RelyingParty{
+		ID:                      rpID,
+		Origins:                 origins,
+		RequireUserVerification: s.config.Passkey.UserVerification == "required",
+	}, nil
+}
+
+func credentialDescriptors(passkeys []models.Passkey) []models.PasskeyCredentialDescriptor {
+	descriptors := make([]models.PasskeyCredentialDescriptor, 0, len(passkeys))
+	for _, passkey := range passkeys {
+		descriptor := models.PasskeyCredentialDescriptor{
+			Type: "public-key",
+			ID:   passkey.CredentialID,
+		}
+		if passkey.Transports != "" {
+			descriptor.Transports = strings.Split(passkey.Transports, ",")
+		}
+		descriptors = append(descriptors, descriptor)
+	}
+	return descriptors
+}
@@ -0,0 +1,378 @@
+package passkey
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/GoBetterAuth/go-better-auth/config"
+	"github.com/GoBetterAuth/go-better-auth/internal/constants"
+	"github.com/GoBetterAuth/go-better-auth/internal/events"
+	"github.com/GoBetterAuth/go-better-auth/internal/services"
+	"github.com/GoBetterAuth/go-better-auth/internal/testutil"
+	"github.com/GoBetterAuth/go-better-auth/internal/webauthn"
+	"github.com/GoBetterAuth/go-better-auth/models"
+	"github.com/GoBetterAuth/go-better-auth/storage"
+)
+
+const (
+	testRPID   = "example.com"
+	testOrigin = "https://example.com"
+)
+
+// slowReadStorage widens the window between reading a challenge and consuming it.
+type slowReadStorage struct {
+	models.SecondaryStorage
+}
+
+func (s slowReadStorage) Get(ctx context.Context, key string) (any, error) {
+	value, err := s.SecondaryStorage.Get(ctx, key)
+	time.Sleep(20 * time.Millisecond)
+	return value, err
+}
+
+type testEnv struct {
+	userService    *services.UserServiceImpl
+	passkeyService *services.PasskeyServiceImpl
+	useCase        *service
+}
+
+func newTestEnv(t *testing.T, secondaryStorage models.SecondaryStorage) *testEnv {
+	t.Helper()
+
+	db := testutil.NewDB(t, &models.User{}, &models.Session{}, &models.Passkey{})
+	cfg := config.NewConfig(
+		config.WithBaseURL(testOrigin),
+		config.WithLogger(models.LoggerConfig{Logger: testutil.NewLogger()}),
+		config.WithSecondaryStorage(models.SecondaryStorageConfig{Storage: secondaryStorage}),
+		config.WithPasskey(models.PasskeyConfig{Enabled: true}),
+	)
+
+	env := &testEnv{
+		userService:    services.NewUserServiceImpl(cfg, db),
+		passkeyService: services.NewPasskeyServiceImpl(cfg, db),
+	}
+	env.useCase = New(
+		cfg,
+		cfg.Logger.Logger,
+		env.userService,
+		services.NewSessionServiceImpl(cfg, db),
+		services.NewTokenServiceImpl(cfg, nil),
+		env.passkeyService,
+		events.NewEventEmitter(cfg, cfg.Logger.Logger, nil, nil),
+	)
+	return env
+}
+
+func (env *testEnv) createUser(t *testing.T, email string) *models.User {
+	t.Helper()
+
+	user := &models.User{Email: email, EmailVerified: true}
+	if err := env.userService.CreateUser(user); err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+	return user
+}
+
+// ---------------------------------
+// Software authenticator
+// ---------------------------------
+
+type cborPair struct {
+	key   any
+	value any
+}
+
+// cborMap keeps insertion order so that encoded fixtures are deterministic
+type cborMap []cborPair
+
+func encodeCBORHead(major byte, n uint64) []byte {
+	switch {
+	case n < 24:
+		return []byte{major<<5 | byte(n)}
+	case n <= 0xff:
+		return []byte{major<<5 | 24, byte(n)}
+	default:
+		return binary.BigEndian.AppendUint16([]byte{major<<5 | 25}, uint16(n))
+	}
+}
+
+func encodeCBOR(v any) []byte {
+	switch value := v.(type) {
+	case int:
+		if value < 0 {
+			return encodeCBORHead(1, uint64(-1-value))
+		}
+		return encodeCBORHead(0, uint64(value))
+	case []byte:
+		return append(encodeCBORHead(2, uint64(len(value))), value...)
+	case string:
+		return append(encodeCBORHead(3, uint64(len(value))), value...)
+	case cborMap:
+		out := encodeCBORHead(5, uint64(len(value)))
+		for _, pair := range value {
+			out = append(out, encodeCBOR(pair.key)...)
+			out = append(out, encodeCBOR(pair.value)...)
+		}
+		return out
+	}
+	panic("unsupported fixture type")
+}
+
+// authenticator is a software P-256 authenticator holding a single credential.
+type authenticator struct {
+	privateKey   *ecdsa.PrivateKey
+	credentialID []byte
+	userID       string
+	signCount    uint32
+}
+
+func newAuthenticator(t *testing.T) *authenticator {
+	t.Helper()
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	credentialID := make([]byte, 16)
+	if _, err := rand.Read(credentialID); err != nil {
+		t.Fatalf("failed to generate credential id: %v", err)
+	}
+	return &authenticator{privateKey: privateKey, credentialID: credentialID}
+}
+
+func (a *authenticator) authenticatorData(flags byte, attested bool) []byte {
+	rpIDHash := sha256.Sum256([]byte(testRPID))
+	data := append([]byte{}, rpIDHash[:]...)
+	data = append(data, flags)
+	data = binary.BigEndian.AppendUint32(data, a.signCount)
+	if attested {
+		point, _ := a.privateKey.PublicKey.Bytes()
+		data = append(data, make([]byte, 16)...)
+		data = binary.BigEndian.AppendUint16(data, uint16(len(a.credentialID)))
+		data = append(data, a.credentialID...)
+		data = append(data, encodeCBOR(cborMap{
+			{1, 2},
+			{3, -7},
+			{-1, 1},
+			{-2, point[1:33]},
+			{-3, point[33:]},
+		})...)
+	}
+	return data
+}
+
+func clientData(t *testing.T, clientDataType string, challenge string) []byte {
+	t.Helper()
+
+	data, err := json.Marshal(webauthn.CollectedClientData{Type: clientDataType, Challenge: challenge, Origin: testOrigin})
+	if err != nil {
+		t.Fatalf("failed to marshal client data: %v", err)
+	}
+	return data
+}
+
+// create answers navigator.credentials.create() for the given challenge.
+func (a *authenticator) create(t *testing.T, challenge string) models.PasskeyRegistrationCredential {
+	t.Helper()
+
+	authData := a.authenticatorData(webauthn.FlagUserPresent|webauthn.FlagUserVerified|webauthn.FlagAttestedCredentialData, true)
+	attestationObject := encodeCBOR(cborMap{
+		{"fmt", "none"},
+		{"attStmt", cborMap{}},
+		{"authData", authData},
+	})
+	id := webauthn.Base64URL.EncodeToString(a.credentialID)
+	return models.PasskeyRegistrationCredential{
+		ID:    id,
+		RawID: id,
+		Type:  "public-key",
+		Response: models.PasskeyAttestationResponse{
+			ClientDataJSON:    webauthn.Base64URL.EncodeToString(clientData(t, webauthn.ClientDataTypeCreate, challenge)),
+			AttestationObject: webauthn.Base64URL.EncodeToString(attestationObject),
+		},
+	}
+}
+
+// get answers navigator.credentials.get() for the given challenge with the current sign count.
+func (a *authenticator) get(t *testing.T, challenge string) models.PasskeyAuthenticationCredential {
+	t.Helper()
+
+	authData := a.authenticatorData(webauthn.FlagUserPresent|webauthn.FlagUserVerified, false)
+	clientDataJSON := clientData(t, webauthn.ClientDataTypeGet, challenge)
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	digest := sha256.Sum256(append(authData, clientDataHash[:]...))
+	signature, err := ecdsa.SignASN1(rand.Reader, a.privateKey, digest[:])
+	if err != nil {
+		t.Fatalf("failed to sign assertion: %v", err)
+	}
+
+	id := webauthn.Base64URL.EncodeToString(a.credentialID)
+	userHandle := webauthn.Base64URL.EncodeToString([]byte(a.userID))
+	return models.PasskeyAuthenticationCredential{
+		ID:    id,
+		RawID: id,
+		Type:  "public-key",
+		Response: models.PasskeyAssertionResponse{
+			ClientDataJSON:    webauthn.Base64URL.EncodeToString(clientDataJSON),
+			AuthenticatorData: webauthn.Base64URL.EncodeToString(authData),
+			Signature:         webauthn.Base64URL.EncodeToString(signature),
+			UserHandle:        &userHandle,
+		},
+	}
+}
+
+// register runs the registration ceremony for the user on the authenticator.
+func (env *testEnv) register(t *testing.T, user *models.User, device *authenticator) *models.Passkey {
+	t.Helper()
+
+	ctx := context.Background()
+	options, err := env.useCase.BeginPasskeyRegistration(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("BeginPasskeyRegistration failed: %v", err)
+	}
+	device.userID = user.ID
+	passkey, err := env.useCase.FinishPasskeyRegistration(ctx, user.ID, "Laptop", device.create(t, options.Challenge))
+	if err != nil {
+		t.Fatalf("FinishPasskeyRegistration failed: %v", err)
+	}
+	return passkey
+}
+
+func (env *testEnv) beginAuthentication(t *testing.T) string {
+	t.Helper()
+
+	options, err := env.useCase.BeginPasskeyAuthentication(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("BeginPasskeyAuthentication failed: %v", err)
+	}
+	return options.Challenge
+}
+
+// ---------------------------------
+// Tests
+// ---------------------------------
+
+func TestPasskey_RegisterAndSignIn(t *testing.T) {
+	env := newTestEnv(t, storage.NewMemorySecondaryStorage(models.SecondaryStorageMemoryOptions{}))
+	user := env.createUser(t, "user@example.com")
+	device := newAuthenticator(t)
+
+	passkey := env.register(t, user, device)
+	if passkey.UserID != user.ID || passkey.Name != "Laptop" || passkey.CredentialID != webauthn.Base64URL.EncodeToString(device.credentialID) {
+		t.Fatalf("unexpected passkey: %+v", passkey)
+	}
+
+	device.signCount = 1
+	result, err := env.useCase.FinishPasskeyAuthentication(context.Background(), device.get(t, env.beginAuthentication(t)))
+	if err != nil {
+		t.Fatalf("FinishPasskeyAuthentication failed: %v", err)
+	}
+	if result.User.ID != user.ID || result.Token == "" {
+		t.Fatalf("unexpected sign in result: %+v", result)
+	}
+
+	stored, err := env.passkeyService.GetPasskeyByCredentialID(passkey.CredentialID)
+	if err != nil {
+		t.Fatalf("GetPasskeyByCredentialID failed: %v", err)
+	}
+	if stored.SignCount != 1 || stored.LastUsedAt == nil {
+		t.Fatalf("expected the sign count and last use to be recorded, got %+v", stored)
+	}
+}
+
+func TestPasskey_RegistrationChallengeReplay(t *testing.T) {
+	env := newTestEnv(t, storage.NewMemorySecondaryStorage(models.SecondaryStorageMemoryOptions{}))
+	user := env.createUser(t, "user@example.com")
+	ctx := context.Background()
+
+	options, err := env.useCase.BeginPasskeyRegistration(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("BeginPasskeyRegistration failed: %v", err)
+	}
+	if _, err := env.useCase.FinishPasskeyRegistration(ctx, user.ID, "", newAuthenticator(t).create(t, options.Challenge)); err != nil {
+		t.Fatalf("FinishPasskeyRegistration failed: %v", err)
+	}
+
+	// A second authenticator cannot be registered against the spent challenge
+	_, err = env.useCase.FinishPasskeyRegistration(ctx, user.ID, "", newAuthenticator(t).create(t, options.Challenge))
+	if !errors.Is(err, constants.ErrPasskeyChallengeInvalid) {
+		t.Fatalf("expected ErrPasskeyChallengeInvalid, got %v", err)
+	}
+}
+
+func TestPasskey_AssertionReplay(t *testing.T) {
+	env := newTestEnv(t, storage.NewMemorySecondaryStorage(models.SecondaryStorageMemoryOptions{}))
+	device := newAuthenticator(t)
+	env.register(t, env.createUser(t, "user@example.com"), device)
+
+	device.signCount = 1
+	assertion := device.get(t, env.beginAuthentication(t))
+	if _, err := env.useCase.FinishPasskeyAuthentication(context.Background(), assertion); err != nil {
+		t.Fatalf("FinishPasskeyAuthentication failed: %v", err)
+	}
+
+	_, err := env.useCase.FinishPasskeyAuthentication(context.Background(), assertion)
+	if !errors.Is(err, constants.ErrPasskeyChallengeInvalid) {
+		t.Fatalf("expected ErrPasskeyChallengeInvalid, got %v", err)
+	}
+}
+
+func TestPasskey_AssertionReplayedConcurrently(t *testing.T) {
+	env := newTestEnv(t, slowReadStorage{storage.NewMemorySecondaryStorage(models.SecondaryStorageMemoryOptions{})})
+	device := newAuthenticator(t)
+	env.register(t, env.createUser(t, "user@example.com"), device)
+
+	// Synced passkeys always report a zero counter, leaving the challenge as the only replay guard
+	assertion := device.get(t, env.beginAuthentication(t))
+
+	var wg sync.WaitGroup
+	var succeeded atomic.Int32
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := env.useCase.FinishPasskeyAuthentication(context.Background(), assertion); err == nil {
+				succeeded.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := succeeded.Load(); got != 1 {
+		t.Fatalf("expected the challenge to be redeemed once, got %d", got)
+	}
+}
+
+func TestPasskey_SignCountRegression(t *testing.T) {
+	env := newTestEnv(t, storage.NewMemorySecondaryStorage(models.SecondaryStorageMemoryOptions{}))
+	device := newAuthenticator(t)
+	device.signCount = 5
+	passkey := env.register(t, env.createUser(t, "user@example.com"), device)
+
+	// A cloned authenticator reports a counter that does not move forward
+	for _, signCount := range []uint32{5, 4} {
+		device.signCount = signCount
+		_, err := env.useCase.FinishPasskeyAuthentication(context.Background(), device.get(t, env.beginAuthentication(t)))
+		if !errors.Is(err, constants.ErrPasskeyVerificationFailed) {
+			t.Fatalf("expected ErrPasskeyVerificationFailed for sign count %d, got %v", signCount, err)
+		}
+	}
+
+	stored, err := env.passkeyService.GetPasskeyByCredentialID(passkey.CredentialID)
+	if err != nil {
+		t.Fatalf("GetPasskeyByCredentialID failed: %v", err)
+	}
+	if stored.SignCount != 5 || stored.LastUsedAt != nil {
+		t.Fatalf("expected the passkey to be left untouched, got %+v", stored)
+	}
+}
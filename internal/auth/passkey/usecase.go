@@ -0,0 +1,22 @@
+package passkey
+
+import (
+	"context"
+
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+type PasskeyUseCase interface {
+	// BeginPasskeyRegistration creates the options for registering a new passkey for the user
+	BeginPasskeyRegistration(ctx context.Context, userID string) (*models.PasskeyRegistrationOptions, error)
+
+	// FinishPasskeyRegistration verifies the authenticator response and stores the passkey
+	FinishPasskeyRegistration(ctx context.Context, userID string, name string, credential models.PasskeyRegistrationCredential) (*models.Passkey, error)
+
+	// BeginPasskeyAuthentication creates the options for signing in with a passkey.
+	// When an email is given the allowed credentials are restricted to that user's passkeys.
+	BeginPasskeyAuthentication(ctx context.Context, email *string) (*models.PasskeyAuthenticationOptions, error)
+
+	// FinishPasskeyAuthentication verifies the authenticator assertion and creates a session
+	FinishPasskeyAuthentication(ctx context.Context, credential models.PasskeyAuthenticationCredential) (*models.SignInResult, error)
+}
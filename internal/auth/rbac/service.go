@@ -0,0 +1,281 @@
+package rbac
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/GoBetterAuth/go-better-auth/internal/constants"
+	"github.com/GoBetterAuth/go-better-auth/internal/util"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+type service struct {
+	config      *models.Config
+	logger      models.Logger
+	userService models.UserService
+	rbacService models.RBACService
+}
+
+func New(
+	config *models.Config,
+	logger models.Logger,
+	userService models.UserService,
+	rbacService models.RBACService,
+) *service {
+	return &service{
+		config:      config,
+		logger:      logger,
+		userService: userService,
+		rbacService: rbacService,
+	}
+}
+
+func (s *service) ListRoles(ctx context.Context) ([]models.Role, error) {
+	roles, err := s.rbacService.ListRoles()
+	if err != nil {
+		s.logger.Error("failed to list roles", "error", err)
+		return nil, fmt.Errorf("failed to list roles: %w", err)
+	}
+	return roles, nil
+}
+
+func (s *service) GetRole(ctx context.Context, roleID string) (*models.RoleWithPermissions, error) {
+	role, err := s.getRole(roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions, err := s.rbacService.ListRolePermissions(role.ID)
+	if err != nil {
+		s.logger.Error("failed to list role permissions", "role_id", roleID, "error", err)
+		return nil, fmt.Errorf("failed to list role permissions: %w", err)
+	}
+
+	return &models.RoleWithPermissions{Role: *role, Permissions: permissions}, nil
+}
+
+func (s *service) CreateRole(ctx context.Context, request models.CreateRoleRequest) (*models.Role, error) {
+	name := strings.TrimSpace(request.Name)
+	if err := s.checkRoleNameAvailable(name); err != nil {
+		return nil, err
+	}
+
+	role := &models.Role{Name: name, Description: request.Description}
+	if err := s.rbacService.CreateRole(role); err != nil {
+		s.logger.Error("failed to create role", "name", name, "error", err)
+		return nil, fmt.Errorf("failed to create role: %w", err)
+	}
+
+	return role, nil
+}
+
+func (s *service) UpdateRole(ctx context.Context, roleID string, request models.UpdateRoleRequest) (*models.Role, error) {
+	role, err := s.getRole(roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Name != nil {
+		name := strings.TrimSpace(*request.Name)
+		if name != role.Name {
+			if err := s.checkRoleNameAvailable(name); err != nil {
+				return nil, err
+			}
+			role.Name = name
+		}
+	}
+	if request.Description != nil {
+		role.Description = request.Description
+		if *request.Description == "" {
+			role.Description = nil
+		}
+	}
+
+	if err := s.rbacService.UpdateRole(role); err != nil {
+		s.logger.Error("failed to update role", "role_id", roleID, "error", err)
+		return nil, fmt.Errorf("failed to update role: %w", err)
+	}
+
+	return role, nil
+}
+
+func (s *service) DeleteRole(ctx context.Context, roleID string) error {
+	if _, err := s.getRole(roleID); err != nil {
+		return err
+	}
+
+	if err := s.rbacService.DeleteRole(roleID); err != nil {
+		s.logger.Error("failed to delete role", "role_id", roleID, "error", err)
+		return fmt.Errorf("failed to delete role: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) ListPermissions(ctx context.Context) ([]models.Permission, error) {
+	permissions, err := s.rbacService.ListPermissions()
+	if err != nil {
+		s.logger.Error("failed to list permissions", "error", err)
+		return nil, fmt.Errorf("failed to list permissions: %w", err)
+	}
+	return permissions, nil
+}
+
+func (s *service) CreatePermission(ctx context.Context, request models.CreatePermissionRequest) (*models.Permission, error) {
+	name := strings.TrimSpace(request.Name)
+	if !util.IsValidPermission(name) {
+		return nil, constants.ErrPermissionInvalid
+	}
+
+	existing, err := s.rbacService.GetPermissionByName(name)
+	if err != nil {
+		s.logger.Error("failed to check existing permission", "name", name, "error", err)
+		return nil, fmt.Errorf("failed to check existing permission: %w", err)
+	}
+	if existing != nil {
+		return nil, constants.ErrPermissionAlreadyExists
+	}
+
+	permission := &models.Permission{Name: name, Description: request.Description}
+	if err := s.rbacService.CreatePermission(permission); err != nil {
+		s.logger.Error("failed to create permission", "name", name, "error", err)
+		return nil, fmt.Errorf("failed to create permission: %w", err)
+	}
+
+	return permission, nil
+}
+
+func (s *service) DeletePermission(ctx context.Context, permissionID string) error {
+	if _, err := s.getPermission(permissionID); err != nil {
+		return err
+	}
+
+	if err := s.rbacService.DeletePermission(permissionID); err != nil {
+		s.logger.Error("failed to delete permission", "permission_id", permissionID, "error", err)
+		return fmt.Errorf("failed to delete permission: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) AddRolePermission(ctx context.Context, roleID string, permissionID string) error {
+	if _, err := s.getRole(roleID); err != nil {
+		return err
+	}
+	if _, err := s.getPermission(permissionID); err != nil {
+		return err
+	}
+
+	if err := s.rbacService.AddPermissionToRole(roleID, permissionID); err != nil {
+		s.logger.Error("failed to grant permission", "role_id", roleID, "permission_id", permissionID, "error", err)
+		return fmt.Errorf("failed to grant permission: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) RemoveRolePermission(ctx context.Context, roleID string, permissionID string) error {
+	if err := s.rbacService.RemovePermissionFromRole(roleID, permissionID); err != nil {
+		s.logger.Error("failed to revoke permission", "role_id", roleID, "permission_id", permissionID, "error", err)
+		return fmt.Errorf("failed to revoke permission: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) ListUserRoles(ctx context.Context, userID string) ([]models.Role, error) {
+	if err := s.checkUserExists(userID); err != nil {
+		return nil, err
+	}
+
+	roles, err := s.rbacService.ListUserRoles(userID)
+	if err != nil {
+		s.logger.Error("failed to list user roles", "user_id", userID, "error", err)
+		return nil, fmt.Errorf("failed to list user roles: %w", err)
+	}
+
+	return roles, nil
+}
+
+func (s *service) AssignUserRole(ctx context.Context, userID string, roleID string) error {
+	if err := s.checkUserExists(userID); err != nil {
+		return err
+	}
+	if _, err := s.getRole(roleID); err != nil {
+		return err
+	}
+
+	if err := s.rbacService.AssignRoleToUser(userID, roleID); err != nil {
+		s.logger.Error("failed to assign role", "user_id", userID, "role_id", roleID, "error", err)
+		return fmt.Errorf("failed to assign role: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) RemoveUserRole(ctx context.Context, userID string, roleID string) error {
+	if err := s.rbacService.RemoveRoleFromUser(userID, roleID); err != nil {
+		s.logger.Error("failed to remove role", "user_id", userID, "role_id", roleID, "error", err)
+		return fmt.Errorf("failed to remove role: %w", err)
+	}
+
+	return nil
+}
+
+func (s *service) HasPermission(ctx context.Context, userID string, permission string) (bool, error) {
+	allowed, err := s.rbacService.HasPermission(userID, permission)
+	if err != nil {
+		s.logger.Error("failed to check permission", "user_id", userID, "permission", permission, "error", err)
+		return false, fmt.Errorf("failed to check permission: %w", err)
+	}
+	return allowed, nil
+}
+
+func (s *service) getRole(roleID string) (*models.Role, error) {
+	role, err := s.rbacService.GetRoleByID(roleID)
+	if err != nil {
+		s.logger.Error("failed to get role", "role_id", roleID, "error", err)
+		return nil, fmt.Errorf("failed to get role: %w", err)
+	}
+	if role == nil {
+		return nil, constants.ErrRoleNotFound
+	}
+	return role, nil
+}
+
+func (s *service) getPermission(permissionID string) (*models.Permission, error) {
+	permission, err := s.rbacService.GetPermissionByID(permissionID)
+	if err != nil {
+		s.logger.Error("failed to get permission", "permission_id", permissionID, "error", err)
+		return nil, fmt.Errorf("failed to get permission: %w", err)
+	}
+	if permission == nil {
+		return nil, constants.ErrPermissionNotFound
+	}
+	return permission, nil
+}
+
+func (s *service) checkRoleNameAvailable(name string) error {
+	existing, err := s.rbacService.GetRoleByName(name)
+	if err != nil {
+		s.logger.Error("failed to check existing role", "name", name, "error", err)
+		return fmt.Errorf("failed to check existing role: %w", err)
+	}
+	if existing != nil {
+		return constants.ErrRoleAlreadyExists
+	}
+	return nil
+}
+
+func (s *service) checkUserExists(userID string) error {
+	user, err := s.userService.GetUserByID(userID)
+	if err != nil {
+		s.logger.Error("failed to get user", "user_id", userID, "error", err)
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+	if user == nil {
+		return constants.ErrUserNotFound
+	}
+	return nil
+}
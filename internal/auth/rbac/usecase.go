@@ -0,0 +1,38 @@
+package rbac
+
+import (
+	"context"
+
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+type RBACUseCase interface {
+	// ListRoles returns every role.
+	ListRoles(ctx context.Context) ([]models.Role, error)
+	// GetRole returns a role along with the permissions it grants.
+	GetRole(ctx context.Context, roleID string) (*models.RoleWithPermissions, error)
+	// CreateRole creates a role with a unique name.
+	CreateRole(ctx context.Context, request models.CreateRoleRequest) (*models.Role, error)
+	// UpdateRole changes the given fields of a role.
+	UpdateRole(ctx context.Context, roleID string, request models.UpdateRoleRequest) (*models.Role, error)
+	// DeleteRole deletes a role, unassigning it from its users.
+	DeleteRole(ctx context.Context, roleID string) error
+	// ListPermissions returns every permission.
+	ListPermissions(ctx context.Context) ([]models.Permission, error)
+	// CreatePermission creates a "resource:action" permission.
+	CreatePermission(ctx context.Context, request models.CreatePermissionRequest) (*models.Permission, error)
+	// DeletePermission deletes a permission, revoking it from every role.
+	DeletePermission(ctx context.Context, permissionID string) error
+	// AddRolePermission grants a permission to a role.
+	AddRolePermission(ctx context.Context, roleID string, permissionID string) error
+	// RemoveRolePermission revokes a permission from a role.
+	RemoveRolePermission(ctx context.Context, roleID string, permissionID string) error
+	// ListUserRoles returns the roles assigned to a user.
+	ListUserRoles(ctx context.Context, userID string) ([]models.Role, error)
+	// AssignUserRole assigns a role to a user.
+	AssignUserRole(ctx context.Context, userID string, roleID string) error
+	// RemoveUserRole unassigns a role from a user.
+	RemoveUserRole(ctx context.Context, userID string, roleID string) error
+	// HasPermission reports whether the roles of a user grant the permission.
+	HasPermission(ctx context.Context, userID string, permission string) (bool, error)
+}
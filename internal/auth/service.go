@@ -1,6 +1,8 @@
 package auth
 
 import (
+	"io"
+
 	"github.com/GoBetterAuth/go-better-auth/models"
 	oauth2providers "github.com/GoBetterAuth/go-better-auth/oauth2-providers"
 )
@@ -14,11 +16,18 @@ type Service struct {
 	UserService            models.UserService
 	AccountService         models.AccountService
 	SessionService         models.SessionService
+	TwoFactorService       models.TwoFactorService
+	PasskeyService         models.PasskeyService
+	EmailOTPService        models.EmailOTPService
 	VerificationService    models.VerificationService
 	PasswordService        models.PasswordService
 	TokenService           models.TokenService
+	SigningKeyService      models.SigningKeyService
+	OIDCProviderService    models.OIDCProviderService
 	RateLimitService       models.RateLimitService
 	MailerService          models.MailerService
+	RBACService            models.RBACService
+	OrganizationService    models.OrganizationService
 	OAuth2ProviderRegistry *oauth2providers.OAuth2ProviderRegistry
 }
 
@@ -31,11 +40,18 @@ func NewService(
 	userService models.UserService,
 	accountService models.AccountService,
 	sessionService models.SessionService,
+	twoFactorService models.TwoFactorService,
+	passkeyService models.PasskeyService,
+	emailOTPService models.EmailOTPService,
 	verificationService models.VerificationService,
 	passwordService models.PasswordService,
 	tokenService models.TokenService,
+	signingKeyService models.SigningKeyService,
+	oidcProviderService models.OIDCProviderService,
 	rateLimitService models.RateLimitService,
 	mailerService models.MailerService,
+	rbacService models.RBACService,
+	organizationService models.OrganizationService,
 	oauth2ProviderRegistry *oauth2providers.OAuth2ProviderRegistry,
 ) *Service {
 	return &Service{
@@ -46,11 +62,26 @@ func NewService(
 		UserService:            userService,
 		AccountService:         accountService,
 		SessionService:         sessionService,
+		TwoFactorService:       twoFactorService,
+		PasskeyService:         passkeyService,
+		EmailOTPService:        emailOTPService,
 		VerificationService:    verificationService,
 		PasswordService:        passwordService,
 		TokenService:           tokenService,
+		SigningKeyService:      signingKeyService,
+		OIDCProviderService:    oidcProviderService,
 		RateLimitService:       rateLimitService,
 		MailerService:          mailerService,
+		RBACService:            rbacService,
+		OrganizationService:    organizationService,
 		OAuth2ProviderRegistry: oauth2ProviderRegistry,
 	}
 }
+
+// Close stops the background jobs of the services, such as the periodic purge of expired sessions.
+func (s *Service) Close() error {
+	if closer, ok := s.SessionService.(io.Closer); ok {
+		return closer.Close()
+	}
+	return nil
+}
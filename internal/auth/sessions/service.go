@@ -0,0 +1,80 @@
+package sessions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/GoBetterAuth/go-better-auth/internal/constants"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+type service struct {
+	config         *models.Config
+	logger         models.Logger
+	sessionService models.SessionService
+}
+
+func New(
+	config *models.Config,
+	logger models.Logger,
+	sessionService models.SessionService,
+) *service {
+	return &service{
+		config:         config,
+		logger:         logger,
+		sessionService: sessionService,
+	}
+}
+
+func (s *service) ListSessions(ctx context.Context, userID string, currentSessionID string) ([]models.ActiveSession, error) {
+	sessions, err := s.sessionService.ListSessionsByUserID(userID)
+	if err != nil {
+		s.logger.Error("failed to list sessions", "user_id", userID, "error", err)
+		return nil, fmt.Errorf("failed to list sessions: %w", err)
+	}
+
+	result := make([]models.ActiveSession, 0, len(sessions))
+	for _, sess := range sessions {
+		result = append(result, models.ActiveSession{
+			ID:        sess.ID,
+			IPAddress: sess.IPAddress,
+			UserAgent: sess.UserAgent,
+			ExpiresAt: sess.ExpiresAt,
+			CreatedAt: sess.CreatedAt,
+			UpdatedAt: sess.UpdatedAt,
+			Current:   sess.ID == currentSessionID,
+		})
+	}
+
+	return result, nil
+}
+
+func (s *service) RevokeSession(ctx context.Context, userID string, sessionID string) error {
+	if sessionID == "" {
+		return constants.ErrSessionNotFound
+	}
+
+	if err := s.sessionService.RevokeSession(userID, sessionID); err != nil {
+		if errors.Is(err, constants.ErrSessionNotFound) {
+			return err
+		}
+		s.logger.Error("failed to revoke session", "user_id", userID, "session_id", sessionID, "error", err)
+		return fmt.Errorf("%w: %w", constants.ErrSessionDeletionFailed, err)
+	}
+
+	return nil
+}
+
+func (s *service) RevokeOtherSessions(ctx context.Context, userID string, currentSessionID string) error {
+	if currentSessionID == "" {
+		return constants.ErrSessionNotFound
+	}
+
+	if err := s.sessionService.RevokeOtherSessions(userID, currentSessionID); err != nil {
+		s.logger.Error("failed to revoke other sessions", "user_id", userID, "error", err)
+		return fmt.Errorf("%w: %w", constants.ErrSessionDeletionFailed, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package sessions
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/GoBetterAuth/go-better-auth/config"
+	"github.com/GoBetterAuth/go-better-auth/internal/constants"
+	"github.com/GoBetterAuth/go-better-auth/internal/services"
+	"github.com/GoBetterAuth/go-better-auth/internal/testutil"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+func TestSessions_ListAndRevoke(t *testing.T) {
+	cfg := config.NewConfig()
+	sessionService := services.NewSessionServiceImpl(cfg, testutil.NewDB(t, &models.Session{}))
+	useCase := New(cfg, testutil.NewLogger(), sessionService)
+	ctx := context.Background()
+
+	current, err := sessionService.CreateSession("user-1", "current")
+	if err != nil {
+		t.Fatalf("CreateSession failed: %v", err)
+	}
+	other, err := sessionService.CreateSession("user-1", "other")
+	if err != nil {
+		t.Fatalf("CreateSession failed: %v", err)
+	}
+
+	sessions, err := useCase.ListSessions(ctx, "user-1", current.ID)
+	if err != nil {
+		t.Fatalf("ListSessions failed: %v", err)
+	}
+	if len(sessions) != 2 {
+		t.Fatalf("expected 2 sessions, got %d", len(sessions))
+	}
+	for _, session := range sessions {
+		if session.Current != (session.ID == current.ID) {
+			t.Fatalf("expected only the current session to be flagged, got %+v", session)
+		}
+	}
+
+	if err := useCase.RevokeSession(ctx, "user-2", other.ID); !errors.Is(err, constants.ErrSessionNotFound) {
+		t.Fatalf("expected another user's session to be not found, got %v", err)
+	}
+	if err := useCase.RevokeOtherSessions(ctx, "user-1", ""); !errors.Is(err, constants.ErrSessionNotFound) {
+		t.Fatalf("expected revoking without a current session to be refused, got %v", err)
+	}
+
+	if err := useCase.RevokeOtherSessions(ctx, "user-1", current.ID); err != nil {
+		t.Fatalf("RevokeOtherSessions failed: %v", err)
+	}
+	sessions, _ = useCase.ListSessions(ctx, "user-1", current.ID)
+	if len(sessions) != 1 || !sessions[0].Current {
+		t.Fatalf("expected only the current session to be kept, got %+v", sessions)
+	}
+}
@@ -0,0 +1,16 @@
+package sessions
+
+import (
+	"context"
+
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+type SessionsUseCase interface {
+	// ListSessions returns the active sessions of the user. currentSessionID marks the caller's own session and may be empty.
+	ListSessions(ctx context.Context, userID string, currentSessionID string) ([]models.ActiveSession, error)
+	// RevokeSession signs a single session of the user out.
+	RevokeSession(ctx context.Context, userID string, sessionID string) error
+	// RevokeOtherSessions signs the user out everywhere except the current session.
+	RevokeOtherSessions(ctx context.Context, userID string, currentSessionID string) error
+}
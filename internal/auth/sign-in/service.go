@@ -20,6 +20,7 @@ type service struct {
 	verificationService models.VerificationService
 	mailerService       models.MailerService
 	passwordService     models.PasswordService
+	twoFactorService    models.TwoFactorService
 	eventEmitter        models.EventEmitter
 }
 
@@ -33,6 +34,7 @@ func New(
 	verificationService models.VerificationService,
 	mailerService models.MailerService,
 	passwordService models.PasswordService,
+	twoFactorService models.TwoFactorService,
 	eventEmitter models.EventEmitter,
 ) *service {
 	return &service{
@@ -45,6 +47,7 @@ func New(
 		verificationService: verificationService,
 		mailerService:       mailerService,
 		passwordService:     passwordService,
+		twoFactorService:    twoFactorService,
 		eventEmitter:        eventEmitter,
 	}
 }
@@ -59,9 +62,9 @@ func (s *service) SignInWithEmailAndPassword(ctx context.Context, email, passwor
 		return nil, constants.ErrInvalidCredentials
 	}
 
-	acc, err := s.accountService.GetAccountByUserID(user.ID)
+	acc, err := s.accountService.GetCredentialAccount(user.ID)
 	if err != nil {
-		s.logger.Error("failed to get account", "user_id", user.ID, "error", err)
+		s.logger.Error("failed to get credential account", "user_id", user.ID, "error", err)
 		return nil, fmt.Errorf("%w: %w", constants.ErrAccountNotFound, err)
 	}
 	if acc == nil {
@@ -77,13 +80,27 @@ func (s *service) SignInWithEmailAndPassword(ctx context.Context, email, passwor
 		return nil, constants.ErrInvalidCredentials
 	}
 
-	existingSession, err := s.sessionService.GetSessionByUserID(user.ID)
+	if user.IsBanned() {
+		return nil, constants.ErrUserBanned
+	}
+
+	// Users with two factor enabled get a challenge instead of a session
+	twoFactorRequired, err := s.twoFactorService.IsEnabledForUser(user.ID)
 	if err != nil {
-		s.logger.Error("failed to get existing session", "user_id", user.ID, "error", err)
-	} else if existingSession != nil {
-		if err := s.sessionService.DeleteSessionByID(existingSession.ID); err != nil {
-			s.logger.Warn("failed to delete existing session", "session_id", existingSession.ID, "error", err)
+		s.logger.Error("failed to check two factor", "user_id", user.ID, "error", err)
+		return nil, err
+	}
+	if twoFactorRequired {
+		challengeToken, err := s.twoFactorService.CreateChallenge(ctx, user.ID)
+		if err != nil {
+			s.logger.Error("failed to create two factor challenge", "user_id", user.ID, "error", err)
+			return nil, fmt.Errorf("%w: %w", constants.ErrTokenGenerationFailed, err)
 		}
+		return &models.SignInResult{
+			User:              user,
+			TwoFactorRequired: true,
+			ChallengeToken:    &challengeToken,
+		}, nil
 	}
 
 	token, err := s.tokenService.GenerateToken()
@@ -0,0 +1,66 @@
+package signin
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/GoBetterAuth/go-better-auth/config"
+	"github.com/GoBetterAuth/go-better-auth/internal/constants"
+	"github.com/GoBetterAuth/go-better-auth/internal/events"
+	"github.com/GoBetterAuth/go-better-auth/internal/services"
+	"github.com/GoBetterAuth/go-better-auth/internal/testutil"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+func TestSignInWithEmailAndPassword_UserWithOAuth2Account(t *testing.T) {
+	db := testutil.NewDB(t, &models.User{}, &models.Account{}, &models.Session{}, &models.TwoFactor{})
+	cfg := config.NewConfig(config.WithLogger(models.LoggerConfig{Logger: testutil.NewLogger()}))
+	userService := services.NewUserServiceImpl(cfg, db)
+	accountService := services.NewAccountServiceImpl(cfg, db)
+	passwordService := services.NewArgon2PasswordService()
+	useCase := New(
+		cfg,
+		cfg.Logger.Logger,
+		userService,
+		accountService,
+		services.NewSessionServiceImpl(cfg, db),
+		services.NewTokenServiceImpl(cfg, nil),
+		nil,
+		nil,
+		passwordService,
+		services.NewTwoFactorServiceImpl(cfg, db),
+		events.NewEventEmitter(cfg, cfg.Logger.Logger, nil, nil),
+	)
+
+	user := &models.User{Email: "user@example.com", EmailVerified: true}
+	if err := userService.CreateUser(user); err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+	hashedPassword, err := passwordService.HashPassword("password123")
+	if err != nil {
+		t.Fatalf("HashPassword failed: %v", err)
+	}
+	// The password is set after signing up with GitHub, so the GitHub account comes first
+	for _, account := range []*models.Account{
+		{UserID: user.ID, AccountID: "github-1", ProviderID: models.ProviderGitHub},
+		{UserID: user.ID, AccountID: user.ID, ProviderID: models.ProviderEmail, Password: &hashedPassword},
+	} {
+		if err := accountService.CreateAccount(account); err != nil {
+			t.Fatalf("CreateAccount failed: %v", err)
+		}
+	}
+
+	result, err := useCase.SignInWithEmailAndPassword(context.Background(), user.Email, "password123", nil)
+	if err != nil {
+		t.Fatalf("SignInWithEmailAndPassword failed: %v", err)
+	}
+	if result.User.ID != user.ID || result.Token == "" {
+		t.Fatalf("unexpected sign in result: %+v", result)
+	}
+
+	_, err = useCase.SignInWithEmailAndPassword(context.Background(), user.Email, "wrong-password", nil)
+	if !errors.Is(err, constants.ErrInvalidCredentials) {
+		t.Fatalf("expected ErrInvalidCredentials, got %v", err)
+	}
+}
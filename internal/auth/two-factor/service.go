@@ -0,0 +1,326 @@
+package twofactor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/GoBetterAuth/go-better-auth/internal/constants"
+	"github.com/GoBetterAuth/go-better-auth/internal/util"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+type service struct {
+	config           *models.Config
+	logger           models.Logger
+	userService      models.UserService
+	sessionService   models.SessionService
+	tokenService     models.TokenService
+	twoFactorService models.TwoFactorService
+	eventEmitter     models.EventEmitter
+}
+
+func New(
+	config *models.Config,
+	logger models.Logger,
+	userService models.UserService,
+	sessionService models.SessionService,
+	tokenService models.TokenService,
+	twoFactorService models.TwoFactorService,
+	eventEmitter models.EventEmitter,
+) *service {
+	return &service{
+		config:           config,
+		logger:           logger,
+		userService:      userService,
+		sessionService:   sessionService,
+		tokenService:     tokenService,
+		twoFactorService: twoFactorService,
+		eventEmitter:     eventEmitter,
+	}
+}
+
+func (s *service) EnrollTwoFactor(ctx context.Context, userID string) (*models.TwoFactorEnrollResult, error) {
+	if !s.config.TwoFactor.Enabled {
+		return nil, constants.ErrTwoFactorDisabled
+	}
+
+	user, err := s.userService.GetUserByID(userID)
+	if err != nil {
+		s.logger.Error("failed to get user", "user_id", userID, "error", err)
+		return nil, fmt.Errorf("%w: %w", constants.ErrUserNotFound, err)
+	}
+	if user == nil {
+		return nil, constants.ErrUserNotFound
+	}
+
+	existing, err := s.twoFactorService.GetTwoFactorByUserID(user.ID)
+	if err != nil {
+		s.logger.Error("failed to get two factor", "user_id", user.ID, "error", err)
+		return nil, err
+	}
+	if existing != nil && existing.Enabled {
+		return nil, constants.ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := util.GenerateTOTPSecret()
+	if err != nil {
+		s.logger.Error("failed to generate totp secret", "error", err)
+		return nil, fmt.Errorf("%w: %w", constants.ErrTokenGenerationFailed, err)
+	}
+
+	encryptedSecret, err := s.tokenService.EncryptToken(secret)
+	if err != nil {
+		s.logger.Error("failed to encrypt totp secret", "error", err)
+		return nil, err
+	}
+
+	// Re-enrolling before verification replaces the pending secret
+	if existing != nil {
+		existing.Secret = encryptedSecret
+		existing.LastUsedStep = 0
+		if err := s.twoFactorService.UpdateTwoFactor(existing); err != nil {
+			s.logger.Error("failed to update two factor", "user_id", user.ID, "error", err)
+			return nil, err
+		}
+	} else {
+		twoFactor := &models.TwoFactor{
+			UserID:  user.ID,
+			Secret:  encryptedSecret,
+			Enabled: false,
+		}
+		if err := s.twoFactorService.CreateTwoFactor(twoFactor); err != nil {
+			s.logger.Error("failed to create two factor", "user_id", user.ID, "error", err)
+			return nil, err
+		}
+	}
+
+	backupCodes, err := s.generateBackupCodes(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.TwoFactorEnrollResult{
+		Secret: secret,
+		URI: util.BuildTOTPURI(
+			s.config.TwoFactor.Issuer,
+			user.Email,
+			secret,
+			s.config.TwoFactor.Digits,
+			s.config.TwoFactor.Period,
+		),
+		BackupCodes: backupCodes,
+	}, nil
+}
+
+func (s *service) EnableTwoFactor(ctx context.Context, userID string, code string) error {
+	if !s.config.TwoFactor.Enabled {
+		return constants.ErrTwoFactorDisabled
+	}
+
+	twoFactor, err := s.twoFactorService.GetTwoFactorByUserID(userID)
+	if err != nil {
+		s.logger.Error("failed to get two factor", "user_id", userID, "error", err)
+		return err
+	}
+	if twoFactor == nil {
+		return constants.ErrTwoFactorNotEnrolled
+	}
+	if twoFactor.Enabled {
+		return constants.ErrTwoFactorAlreadyEnabled
+	}
+
+	if err := s.verifyCode(twoFactor, code); err != nil {
+		return err
+	}
+
+	twoFactor.Enabled = true
+	if err := s.twoFactorService.UpdateTwoFactor(twoFactor); err != nil {
+		s.logger.Error("failed to enable two factor", "user_id", userID, "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *service) DisableTwoFactor(ctx context.Context, userID string, code string) error {
+	twoFactor, err := s.twoFactorService.GetTwoFactorByUserID(userID)
+	if err != nil {
+		s.logger.Error("failed to get two factor", "user_id", userID, "error", err)
+		return err
+	}
+	if twoFactor == nil {
+		return constants.ErrTwoFactorNotEnrolled
+	}
+
+	if err := s.verifyCode(twoFactor, code); err != nil {
+		return err
+	}
+
+	if err := s.twoFactorService.DeleteTwoFactorByUserID(userID); err != nil {
+		s.logger.Error("failed to delete two factor", "user_id", userID, "error", err)
+		return err
+	}
+
+	return nil
+}
+
+func (s *service) VerifyTwoFactor(ctx context.Context, challengeToken string, code string) (*models.SignInResult, error) {
+	userID, err := s.twoFactorService.VerifyChallenge(ctx, challengeToken)
+	if err != nil {
+		return nil, err
+	}
+
+	twoFactor, err := s.twoFactorService.GetTwoFactorByUserID(userID)
+	if err != nil {
+		s.logger.Error("failed to get two factor", "user_id", userID, "error", err)
+		return nil, err
+	}
+	if twoFactor == nil || !twoFactor.Enabled {
+		return nil, constants.ErrTwoFactorNotEnrolled
+	}
+
+	// A backup code can be used in place of a TOTP code
+	usedBackupCode := false
+	if err := s.verifyCode(twoFactor, code); err != nil {
+		if !errors.Is(err, constants.ErrInvalidTwoFactorCode) {
+			return nil, err
+		}
+		used, err := s.twoFactorService.UseBackupCode(userID, s.hashBackupCode(code))
+		if err != nil {
+			s.logger.Error("failed to use backup code", "user_id", userID, "error", err)
+			return nil, err
+		}
+		if !used {
+			return nil, constants.ErrInvalidTwoFactorCode
+		}
+		usedBackupCode = true
+	}
+
+	if err := s.twoFactorService.DeleteChallenge(ctx, challengeToken); err != nil {
+		s.logger.Warn("failed to delete two factor challenge", "user_id", userID, "error", err)
+	}
+
+	user, err := s.userService.GetUserByID(userID)
+	if err != nil {
+		s.logger.Error("failed to get user", "user_id", userID, "error", err)
+		return nil, fmt.Errorf("%w: %w", constants.ErrUserNotFound, err)
+	}
+	if user == nil {
+		return nil, constants.ErrUserNotFound
+	}
+	// The user may have been banned after the challenge was issued
+	if user.IsBanned() {
+		return nil, constants.ErrUserBanned
+	}
+
+	if usedBackupCode {
+		s.eventEmitter.OnBackupCodeUsed(*user)
+	}
+
+	token, err := s.tokenService.GenerateToken()
+	if err != nil {
+		s.logger.Error("failed to generate token", "error", err)
+		return nil, fmt.Errorf("%w: %w", constants.ErrTokenGenerationFailed, err)
+	}
+
+	_, err = s.sessionService.CreateSession(user.ID, s.tokenService.HashToken(token))
+	if err != nil {
+		s.logger.Error("failed to create session", "user_id", user.ID, "error", err)
+		return nil, fmt.Errorf("%w: %w", constants.ErrSessionCreationFailed, err)
+	}
+
+	s.eventEmitter.OnUserLoggedIn(*user)
+
+	var csrfToken *string = nil
+	if s.config.CSRF.Enabled {
+		csrfTokenGenerated, err := s.tokenService.GenerateToken()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", constants.ErrTokenGenerationFailed, err)
+		}
+		csrfToken = &csrfTokenGenerated
+	}
+
+	return &models.SignInResult{
+		User:      user,
+		Token:     token,
+		CSRFToken: csrfToken,
+	}, nil
+}
+
+func (s *service) RegenerateBackupCodes(ctx context.Context, userID string) ([]string, error) {
+	if !s.config.TwoFactor.Enabled {
+		return nil, constants.ErrTwoFactorDisabled
+	}
+
+	twoFactor, err := s.twoFactorService.GetTwoFactorByUserID(userID)
+	if err != nil {
+		s.logger.Error("failed to get two factor", "user_id", userID, "error", err)
+		return nil, err
+	}
+	if twoFactor == nil || !twoFactor.Enabled {
+		return nil, constants.ErrTwoFactorNotEnrolled
+	}
+
+	return s.generateBackupCodes(userID)
+}
+
+// generateBackupCodes replaces the user's backup codes with a new set and returns the raw codes
+func (s *service) generateBackupCodes(userID string) ([]string, error) {
+	codes, err := util.GenerateBackupCodes(s.config.TwoFactor.BackupCodesCount)
+	if err != nil {
+		s.logger.Error("failed to generate backup codes", "error", err)
+		return nil, fmt.Errorf("%w: %w", constants.ErrTokenGenerationFailed, err)
+	}
+
+	hashedCodes := make([]string, 0, len(codes))
+	for _, code := range codes {
+		hashedCodes = append(hashedCodes, s.hashBackupCode(code))
+	}
+
+	if err := s.twoFactorService.ReplaceBackupCodes(userID, hashedCodes); err != nil {
+		s.logger.Error("failed to store backup codes", "user_id", userID, "error", err)
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+func (s *service) hashBackupCode(code string) string {
+	return s.tokenService.HashToken(util.NormalizeBackupCode(code))
+}
+
+// verifyCode decrypts the stored secret and validates a TOTP code against it. Each code is
+// accepted only once, a replayed code is rejected like a wrong one.
+func (s *service) verifyCode(twoFactor *models.TwoFactor, code string) error {
+	secret, err := s.tokenService.DecryptToken(twoFactor.Secret)
+	if err != nil {
+		s.logger.Error("failed to decrypt totp secret", "user_id", twoFactor.UserID, "error", err)
+		return err
+	}
+
+	step, ok := util.ValidateTOTPCode(
+		secret,
+		code,
+		time.Now(),
+		s.config.TwoFactor.Digits,
+		s.config.TwoFactor.Period,
+		s.config.TwoFactor.Skew,
+	)
+	if !ok || step <= twoFactor.LastUsedStep {
+		return constants.ErrInvalidTwoFactorCode
+	}
+
+	used, err := s.twoFactorService.UseTimeStep(twoFactor.UserID, step)
+	if err != nil {
+		s.logger.Error("failed to record totp time step", "user_id", twoFactor.UserID, "error", err)
+		return err
+	}
+	if !used {
+		return constants.ErrInvalidTwoFactorCode
+	}
+	twoFactor.LastUsedStep = step
+
+	return nil
+}
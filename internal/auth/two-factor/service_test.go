@@ -0,0 +1,145 @@
+package twofactor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/GoBetterAuth/go-better-auth/config"
+	"github.com/GoBetterAuth/go-better-auth/internal/constants"
+	"github.com/GoBetterAuth/go-better-auth/internal/events"
+	"github.com/GoBetterAuth/go-better-auth/internal/services"
+	"github.com/GoBetterAuth/go-better-auth/internal/testutil"
+	"github.com/GoBetterAuth/go-better-auth/internal/util"
+	"github.com/GoBetterAuth/go-better-auth/models"
+	"github.com/GoBetterAuth/go-better-auth/storage"
+)
+
+type testEnv struct {
+	config           *models.Config
+	userService      *services.UserServiceImpl
+	twoFactorService *services.TwoFactorServiceImpl
+	useCase          *service
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+
+	db := testutil.NewDB(t, &models.User{}, &models.Session{}, &models.TwoFactor{}, &models.TwoFactorBackupCode{})
+	cfg := config.NewConfig(
+		config.WithSecret("test-secret"),
+		config.WithLogger(models.LoggerConfig{Logger: testutil.NewLogger()}),
+		config.WithSecondaryStorage(models.SecondaryStorageConfig{
+			Storage: storage.NewMemorySecondaryStorage(models.SecondaryStorageMemoryOptions{}),
+		}),
+		config.WithTwoFactor(models.TwoFactorConfig{Enabled: true}),
+	)
+
+	env := &testEnv{
+		config:           cfg,
+		userService:      services.NewUserServiceImpl(cfg, db),
+		twoFactorService: services.NewTwoFactorServiceImpl(cfg, db),
+	}
+	env.useCase = New(
+		cfg,
+		cfg.Logger.Logger,
+		env.userService,
+		services.NewSessionServiceImpl(cfg, db),
+		services.NewTokenServiceImpl(cfg, nil),
+		env.twoFactorService,
+		events.NewEventEmitter(cfg, cfg.Logger.Logger, nil, nil),
+	)
+	return env
+}
+
+// createUserWithTwoFactor creates a user with two factor enabled and returns the user and their backup codes.
+func (env *testEnv) createUserWithTwoFactor(t *testing.T) (*models.User, []string) {
+	t.Helper()
+
+	ctx := context.Background()
+	user := &models.User{Email: "user@example.com", EmailVerified: true}
+	if err := env.userService.CreateUser(user); err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+
+	enrollment, err := env.useCase.EnrollTwoFactor(ctx, user.ID)
+	if err != nil {
+		t.Fatalf("EnrollTwoFactor failed: %v", err)
+	}
+	code, err := util.GenerateTOTPCode(enrollment.Secret, time.Now(), env.config.TwoFactor.Digits, env.config.TwoFactor.Period)
+	if err != nil {
+		t.Fatalf("GenerateTOTPCode failed: %v", err)
+	}
+	if err := env.useCase.EnableTwoFactor(ctx, user.ID, code); err != nil {
+		t.Fatalf("EnableTwoFactor failed: %v", err)
+	}
+	return user, enrollment.BackupCodes
+}
+
+func (env *testEnv) createChallenge(t *testing.T, userID string) string {
+	t.Helper()
+
+	challengeToken, err := env.twoFactorService.CreateChallenge(context.Background(), userID)
+	if err != nil {
+		t.Fatalf("CreateChallenge failed: %v", err)
+	}
+	return challengeToken
+}
+
+func TestVerifyTwoFactor_BackupCodeIsSingleUse(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	user, backupCodes := env.createUserWithTwoFactor(t)
+
+	result, err := env.useCase.VerifyTwoFactor(ctx, env.createChallenge(t, user.ID), backupCodes[0])
+	if err != nil {
+		t.Fatalf("VerifyTwoFactor failed: %v", err)
+	}
+	if result.User.ID != user.ID || result.Token == "" {
+		t.Fatalf("unexpected sign in result: %+v", result)
+	}
+
+	challengeToken := env.createChallenge(t, user.ID)
+	if _, err := env.useCase.VerifyTwoFactor(ctx, challengeToken, backupCodes[0]); !errors.Is(err, constants.ErrInvalidTwoFactorCode) {
+		t.Fatalf("expected a used backup code to be rejected, got %v", err)
+	}
+
+	// The other backup codes are left untouched
+	if _, err := env.useCase.VerifyTwoFactor(ctx, challengeToken, backupCodes[1]); err != nil {
+		t.Fatalf("VerifyTwoFactor failed: %v", err)
+	}
+	remaining, err := env.twoFactorService.CountBackupCodes(user.ID)
+	if err != nil {
+		t.Fatalf("CountBackupCodes failed: %v", err)
+	}
+	if remaining != len(backupCodes)-2 {
+		t.Fatalf("expected %d backup codes left, got %d", len(backupCodes)-2, remaining)
+	}
+}
+
+func TestVerifyTwoFactor_ChallengeAttempts(t *testing.T) {
+	env := newTestEnv(t)
+	ctx := context.Background()
+	user, backupCodes := env.createUserWithTwoFactor(t)
+
+	challengeToken := env.createChallenge(t, user.ID)
+	for i := range 5 {
+		if _, err := env.useCase.VerifyTwoFactor(ctx, challengeToken, "wrong-code"); !errors.Is(err, constants.ErrInvalidTwoFactorCode) {
+			t.Fatalf("attempt %d: expected ErrInvalidTwoFactorCode, got %v", i+1, err)
+		}
+	}
+
+	// Once the attempts are exhausted even a valid code is refused, and the challenge is gone
+	if _, err := env.useCase.VerifyTwoFactor(ctx, challengeToken, backupCodes[0]); !errors.Is(err, constants.ErrTwoFactorChallengeAttempts) {
+		t.Fatalf("expected ErrTwoFactorChallengeAttempts, got %v", err)
+	}
+	if _, err := env.useCase.VerifyTwoFactor(ctx, challengeToken, backupCodes[0]); !errors.Is(err, constants.ErrTwoFactorChallengeInvalid) {
+		t.Fatalf("expected ErrTwoFactorChallengeInvalid, got %v", err)
+	}
+
+	// The refused backup code was not spent
+	if _, err := env.useCase.VerifyTwoFactor(ctx, env.createChallenge(t, user.ID), backupCodes[0]); err != nil {
+		t.Fatalf("VerifyTwoFactor failed: %v", err)
+	}
+}
@@ -0,0 +1,25 @@
+package twofactor
+
+import (
+	"context"
+
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+type TwoFactorUseCase interface {
+	// EnrollTwoFactor generates a new TOTP secret for the user. The factor stays disabled
+	// until a valid code is submitted through EnableTwoFactor.
+	EnrollTwoFactor(ctx context.Context, userID string) (*models.TwoFactorEnrollResult, error)
+
+	// EnableTwoFactor verifies a code from the authenticator app and enables the factor
+	EnableTwoFactor(ctx context.Context, userID string, code string) error
+
+	// DisableTwoFactor removes the factor after verifying a code
+	DisableTwoFactor(ctx context.Context, userID string, code string) error
+
+	// VerifyTwoFactor exchanges a pending sign-in challenge and a valid code for a session
+	VerifyTwoFactor(ctx context.Context, challengeToken string, code string) (*models.SignInResult, error)
+
+	// RegenerateBackupCodes invalidates all existing backup codes and issues a new set
+	RegenerateBackupCodes(ctx context.Context, userID string) ([]string, error)
+}
@@ -1,15 +1,28 @@
 package auth
 
 import (
+	accounts "github.com/GoBetterAuth/go-better-auth/internal/auth/accounts"
+	admin "github.com/GoBetterAuth/go-better-auth/internal/auth/admin"
 	changepassword "github.com/GoBetterAuth/go-better-auth/internal/auth/change-password"
 	emailchange "github.com/GoBetterAuth/go-better-auth/internal/auth/email-change"
+	emailotp "github.com/GoBetterAuth/go-better-auth/internal/auth/email-otp"
+	forwardauth "github.com/GoBetterAuth/go-better-auth/internal/auth/forward-auth"
+	introspection "github.com/GoBetterAuth/go-better-auth/internal/auth/introspection"
+	jwt "github.com/GoBetterAuth/go-better-auth/internal/auth/jwt"
+	magiclink "github.com/GoBetterAuth/go-better-auth/internal/auth/magic-link"
 	me "github.com/GoBetterAuth/go-better-auth/internal/auth/me"
 	oauth2 "github.com/GoBetterAuth/go-better-auth/internal/auth/oauth2"
+	oidcprovider "github.com/GoBetterAuth/go-better-auth/internal/auth/oidc-provider"
+	organization "github.com/GoBetterAuth/go-better-auth/internal/auth/organization"
+	passkey "github.com/GoBetterAuth/go-better-auth/internal/auth/passkey"
+	rbac "github.com/GoBetterAuth/go-better-auth/internal/auth/rbac"
 	resetpassword "github.com/GoBetterAuth/go-better-auth/internal/auth/reset-password"
 	sendemailverification "github.com/GoBetterAuth/go-better-auth/internal/auth/send-email-verification"
+	sessions "github.com/GoBetterAuth/go-better-auth/internal/auth/sessions"
 	signin "github.com/GoBetterAuth/go-better-auth/internal/auth/sign-in"
 	signout "github.com/GoBetterAuth/go-better-auth/internal/auth/sign-out"
 	signup "github.com/GoBetterAuth/go-better-auth/internal/auth/sign-up"
+	twofactor "github.com/GoBetterAuth/go-better-auth/internal/auth/two-factor"
 	verifyemail "github.com/GoBetterAuth/go-better-auth/internal/auth/verify-email"
 	"github.com/GoBetterAuth/go-better-auth/models"
 )
@@ -18,13 +31,26 @@ type UseCases struct {
 	SignUpUseCase                signup.SignUpUseCase
 	SignInUseCase                signin.SignInUseCase
 	SignOutUseCase               signout.SignOutUseCase
+	SessionsUseCase              sessions.SessionsUseCase
 	VerifyEmailUseCase           verifyemail.VerifyEmailUseCase
 	SendEmailVerificationUseCase sendemailverification.SendEmailVerificationUseCase
 	ResetPasswordUseCase         resetpassword.ResetPasswordUseCase
+	MagicLinkUseCase             magiclink.MagicLinkUseCase
+	EmailOTPUseCase              emailotp.EmailOTPUseCase
 	ChangePasswordUseCase        changepassword.ChangePasswordUseCase
 	EmailChangeUseCase           emailchange.EmailChangeUseCase
 	MeUseCase                    me.MeUseCase
 	OAuth2UseCase                oauth2.OAuth2UseCase
+	TwoFactorUseCase             twofactor.TwoFactorUseCase
+	PasskeyUseCase               passkey.PasskeyUseCase
+	JWTUseCase                   jwt.JWTUseCase
+	OIDCProviderUseCase          oidcprovider.OIDCProviderUseCase
+	IntrospectionUseCase         introspection.IntrospectionUseCase
+	ForwardAuthUseCase           forwardauth.ForwardAuthUseCase
+	AccountsUseCase              accounts.AccountsUseCase
+	AdminUseCase                 admin.AdminUseCase
+	RBACUseCase                  rbac.RBACUseCase
+	OrganizationUseCase          organization.OrganizationUseCase
 }
 
 func NewUseCases(config *models.Config, authService *Service) *UseCases {
@@ -38,6 +64,7 @@ func NewUseCases(config *models.Config, authService *Service) *UseCases {
 		authService.VerificationService,
 		authService.MailerService,
 		authService.PasswordService,
+		authService.TwoFactorService,
 		authService.EventEmitter,
 	)
 
@@ -60,12 +87,21 @@ func NewUseCases(config *models.Config, authService *Service) *UseCases {
 		authService.TokenService,
 	)
 
+	sessionsUseCase := sessions.New(
+		config,
+		config.Logger.Logger,
+		authService.SessionService,
+	)
+
 	verifyEmailUseCase := verifyemail.New(
 		config,
 		config.Logger.Logger,
 		authService.UserService,
+		authService.AccountService,
 		authService.TokenService,
 		authService.VerificationService,
+		authService.SessionService,
+		authService.TwoFactorService,
 		authService.EventEmitter,
 	)
 
@@ -87,11 +123,35 @@ func NewUseCases(config *models.Config, authService *Service) *UseCases {
 		authService.MailerService,
 	)
 
+	magicLinkUseCase := magiclink.New(
+		config,
+		config.Logger.Logger,
+		authService.UserService,
+		authService.TokenService,
+		authService.VerificationService,
+		authService.MailerService,
+	)
+
+	emailOTPUseCase := emailotp.New(
+		config,
+		config.Logger.Logger,
+		authService.UserService,
+		authService.AccountService,
+		authService.SessionService,
+		authService.TokenService,
+		authService.EmailOTPService,
+		authService.MailerService,
+		authService.PasswordService,
+		authService.TwoFactorService,
+		authService.EventEmitter,
+	)
+
 	changePasswordUseCase := changepassword.New(
 		config,
 		config.Logger.Logger,
 		authService.UserService,
 		authService.AccountService,
+		authService.SessionService,
 		authService.VerificationService,
 		authService.TokenService,
 		authService.PasswordService,
@@ -112,6 +172,8 @@ func NewUseCases(config *models.Config, authService *Service) *UseCases {
 		config.Logger.Logger,
 		authService.UserService,
 		authService.SessionService,
+		authService.TwoFactorService,
+		authService.RBACService,
 	)
 
 	oauth2UseCase := oauth2.New(
@@ -121,19 +183,122 @@ func NewUseCases(config *models.Config, authService *Service) *UseCases {
 		authService.AccountService,
 		authService.SessionService,
 		authService.TokenService,
+		authService.TwoFactorService,
 		authService.OAuth2ProviderRegistry,
 	)
 
+	twoFactorUseCase := twofactor.New(
+		config,
+		config.Logger.Logger,
+		authService.UserService,
+		authService.SessionService,
+		authService.TokenService,
+		authService.TwoFactorService,
+		authService.EventEmitter,
+	)
+
+	passkeyUseCase := passkey.New(
+		config,
+		config.Logger.Logger,
+		authService.UserService,
+		authService.SessionService,
+		authService.TokenService,
+		authService.PasskeyService,
+		authService.EventEmitter,
+	)
+
+	jwtUseCase := jwt.New(
+		config,
+		config.Logger.Logger,
+		authService.UserService,
+		authService.SessionService,
+		authService.TokenService,
+		authService.SigningKeyService,
+	)
+
+	oidcProviderUseCase := oidcprovider.New(
+		config,
+		config.Logger.Logger,
+		authService.UserService,
+		authService.SessionService,
+		authService.TokenService,
+		authService.OIDCProviderService,
+	)
+
+	introspectionUseCase := introspection.New(
+		config,
+		config.Logger.Logger,
+		authService.EventEmitter,
+		authService.SessionService,
+		authService.TokenService,
+		authService.OIDCProviderService,
+	)
+
+	forwardAuthUseCase := forwardauth.New(
+		config,
+		config.Logger.Logger,
+		authService.UserService,
+	)
+
+	accountsUseCase := accounts.New(
+		config,
+		config.Logger.Logger,
+		authService.AccountService,
+	)
+
+	adminUseCase := admin.New(
+		config,
+		config.Logger.Logger,
+		authService.UserService,
+		authService.AccountService,
+		authService.SessionService,
+		authService.TokenService,
+		authService.PasswordService,
+		authService.OIDCProviderService,
+		authService.EventEmitter,
+		resetPasswordUseCase,
+	)
+
+	rbacUseCase := rbac.New(
+		config,
+		config.Logger.Logger,
+		authService.UserService,
+		authService.RBACService,
+	)
+
+	organizationUseCase := organization.New(
+		config,
+		config.Logger.Logger,
+		authService.UserService,
+		authService.SessionService,
+		authService.TokenService,
+		authService.MailerService,
+		authService.OrganizationService,
+	)
+
 	return &UseCases{
 		SignUpUseCase:                signUpUseCase,
 		SignInUseCase:                signInUseCase,
 		SignOutUseCase:               signOutUseCase,
+		SessionsUseCase:              sessionsUseCase,
 		VerifyEmailUseCase:           verifyEmailUseCase,
 		SendEmailVerificationUseCase: sendEmailVerificationUseCase,
 		ResetPasswordUseCase:         resetPasswordUseCase,
+		MagicLinkUseCase:             magicLinkUseCase,
+		EmailOTPUseCase:              emailOTPUseCase,
 		ChangePasswordUseCase:        changePasswordUseCase,
 		EmailChangeUseCase:           emailChangeUseCase,
 		MeUseCase:                    meUseCase,
 		OAuth2UseCase:                oauth2UseCase,
+		TwoFactorUseCase:             twoFactorUseCase,
+		PasskeyUseCase:               passkeyUseCase,
+		JWTUseCase:                   jwtUseCase,
+		OIDCProviderUseCase:          oidcProviderUseCase,
+		IntrospectionUseCase:         introspectionUseCase,
+		ForwardAuthUseCase:           forwardAuthUseCase,
+		AccountsUseCase:              accountsUseCase,
+		AdminUseCase:                 adminUseCase,
+		RBACUseCase:                  rbacUseCase,
+		OrganizationUseCase:          organizationUseCase,
 	}
 }
@@ -3,6 +3,8 @@ package verifyemail
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/GoBetterAuth/go-better-auth/internal/constants"
 	"github.com/GoBetterAuth/go-better-auth/models"
@@ -12,8 +14,11 @@ type service struct {
 	config              *models.Config
 	logger              models.Logger
 	userService         models.UserService
+	accountService      models.AccountService
 	tokenService        models.TokenService
 	verificationService models.VerificationService
+	sessionService      models.SessionService
+	twoFactorService    models.TwoFactorService
 	eventEmitter        models.EventEmitter
 }
 
@@ -21,16 +26,22 @@ func New(
 	config *models.Config,
 	logger models.Logger,
 	userService models.UserService,
+	accountService models.AccountService,
 	tokenService models.TokenService,
 	verificationService models.VerificationService,
+	sessionService models.SessionService,
+	twoFactorService models.TwoFactorService,
 	eventEmitter models.EventEmitter,
 ) *service {
 	return &service{
 		config:              config,
 		logger:              logger,
 		userService:         userService,
+		accountService:      accountService,
 		tokenService:        tokenService,
 		verificationService: verificationService,
+		sessionService:      sessionService,
+		twoFactorService:    twoFactorService,
 		eventEmitter:        eventEmitter,
 	}
 }
@@ -60,6 +71,8 @@ func (s *service) VerifyEmail(ctx context.Context, rawToken string) (*models.Ver
 		return s.handlePasswordResetConfirmation(ver)
 	case models.TypeEmailChange:
 		return s.handleEmailChange(ver)
+	case models.TypeMagicLink:
+		return s.handleMagicLink(ctx, ver)
 	default:
 		return nil, fmt.Errorf("unknown verification type: %s", ver.Type)
 	}
@@ -139,3 +152,136 @@ func (s *service) handleEmailChange(ver *models.Verification) (*models.VerifyEma
 		User:    user,
 	}, nil
 }
+
+// handleMagicLink signs the user in, creating the user on first use unless sign up is disabled
+func (s *service) handleMagicLink(ctx context.Context, ver *models.Verification) (*models.VerifyEmailResult, error) {
+	if !s.config.MagicLink.Enabled {
+		return nil, constants.ErrMagicLinkDisabled
+	}
+
+	// The link is single use, delete it before anything else so it cannot be replayed
+	if err := s.verificationService.DeleteVerification(ver.ID); err != nil {
+		s.logger.Error("failed to delete verification", "verification_id", ver.ID, "error", err)
+		return nil, fmt.Errorf("%w: %w", constants.ErrVerificationInvalid, err)
+	}
+
+	user, err := s.userService.GetUserByEmail(ver.Identifier)
+	if err != nil {
+		s.logger.Error("failed to get user by email", "email", ver.Identifier, "error", err)
+		return nil, fmt.Errorf("%w: %w", constants.ErrUserNotFound, err)
+	}
+
+	signedUp := false
+	if user == nil {
+		if s.config.MagicLink.DisableSignUp {
+			return nil, constants.ErrMagicLinkSignUpDisabled
+		}
+
+		name, _, _ := strings.Cut(ver.Identifier, "@")
+		user = &models.User{
+			Name:          name,
+			Email:         ver.Identifier,
+			EmailVerified: true,
+			CreatedAt:     time.Now().UTC(),
+			UpdatedAt:     time.Now().UTC(),
+		}
+		if err := s.userService.CreateUser(user); err != nil {
+			s.logger.Error("failed to create user", "email", ver.Identifier, "error", err)
+			return nil, fmt.Errorf("failed to create user: %w", err)
+		}
+		signedUp = true
+	} else if !user.EmailVerified {
+		if err := s.claimUnverifiedUser(user); err != nil {
+			return nil, err
+		}
+	}
+
+	if signedUp {
+		s.eventEmitter.OnUserSignedUp(*user)
+	}
+
+	if user.IsBanned() {
+		return nil, constants.ErrUserBanned
+	}
+
+	// Users with two factor enabled get a challenge instead of a session
+	twoFactorRequired, err := s.twoFactorService.IsEnabledForUser(user.ID)
+	if err != nil {
+		s.logger.Error("failed to check two factor", "user_id", user.ID, "error", err)
+		return nil, err
+	}
+	if twoFactorRequired {
+		challengeToken, err := s.twoFactorService.CreateChallenge(ctx, user.ID)
+		if err != nil {
+			s.logger.Error("failed to create two factor challenge", "user_id", user.ID, "error", err)
+			return nil, fmt.Errorf("%w: %w", constants.ErrTokenGenerationFailed, err)
+		}
+		return &models.VerifyEmailResult{
+			Message:           "Two factor authentication required",
+			User:              user,
+			TwoFactorRequired: true,
+			ChallengeToken:    &challengeToken,
+		}, nil
+	}
+
+	token, err := s.tokenService.GenerateToken()
+	if err != nil {
+		s.logger.Error("failed to generate token", "error", err)
+		return nil, fmt.Errorf("%w: %w", constants.ErrTokenGenerationFailed, err)
+	}
+
+	_, err = s.sessionService.CreateSession(user.ID, s.tokenService.HashToken(token))
+	if err != nil {
+		s.logger.Error("failed to create session", "user_id", user.ID, "error", err)
+		return nil, fmt.Errorf("%w: %w", constants.ErrSessionCreationFailed, err)
+	}
+
+	s.eventEmitter.OnUserLoggedIn(*user)
+
+	var csrfToken *string
+	if s.config.CSRF.Enabled {
+		csrf, err := s.tokenService.GenerateToken()
+		if err != nil {
+			s.logger.Error("failed to generate csrf token", "error", err)
+			return nil, fmt.Errorf("%w: %w", constants.ErrTokenGenerationFailed, err)
+		}
+		csrfToken = &csrf
+	}
+
+	return &models.VerifyEmailResult{
+		Message:   "Signed in successfully",
+		User:      user,
+		Token:     token,
+		CSRFToken: csrfToken,
+	}, nil
+}
+
+// claimUnverifiedUser marks the email of a user as verified once the link proved ownership of the address.
+// Anyone could have signed up with the address before, so a password set while it was unverified is
+// removed along with the sessions it may have created.
+func (s *service) claimUnverifiedUser(user *models.User) error {
+	account, err := s.accountService.GetCredentialAccount(user.ID)
+	if err != nil {
+		s.logger.Error("failed to get credential account", "user_id", user.ID, "error", err)
+		return err
+	}
+	if account != nil {
+		if err := s.accountService.DeleteAccount(account.ID); err != nil {
+			s.logger.Error("failed to delete credential account", "user_id", user.ID, "error", err)
+			return err
+		}
+		if err := s.sessionService.RevokeUserSessions(user.ID); err != nil {
+			s.logger.Error("failed to revoke sessions", "user_id", user.ID, "error", err)
+			return err
+		}
+	}
+
+	user.EmailVerified = true
+	if err := s.userService.UpdateUser(user); err != nil {
+		s.logger.Error("failed to update user", "user_id", user.ID, "error", err)
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+	s.eventEmitter.OnEmailVerified(*user)
+
+	return nil
+}
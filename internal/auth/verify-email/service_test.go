@@ -0,0 +1,209 @@
+package verifyemail
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/GoBetterAuth/go-better-auth/config"
+	"github.com/GoBetterAuth/go-better-auth/internal/constants"
+	"github.com/GoBetterAuth/go-better-auth/internal/events"
+	"github.com/GoBetterAuth/go-better-auth/internal/services"
+	"github.com/GoBetterAuth/go-better-auth/internal/testutil"
+	"github.com/GoBetterAuth/go-better-auth/models"
+	"github.com/GoBetterAuth/go-better-auth/storage"
+)
+
+type testEnv struct {
+	db                  *gorm.DB
+	useCase             *service
+	userService         *services.UserServiceImpl
+	accountService      *services.AccountServiceImpl
+	sessionService      *services.SessionServiceImpl
+	tokenService        *services.TokenServiceImpl
+	verificationService *services.VerificationServiceImpl
+}
+
+func newTestEnv(t *testing.T, magicLinkConfig models.MagicLinkConfig) *testEnv {
+	t.Helper()
+
+	db := testutil.NewDB(t, &models.User{}, &models.Account{}, &models.Session{}, &models.Verification{}, &models.TwoFactor{})
+	cfg := config.NewConfig(
+		config.WithSecondaryStorage(models.SecondaryStorageConfig{
+			Storage: storage.NewMemorySecondaryStorage(models.SecondaryStorageMemoryOptions{}),
+		}),
+		config.WithMagicLink(magicLinkConfig),
+	)
+	logger := testutil.NewLogger()
+
+	env := &testEnv{
+		db:                  db,
+		userService:         services.NewUserServiceImpl(cfg, db),
+		accountService:      services.NewAccountServiceImpl(cfg, db),
+		sessionService:      services.NewSessionServiceImpl(cfg, db),
+		tokenService:        services.NewTokenServiceImpl(cfg, nil),
+		verificationService: services.NewVerificationServiceImpl(cfg, db),
+	}
+	env.useCase = New(
+		cfg,
+		logger,
+		env.userService,
+		env.accountService,
+		env.tokenService,
+		env.verificationService,
+		env.sessionService,
+		services.NewTwoFactorServiceImpl(cfg, db),
+		events.NewEventEmitter(cfg, logger, nil, nil),
+	)
+
+	return env
+}
+
+// createMagicLink stores a magic link verification the way the magic link use case does and returns its raw token
+func (env *testEnv) createMagicLink(t *testing.T, email string) string {
+	t.Helper()
+
+	token, err := env.tokenService.GenerateToken()
+	if err != nil {
+		t.Fatalf("GenerateToken failed: %v", err)
+	}
+	if err := env.verificationService.CreateVerification(&models.Verification{
+		Identifier: email,
+		Token:      env.tokenService.HashToken(token),
+		Type:       models.TypeMagicLink,
+		ExpiresAt:  time.Now().UTC().Add(15 * time.Minute),
+	}); err != nil {
+		t.Fatalf("CreateVerification failed: %v", err)
+	}
+
+	return token
+}
+
+func TestVerifyEmail_MagicLinkSignsUpNewUser(t *testing.T) {
+	env := newTestEnv(t, models.MagicLinkConfig{Enabled: true})
+	ctx := context.Background()
+
+	token := env.createMagicLink(t, "new@example.com")
+
+	result, err := env.useCase.VerifyEmail(ctx, token)
+	if err != nil {
+		t.Fatalf("VerifyEmail failed: %v", err)
+	}
+	if result.User == nil || result.User.Email != "new@example.com" || !result.User.EmailVerified {
+		t.Fatalf("expected a verified user to be created, got %+v", result.User)
+	}
+	if result.Token == "" {
+		t.Fatal("expected a session token")
+	}
+
+	session, err := env.sessionService.GetSessionByToken(env.tokenService.HashToken(result.Token))
+	if err != nil || session == nil || session.UserID != result.User.ID {
+		t.Fatalf("expected a session for the new user, got %+v (%v)", session, err)
+	}
+
+	// Links are single use
+	if _, err := env.useCase.VerifyEmail(ctx, token); !errors.Is(err, constants.ErrVerificationNotFound) {
+		t.Fatalf("expected used link to be rejected, got %v", err)
+	}
+}
+
+func TestVerifyEmail_MagicLinkSignUpDisabled(t *testing.T) {
+	env := newTestEnv(t, models.MagicLinkConfig{Enabled: true, DisableSignUp: true})
+
+	token := env.createMagicLink(t, "new@example.com")
+
+	if _, err := env.useCase.VerifyEmail(context.Background(), token); !errors.Is(err, constants.ErrMagicLinkSignUpDisabled) {
+		t.Fatalf("expected sign up to be refused, got %v", err)
+	}
+	if user, _ := env.userService.GetUserByEmail("new@example.com"); user != nil {
+		t.Fatal("expected no user to be created")
+	}
+}
+
+func TestVerifyEmail_MagicLinkExpired(t *testing.T) {
+	env := newTestEnv(t, models.MagicLinkConfig{Enabled: true})
+
+	token, _ := env.tokenService.GenerateToken()
+	if err := env.verificationService.CreateVerification(&models.Verification{
+		Identifier: "user@example.com",
+		Token:      env.tokenService.HashToken(token),
+		Type:       models.TypeMagicLink,
+		ExpiresAt:  time.Now().UTC().Add(-time.Minute),
+	}); err != nil {
+		t.Fatalf("CreateVerification failed: %v", err)
+	}
+
+	if _, err := env.useCase.VerifyEmail(context.Background(), token); !errors.Is(err, constants.ErrVerificationExpired) {
+		t.Fatalf("expected expired link to be rejected, got %v", err)
+	}
+}
+
+func TestVerifyEmail_MagicLinkClaimsUnverifiedUser(t *testing.T) {
+	env := newTestEnv(t, models.MagicLinkConfig{Enabled: true})
+	ctx := context.Background()
+
+	// Someone else signed up with the address first and set a password
+	user := &models.User{Name: "squatter", Email: "owner@example.com"}
+	if err := env.userService.CreateUser(user); err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+	password := "hashed-password"
+	if err := env.accountService.CreateAccount(&models.Account{
+		UserID:     user.ID,
+		AccountID:  user.ID,
+		ProviderID: models.ProviderEmail,
+		Password:   &password,
+	}); err != nil {
+		t.Fatalf("CreateAccount failed: %v", err)
+	}
+	squatterSession, err := env.sessionService.CreateSession(user.ID, env.tokenService.HashToken("squatter-token"))
+	if err != nil {
+		t.Fatalf("CreateSession failed: %v", err)
+	}
+
+	result, err := env.useCase.VerifyEmail(ctx, env.createMagicLink(t, "owner@example.com"))
+	if err != nil {
+		t.Fatalf("VerifyEmail failed: %v", err)
+	}
+	if result.User.ID != user.ID || !result.User.EmailVerified {
+		t.Fatalf("expected the existing user to be verified, got %+v", result.User)
+	}
+
+	if account, _ := env.accountService.GetCredentialAccount(user.ID); account != nil {
+		t.Fatal("expected the password set before verification to be removed")
+	}
+	if session, _ := env.sessionService.GetSessionByID(squatterSession.ID); session != nil {
+		t.Fatal("expected sessions created before verification to be revoked")
+	}
+	if session, _ := env.sessionService.GetSessionByToken(env.tokenService.HashToken(result.Token)); session == nil {
+		t.Fatal("expected the owner to be signed in")
+	}
+}
+
+func TestVerifyEmail_MagicLinkKeepsVerifiedUserPassword(t *testing.T) {
+	env := newTestEnv(t, models.MagicLinkConfig{Enabled: true})
+
+	user := &models.User{Name: "owner", Email: "owner@example.com", EmailVerified: true}
+	if err := env.userService.CreateUser(user); err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+	password := "hashed-password"
+	if err := env.accountService.CreateAccount(&models.Account{
+		UserID:     user.ID,
+		AccountID:  user.ID,
+		ProviderID: models.ProviderEmail,
+		Password:   &password,
+	}); err != nil {
+		t.Fatalf("CreateAccount failed: %v", err)
+	}
+
+	if _, err := env.useCase.VerifyEmail(context.Background(), env.createMagicLink(t, "owner@example.com")); err != nil {
+		t.Fatalf("VerifyEmail failed: %v", err)
+	}
+	if account, _ := env.accountService.GetCredentialAccount(user.ID); account == nil {
+		t.Fatal("expected the password of a verified user to be kept")
+	}
+}
@@ -0,0 +1,27 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/GoBetterAuth/go-better-auth/config"
+	"github.com/GoBetterAuth/go-better-auth/internal/util"
+)
+
+// TestValidateAndMergeConfig_TwoFactorPeriod verifies that TOTP periods below one second are rejected
+func TestValidateAndMergeConfig_TwoFactorPeriod(t *testing.T) {
+	util.InitValidator()
+	current := config.NewConfig()
+
+	updated, err := ValidateAndMergeConfig(current, "two_factor.period", 60*time.Second)
+	if err != nil {
+		t.Fatalf("expected valid period to be accepted, got %v", err)
+	}
+	if updated.TwoFactor.Period != 60*time.Second {
+		t.Fatalf("expected period to be updated, got %s", updated.TwoFactor.Period)
+	}
+
+	if _, err := ValidateAndMergeConfig(current, "two_factor.period", 500*time.Millisecond); err == nil {
+		t.Fatal("expected period below one second to be rejected")
+	}
+}
@@ -7,9 +7,16 @@ var (
 	ErrInvalidCredentials    = errors.New("invalid credentials")
 	ErrUserNotFound          = errors.New("user not found")
 	ErrUserAlreadyExists     = errors.New("user already exists")
+	ErrUserBanned            = errors.New("user is banned")
+	ErrBanExpiryInPast       = errors.New("ban expiry must be in the future")
 	ErrInvalidPassword       = errors.New("invalid password")
 	ErrPasswordHashingFailed = errors.New("password hashing failed")
 
+	// Impersonation errors
+	ErrImpersonateSelf      = errors.New("admins cannot impersonate themselves")
+	ErrAlreadyImpersonating = errors.New("cannot impersonate from an impersonation session")
+	ErrNotImpersonating     = errors.New("session is not an impersonation session")
+
 	// Token errors
 	ErrMissingToken          = errors.New("missing token")
 	ErrTokenGenerationFailed = errors.New("token generation failed")
@@ -33,6 +40,10 @@ var (
 	ErrAccountCreationFailed  = errors.New("account creation failed")
 	ErrAccountUpdateFailed    = errors.New("account update failed")
 	ErrAccountLinkingRequired = errors.New("account linking required: user exists with a different provider")
+	ErrAccountLinkingDisabled = errors.New("account linking is not enabled")
+	ErrAccountAlreadyLinked   = errors.New("this provider account is already linked to another user")
+	ErrAccountEmailMismatch   = errors.New("the provider account email does not match the user's email")
+	ErrLastAccount            = errors.New("cannot unlink the last account of a user")
 
 	// Email verification errors
 	ErrEmailVerificationFailed = errors.New("email verification failed")
@@ -46,6 +57,42 @@ var (
 	ErrPasswordResetFailed        = errors.New("password reset failed")
 	ErrPasswordResetRequestFailed = errors.New("password reset request failed")
 
+	// Two factor errors
+	ErrTwoFactorDisabled          = errors.New("two factor authentication is not enabled")
+	ErrTwoFactorNotEnrolled       = errors.New("two factor authentication is not set up")
+	ErrTwoFactorAlreadyEnabled    = errors.New("two factor authentication is already enabled")
+	ErrInvalidTwoFactorCode       = errors.New("invalid two factor code")
+	ErrTwoFactorChallengeInvalid  = errors.New("invalid or expired two factor challenge")
+	ErrTwoFactorChallengeAttempts = errors.New("too many two factor attempts")
+
+	// Passkey errors
+	ErrPasskeyDisabled           = errors.New("passkeys are not enabled")
+	ErrPasskeyNotFound           = errors.New("passkey not found")
+	ErrPasskeyAlreadyRegistered  = errors.New("passkey already registered")
+	ErrPasskeyChallengeInvalid   = errors.New("invalid or expired passkey challenge")
+	ErrPasskeyVerificationFailed = errors.New("passkey verification failed")
+
+	// Magic link errors
+	ErrMagicLinkDisabled       = errors.New("magic link sign in is not enabled")
+	ErrMagicLinkSignUpDisabled = errors.New("sign up with magic link is disabled")
+
+	// Email OTP errors
+	ErrEmailOTPDisabled       = errors.New("email one-time codes are not enabled")
+	ErrEmailOTPInvalid        = errors.New("invalid or expired code")
+	ErrEmailOTPAttempts       = errors.New("too many attempts, request a new code")
+	ErrEmailOTPTypeInvalid    = errors.New("invalid code type")
+	ErrEmailOTPSignUpDisabled = errors.New("sign up with email code is disabled")
+
+	// JWT errors
+	ErrJWTDisabled = errors.New("jwt is not enabled")
+
+	// OIDC provider errors
+	ErrOIDCProviderDisabled = errors.New("oidc provider is not enabled")
+	ErrOIDCConsentInvalid   = errors.New("invalid or expired consent request")
+
+	// Forward auth errors
+	ErrForwardAuthDisabled = errors.New("forward auth is not enabled")
+
 	// Configuration errors
 	ErrConfigInvalid = errors.New("invalid configuration")
 
@@ -53,4 +100,33 @@ var (
 	ErrOAuth2ProviderNotConfigured = errors.New("oauth2 provider not configured")
 	ErrOAuth2ExchangeFailed        = errors.New("oauth2 token exchange failed")
 	ErrOAuth2UserInfoFailed        = errors.New("failed to get oauth2 user info")
+	ErrOAuth2IDTokenInvalid        = errors.New("invalid oauth2 id token")
+	ErrOAuth2IDTokenNotSupported   = errors.New("oauth2 provider does not support id token sign in")
+	ErrOAuth2StateInvalid          = errors.New("invalid or expired oauth2 state")
+	ErrOAuth2RefreshFailed         = errors.New("failed to refresh oauth2 access token, the account must be authorized again")
+	ErrUntrustedRedirect           = errors.New("redirect target is not trusted")
+
+	// RBAC errors
+	ErrRoleNotFound            = errors.New("role not found")
+	ErrRoleAlreadyExists       = errors.New("role already exists")
+	ErrPermissionNotFound      = errors.New("permission not found")
+	ErrPermissionAlreadyExists = errors.New("permission already exists")
+	ErrPermissionInvalid       = errors.New("permissions must have the form resource:action")
+
+	// Organization errors
+	ErrOrganizationsDisabled     = errors.New("organizations are not enabled")
+	ErrOrganizationNotFound      = errors.New("organization not found")
+	ErrOrganizationSlugTaken     = errors.New("organization slug is already taken")
+	ErrOrganizationSlugInvalid   = errors.New("organization slugs may only contain lowercase letters, digits and dashes")
+	ErrNotOrganizationMember     = errors.New("user is not a member of the organization")
+	ErrOrganizationForbidden     = errors.New("organization role does not allow this action")
+	ErrOrganizationRoleInvalid   = errors.New("organization role must be owner, admin or member")
+	ErrAlreadyOrganizationMember = errors.New("user is already a member of the organization")
+	ErrLastOrganizationOwner     = errors.New("organization must keep at least one owner")
+	ErrInvitationNotFound        = errors.New("invitation not found")
+	ErrInvitationExpired         = errors.New("invitation expired")
+	ErrInvitationAlreadyExists   = errors.New("a pending invitation already exists for this email")
+	ErrInvitationRoleInvalid     = errors.New("invitations may only grant the admin or member role")
+	ErrInvitationEmailMismatch   = errors.New("invitation was sent to another email address")
+	ErrInvitationPageMissing     = errors.New("an invitation page must be configured to send invitations")
 )
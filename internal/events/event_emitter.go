@@ -161,3 +161,67 @@ func (e *EventEmitterImpl) OnPasswordChanged(user models.User) {
 	e.callWebhook(cfg.Webhooks.OnPasswordChanged, models.EventPasswordChanged, &user)
 	e.emitEvent(models.EventPasswordChanged, user)
 }
+
+// OnBackupCodeUsed implements the backup code used event logic.
+func (e *EventEmitterImpl) OnBackupCodeUsed(user models.User) {
+	cfg := e.getConfig()
+	if cfg == nil {
+		return
+	}
+	e.callEventHook(cfg.EventHooks.OnBackupCodeUsed, &user)
+	e.callWebhook(cfg.Webhooks.OnBackupCodeUsed, models.EventBackupCodeUsed, &user)
+	e.emitEvent(models.EventBackupCodeUsed, user)
+}
+
+// OnUserBanned implements the user banned event logic.
+func (e *EventEmitterImpl) OnUserBanned(user models.User) {
+	cfg := e.getConfig()
+	if cfg == nil {
+		return
+	}
+	e.callEventHook(cfg.EventHooks.OnUserBanned, &user)
+	e.callWebhook(cfg.Webhooks.OnUserBanned, models.EventUserBanned, &user)
+	e.emitEvent(models.EventUserBanned, user)
+}
+
+// OnUserUnbanned implements the user unbanned event logic.
+func (e *EventEmitterImpl) OnUserUnbanned(user models.User) {
+	cfg := e.getConfig()
+	if cfg == nil {
+		return
+	}
+	e.callEventHook(cfg.EventHooks.OnUserUnbanned, &user)
+	e.callWebhook(cfg.Webhooks.OnUserUnbanned, models.EventUserUnbanned, &user)
+	e.emitEvent(models.EventUserUnbanned, user)
+}
+
+// OnSessionRevoked publishes the session revoked event so that other services can drop the session.
+func (e *EventEmitterImpl) OnSessionRevoked(session models.Session) {
+	e.emitEvent(models.EventSessionRevoked, models.SessionRevokedPayload{
+		SessionID: session.ID,
+		UserID:    session.UserID,
+		RevokedAt: time.Now().UTC(),
+	})
+}
+
+// OnImpersonationStarted publishes the impersonation started event of an impersonation session.
+func (e *EventEmitterImpl) OnImpersonationStarted(session models.Session) {
+	e.emitEvent(models.EventImpersonationStarted, impersonationPayload(session))
+}
+
+// OnImpersonationStopped publishes the impersonation stopped event of an impersonation session.
+func (e *EventEmitterImpl) OnImpersonationStopped(session models.Session) {
+	e.emitEvent(models.EventImpersonationStopped, impersonationPayload(session))
+}
+
+func impersonationPayload(session models.Session) models.ImpersonationPayload {
+	payload := models.ImpersonationPayload{
+		SessionID: session.ID,
+		UserID:    session.UserID,
+		Timestamp: time.Now().UTC(),
+	}
+	if session.ImpersonatedBy != nil {
+		payload.ImpersonatedBy = *session.ImpersonatedBy
+	}
+	return payload
+}
@@ -0,0 +1,71 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	accounts "github.com/GoBetterAuth/go-better-auth/internal/auth/accounts"
+	"github.com/GoBetterAuth/go-better-auth/internal/common"
+	"github.com/GoBetterAuth/go-better-auth/internal/constants"
+	"github.com/GoBetterAuth/go-better-auth/internal/middleware"
+	"github.com/GoBetterAuth/go-better-auth/internal/util"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+// ListAccountsHandler returns the accounts linked to the signed in user.
+type ListAccountsHandler struct {
+	Config  *models.Config
+	UseCase accounts.AccountsUseCase
+}
+
+func (h *ListAccountsHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(middleware.ContextUserID).(string)
+	if !ok || userID == "" {
+		util.JSONResponse(w, http.StatusUnauthorized, map[string]any{"message": "unauthorized"})
+		return
+	}
+
+	result, err := h.UseCase.ListAccounts(r.Context(), userID)
+	if err != nil {
+		util.JSONResponse(w, http.StatusInternalServerError, map[string]any{"message": err.Error()})
+		return
+	}
+
+	util.JSONResponse(w, http.StatusOK, map[string]any{"accounts": result})
+}
+
+func (h *ListAccountsHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// UnlinkAccountHandler removes one of the accounts of the signed in user by ID.
+type UnlinkAccountHandler struct {
+	Config  *models.Config
+	UseCase accounts.AccountsUseCase
+}
+
+func (h *UnlinkAccountHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(middleware.ContextUserID).(string)
+	if !ok || userID == "" {
+		util.JSONResponse(w, http.StatusUnauthorized, map[string]any{"message": "unauthorized"})
+		return
+	}
+
+	if err := h.UseCase.UnlinkAccount(r.Context(), userID, r.PathValue("id")); err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, constants.ErrAccountNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, constants.ErrLastAccount):
+			status = http.StatusConflict
+		}
+		util.JSONResponse(w, status, map[string]any{"message": err.Error()})
+		return
+	}
+
+	util.JSONResponse(w, http.StatusOK, map[string]any{"message": "Account unlinked"})
+}
+
+func (h *UnlinkAccountHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
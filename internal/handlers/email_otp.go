@@ -0,0 +1,209 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	emailotp "github.com/GoBetterAuth/go-better-auth/internal/auth/email-otp"
+	"github.com/GoBetterAuth/go-better-auth/internal/common"
+	"github.com/GoBetterAuth/go-better-auth/internal/constants"
+	"github.com/GoBetterAuth/go-better-auth/internal/util"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+type EmailOTPSendPayload struct {
+	Email string              `json:"email" validate:"required,email"`
+	Type  models.EmailOTPType `json:"type" validate:"required,oneof=sign_in email_verification password_reset"`
+}
+
+type EmailOTPPayload struct {
+	Email string `json:"email" validate:"required,email"`
+	OTP   string `json:"otp" validate:"required"`
+}
+
+type EmailOTPResetPasswordPayload struct {
+	Email       string `json:"email" validate:"required,email"`
+	OTP         string `json:"otp" validate:"required"`
+	NewPassword string `json:"new_password" validate:"required"`
+}
+
+// emailOTPErrorStatus maps code verification failures to a response status.
+func emailOTPErrorStatus(err error) int {
+	if errors.Is(err, constants.ErrEmailOTPAttempts) {
+		return http.StatusTooManyRequests
+	}
+	return http.StatusBadRequest
+}
+
+// EmailOTPSendHandler emails a one-time code for sign in, email verification or password reset.
+type EmailOTPSendHandler struct {
+	Config  *models.Config
+	UseCase emailotp.EmailOTPUseCase
+}
+
+func (h *EmailOTPSendHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	if !h.Config.EmailOTP.Enabled {
+		util.JSONResponse(w, http.StatusNotImplemented, map[string]any{"message": constants.ErrEmailOTPDisabled.Error()})
+		return
+	}
+
+	var payload EmailOTPSendPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.JSONResponse(w, http.StatusBadRequest, map[string]any{"message": "invalid request"})
+		return
+	}
+	if err := util.Validate.Struct(payload); err != nil {
+		util.JSONResponse(w, http.StatusUnprocessableEntity, map[string]any{"message": err.Error()})
+		return
+	}
+
+	if err := h.UseCase.SendEmailOTP(r.Context(), payload.Email, payload.Type); err != nil {
+		util.JSONResponse(w, http.StatusInternalServerError, map[string]any{"message": "email code request failed"})
+		return
+	}
+
+	util.JSONResponse(w, http.StatusOK, map[string]any{"message": "Verification code sent"})
+}
+
+func (h *EmailOTPSendHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// EmailOTPSignInHandler signs the user in with an emailed code.
+type EmailOTPSignInHandler struct {
+	Config  *models.Config
+	UseCase emailotp.EmailOTPUseCase
+}
+
+func (h *EmailOTPSignInHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	if !h.Config.EmailOTP.Enabled {
+		util.JSONResponse(w, http.StatusNotImplemented, map[string]any{"message": constants.ErrEmailOTPDisabled.Error()})
+		return
+	}
+
+	var payload EmailOTPPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.JSONResponse(w, http.StatusBadRequest, map[string]any{"message": "invalid request"})
+		return
+	}
+	if err := util.Validate.Struct(payload); err != nil {
+		util.JSONResponse(w, http.StatusUnprocessableEntity, map[string]any{"message": "validation failed"})
+		return
+	}
+
+	result, err := h.UseCase.SignInWithEmailOTP(r.Context(), payload.Email, payload.OTP)
+	if err != nil {
+		status := http.StatusUnauthorized
+		if errors.Is(err, constants.ErrEmailOTPAttempts) {
+			status = http.StatusTooManyRequests
+		}
+		util.JSONResponse(w, status, map[string]any{"message": err.Error()})
+		return
+	}
+
+	// No session exists until the two factor challenge has been completed
+	if result.TwoFactorRequired {
+		util.JSONResponse(w, http.StatusOK, result)
+		return
+	}
+
+	isSecure, sameSite := util.GetCookieOptions(h.Config)
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     h.Config.Session.CookieName,
+		Value:    result.Token,
+		Path:     "/",
+		HttpOnly: true,
+		MaxAge:   int(h.Config.Session.ExpiresIn.Seconds()),
+		SameSite: sameSite,
+		Secure:   isSecure,
+	})
+
+	if h.Config.CSRF.Enabled && result.CSRFToken != nil {
+		http.SetCookie(w, &http.Cookie{
+			Name:     h.Config.CSRF.CookieName,
+			Value:    *result.CSRFToken,
+			Path:     "/",
+			HttpOnly: false,
+			Secure:   isSecure,
+			SameSite: sameSite,
+			MaxAge:   int(h.Config.CSRF.ExpiresIn.Seconds()),
+		})
+	}
+
+	util.JSONResponse(w, http.StatusOK, result)
+}
+
+func (h *EmailOTPSignInHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// EmailOTPVerifyEmailHandler verifies the user's email address with an emailed code.
+type EmailOTPVerifyEmailHandler struct {
+	Config  *models.Config
+	UseCase emailotp.EmailOTPUseCase
+}
+
+func (h *EmailOTPVerifyEmailHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	if !h.Config.EmailOTP.Enabled {
+		util.JSONResponse(w, http.StatusNotImplemented, map[string]any{"message": constants.ErrEmailOTPDisabled.Error()})
+		return
+	}
+
+	var payload EmailOTPPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.JSONResponse(w, http.StatusBadRequest, map[string]any{"message": "invalid request"})
+		return
+	}
+	if err := util.Validate.Struct(payload); err != nil {
+		util.JSONResponse(w, http.StatusUnprocessableEntity, map[string]any{"message": "validation failed"})
+		return
+	}
+
+	result, err := h.UseCase.VerifyEmailWithOTP(r.Context(), payload.Email, payload.OTP)
+	if err != nil {
+		util.JSONResponse(w, emailOTPErrorStatus(err), map[string]any{"message": err.Error()})
+		return
+	}
+
+	util.JSONResponse(w, http.StatusOK, result)
+}
+
+func (h *EmailOTPVerifyEmailHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// EmailOTPResetPasswordHandler sets a new password after verifying an emailed code.
+type EmailOTPResetPasswordHandler struct {
+	Config  *models.Config
+	UseCase emailotp.EmailOTPUseCase
+}
+
+func (h *EmailOTPResetPasswordHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	if !h.Config.EmailOTP.Enabled {
+		util.JSONResponse(w, http.StatusNotImplemented, map[string]any{"message": constants.ErrEmailOTPDisabled.Error()})
+		return
+	}
+
+	var payload EmailOTPResetPasswordPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.JSONResponse(w, http.StatusBadRequest, map[string]any{"message": "invalid request"})
+		return
+	}
+	if err := util.Validate.Struct(payload); err != nil {
+		util.JSONResponse(w, http.StatusUnprocessableEntity, map[string]any{"message": err.Error()})
+		return
+	}
+
+	if err := h.UseCase.ResetPasswordWithOTP(r.Context(), payload.Email, payload.OTP, payload.NewPassword); err != nil {
+		util.JSONResponse(w, emailOTPErrorStatus(err), map[string]any{"message": "password reset failed"})
+		return
+	}
+
+	util.JSONResponse(w, http.StatusOK, map[string]any{"message": "Password has been reset successfully"})
+}
+
+func (h *EmailOTPResetPasswordHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
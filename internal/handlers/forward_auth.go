@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"net/http"
+
+	forwardauth "github.com/GoBetterAuth/go-better-auth/internal/auth/forward-auth"
+	"github.com/GoBetterAuth/go-better-auth/internal/common"
+	"github.com/GoBetterAuth/go-better-auth/internal/constants"
+	"github.com/GoBetterAuth/go-better-auth/internal/middleware"
+	"github.com/GoBetterAuth/go-better-auth/internal/util"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+// VerifyRequestHandler answers the subrequests of reverse proxies gating upstream applications.
+// Authentication is handled by the forward auth middleware; the user is passed upstream in headers.
+type VerifyRequestHandler struct {
+	Config  *models.Config
+	UseCase forwardauth.ForwardAuthUseCase
+}
+
+func (h *VerifyRequestHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	if !h.Config.ForwardAuth.Enabled {
+		util.JSONResponse(w, http.StatusNotImplemented, map[string]any{"message": constants.ErrForwardAuthDisabled.Error()})
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+
+	// Anonymous requests only get this far on public hosts
+	userID, ok := r.Context().Value(middleware.ContextUserID).(string)
+	if !ok || userID == "" {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	user, err := h.UseCase.VerifyRequest(r.Context(), userID)
+	if err != nil {
+		util.JSONResponse(w, http.StatusInternalServerError, map[string]any{"message": err.Error()})
+		return
+	}
+	if user == nil {
+		util.JSONResponse(w, http.StatusUnauthorized, map[string]any{"message": "unauthorized"})
+		return
+	}
+
+	w.Header().Set("X-Auth-User-Id", user.ID)
+	w.Header().Set("X-Auth-User-Email", user.Email)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *VerifyRequestHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/GoBetterAuth/go-better-auth/internal/auth/introspection"
+	"github.com/GoBetterAuth/go-better-auth/internal/common"
+	"github.com/GoBetterAuth/go-better-auth/internal/middleware"
+	"github.com/GoBetterAuth/go-better-auth/internal/util"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+// IntrospectHandler implements token introspection (RFC 7662) for resource servers and gateways.
+type IntrospectHandler struct {
+	Config  *models.Config
+	UseCase introspection.IntrospectionUseCase
+}
+
+func (h *IntrospectHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "no-store")
+
+	if err := r.ParseForm(); err != nil {
+		util.JSONResponse(w, http.StatusBadRequest, models.NewOAuthError("invalid_request", "invalid form body"))
+		return
+	}
+
+	token := r.PostForm.Get("token")
+	if token == "" {
+		util.JSONResponse(w, http.StatusBadRequest, models.NewOAuthError("invalid_request", "token is required"))
+		return
+	}
+
+	clientID, _ := r.Context().Value(middleware.ContextClientID).(string)
+	result, err := h.UseCase.Introspect(r.Context(), clientID, token, r.PostForm.Get("token_type_hint"))
+	if err != nil {
+		util.JSONResponse(w, http.StatusInternalServerError, models.NewOAuthError("server_error", err.Error()))
+		return
+	}
+
+	util.JSONResponse(w, http.StatusOK, result)
+}
+
+func (h *IntrospectHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// RevokeHandler implements token revocation (RFC 7009).
+type RevokeHandler struct {
+	Config  *models.Config
+	UseCase introspection.IntrospectionUseCase
+}
+
+func (h *RevokeHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		util.JSONResponse(w, http.StatusBadRequest, models.NewOAuthError("invalid_request", "invalid form body"))
+		return
+	}
+
+	token := r.PostForm.Get("token")
+	if token == "" {
+		util.JSONResponse(w, http.StatusBadRequest, models.NewOAuthError("invalid_request", "token is required"))
+		return
+	}
+
+	// Unknown and already revoked tokens are still answered with 200 OK
+	clientID, _ := r.Context().Value(middleware.ContextClientID).(string)
+	if err := h.UseCase.Revoke(r.Context(), clientID, token, r.PostForm.Get("token_type_hint")); err != nil {
+		util.JSONResponse(w, http.StatusServiceUnavailable, models.NewOAuthError("server_error", err.Error()))
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *RevokeHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
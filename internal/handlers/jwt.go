@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	jwt "github.com/GoBetterAuth/go-better-auth/internal/auth/jwt"
+	"github.com/GoBetterAuth/go-better-auth/internal/common"
+	"github.com/GoBetterAuth/go-better-auth/internal/constants"
+	"github.com/GoBetterAuth/go-better-auth/internal/middleware"
+	"github.com/GoBetterAuth/go-better-auth/internal/util"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+// JWKSHandler publishes the public keys JWT access tokens can be verified with.
+type JWKSHandler struct {
+	Config  *models.Config
+	UseCase jwt.JWTUseCase
+}
+
+func (h *JWKSHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	if !h.Config.JWT.Enabled && !h.Config.OIDCProvider.Enabled {
+		util.JSONResponse(w, http.StatusNotImplemented, map[string]any{"message": constants.ErrJWTDisabled.Error()})
+		return
+	}
+
+	result, err := h.UseCase.GetJWKS(r.Context())
+	if err != nil {
+		util.JSONResponse(w, http.StatusInternalServerError, map[string]any{"message": err.Error()})
+		return
+	}
+
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	util.JSONResponse(w, http.StatusOK, result)
+}
+
+func (h *JWKSHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// TokenHandler exchanges the current session for a short-lived JWT access token.
+type TokenHandler struct {
+	Config  *models.Config
+	UseCase jwt.JWTUseCase
+}
+
+func (h *TokenHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	if !h.Config.JWT.Enabled {
+		util.JSONResponse(w, http.StatusNotImplemented, map[string]any{"message": constants.ErrJWTDisabled.Error()})
+		return
+	}
+
+	userID, ok := r.Context().Value(middleware.ContextUserID).(string)
+	if !ok || userID == "" {
+		util.JSONResponse(w, http.StatusUnauthorized, map[string]any{"message": "unauthorized"})
+		return
+	}
+	sessionID, _ := r.Context().Value(middleware.ContextSessionID).(string)
+
+	result, err := h.UseCase.IssueToken(r.Context(), userID, sessionID)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, constants.ErrUserNotFound) || errors.Is(err, constants.ErrSessionNotFound) {
+			status = http.StatusUnauthorized
+		}
+		util.JSONResponse(w, status, map[string]any{"message": err.Error()})
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	util.JSONResponse(w, http.StatusOK, result)
+}
+
+func (h *TokenHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	magiclink "github.com/GoBetterAuth/go-better-auth/internal/auth/magic-link"
+	"github.com/GoBetterAuth/go-better-auth/internal/common"
+	"github.com/GoBetterAuth/go-better-auth/internal/constants"
+	"github.com/GoBetterAuth/go-better-auth/internal/util"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+type MagicLinkHandlerPayload struct {
+	Email       string  `json:"email" validate:"required,email"`
+	CallbackURL *string `json:"callback_url,omitempty"`
+}
+
+// MagicLinkHandler emails a sign in link. The link itself is handled by /verify-email.
+type MagicLinkHandler struct {
+	Config  *models.Config
+	UseCase magiclink.MagicLinkUseCase
+}
+
+func (h *MagicLinkHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	if !h.Config.MagicLink.Enabled {
+		util.JSONResponse(w, http.StatusNotImplemented, map[string]any{"message": constants.ErrMagicLinkDisabled.Error()})
+		return
+	}
+
+	var payload MagicLinkHandlerPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.JSONResponse(w, http.StatusBadRequest, map[string]any{"message": "invalid request"})
+		return
+	}
+	if err := util.Validate.Struct(payload); err != nil {
+		util.JSONResponse(w, http.StatusUnprocessableEntity, map[string]any{"message": err.Error()})
+		return
+	}
+
+	if err := h.UseCase.SendMagicLink(r.Context(), payload.Email, payload.CallbackURL); err != nil {
+		if errors.Is(err, constants.ErrUntrustedRedirect) {
+			util.JSONResponse(w, http.StatusBadRequest, map[string]any{"message": err.Error()})
+			return
+		}
+		util.JSONResponse(w, http.StatusInternalServerError, map[string]any{"message": "magic link request failed"})
+		return
+	}
+
+	util.JSONResponse(w, http.StatusOK, map[string]any{"message": "Magic link sent"})
+}
+
+func (h *MagicLinkHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
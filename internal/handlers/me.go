@@ -22,7 +22,9 @@ func (h *MeHandler) Handle(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	result, err := h.UseCase.GetMe(r.Context(), userID)
+	sessionID, _ := r.Context().Value(middleware.ContextSessionID).(string)
+
+	result, err := h.UseCase.GetMe(r.Context(), userID, sessionID)
 	if err != nil {
 		util.JSONResponse(w, http.StatusInternalServerError, map[string]any{"message": err.Error()})
 		return
@@ -1,11 +1,16 @@
 package handlers
 
 import (
+	"encoding/json"
+	"errors"
 	"net/http"
+	"strings"
 	"time"
 
 	internaloauth2 "github.com/GoBetterAuth/go-better-auth/internal/auth/oauth2"
 	"github.com/GoBetterAuth/go-better-auth/internal/common"
+	"github.com/GoBetterAuth/go-better-auth/internal/constants"
+	"github.com/GoBetterAuth/go-better-auth/internal/middleware"
 	"github.com/GoBetterAuth/go-better-auth/internal/util"
 	"github.com/GoBetterAuth/go-better-auth/models"
 )
@@ -23,14 +28,147 @@ func (h *OAuth2LoginHandler) Handle(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Use the usecase to prepare the OAuth2 login flow
-	// This generates the state, PKCE verifier if needed, and the authorization URL
-	loginResult, err := h.UseCase.PrepareOAuth2Login(r.Context(), providerName)
+	// This stores the state, PKCE verifier if needed and redirect target, and builds the authorization URL
+	loginResult, err := h.UseCase.PrepareOAuth2Login(r.Context(), providerName, getOAuth2LoginOptions(r))
 	if err != nil {
 		util.JSONResponse(w, http.StatusBadRequest, map[string]any{"message": err.Error()})
 		return
 	}
 
-	isSecure, sameSite := util.GetCookieOptions(h.Config)
+	startOAuth2Flow(w, r, h.Config, loginResult)
+}
+
+func (h *OAuth2LoginHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// OAuth2LinkHandler starts the OAuth2 flow to link a provider account to the signed in user.
+type OAuth2LinkHandler struct {
+	Config  *models.Config
+	UseCase internaloauth2.OAuth2UseCase
+}
+
+func (h *OAuth2LinkHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(middleware.ContextUserID).(string)
+	if !ok || userID == "" {
+		util.JSONResponse(w, http.StatusUnauthorized, map[string]any{"message": "unauthorized"})
+		return
+	}
+
+	providerName := util.ExtractProviderName(r.URL.Path)
+	if providerName == "" {
+		util.JSONResponse(w, http.StatusBadRequest, map[string]any{"message": "oauth2 provider is required"})
+		return
+	}
+
+	loginResult, err := h.UseCase.PrepareOAuth2Link(r.Context(), providerName, userID, getOAuth2LoginOptions(r))
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, constants.ErrAccountLinkingDisabled) {
+			status = http.StatusNotImplemented
+		}
+		util.JSONResponse(w, status, map[string]any{"message": err.Error()})
+		return
+	}
+
+	startOAuth2Flow(w, r, h.Config, loginResult)
+}
+
+func (h *OAuth2LinkHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// OAuth2ReauthorizeHandler starts the OAuth2 flow again for a provider the signed in user already linked,
+// to grant the additional scopes passed in the scope query parameter.
+type OAuth2ReauthorizeHandler struct {
+	Config  *models.Config
+	UseCase internaloauth2.OAuth2UseCase
+}
+
+func (h *OAuth2ReauthorizeHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(middleware.ContextUserID).(string)
+	if !ok || userID == "" {
+		util.JSONResponse(w, http.StatusUnauthorized, map[string]any{"message": "unauthorized"})
+		return
+	}
+
+	providerName := util.ExtractProviderName(r.URL.Path)
+	if providerName == "" {
+		util.JSONResponse(w, http.StatusBadRequest, map[string]any{"message": "oauth2 provider is required"})
+		return
+	}
+
+	loginResult, err := h.UseCase.PrepareOAuth2Reauthorize(r.Context(), providerName, userID, getOAuth2LoginOptions(r))
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, constants.ErrAccountNotFound) {
+			status = http.StatusNotFound
+		}
+		util.JSONResponse(w, status, map[string]any{"message": err.Error()})
+		return
+	}
+
+	startOAuth2Flow(w, r, h.Config, loginResult)
+}
+
+func (h *OAuth2ReauthorizeHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// OAuth2AccessTokenHandler returns an access token of the signed in user's linked account, for the app to
+// call the provider's APIs on their behalf.
+type OAuth2AccessTokenHandler struct {
+	Config  *models.Config
+	UseCase internaloauth2.OAuth2UseCase
+}
+
+func (h *OAuth2AccessTokenHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(middleware.ContextUserID).(string)
+	if !ok || userID == "" {
+		util.JSONResponse(w, http.StatusUnauthorized, map[string]any{"message": "unauthorized"})
+		return
+	}
+
+	providerName := util.ExtractProviderName(r.URL.Path)
+	if providerName == "" {
+		util.JSONResponse(w, http.StatusBadRequest, map[string]any{"message": "oauth2 provider is required"})
+		return
+	}
+
+	result, err := h.UseCase.GetAccessToken(r.Context(), userID, providerName)
+	if err != nil {
+		switch {
+		case errors.Is(err, constants.ErrAccountNotFound):
+			util.JSONResponse(w, http.StatusNotFound, map[string]any{"message": err.Error()})
+		case errors.Is(err, constants.ErrNoRefreshToken), errors.Is(err, constants.ErrOAuth2RefreshFailed):
+			// The user has to go through /oauth2/{provider}/reauthorize
+			util.JSONResponse(w, http.StatusConflict, map[string]any{"message": err.Error()})
+		default:
+			util.JSONResponse(w, http.StatusInternalServerError, map[string]any{"message": err.Error()})
+		}
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	util.JSONResponse(w, http.StatusOK, result)
+}
+
+func (h *OAuth2AccessTokenHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// getOAuth2LoginOptions reads the redirect target and additional space separated scopes of a flow.
+func getOAuth2LoginOptions(r *http.Request) models.OAuth2LoginOptions {
+	return models.OAuth2LoginOptions{
+		RedirectTo: r.URL.Query().Get("redirect_to"),
+		Scopes:     strings.Fields(r.URL.Query().Get("scope")),
+	}
+}
+
+// startOAuth2Flow redirects to the provider's authorization URL. The state of the flow is stored server side,
+// the cookie only binds it to this browser so that a callback started elsewhere cannot sign it in.
+func startOAuth2Flow(w http.ResponseWriter, r *http.Request, config *models.Config, loginResult *models.OAuth2LoginResult) {
+	isSecure, sameSite := util.GetCookieOptions(config)
 
 	// Set the OAuth2 state cookie for CSRF protection
 	http.SetCookie(w, &http.Cookie{
@@ -43,41 +181,10 @@ func (h *OAuth2LoginHandler) Handle(w http.ResponseWriter, r *http.Request) {
 		Expires:  time.Now().Add(10 * time.Minute),
 	})
 
-	// Set the PKCE verifier cookie if PKCE is required
-	if loginResult.Verifier != nil {
-		http.SetCookie(w, &http.Cookie{
-			Name:     "oauth2_verifier",
-			Value:    *loginResult.Verifier,
-			Path:     "/",
-			HttpOnly: true,
-			Secure:   isSecure,
-			SameSite: sameSite,
-			Expires:  time.Now().Add(10 * time.Minute),
-		})
-	}
-
-	// Set the redirect_to cookie if provided in the request
-	redirectTo := r.URL.Query().Get("redirect_to")
-	if redirectTo != "" {
-		http.SetCookie(w, &http.Cookie{
-			Name:     "oauth2_redirect_to",
-			Value:    redirectTo,
-			Path:     "/",
-			HttpOnly: true,
-			Secure:   isSecure,
-			SameSite: sameSite,
-			Expires:  time.Now().Add(10 * time.Minute),
-		})
-	}
-
 	// Redirect to the OAuth2 provider's authorization URL
 	http.Redirect(w, r, loginResult.AuthURL, http.StatusTemporaryRedirect)
 }
 
-func (h *OAuth2LoginHandler) Handler() models.CustomRouteHandler {
-	return common.WrapHandler(h)
-}
-
 type OAuth2CallbackHandler struct {
 	Config  *models.Config
 	UseCase internaloauth2.OAuth2UseCase
@@ -87,14 +194,15 @@ func (h *OAuth2CallbackHandler) Handle(w http.ResponseWriter, r *http.Request) {
 	providerName := util.ExtractProviderName(r.URL.Path)
 
 	// Extract the authorization code from the callback
-	code := r.URL.Query().Get("code")
+	// Providers using form_post, such as Apple, send the parameters in a POST body instead of the query
+	code := r.FormValue("code")
 	if code == "" {
 		util.JSONResponse(w, http.StatusBadRequest, map[string]any{"message": "missing code"})
 		return
 	}
 
 	// Extract the state from the callback for CSRF validation
-	state := r.URL.Query().Get("state")
+	state := r.FormValue("state")
 	if state == "" {
 		util.JSONResponse(w, http.StatusBadRequest, map[string]any{"message": "missing state"})
 		return
@@ -120,43 +228,137 @@ func (h *OAuth2CallbackHandler) Handle(w http.ResponseWriter, r *http.Request) {
 		SameSite: sameSite,
 	})
 
-	// Get the PKCE verifier from the cookie if it exists
-	var verifier *string
-	if verifierCookie, err := r.Cookie("oauth2_verifier"); err == nil && verifierCookie.Value != "" {
-		verifier = &verifierCookie.Value
+	// Use the usecase to handle the OAuth2 callback
+	// This consumes the stored state, exchanges the code for tokens, and creates/updates the user
+	result, err := h.UseCase.SignInWithOAuth2(r.Context(), providerName, code, state)
+	if err != nil {
+		status := http.StatusUnauthorized
+		if errors.Is(err, constants.ErrOAuth2StateInvalid) {
+			status = http.StatusBadRequest
+		} else if errors.Is(err, constants.ErrUserBanned) {
+			status = http.StatusForbidden
+		}
+		util.JSONResponse(w, status, map[string]any{"message": err.Error()})
+		return
+	}
 
-		// Clear the verifier cookie as it's been used
+	if result.TwoFactorRequired {
+		// Keep the challenge in a cookie so the client can complete the sign-in via /two-factor/verify
+		http.SetCookie(w, &http.Cookie{
+			Name:     twoFactorChallengeCookieName,
+			Value:    *result.ChallengeToken,
+			Path:     "/",
+			HttpOnly: true,
+			Secure:   isSecure,
+			SameSite: sameSite,
+			Expires:  time.Now().Add(h.Config.TwoFactor.ChallengeExpiresIn),
+		})
+	} else if !result.AccountLinked {
+		// Set the session cookie with the generated session token, linking keeps the current session
 		http.SetCookie(w, &http.Cookie{
-			Name:     "oauth2_verifier",
-			Value:    "",
+			Name:     h.Config.Session.CookieName,
+			Value:    result.Token,
 			Path:     "/",
-			MaxAge:   -1,
 			HttpOnly: true,
 			Secure:   isSecure,
 			SameSite: sameSite,
+			Expires:  time.Now().Add(h.Config.Session.ExpiresIn),
 		})
+
+		// Set the CSRF cookie if CSRF protection is enabled
+		if h.Config.CSRF.Enabled && result.CSRFToken != nil {
+			http.SetCookie(w, &http.Cookie{
+				Name:     h.Config.CSRF.CookieName,
+				Value:    *result.CSRFToken,
+				Path:     "/",
+				HttpOnly: false,
+				Secure:   isSecure,
+				SameSite: sameSite,
+				MaxAge:   int(h.Config.CSRF.ExpiresIn.Seconds()),
+			})
+		}
 	}
 
-	// Use the usecase to handle the OAuth2 callback
-	// This validates the state, exchanges the code for tokens, and creates/updates the user
-	result, err := h.UseCase.SignInWithOAuth2(r.Context(), providerName, code, state, verifier)
+	// Determine the redirect target, which was checked against the trusted origins when the flow started
+	target := "/"
+	if result.RedirectTo != "" {
+		target = result.RedirectTo
+	}
+
+	if result.TwoFactorRequired {
+		target = util.AppendQueryParam(target, "two_factor_required", "true")
+	}
+	if result.AccountLinked {
+		target = util.AppendQueryParam(target, "account_linked", "true")
+	}
+
+	// Redirect to the target URL
+	http.Redirect(w, r, target, http.StatusTemporaryRedirect)
+}
+
+func (h *OAuth2CallbackHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+type OAuth2IDTokenHandlerPayload struct {
+	IDToken string `json:"id_token" validate:"required"`
+	Nonce   string `json:"nonce"`
+}
+
+// OAuth2IDTokenHandler signs in native apps with an ID token obtained from the provider's SDK,
+// as they cannot follow the redirect based flow.
+type OAuth2IDTokenHandler struct {
+	Config  *models.Config
+	UseCase internaloauth2.OAuth2UseCase
+}
+
+func (h *OAuth2IDTokenHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	providerName := util.ExtractProviderName(r.URL.Path)
+	if providerName == "" {
+		util.JSONResponse(w, http.StatusBadRequest, map[string]any{"message": "oauth2 provider is required"})
+		return
+	}
+
+	var payload OAuth2IDTokenHandlerPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.JSONResponse(w, http.StatusBadRequest, map[string]any{"message": "invalid request"})
+		return
+	}
+	if err := util.Validate.Struct(payload); err != nil {
+		util.JSONResponse(w, http.StatusUnprocessableEntity, map[string]any{"message": "validation failed"})
+		return
+	}
+
+	result, err := h.UseCase.SignInWithIDToken(r.Context(), providerName, payload.IDToken, payload.Nonce)
 	if err != nil {
-		util.JSONResponse(w, http.StatusUnauthorized, map[string]any{"message": err.Error()})
+		status := http.StatusUnauthorized
+		if errors.Is(err, constants.ErrOAuth2IDTokenNotSupported) {
+			status = http.StatusBadRequest
+		} else if errors.Is(err, constants.ErrUserBanned) {
+			status = http.StatusForbidden
+		}
+		util.JSONResponse(w, status, map[string]any{"message": err.Error()})
+		return
+	}
+
+	// No session exists until the two factor challenge has been completed
+	if result.TwoFactorRequired {
+		util.JSONResponse(w, http.StatusOK, result)
 		return
 	}
 
-	// Set the session cookie with the generated session token
+	isSecure, sameSite := util.GetCookieOptions(h.Config)
+
 	http.SetCookie(w, &http.Cookie{
 		Name:     h.Config.Session.CookieName,
 		Value:    result.Token,
 		Path:     "/",
 		HttpOnly: true,
-		Secure:   isSecure,
+		MaxAge:   int(h.Config.Session.ExpiresIn.Seconds()),
 		SameSite: sameSite,
-		Expires:  time.Now().Add(h.Config.Session.ExpiresIn),
+		Secure:   isSecure,
 	})
 
-	// Set the CSRF cookie if CSRF protection is enabled
 	if h.Config.CSRF.Enabled && result.CSRFToken != nil {
 		http.SetCookie(w, &http.Cookie{
 			Name:     h.Config.CSRF.CookieName,
@@ -169,31 +371,9 @@ func (h *OAuth2CallbackHandler) Handle(w http.ResponseWriter, r *http.Request) {
 		})
 	}
 
-	// Determine the redirect target
-	target := "/"
-	if cookie, err := r.Cookie("oauth2_redirect_to"); err == nil && cookie.Value != "" {
-		redirectTo := cookie.Value
-		// Only redirect to trusted origins for security
-		if util.IsTrustedRedirect(redirectTo, h.Config.TrustedOrigins.Origins) {
-			target = redirectTo
-		}
-	}
-
-	// Clear the redirect_to cookie as it's been used
-	http.SetCookie(w, &http.Cookie{
-		Name:     "oauth2_redirect_to",
-		Value:    "",
-		Path:     "/",
-		MaxAge:   -1,
-		HttpOnly: true,
-		Secure:   isSecure,
-		SameSite: sameSite,
-	})
-
-	// Redirect to the target URL
-	http.Redirect(w, r, target, http.StatusTemporaryRedirect)
+	util.JSONResponse(w, http.StatusOK, result)
 }
 
-func (h *OAuth2CallbackHandler) Handler() models.CustomRouteHandler {
+func (h *OAuth2IDTokenHandler) Handler() models.CustomRouteHandler {
 	return common.WrapHandler(h)
 }
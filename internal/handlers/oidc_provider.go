@@ -0,0 +1,262 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+
+	oidcprovider "github.com/GoBetterAuth/go-better-auth/internal/auth/oidc-provider"
+	"github.com/GoBetterAuth/go-better-auth/internal/common"
+	"github.com/GoBetterAuth/go-better-auth/internal/constants"
+	"github.com/GoBetterAuth/go-better-auth/internal/middleware"
+	"github.com/GoBetterAuth/go-better-auth/internal/util"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+type OIDCConsentPayload struct {
+	ConsentCode string `json:"consent_code" validate:"required"`
+	Accept      bool   `json:"accept"`
+}
+
+// OIDCDiscoveryHandler serves the OpenID Connect provider metadata.
+type OIDCDiscoveryHandler struct {
+	Config  *models.Config
+	UseCase oidcprovider.OIDCProviderUseCase
+}
+
+func (h *OIDCDiscoveryHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	if !h.Config.OIDCProvider.Enabled {
+		util.JSONResponse(w, http.StatusNotImplemented, map[string]any{"message": constants.ErrOIDCProviderDisabled.Error()})
+		return
+	}
+
+	result, err := h.UseCase.GetDiscoveryDocument(r.Context())
+	if err != nil {
+		util.JSONResponse(w, http.StatusInternalServerError, map[string]any{"message": err.Error()})
+		return
+	}
+
+	util.JSONResponse(w, http.StatusOK, result)
+}
+
+func (h *OIDCDiscoveryHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// OIDCRegisterClientHandler registers an application with the OpenID Connect provider.
+type OIDCRegisterClientHandler struct {
+	Config  *models.Config
+	UseCase oidcprovider.OIDCProviderUseCase
+}
+
+func (h *OIDCRegisterClientHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	if !h.Config.OIDCProvider.Enabled {
+		util.JSONResponse(w, http.StatusNotImplemented, map[string]any{"message": constants.ErrOIDCProviderDisabled.Error()})
+		return
+	}
+
+	var payload models.OAuthClientRegistration
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.JSONResponse(w, http.StatusBadRequest, map[string]any{"message": "invalid request"})
+		return
+	}
+	if err := util.Validate.Struct(payload); err != nil {
+		util.JSONResponse(w, http.StatusUnprocessableEntity, map[string]any{"message": "validation failed"})
+		return
+	}
+
+	result, err := h.UseCase.RegisterClient(r.Context(), payload)
+	if err != nil {
+		var oauthErr *models.OAuthError
+		if errors.As(err, &oauthErr) {
+			util.JSONResponse(w, http.StatusBadRequest, oauthErr)
+			return
+		}
+		util.JSONResponse(w, http.StatusInternalServerError, map[string]any{"message": err.Error()})
+		return
+	}
+
+	util.JSONResponse(w, http.StatusCreated, result)
+}
+
+func (h *OIDCRegisterClientHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// OIDCAuthorizeHandler starts the authorization code flow and redirects the browser to the next step.
+type OIDCAuthorizeHandler struct {
+	Config  *models.Config
+	UseCase oidcprovider.OIDCProviderUseCase
+}
+
+func (h *OIDCAuthorizeHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	if !h.Config.OIDCProvider.Enabled {
+		util.JSONResponse(w, http.StatusNotImplemented, map[string]any{"message": constants.ErrOIDCProviderDisabled.Error()})
+		return
+	}
+
+	// Signed out users are sent to the login page and come back here afterwards
+	userID, _ := r.Context().Value(middleware.ContextUserID).(string)
+	sessionID, _ := r.Context().Value(middleware.ContextSessionID).(string)
+
+	query := r.URL.Query()
+	request := models.OIDCAuthorizeRequest{
+		ResponseType:        query.Get("response_type"),
+		ClientID:            query.Get("client_id"),
+		RedirectURI:         query.Get("redirect_uri"),
+		Scope:               query.Get("scope"),
+		State:               query.Get("state"),
+		Nonce:               query.Get("nonce"),
+		CodeChallenge:       query.Get("code_challenge"),
+		CodeChallengeMethod: query.Get("code_challenge_method"),
+	}
+
+	result, err := h.UseCase.Authorize(r.Context(), userID, sessionID, request)
+	if err != nil {
+		var oauthErr *models.OAuthError
+		if errors.As(err, &oauthErr) {
+			util.JSONResponse(w, http.StatusBadRequest, oauthErr)
+			return
+		}
+		util.JSONResponse(w, http.StatusInternalServerError, map[string]any{"message": err.Error()})
+		return
+	}
+
+	http.Redirect(w, r, result.RedirectURL, http.StatusFound)
+}
+
+func (h *OIDCAuthorizeHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// OIDCConsentHandler records the decision made on the consent page.
+type OIDCConsentHandler struct {
+	Config  *models.Config
+	UseCase oidcprovider.OIDCProviderUseCase
+}
+
+func (h *OIDCConsentHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	if !h.Config.OIDCProvider.Enabled {
+		util.JSONResponse(w, http.StatusNotImplemented, map[string]any{"message": constants.ErrOIDCProviderDisabled.Error()})
+		return
+	}
+
+	userID, ok := r.Context().Value(middleware.ContextUserID).(string)
+	if !ok || userID == "" {
+		util.JSONResponse(w, http.StatusUnauthorized, map[string]any{"message": "unauthorized"})
+		return
+	}
+
+	var payload OIDCConsentPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.JSONResponse(w, http.StatusBadRequest, map[string]any{"message": "invalid request"})
+		return
+	}
+	if err := util.Validate.Struct(payload); err != nil {
+		util.JSONResponse(w, http.StatusUnprocessableEntity, map[string]any{"message": "validation failed"})
+		return
+	}
+
+	result, err := h.UseCase.Consent(r.Context(), userID, payload.ConsentCode, payload.Accept)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, constants.ErrOIDCConsentInvalid) {
+			status = http.StatusBadRequest
+		}
+		util.JSONResponse(w, status, map[string]any{"message": err.Error()})
+		return
+	}
+
+	util.JSONResponse(w, http.StatusOK, result)
+}
+
+func (h *OIDCConsentHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// OIDCTokenHandler redeems authorization codes. Requests are form encoded as required by RFC 6749.
+type OIDCTokenHandler struct {
+	Config  *models.Config
+	UseCase oidcprovider.OIDCProviderUseCase
+}
+
+func (h *OIDCTokenHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	if !h.Config.OIDCProvider.Enabled {
+		util.JSONResponse(w, http.StatusNotImplemented, map[string]any{"message": constants.ErrOIDCProviderDisabled.Error()})
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+
+	if err := r.ParseForm(); err != nil {
+		util.JSONResponse(w, http.StatusBadRequest, models.NewOAuthError("invalid_request", "invalid form body"))
+		return
+	}
+
+	request := models.OIDCTokenRequest{
+		GrantType:    r.PostForm.Get("grant_type"),
+		Code:         r.PostForm.Get("code"),
+		RedirectURI:  r.PostForm.Get("redirect_uri"),
+		ClientID:     r.PostForm.Get("client_id"),
+		ClientSecret: r.PostForm.Get("client_secret"),
+		CodeVerifier: r.PostForm.Get("code_verifier"),
+	}
+	// client_secret_basic credentials are form encoded before being base64 encoded
+	if clientID, clientSecret, ok := r.BasicAuth(); ok {
+		request.ClientID, _ = url.QueryUnescape(clientID)
+		request.ClientSecret, _ = url.QueryUnescape(clientSecret)
+	}
+
+	result, err := h.UseCase.ExchangeToken(r.Context(), request)
+	if err != nil {
+		var oauthErr *models.OAuthError
+		if errors.As(err, &oauthErr) {
+			status := http.StatusBadRequest
+			if oauthErr.Code == "invalid_client" {
+				status = http.StatusUnauthorized
+				w.Header().Set("WWW-Authenticate", `Basic realm="oidc"`)
+			}
+			util.JSONResponse(w, status, oauthErr)
+			return
+		}
+		util.JSONResponse(w, http.StatusInternalServerError, models.NewOAuthError("server_error", err.Error()))
+		return
+	}
+
+	util.JSONResponse(w, http.StatusOK, result)
+}
+
+func (h *OIDCTokenHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// OIDCUserInfoHandler returns the claims of the user an access token was issued for.
+type OIDCUserInfoHandler struct {
+	Config  *models.Config
+	UseCase oidcprovider.OIDCProviderUseCase
+}
+
+func (h *OIDCUserInfoHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	if !h.Config.OIDCProvider.Enabled {
+		util.JSONResponse(w, http.StatusNotImplemented, map[string]any{"message": constants.ErrOIDCProviderDisabled.Error()})
+		return
+	}
+
+	result, err := h.UseCase.GetUserInfo(r.Context(), util.GetBearerToken(r))
+	if err != nil {
+		if errors.Is(err, constants.ErrInvalidToken) {
+			w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+			util.JSONResponse(w, http.StatusUnauthorized, models.NewOAuthError("invalid_token", err.Error()))
+			return
+		}
+		util.JSONResponse(w, http.StatusInternalServerError, map[string]any{"message": err.Error()})
+		return
+	}
+
+	util.JSONResponse(w, http.StatusOK, result)
+}
+
+func (h *OIDCUserInfoHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
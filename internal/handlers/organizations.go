@@ -0,0 +1,351 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	organization "github.com/GoBetterAuth/go-better-auth/internal/auth/organization"
+	"github.com/GoBetterAuth/go-better-auth/internal/common"
+	"github.com/GoBetterAuth/go-better-auth/internal/constants"
+	"github.com/GoBetterAuth/go-better-auth/internal/middleware"
+	"github.com/GoBetterAuth/go-better-auth/internal/util"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+// organizationErrorStatus maps the errors of the organization use case to a response status.
+func organizationErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, constants.ErrOrganizationsDisabled), errors.Is(err, constants.ErrInvitationPageMissing):
+		return http.StatusNotImplemented
+	case errors.Is(err, constants.ErrOrganizationNotFound), errors.Is(err, constants.ErrInvitationNotFound),
+		errors.Is(err, constants.ErrSessionNotFound), errors.Is(err, constants.ErrUserNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, constants.ErrNotOrganizationMember), errors.Is(err, constants.ErrOrganizationForbidden),
+		errors.Is(err, constants.ErrUserBanned), errors.Is(err, constants.ErrInvitationEmailMismatch):
+		return http.StatusForbidden
+	case errors.Is(err, constants.ErrOrganizationSlugTaken), errors.Is(err, constants.ErrAlreadyOrganizationMember),
+		errors.Is(err, constants.ErrInvitationAlreadyExists), errors.Is(err, constants.ErrLastOrganizationOwner):
+		return http.StatusConflict
+	case errors.Is(err, constants.ErrInvitationExpired):
+		return http.StatusGone
+	case errors.Is(err, constants.ErrOrganizationSlugInvalid), errors.Is(err, constants.ErrUntrustedRedirect),
+		errors.Is(err, constants.ErrInvitationRoleInvalid), errors.Is(err, constants.ErrOrganizationRoleInvalid):
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// CreateOrganizationHandler creates an organization owned by the signed in user.
+type CreateOrganizationHandler struct {
+	Config  *models.Config
+	UseCase organization.OrganizationUseCase
+}
+
+func (h *CreateOrganizationHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(middleware.ContextUserID).(string)
+	if !ok || userID == "" {
+		util.JSONResponse(w, http.StatusUnauthorized, map[string]any{"message": "unauthorized"})
+		return
+	}
+	sessionID, _ := r.Context().Value(middleware.ContextSessionID).(string)
+
+	var payload models.CreateOrganizationRequest
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.JSONResponse(w, http.StatusBadRequest, map[string]any{"message": "invalid request"})
+		return
+	}
+	if err := util.Validate.Struct(payload); err != nil {
+		util.JSONResponse(w, http.StatusUnprocessableEntity, map[string]any{"message": err.Error()})
+		return
+	}
+
+	result, err := h.UseCase.CreateOrganization(r.Context(), userID, sessionID, payload)
+	if err != nil {
+		util.JSONResponse(w, organizationErrorStatus(err), map[string]any{"message": err.Error()})
+		return
+	}
+
+	util.JSONResponse(w, http.StatusCreated, map[string]any{"organization": result})
+}
+
+func (h *CreateOrganizationHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// ListOrganizationsHandler returns the organizations of the signed in user.
+type ListOrganizationsHandler struct {
+	Config  *models.Config
+	UseCase organization.OrganizationUseCase
+}
+
+func (h *ListOrganizationsHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(middleware.ContextUserID).(string)
+	if !ok || userID == "" {
+		util.JSONResponse(w, http.StatusUnauthorized, map[string]any{"message": "unauthorized"})
+		return
+	}
+
+	result, err := h.UseCase.ListOrganizations(r.Context(), userID)
+	if err != nil {
+		util.JSONResponse(w, organizationErrorStatus(err), map[string]any{"message": err.Error()})
+		return
+	}
+
+	util.JSONResponse(w, http.StatusOK, map[string]any{"organizations": result})
+}
+
+func (h *ListOrganizationsHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// SetActiveOrganizationHandler switches the organization the current session works in.
+type SetActiveOrganizationHandler struct {
+	Config  *models.Config
+	UseCase organization.OrganizationUseCase
+}
+
+func (h *SetActiveOrganizationHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(middleware.ContextUserID).(string)
+	if !ok || userID == "" {
+		util.JSONResponse(w, http.StatusUnauthorized, map[string]any{"message": "unauthorized"})
+		return
+	}
+	sessionID, _ := r.Context().Value(middleware.ContextSessionID).(string)
+
+	var payload models.SetActiveOrganizationRequest
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.JSONResponse(w, http.StatusBadRequest, map[string]any{"message": "invalid request"})
+		return
+	}
+
+	session, err := h.UseCase.SetActiveOrganization(r.Context(), userID, sessionID, payload.OrganizationID)
+	if err != nil {
+		util.JSONResponse(w, organizationErrorStatus(err), map[string]any{"message": err.Error()})
+		return
+	}
+
+	util.JSONResponse(w, http.StatusOK, map[string]any{"session": session})
+}
+
+func (h *SetActiveOrganizationHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// ListOrganizationMembersHandler returns the members of an organization of the signed in user.
+type ListOrganizationMembersHandler struct {
+	Config  *models.Config
+	UseCase organization.OrganizationUseCase
+}
+
+func (h *ListOrganizationMembersHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(middleware.ContextUserID).(string)
+	if !ok || userID == "" {
+		util.JSONResponse(w, http.StatusUnauthorized, map[string]any{"message": "unauthorized"})
+		return
+	}
+
+	result, err := h.UseCase.ListMembers(r.Context(), userID, r.PathValue("organizationId"))
+	if err != nil {
+		util.JSONResponse(w, organizationErrorStatus(err), map[string]any{"message": err.Error()})
+		return
+	}
+
+	util.JSONResponse(w, http.StatusOK, map[string]any{"members": result})
+}
+
+func (h *ListOrganizationMembersHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// DeleteOrganizationHandler deletes an organization owned by the signed in user.
+type DeleteOrganizationHandler struct {
+	Config  *models.Config
+	UseCase organization.OrganizationUseCase
+}
+
+func (h *DeleteOrganizationHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(middleware.ContextUserID).(string)
+	if !ok || userID == "" {
+		util.JSONResponse(w, http.StatusUnauthorized, map[string]any{"message": "unauthorized"})
+		return
+	}
+
+	if err := h.UseCase.DeleteOrganization(r.Context(), userID, r.PathValue("organizationId")); err != nil {
+		util.JSONResponse(w, organizationErrorStatus(err), map[string]any{"message": err.Error()})
+		return
+	}
+
+	util.JSONResponse(w, http.StatusOK, map[string]any{"message": "Organization deleted"})
+}
+
+func (h *DeleteOrganizationHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// UpdateOrganizationMemberHandler changes the role of a member of an organization.
+type UpdateOrganizationMemberHandler struct {
+	Config  *models.Config
+	UseCase organization.OrganizationUseCase
+}
+
+func (h *UpdateOrganizationMemberHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(middleware.ContextUserID).(string)
+	if !ok || userID == "" {
+		util.JSONResponse(w, http.StatusUnauthorized, map[string]any{"message": "unauthorized"})
+		return
+	}
+
+	var payload models.UpdateMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.JSONResponse(w, http.StatusBadRequest, map[string]any{"message": "invalid request"})
+		return
+	}
+	if err := util.Validate.Struct(payload); err != nil {
+		util.JSONResponse(w, http.StatusUnprocessableEntity, map[string]any{"message": err.Error()})
+		return
+	}
+
+	member, err := h.UseCase.UpdateMemberRole(r.Context(), userID, r.PathValue("organizationId"), r.PathValue("userId"), payload.Role)
+	if err != nil {
+		util.JSONResponse(w, organizationErrorStatus(err), map[string]any{"message": err.Error()})
+		return
+	}
+
+	util.JSONResponse(w, http.StatusOK, map[string]any{"member": member})
+}
+
+func (h *UpdateOrganizationMemberHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// RemoveOrganizationMemberHandler removes a member from an organization, or lets the signed in user leave it.
+type RemoveOrganizationMemberHandler struct {
+	Config  *models.Config
+	UseCase organization.OrganizationUseCase
+}
+
+func (h *RemoveOrganizationMemberHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(middleware.ContextUserID).(string)
+	if !ok || userID == "" {
+		util.JSONResponse(w, http.StatusUnauthorized, map[string]any{"message": "unauthorized"})
+		return
+	}
+
+	if err := h.UseCase.RemoveMember(r.Context(), userID, r.PathValue("organizationId"), r.PathValue("userId")); err != nil {
+		util.JSONResponse(w, organizationErrorStatus(err), map[string]any{"message": err.Error()})
+		return
+	}
+
+	util.JSONResponse(w, http.StatusOK, map[string]any{"message": "Member removed"})
+}
+
+func (h *RemoveOrganizationMemberHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// CreateOrganizationInvitationHandler invites an email address to an organization.
+type CreateOrganizationInvitationHandler struct {
+	Config  *models.Config
+	UseCase organization.OrganizationUseCase
+}
+
+func (h *CreateOrganizationInvitationHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(middleware.ContextUserID).(string)
+	if !ok || userID == "" {
+		util.JSONResponse(w, http.StatusUnauthorized, map[string]any{"message": "unauthorized"})
+		return
+	}
+
+	var payload models.CreateInvitationRequest
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.JSONResponse(w, http.StatusBadRequest, map[string]any{"message": "invalid request"})
+		return
+	}
+	if err := util.Validate.Struct(payload); err != nil {
+		util.JSONResponse(w, http.StatusUnprocessableEntity, map[string]any{"message": err.Error()})
+		return
+	}
+
+	invitation, err := h.UseCase.CreateInvitation(r.Context(), userID, r.PathValue("organizationId"), payload)
+	if err != nil {
+		util.JSONResponse(w, organizationErrorStatus(err), map[string]any{"message": err.Error()})
+		return
+	}
+
+	util.JSONResponse(w, http.StatusCreated, map[string]any{"invitation": invitation})
+}
+
+func (h *CreateOrganizationInvitationHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// ListOrganizationInvitationsHandler returns the pending invitations of an organization.
+type ListOrganizationInvitationsHandler struct {
+	Config  *models.Config
+	UseCase organization.OrganizationUseCase
+}
+
+func (h *ListOrganizationInvitationsHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(middleware.ContextUserID).(string)
+	if !ok || userID == "" {
+		util.JSONResponse(w, http.StatusUnauthorized, map[string]any{"message": "unauthorized"})
+		return
+	}
+
+	result, err := h.UseCase.ListInvitations(r.Context(), userID, r.PathValue("organizationId"))
+	if err != nil {
+		util.JSONResponse(w, organizationErrorStatus(err), map[string]any{"message": err.Error()})
+		return
+	}
+
+	util.JSONResponse(w, http.StatusOK, map[string]any{"invitations": result})
+}
+
+func (h *ListOrganizationInvitationsHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// AnswerOrganizationInvitationHandler accepts or declines an invitation on behalf of the signed in invitee,
+// posted by the invitation page with the emailed token.
+type AnswerOrganizationInvitationHandler struct {
+	Config  *models.Config
+	UseCase organization.OrganizationUseCase
+	Accept  bool
+}
+
+func (h *AnswerOrganizationInvitationHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(middleware.ContextUserID).(string)
+	if !ok || userID == "" {
+		util.JSONResponse(w, http.StatusUnauthorized, map[string]any{"message": "unauthorized"})
+		return
+	}
+
+	var payload models.AnswerInvitationRequest
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.JSONResponse(w, http.StatusBadRequest, map[string]any{"message": "invalid request"})
+		return
+	}
+	if err := util.Validate.Struct(payload); err != nil {
+		util.JSONResponse(w, http.StatusUnprocessableEntity, map[string]any{"message": err.Error()})
+		return
+	}
+
+	answer := h.UseCase.DeclineInvitation
+	if h.Accept {
+		answer = h.UseCase.AcceptInvitation
+	}
+
+	result, err := answer(r.Context(), userID, payload.Token)
+	if err != nil {
+		util.JSONResponse(w, organizationErrorStatus(err), map[string]any{"message": err.Error()})
+		return
+	}
+
+	util.JSONResponse(w, http.StatusOK, result)
+}
+
+func (h *AnswerOrganizationInvitationHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
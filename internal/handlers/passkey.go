@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	passkey "github.com/GoBetterAuth/go-better-auth/internal/auth/passkey"
+	"github.com/GoBetterAuth/go-better-auth/internal/common"
+	"github.com/GoBetterAuth/go-better-auth/internal/constants"
+	"github.com/GoBetterAuth/go-better-auth/internal/middleware"
+	"github.com/GoBetterAuth/go-better-auth/internal/util"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+type PasskeyRegisterFinishPayload struct {
+	Name       string                               `json:"name,omitempty"`
+	Credential models.PasskeyRegistrationCredential `json:"credential" validate:"required"`
+}
+
+type PasskeyAuthenticateBeginPayload struct {
+	Email *string `json:"email,omitempty" validate:"omitempty,email"`
+}
+
+// PasskeyRegisterBeginHandler returns the creation options for registering a passkey.
+type PasskeyRegisterBeginHandler struct {
+	Config  *models.Config
+	UseCase passkey.PasskeyUseCase
+}
+
+func (h *PasskeyRegisterBeginHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	if !h.Config.Passkey.Enabled {
+		util.JSONResponse(w, http.StatusNotImplemented, map[string]any{"message": constants.ErrPasskeyDisabled.Error()})
+		return
+	}
+
+	userID, ok := r.Context().Value(middleware.ContextUserID).(string)
+	if !ok || userID == "" {
+		util.JSONResponse(w, http.StatusUnauthorized, map[string]any{"message": "unauthorized"})
+		return
+	}
+
+	options, err := h.UseCase.BeginPasskeyRegistration(r.Context(), userID)
+	if err != nil {
+		util.JSONResponse(w, http.StatusBadRequest, map[string]any{"message": err.Error()})
+		return
+	}
+
+	util.JSONResponse(w, http.StatusOK, options)
+}
+
+func (h *PasskeyRegisterBeginHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// PasskeyRegisterFinishHandler verifies the authenticator response and stores the passkey.
+type PasskeyRegisterFinishHandler struct {
+	Config  *models.Config
+	UseCase passkey.PasskeyUseCase
+}
+
+func (h *PasskeyRegisterFinishHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	if !h.Config.Passkey.Enabled {
+		util.JSONResponse(w, http.StatusNotImplemented, map[string]any{"message": constants.ErrPasskeyDisabled.Error()})
+		return
+	}
+
+	userID, ok := r.Context().Value(middleware.ContextUserID).(string)
+	if !ok || userID == "" {
+		util.JSONResponse(w, http.StatusUnauthorized, map[string]any{"message": "unauthorized"})
+		return
+	}
+
+	var payload PasskeyRegisterFinishPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.JSONResponse(w, http.StatusBadRequest, map[string]any{"message": "invalid request"})
+		return
+	}
+	if err := util.Validate.Struct(payload); err != nil {
+		util.JSONResponse(w, http.StatusUnprocessableEntity, map[string]any{"message": "validation failed"})
+		return
+	}
+
+	result, err := h.UseCase.FinishPasskeyRegistration(r.Context(), userID, payload.Name, payload.Credential)
+	if err != nil {
+		status := http.StatusBadRequest
+		if errors.Is(err, constants.ErrPasskeyAlreadyRegistered) {
+			status = http.StatusConflict
+		}
+		util.JSONResponse(w, status, map[string]any{"message": err.Error()})
+		return
+	}
+
+	util.JSONResponse(w, http.StatusOK, result)
+}
+
+func (h *PasskeyRegisterFinishHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// PasskeyAuthenticateBeginHandler returns the request options for signing in with a passkey.
+type PasskeyAuthenticateBeginHandler struct {
+	Config  *models.Config
+	UseCase passkey.PasskeyUseCase
+}
+
+func (h *PasskeyAuthenticateBeginHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	if !h.Config.Passkey.Enabled {
+		util.JSONResponse(w, http.StatusNotImplemented, map[string]any{"message": constants.ErrPasskeyDisabled.Error()})
+		return
+	}
+
+	// The body is optional, discoverable credentials do not need an email
+	var payload PasskeyAuthenticateBeginPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil && !errors.Is(err, io.EOF) {
+		util.JSONResponse(w, http.StatusBadRequest, map[string]any{"message": "invalid request"})
+		return
+	}
+	if err := util.Validate.Struct(payload); err != nil {
+		util.JSONResponse(w, http.StatusUnprocessableEntity, map[string]any{"message": "validation failed"})
+		return
+	}
+
+	options, err := h.UseCase.BeginPasskeyAuthentication(r.Context(), payload.Email)
+	if err != nil {
+		util.JSONResponse(w, http.StatusBadRequest, map[string]any{"message": err.Error()})
+		return
+	}
+
+	util.JSONResponse(w, http.StatusOK, options)
+}
+
+func (h *PasskeyAuthenticateBeginHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// PasskeyAuthenticateFinishHandler verifies the assertion and signs the user in.
+type PasskeyAuthenticateFinishHandler struct {
+	Config  *models.Config
+	UseCase passkey.PasskeyUseCase
+}
+
+func (h *PasskeyAuthenticateFinishHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	if !h.Config.Passkey.Enabled {
+		util.JSONResponse(w, http.StatusNotImplemented, map[string]any{"message": constants.ErrPasskeyDisabled.Error()})
+		return
+	}
+
+	var payload models.PasskeyAuthenticationCredential
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.JSONResponse(w, http.StatusBadRequest, map[string]any{"message": "invalid request"})
+		return
+	}
+	if err := util.Validate.Struct(payload); err != nil {
+		util.JSONResponse(w, http.StatusUnprocessableEntity, map[string]any{"message": "validation failed"})
+		return
+	}
+
+	result, err := h.UseCase.FinishPasskeyAuthentication(r.Context(), payload)
+	if err != nil {
+		util.JSONResponse(w, http.StatusUnauthorized, map[string]any{"message": err.Error()})
+		return
+	}
+
+	isSecure, sameSite := util.GetCookieOptions(h.Config)
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     h.Config.Session.CookieName,
+		Value:    result.Token,
+		Path:     "/",
+		HttpOnly: true,
+		MaxAge:   int(h.Config.Session.ExpiresIn.Seconds()),
+		SameSite: sameSite,
+		Secure:   isSecure,
+	})
+
+	if h.Config.CSRF.Enabled && result.CSRFToken != nil {
+		http.SetCookie(w, &http.Cookie{
+			Name:     h.Config.CSRF.CookieName,
+			Value:    *result.CSRFToken,
+			Path:     "/",
+			HttpOnly: false,
+			Secure:   isSecure,
+			SameSite: sameSite,
+			MaxAge:   int(h.Config.CSRF.ExpiresIn.Seconds()),
+		})
+	}
+
+	util.JSONResponse(w, http.StatusOK, result)
+}
+
+func (h *PasskeyAuthenticateFinishHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
@@ -20,6 +20,18 @@ func GetRoutes(config *models.Config, authService *auth.Service, basePath string
 		Config:  config,
 		UseCase: useCases.SignOutUseCase,
 	}
+	listSessions := &ListSessionsHandler{
+		Config:  config,
+		UseCase: useCases.SessionsUseCase,
+	}
+	revokeSession := &RevokeSessionHandler{
+		Config:  config,
+		UseCase: useCases.SessionsUseCase,
+	}
+	revokeOtherSessions := &RevokeOtherSessionsHandler{
+		Config:  config,
+		UseCase: useCases.SessionsUseCase,
+	}
 	sendEmailVerification := &SendEmailVerificationHandler{
 		Config:  config,
 		UseCase: useCases.SendEmailVerificationUseCase,
@@ -32,6 +44,26 @@ func GetRoutes(config *models.Config, authService *auth.Service, basePath string
 		Config:  config,
 		UseCase: useCases.ResetPasswordUseCase,
 	}
+	magicLink := &MagicLinkHandler{
+		Config:  config,
+		UseCase: useCases.MagicLinkUseCase,
+	}
+	emailOTPSend := &EmailOTPSendHandler{
+		Config:  config,
+		UseCase: useCases.EmailOTPUseCase,
+	}
+	emailOTPSignIn := &EmailOTPSignInHandler{
+		Config:  config,
+		UseCase: useCases.EmailOTPUseCase,
+	}
+	emailOTPVerifyEmail := &EmailOTPVerifyEmailHandler{
+		Config:  config,
+		UseCase: useCases.EmailOTPUseCase,
+	}
+	emailOTPResetPassword := &EmailOTPResetPasswordHandler{
+		Config:  config,
+		UseCase: useCases.EmailOTPUseCase,
+	}
 	changePassword := &ChangePasswordHandler{
 		Config:  config,
 		UseCase: useCases.ChangePasswordUseCase,
@@ -52,8 +84,157 @@ func GetRoutes(config *models.Config, authService *auth.Service, basePath string
 		Config:  config,
 		UseCase: useCases.OAuth2UseCase,
 	}
+	oauth2IDToken := &OAuth2IDTokenHandler{
+		Config:  config,
+		UseCase: useCases.OAuth2UseCase,
+	}
+	oauth2Link := &OAuth2LinkHandler{
+		Config:  config,
+		UseCase: useCases.OAuth2UseCase,
+	}
+	oauth2Reauthorize := &OAuth2ReauthorizeHandler{
+		Config:  config,
+		UseCase: useCases.OAuth2UseCase,
+	}
+	oauth2AccessToken := &OAuth2AccessTokenHandler{
+		Config:  config,
+		UseCase: useCases.OAuth2UseCase,
+	}
+	listAccounts := &ListAccountsHandler{
+		Config:  config,
+		UseCase: useCases.AccountsUseCase,
+	}
+	unlinkAccount := &UnlinkAccountHandler{
+		Config:  config,
+		UseCase: useCases.AccountsUseCase,
+	}
+	twoFactorEnroll := &TwoFactorEnrollHandler{
+		Config:  config,
+		UseCase: useCases.TwoFactorUseCase,
+	}
+	twoFactorEnable := &TwoFactorEnableHandler{
+		Config:  config,
+		UseCase: useCases.TwoFactorUseCase,
+	}
+	twoFactorDisable := &TwoFactorDisableHandler{
+		Config:  config,
+		UseCase: useCases.TwoFactorUseCase,
+	}
+	twoFactorVerify := &TwoFactorVerifyHandler{
+		Config:  config,
+		UseCase: useCases.TwoFactorUseCase,
+	}
+	twoFactorBackupCodes := &TwoFactorBackupCodesHandler{
+		Config:  config,
+		UseCase: useCases.TwoFactorUseCase,
+	}
+	passkeyRegisterBegin := &PasskeyRegisterBeginHandler{
+		Config:  config,
+		UseCase: useCases.PasskeyUseCase,
+	}
+	passkeyRegisterFinish := &PasskeyRegisterFinishHandler{
+		Config:  config,
+		UseCase: useCases.PasskeyUseCase,
+	}
+	passkeyAuthenticateBegin := &PasskeyAuthenticateBeginHandler{
+		Config:  config,
+		UseCase: useCases.PasskeyUseCase,
+	}
+	passkeyAuthenticateFinish := &PasskeyAuthenticateFinishHandler{
+		Config:  config,
+		UseCase: useCases.PasskeyUseCase,
+	}
+	jwks := &JWKSHandler{
+		Config:  config,
+		UseCase: useCases.JWTUseCase,
+	}
+	token := &TokenHandler{
+		Config:  config,
+		UseCase: useCases.JWTUseCase,
+	}
+	introspect := &IntrospectHandler{
+		Config:  config,
+		UseCase: useCases.IntrospectionUseCase,
+	}
+	revoke := &RevokeHandler{
+		Config:  config,
+		UseCase: useCases.IntrospectionUseCase,
+	}
+	verifyRequest := &VerifyRequestHandler{
+		Config:  config,
+		UseCase: useCases.ForwardAuthUseCase,
+	}
+	oidcDiscovery := &OIDCDiscoveryHandler{
+		Config:  config,
+		UseCase: useCases.OIDCProviderUseCase,
+	}
+	oidcRegisterClient := &OIDCRegisterClientHandler{
+		Config:  config,
+		UseCase: useCases.OIDCProviderUseCase,
+	}
+	oidcAuthorize := &OIDCAuthorizeHandler{
+		Config:  config,
+		UseCase: useCases.OIDCProviderUseCase,
+	}
+	oidcConsent := &OIDCConsentHandler{
+		Config:  config,
+		UseCase: useCases.OIDCProviderUseCase,
+	}
+	oidcToken := &OIDCTokenHandler{
+		Config:  config,
+		UseCase: useCases.OIDCProviderUseCase,
+	}
+	oidcUserInfo := &OIDCUserInfoHandler{
+		Config:  config,
+		UseCase: useCases.OIDCProviderUseCase,
+	}
+	createOrganization := &CreateOrganizationHandler{
+		Config:  config,
+		UseCase: useCases.OrganizationUseCase,
+	}
+	listOrganizations := &ListOrganizationsHandler{
+		Config:  config,
+		UseCase: useCases.OrganizationUseCase,
+	}
+	setActiveOrganization := &SetActiveOrganizationHandler{
+		Config:  config,
+		UseCase: useCases.OrganizationUseCase,
+	}
+	listOrganizationMembers := &ListOrganizationMembersHandler{
+		Config:  config,
+		UseCase: useCases.OrganizationUseCase,
+	}
+	deleteOrganization := &DeleteOrganizationHandler{
+		Config:  config,
+		UseCase: useCases.OrganizationUseCase,
+	}
+	updateOrganizationMember := &UpdateOrganizationMemberHandler{
+		Config:  config,
+		UseCase: useCases.OrganizationUseCase,
+	}
+	removeOrganizationMember := &RemoveOrganizationMemberHandler{
+		Config:  config,
+		UseCase: useCases.OrganizationUseCase,
+	}
+	createOrganizationInvitation := &CreateOrganizationInvitationHandler{
+		Config:  config,
+		UseCase: useCases.OrganizationUseCase,
+	}
+	listOrganizationInvitations := &ListOrganizationInvitationsHandler{
+		Config:  config,
+		UseCase: useCases.OrganizationUseCase,
+	}
+	acceptOrganizationInvitation := &AnswerOrganizationInvitationHandler{
+		Config:  config,
+		UseCase: useCases.OrganizationUseCase,
+		Accept:  true,
+	}
+	declineOrganizationInvitation := &AnswerOrganizationInvitationHandler{
+		Config:  config,
+		UseCase: useCases.OrganizationUseCase,
+	}
 
-	return []models.CustomRoute{
+	routes := []models.CustomRoute{
 		{
 			Method:  "POST",
 			Path:    "/sign-in",
@@ -86,11 +267,62 @@ func GetRoutes(config *models.Config, authService *auth.Service, basePath string
 			},
 			Handler: signOut.Handler(),
 		},
+		{
+			Method: "GET",
+			Path:   "/sessions",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.Auth(),
+			},
+			Handler: listSessions.Handler(),
+		},
+		{
+			Method: "DELETE",
+			Path:   "/sessions/{id}",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.Auth(),
+				middleware.CSRF(),
+			},
+			Handler: revokeSession.Handler(),
+		},
+		{
+			Method: "POST",
+			Path:   "/sessions/revoke-others",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.Auth(),
+				middleware.CSRF(),
+			},
+			Handler: revokeOtherSessions.Handler(),
+		},
 		{
 			Method:  "POST",
 			Path:    "/reset-password",
 			Handler: resetPassword.Handler(),
 		},
+		{
+			Method:  "POST",
+			Path:    "/magic-link",
+			Handler: magicLink.Handler(),
+		},
+		{
+			Method:  "POST",
+			Path:    "/email-otp/send",
+			Handler: emailOTPSend.Handler(),
+		},
+		{
+			Method:  "POST",
+			Path:    "/email-otp/sign-in",
+			Handler: emailOTPSignIn.Handler(),
+		},
+		{
+			Method:  "POST",
+			Path:    "/email-otp/verify-email",
+			Handler: emailOTPVerifyEmail.Handler(),
+		},
+		{
+			Method:  "POST",
+			Path:    "/email-otp/reset-password",
+			Handler: emailOTPResetPassword.Handler(),
+		},
 		{
 			Method:  "POST",
 			Path:    "/change-password",
@@ -119,5 +351,304 @@ func GetRoutes(config *models.Config, authService *auth.Service, basePath string
 			Path:    "/oauth2/{provider}/callback",
 			Handler: oauth2Callback.Handler(),
 		},
+		{
+			Method:  "POST",
+			Path:    "/oauth2/{provider}/callback",
+			Handler: oauth2Callback.Handler(),
+		},
+		{
+			Method:  "POST",
+			Path:    "/oauth2/{provider}/id-token",
+			Handler: oauth2IDToken.Handler(),
+		},
+		{
+			Method: "GET",
+			Path:   "/oauth2/{provider}/link",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.Auth(),
+			},
+			Handler: oauth2Link.Handler(),
+		},
+		{
+			Method: "GET",
+			Path:   "/oauth2/{provider}/reauthorize",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.Auth(),
+			},
+			Handler: oauth2Reauthorize.Handler(),
+		},
+		{
+			Method: "GET",
+			Path:   "/oauth2/{provider}/access-token",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.Auth(),
+			},
+			Handler: oauth2AccessToken.Handler(),
+		},
+		{
+			Method: "GET",
+			Path:   "/accounts",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.Auth(),
+			},
+			Handler: listAccounts.Handler(),
+		},
+		{
+			Method: "DELETE",
+			Path:   "/accounts/{id}",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.Auth(),
+				middleware.CSRF(),
+			},
+			Handler: unlinkAccount.Handler(),
+		},
+		{
+			Method: "POST",
+			Path:   "/two-factor/enroll",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.Auth(),
+				middleware.CSRF(),
+			},
+			Handler: twoFactorEnroll.Handler(),
+		},
+		{
+			Method: "POST",
+			Path:   "/two-factor/enroll/verify",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.Auth(),
+				middleware.CSRF(),
+			},
+			Handler: twoFactorEnable.Handler(),
+		},
+		{
+			Method: "POST",
+			Path:   "/two-factor/disable",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.Auth(),
+				middleware.CSRF(),
+			},
+			Handler: twoFactorDisable.Handler(),
+		},
+		{
+			Method:  "POST",
+			Path:    "/two-factor/verify",
+			Handler: twoFactorVerify.Handler(),
+		},
+		{
+			Method: "POST",
+			Path:   "/two-factor/backup-codes",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.Auth(),
+				middleware.CSRF(),
+			},
+			Handler: twoFactorBackupCodes.Handler(),
+		},
+		{
+			Method: "POST",
+			Path:   "/passkeys/register/begin",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.Auth(),
+				middleware.CSRF(),
+			},
+			Handler: passkeyRegisterBegin.Handler(),
+		},
+		{
+			Method: "POST",
+			Path:   "/passkeys/register/finish",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.Auth(),
+				middleware.CSRF(),
+			},
+			Handler: passkeyRegisterFinish.Handler(),
+		},
+		{
+			Method:  "POST",
+			Path:    "/passkeys/authenticate/begin",
+			Handler: passkeyAuthenticateBegin.Handler(),
+		},
+		{
+			Method:  "POST",
+			Path:    "/passkeys/authenticate/finish",
+			Handler: passkeyAuthenticateFinish.Handler(),
+		},
+		{
+			Method:  "GET",
+			Path:    "/jwks.json",
+			Handler: jwks.Handler(),
+		},
+		{
+			Method: "GET",
+			Path:   "/token",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.Auth(),
+			},
+			Handler: token.Handler(),
+		},
+		{
+			Method:  "GET",
+			Path:    "/.well-known/openid-configuration",
+			Handler: oidcDiscovery.Handler(),
+		},
+		{
+			Method: "POST",
+			Path:   "/oidc/clients",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.AdminAuth(),
+			},
+			Handler: oidcRegisterClient.Handler(),
+		},
+		{
+			Method: "GET",
+			Path:   "/oidc/authorize",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.OptionalAuth(),
+			},
+			Handler: oidcAuthorize.Handler(),
+		},
+		{
+			Method: "POST",
+			Path:   "/oidc/consent",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.Auth(),
+				middleware.CSRF(),
+			},
+			Handler: oidcConsent.Handler(),
+		},
+		{
+			Method:  "POST",
+			Path:    "/oidc/token",
+			Handler: oidcToken.Handler(),
+		},
+		{
+			Method:  "GET",
+			Path:    "/oidc/userinfo",
+			Handler: oidcUserInfo.Handler(),
+		},
+		{
+			Method: "POST",
+			Path:   "/introspect",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.ClientAuth(),
+			},
+			Handler: introspect.Handler(),
+		},
+		{
+			Method: "POST",
+			Path:   "/revoke",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.ClientAuth(),
+			},
+			Handler: revoke.Handler(),
+		},
+		{
+			Method: "POST",
+			Path:   "/organizations",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.Auth(),
+				middleware.CSRF(),
+			},
+			Handler: createOrganization.Handler(),
+		},
+		{
+			Method: "GET",
+			Path:   "/organizations",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.Auth(),
+			},
+			Handler: listOrganizations.Handler(),
+		},
+		{
+			Method: "POST",
+			Path:   "/organizations/active",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.Auth(),
+				middleware.CSRF(),
+			},
+			Handler: setActiveOrganization.Handler(),
+		},
+		{
+			Method: "DELETE",
+			Path:   "/organizations/{organizationId}",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.Auth(),
+				middleware.CSRF(),
+			},
+			Handler: deleteOrganization.Handler(),
+		},
+		{
+			Method: "GET",
+			Path:   "/organizations/{organizationId}/members",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.Auth(),
+			},
+			Handler: listOrganizationMembers.Handler(),
+		},
+		{
+			Method: "PATCH",
+			Path:   "/organizations/{organizationId}/members/{userId}",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.Auth(),
+				middleware.CSRF(),
+			},
+			Handler: updateOrganizationMember.Handler(),
+		},
+		{
+			Method: "DELETE",
+			Path:   "/organizations/{organizationId}/members/{userId}",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.Auth(),
+				middleware.CSRF(),
+			},
+			Handler: removeOrganizationMember.Handler(),
+		},
+		{
+			Method: "POST",
+			Path:   "/organizations/{organizationId}/invitations",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.Auth(),
+				middleware.CSRF(),
+			},
+			Handler: createOrganizationInvitation.Handler(),
+		},
+		{
+			Method: "GET",
+			Path:   "/organizations/{organizationId}/invitations",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.Auth(),
+			},
+			Handler: listOrganizationInvitations.Handler(),
+		},
+		{
+			Method: "POST",
+			Path:   "/organizations/invitations/accept",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.Auth(),
+				middleware.CSRF(),
+			},
+			Handler: acceptOrganizationInvitation.Handler(),
+		},
+		{
+			Method: "POST",
+			Path:   "/organizations/invitations/decline",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.Auth(),
+				middleware.CSRF(),
+			},
+			Handler: declineOrganizationInvitation.Handler(),
+		},
 	}
+
+	// nginx auth_request subrequests keep the method of the original request
+	for _, method := range []string{"GET", "POST", "PUT", "PATCH", "DELETE"} {
+		routes = append(routes, models.CustomRoute{
+			Method: method,
+			Path:   "/verify-request",
+			Middleware: []models.CustomRouteMiddleware{
+				middleware.ForwardAuth(),
+			},
+			Handler: verifyRequest.Handler(),
+		})
+	}
+
+	return routes
 }
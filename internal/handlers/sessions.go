@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	sessions "github.com/GoBetterAuth/go-better-auth/internal/auth/sessions"
+	"github.com/GoBetterAuth/go-better-auth/internal/common"
+	"github.com/GoBetterAuth/go-better-auth/internal/constants"
+	"github.com/GoBetterAuth/go-better-auth/internal/middleware"
+	"github.com/GoBetterAuth/go-better-auth/internal/util"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+// ListSessionsHandler returns the active sessions of the signed in user.
+type ListSessionsHandler struct {
+	Config  *models.Config
+	UseCase sessions.SessionsUseCase
+}
+
+func (h *ListSessionsHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(middleware.ContextUserID).(string)
+	if !ok || userID == "" {
+		util.JSONResponse(w, http.StatusUnauthorized, map[string]any{"message": "unauthorized"})
+		return
+	}
+	sessionID, _ := r.Context().Value(middleware.ContextSessionID).(string)
+
+	result, err := h.UseCase.ListSessions(r.Context(), userID, sessionID)
+	if err != nil {
+		util.JSONResponse(w, http.StatusInternalServerError, map[string]any{"message": err.Error()})
+		return
+	}
+
+	util.JSONResponse(w, http.StatusOK, map[string]any{"sessions": result})
+}
+
+func (h *ListSessionsHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// RevokeSessionHandler signs out one of the user's sessions by ID.
+type RevokeSessionHandler struct {
+	Config  *models.Config
+	UseCase sessions.SessionsUseCase
+}
+
+func (h *RevokeSessionHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(middleware.ContextUserID).(string)
+	if !ok || userID == "" {
+		util.JSONResponse(w, http.StatusUnauthorized, map[string]any{"message": "unauthorized"})
+		return
+	}
+
+	if err := h.UseCase.RevokeSession(r.Context(), userID, r.PathValue("id")); err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, constants.ErrSessionNotFound) {
+			status = http.StatusNotFound
+		}
+		util.JSONResponse(w, status, map[string]any{"message": err.Error()})
+		return
+	}
+
+	util.JSONResponse(w, http.StatusOK, map[string]any{"message": "Session revoked"})
+}
+
+func (h *RevokeSessionHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// RevokeOtherSessionsHandler signs the user out of every session except the current one.
+type RevokeOtherSessionsHandler struct {
+	Config  *models.Config
+	UseCase sessions.SessionsUseCase
+}
+
+func (h *RevokeOtherSessionsHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(middleware.ContextUserID).(string)
+	if !ok || userID == "" {
+		util.JSONResponse(w, http.StatusUnauthorized, map[string]any{"message": "unauthorized"})
+		return
+	}
+	sessionID, ok := r.Context().Value(middleware.ContextSessionID).(string)
+	if !ok || sessionID == "" {
+		util.JSONResponse(w, http.StatusUnauthorized, map[string]any{"message": "unauthorized"})
+		return
+	}
+
+	if err := h.UseCase.RevokeOtherSessions(r.Context(), userID, sessionID); err != nil {
+		util.JSONResponse(w, http.StatusInternalServerError, map[string]any{"message": err.Error()})
+		return
+	}
+
+	util.JSONResponse(w, http.StatusOK, map[string]any{"message": "Other sessions revoked"})
+}
+
+func (h *RevokeOtherSessionsHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
@@ -2,10 +2,12 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	signin "github.com/GoBetterAuth/go-better-auth/internal/auth/sign-in"
 	"github.com/GoBetterAuth/go-better-auth/internal/common"
+	"github.com/GoBetterAuth/go-better-auth/internal/constants"
 	"github.com/GoBetterAuth/go-better-auth/internal/util"
 	"github.com/GoBetterAuth/go-better-auth/models"
 )
@@ -34,7 +36,17 @@ func (h *SignInHandler) Handle(w http.ResponseWriter, r *http.Request) {
 
 	result, err := h.UseCase.SignInWithEmailAndPassword(r.Context(), payload.Email, payload.Password, payload.CallbackURL)
 	if err != nil {
-		util.JSONResponse(w, http.StatusUnauthorized, map[string]any{"message": err.Error()})
+		status := http.StatusUnauthorized
+		if errors.Is(err, constants.ErrUserBanned) {
+			status = http.StatusForbidden
+		}
+		util.JSONResponse(w, status, map[string]any{"message": err.Error()})
+		return
+	}
+
+	// No session exists until the two factor challenge has been completed
+	if result.TwoFactorRequired {
+		util.JSONResponse(w, http.StatusOK, result)
 		return
 	}
 
@@ -19,17 +19,13 @@ type SignOutHandler struct {
 }
 
 func (h *SignOutHandler) Handle(w http.ResponseWriter, r *http.Request) {
-	cookie, err := r.Cookie(h.Config.Session.CookieName)
-	if err != nil {
-		if err == http.ErrNoCookie {
-			util.JSONResponse(w, http.StatusNotFound, map[string]any{"message": "cookie not found"})
-			return
-		}
-		util.JSONResponse(w, http.StatusInternalServerError, map[string]any{"message": err.Error()})
+	token, _ := util.GetSessionToken(h.Config, r)
+	if token == "" {
+		util.JSONResponse(w, http.StatusNotFound, map[string]any{"message": "session token not found"})
 		return
 	}
 
-	if err := h.UseCase.SignOut(r.Context(), cookie.Value); err != nil {
+	if err := h.UseCase.SignOut(r.Context(), token); err != nil {
 		util.JSONResponse(w, http.StatusInternalServerError, map[string]any{"message": err.Error()})
 		return
 	}
@@ -0,0 +1,247 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	twofactor "github.com/GoBetterAuth/go-better-auth/internal/auth/two-factor"
+	"github.com/GoBetterAuth/go-better-auth/internal/common"
+	"github.com/GoBetterAuth/go-better-auth/internal/constants"
+	"github.com/GoBetterAuth/go-better-auth/internal/middleware"
+	"github.com/GoBetterAuth/go-better-auth/internal/util"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+// twoFactorChallengeCookieName holds the pending challenge for redirect based sign-in flows such as OAuth2.
+const twoFactorChallengeCookieName = "two_factor_challenge"
+
+type TwoFactorResponse struct {
+	Message string `json:"message"`
+}
+
+type TwoFactorCodePayload struct {
+	Code string `json:"code" validate:"required"`
+}
+
+type TwoFactorVerifyPayload struct {
+	ChallengeToken string `json:"challenge_token,omitempty"`
+	Code           string `json:"code" validate:"required"`
+}
+
+// TwoFactorEnrollHandler starts enrollment and returns the secret, otpauth URI and backup codes.
+type TwoFactorEnrollHandler struct {
+	Config  *models.Config
+	UseCase twofactor.TwoFactorUseCase
+}
+
+func (h *TwoFactorEnrollHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	if !h.Config.TwoFactor.Enabled {
+		util.JSONResponse(w, http.StatusNotImplemented, map[string]any{"message": constants.ErrTwoFactorDisabled.Error()})
+		return
+	}
+
+	userID, ok := r.Context().Value(middleware.ContextUserID).(string)
+	if !ok || userID == "" {
+		util.JSONResponse(w, http.StatusUnauthorized, map[string]any{"message": "unauthorized"})
+		return
+	}
+
+	result, err := h.UseCase.EnrollTwoFactor(r.Context(), userID)
+	if err != nil {
+		util.JSONResponse(w, http.StatusBadRequest, map[string]any{"message": err.Error()})
+		return
+	}
+
+	util.JSONResponse(w, http.StatusOK, result)
+}
+
+func (h *TwoFactorEnrollHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// TwoFactorEnableHandler confirms enrollment with a code from the authenticator app.
+type TwoFactorEnableHandler struct {
+	Config  *models.Config
+	UseCase twofactor.TwoFactorUseCase
+}
+
+func (h *TwoFactorEnableHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	if !h.Config.TwoFactor.Enabled {
+		util.JSONResponse(w, http.StatusNotImplemented, map[string]any{"message": constants.ErrTwoFactorDisabled.Error()})
+		return
+	}
+
+	userID, ok := r.Context().Value(middleware.ContextUserID).(string)
+	if !ok || userID == "" {
+		util.JSONResponse(w, http.StatusUnauthorized, map[string]any{"message": "unauthorized"})
+		return
+	}
+
+	var payload TwoFactorCodePayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.JSONResponse(w, http.StatusBadRequest, map[string]any{"message": "invalid request"})
+		return
+	}
+	if err := util.Validate.Struct(payload); err != nil {
+		util.JSONResponse(w, http.StatusUnprocessableEntity, map[string]any{"message": "validation failed"})
+		return
+	}
+
+	if err := h.UseCase.EnableTwoFactor(r.Context(), userID, payload.Code); err != nil {
+		util.JSONResponse(w, http.StatusBadRequest, map[string]any{"message": err.Error()})
+		return
+	}
+
+	util.JSONResponse(w, http.StatusOK, TwoFactorResponse{Message: "Two factor authentication enabled"})
+}
+
+func (h *TwoFactorEnableHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// TwoFactorDisableHandler removes the factor after confirming a valid code.
+type TwoFactorDisableHandler struct {
+	Config  *models.Config
+	UseCase twofactor.TwoFactorUseCase
+}
+
+func (h *TwoFactorDisableHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	userID, ok := r.Context().Value(middleware.ContextUserID).(string)
+	if !ok || userID == "" {
+		util.JSONResponse(w, http.StatusUnauthorized, map[string]any{"message": "unauthorized"})
+		return
+	}
+
+	var payload TwoFactorCodePayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.JSONResponse(w, http.StatusBadRequest, map[string]any{"message": "invalid request"})
+		return
+	}
+	if err := util.Validate.Struct(payload); err != nil {
+		util.JSONResponse(w, http.StatusUnprocessableEntity, map[string]any{"message": "validation failed"})
+		return
+	}
+
+	if err := h.UseCase.DisableTwoFactor(r.Context(), userID, payload.Code); err != nil {
+		util.JSONResponse(w, http.StatusBadRequest, map[string]any{"message": err.Error()})
+		return
+	}
+
+	util.JSONResponse(w, http.StatusOK, TwoFactorResponse{Message: "Two factor authentication disabled"})
+}
+
+func (h *TwoFactorDisableHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+// TwoFactorVerifyHandler completes a pending sign-in by exchanging the challenge and a code for a session.
+type TwoFactorVerifyHandler struct {
+	Config  *models.Config
+	UseCase twofactor.TwoFactorUseCase
+}
+
+func (h *TwoFactorVerifyHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	var payload TwoFactorVerifyPayload
+	if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+		util.JSONResponse(w, http.StatusBadRequest, map[string]any{"message": "invalid request"})
+		return
+	}
+	if err := util.Validate.Struct(payload); err != nil {
+		util.JSONResponse(w, http.StatusUnprocessableEntity, map[string]any{"message": "validation failed"})
+		return
+	}
+
+	// Fall back to the challenge cookie set by redirect based flows
+	challengeToken := payload.ChallengeToken
+	if challengeToken == "" {
+		if cookie, err := r.Cookie(twoFactorChallengeCookieName); err == nil {
+			challengeToken = cookie.Value
+		}
+	}
+
+	result, err := h.UseCase.VerifyTwoFactor(r.Context(), challengeToken, payload.Code)
+	if err != nil {
+		status := http.StatusUnauthorized
+		if errors.Is(err, constants.ErrTwoFactorChallengeAttempts) {
+			status = http.StatusTooManyRequests
+		}
+		util.JSONResponse(w, status, map[string]any{"message": err.Error()})
+		return
+	}
+
+	isSecure, sameSite := util.GetCookieOptions(h.Config)
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     twoFactorChallengeCookieName,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   isSecure,
+		SameSite: sameSite,
+	})
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     h.Config.Session.CookieName,
+		Value:    result.Token,
+		Path:     "/",
+		HttpOnly: true,
+		MaxAge:   int(h.Config.Session.ExpiresIn.Seconds()),
+		SameSite: sameSite,
+		Secure:   isSecure,
+	})
+
+	if h.Config.CSRF.Enabled && result.CSRFToken != nil {
+		http.SetCookie(w, &http.Cookie{
+			Name:     h.Config.CSRF.CookieName,
+			Value:    *result.CSRFToken,
+			Path:     "/",
+			HttpOnly: false,
+			Secure:   isSecure,
+			SameSite: sameSite,
+			MaxAge:   int(h.Config.CSRF.ExpiresIn.Seconds()),
+		})
+	}
+
+	util.JSONResponse(w, http.StatusOK, result)
+}
+
+func (h *TwoFactorVerifyHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
+
+type TwoFactorBackupCodesResponse struct {
+	BackupCodes []string `json:"backup_codes"`
+}
+
+// TwoFactorBackupCodesHandler replaces the user's backup codes with a new set.
+type TwoFactorBackupCodesHandler struct {
+	Config  *models.Config
+	UseCase twofactor.TwoFactorUseCase
+}
+
+func (h *TwoFactorBackupCodesHandler) Handle(w http.ResponseWriter, r *http.Request) {
+	if !h.Config.TwoFactor.Enabled {
+		util.JSONResponse(w, http.StatusNotImplemented, map[string]any{"message": constants.ErrTwoFactorDisabled.Error()})
+		return
+	}
+
+	userID, ok := r.Context().Value(middleware.ContextUserID).(string)
+	if !ok || userID == "" {
+		util.JSONResponse(w, http.StatusUnauthorized, map[string]any{"message": "unauthorized"})
+		return
+	}
+
+	codes, err := h.UseCase.RegenerateBackupCodes(r.Context(), userID)
+	if err != nil {
+		util.JSONResponse(w, http.StatusBadRequest, map[string]any{"message": err.Error()})
+		return
+	}
+
+	util.JSONResponse(w, http.StatusOK, TwoFactorBackupCodesResponse{BackupCodes: codes})
+}
+
+func (h *TwoFactorBackupCodesHandler) Handler() models.CustomRouteHandler {
+	return common.WrapHandler(h)
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"net/http"
+	"time"
 
 	verifyemail "github.com/GoBetterAuth/go-better-auth/internal/auth/verify-email"
 	"github.com/GoBetterAuth/go-better-auth/internal/common"
@@ -28,7 +29,16 @@ func (h *VerifyEmailHandler) Handle(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if callbackURL != "" {
+	// Magic links sign the user in
+	if result.TwoFactorRequired || result.Token != "" {
+		h.setSignInCookies(w, result)
+	}
+
+	// The callback is checked again here as verification links can be built by hand
+	if callbackURL != "" && util.IsTrustedRedirect(callbackURL, h.Config.TrustedOrigins.Origins) {
+		if result.TwoFactorRequired {
+			callbackURL = util.AppendQueryParam(callbackURL, "two_factor_required", "true")
+		}
 		http.Redirect(w, r, callbackURL, http.StatusSeeOther)
 		return
 	}
@@ -36,6 +46,46 @@ func (h *VerifyEmailHandler) Handle(w http.ResponseWriter, r *http.Request) {
 	util.JSONResponse(w, http.StatusOK, result)
 }
 
+func (h *VerifyEmailHandler) setSignInCookies(w http.ResponseWriter, result *models.VerifyEmailResult) {
+	isSecure, sameSite := util.GetCookieOptions(h.Config)
+
+	if result.TwoFactorRequired {
+		// Keep the challenge in a cookie so the client can complete the sign-in via /two-factor/verify
+		http.SetCookie(w, &http.Cookie{
+			Name:     twoFactorChallengeCookieName,
+			Value:    *result.ChallengeToken,
+			Path:     "/",
+			HttpOnly: true,
+			Secure:   isSecure,
+			SameSite: sameSite,
+			Expires:  time.Now().Add(h.Config.TwoFactor.ChallengeExpiresIn),
+		})
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     h.Config.Session.CookieName,
+		Value:    result.Token,
+		Path:     "/",
+		HttpOnly: true,
+		MaxAge:   int(h.Config.Session.ExpiresIn.Seconds()),
+		SameSite: sameSite,
+		Secure:   isSecure,
+	})
+
+	if h.Config.CSRF.Enabled && result.CSRFToken != nil {
+		http.SetCookie(w, &http.Cookie{
+			Name:     h.Config.CSRF.CookieName,
+			Value:    *result.CSRFToken,
+			Path:     "/",
+			HttpOnly: false,
+			Secure:   isSecure,
+			SameSite: sameSite,
+			MaxAge:   int(h.Config.CSRF.ExpiresIn.Seconds()),
+		})
+	}
+}
+
 func (h *VerifyEmailHandler) Handler() models.CustomRouteHandler {
 	return common.WrapHandler(h)
 }
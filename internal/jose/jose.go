@@ -0,0 +1,296 @@
+package jose
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+// JWS algorithm identifiers (RFC 7518, RFC 8037)
+const (
+	AlgEdDSA = "EdDSA"
+	AlgRS256 = "RS256"
+	// AlgES256 is only used with keys of external services, such as Apple client secrets.
+	AlgES256 = "ES256"
+)
+
+// rsaKeyBits is the size of generated RSA signing keys.
+const rsaKeyBits = 2048
+
+var (
+	ErrMalformedToken       = errors.New("malformed token")
+	ErrUnsupportedAlgorithm = errors.New("unsupported signing algorithm")
+	ErrInvalidSignature     = errors.New("invalid token signature")
+)
+
+// Header is the protected header of a compact JWS.
+type Header struct {
+	Algorithm string `json:"alg"`
+	Type      string `json:"typ,omitempty"`
+	KeyID     string `json:"kid,omitempty"`
+}
+
+// IsSupportedAlgorithm reports whether keys can be generated and tokens signed with alg.
+func IsSupportedAlgorithm(alg string) bool {
+	return alg == AlgEdDSA || alg == AlgRS256
+}
+
+// GenerateKey creates a new private key for the given algorithm.
+func GenerateKey(alg string) (crypto.Signer, error) {
+	switch alg {
+	case AlgEdDSA:
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		return key, err
+	case AlgRS256:
+		return rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedAlgorithm, alg)
+	}
+}
+
+// MarshalPrivateKey encodes a private key as base64 PKCS #8.
+func MarshalPrivateKey(key crypto.Signer) (string, error) {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return "", fmt.Errorf("marshal private key: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(der), nil
+}
+
+// ParsePrivateKey decodes a private key produced by MarshalPrivateKey.
+func ParsePrivateKey(encoded string) (crypto.Signer, error) {
+	der, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("decode private key: %w", err)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("parse private key: %w", err)
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedAlgorithm, key)
+	}
+	return signer, nil
+}
+
+// MarshalPublicKey encodes a public key as base64 PKIX.
+func MarshalPublicKey(key crypto.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(key)
+	if err != nil {
+		return "", fmt.Errorf("marshal public key: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(der), nil
+}
+
+// ParsePublicKey decodes a public key produced by MarshalPublicKey.
+func ParsePublicKey(encoded string) (crypto.PublicKey, error) {
+	der, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("decode public key: %w", err)
+	}
+	key, err := x509.ParsePKIXPublicKey(der)
+	if err != nil {
+		return nil, fmt.Errorf("parse public key: %w", err)
+	}
+	return key, nil
+}
+
+// PublicJWK returns the JSON Web Key representation of a public key.
+func PublicJWK(keyID string, alg string, key crypto.PublicKey) (models.JSONWebKey, error) {
+	jwk := models.JSONWebKey{KeyID: keyID, Algorithm: alg, Use: "sig"}
+
+	switch k := key.(type) {
+	case ed25519.PublicKey:
+		jwk.KeyType = "OKP"
+		jwk.Curve = "Ed25519"
+		jwk.X = base64.RawURLEncoding.EncodeToString(k)
+	case *rsa.PublicKey:
+		jwk.KeyType = "RSA"
+		jwk.N = base64.RawURLEncoding.EncodeToString(k.N.Bytes())
+		jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.E)).Bytes())
+	default:
+		return models.JSONWebKey{}, fmt.Errorf("%w: %T", ErrUnsupportedAlgorithm, key)
+	}
+
+	return jwk, nil
+}
+
+// ParseJWK returns the public key of a JSON Web Key published by an issuer.
+func ParseJWK(jwk models.JSONWebKey) (crypto.PublicKey, error) {
+	decode := func(value string) ([]byte, error) {
+		if value == "" {
+			return nil, fmt.Errorf("missing key parameter in jwk %q", jwk.KeyID)
+		}
+		return base64.RawURLEncoding.DecodeString(value)
+	}
+
+	switch {
+	case jwk.KeyType == "OKP" && jwk.Curve == "Ed25519":
+		x, err := decode(jwk.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("invalid Ed25519 jwk %q", jwk.KeyID)
+		}
+		return ed25519.PublicKey(x), nil
+	case jwk.KeyType == "RSA":
+		n, err := decode(jwk.N)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RSA jwk %q: %w", jwk.KeyID, err)
+		}
+		e, err := decode(jwk.E)
+		if err != nil {
+			return nil, fmt.Errorf("invalid RSA jwk %q: %w", jwk.KeyID, err)
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case jwk.KeyType == "EC" && jwk.Curve == "P-256":
+		x, err := decode(jwk.X)
+		if err != nil {
+			return nil, fmt.Errorf("invalid EC jwk %q: %w", jwk.KeyID, err)
+		}
+		y, err := decode(jwk.Y)
+		if err != nil {
+			return nil, fmt.Errorf("invalid EC jwk %q: %w", jwk.KeyID, err)
+		}
+		key := &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !key.Curve.IsOnCurve(key.X, key.Y) {
+			return nil, fmt.Errorf("invalid EC jwk %q: point is not on the curve", jwk.KeyID)
+		}
+		return key, nil
+	default:
+		return nil, fmt.Errorf("%w: %s %s", ErrUnsupportedAlgorithm, jwk.KeyType, jwk.Curve)
+	}
+}
+
+// Sign serializes the claims as a compact JWS signed with key.
+func Sign(alg string, keyID string, key crypto.Signer, claims map[string]any) (string, error) {
+	header, err := json.Marshal(Header{Algorithm: alg, Type: "JWT", KeyID: keyID})
+	if err != nil {
+		return "", fmt.Errorf("encode header: %w", err)
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", fmt.Errorf("encode claims: %w", err)
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	var signature []byte
+	switch alg {
+	case AlgEdDSA:
+		signature, err = key.Sign(rand.Reader, []byte(signingInput), crypto.Hash(0))
+	case AlgRS256:
+		digest := sha256.Sum256([]byte(signingInput))
+		signature, err = key.Sign(rand.Reader, digest[:], crypto.SHA256)
+	case AlgES256:
+		ecdsaKey, ok := key.(*ecdsa.PrivateKey)
+		if !ok {
+			return "", fmt.Errorf("%w: %T", ErrUnsupportedAlgorithm, key)
+		}
+		digest := sha256.Sum256([]byte(signingInput))
+		var r, s *big.Int
+		if r, s, err = ecdsa.Sign(rand.Reader, ecdsaKey, digest[:]); err == nil {
+			signature = make([]byte, 64)
+			r.FillBytes(signature[:32])
+			s.FillBytes(signature[32:])
+		}
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnsupportedAlgorithm, alg)
+	}
+	if err != nil {
+		return "", fmt.Errorf("sign token: %w", err)
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// ParseHeader decodes the header of a compact JWS without verifying it,
+// typically to find the key it was signed with.
+func ParseHeader(token string) (*Header, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrMalformedToken
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, ErrMalformedToken
+	}
+	var header Header
+	if err := json.Unmarshal(raw, &header); err != nil {
+		return nil, ErrMalformedToken
+	}
+	return &header, nil
+}
+
+// Verify checks the signature of a compact JWS against key and returns its claims.
+// The token must have been signed with alg, registered claims such as exp are not checked.
+func Verify(token string, alg string, key crypto.PublicKey) (map[string]any, error) {
+	header, err := ParseHeader(token)
+	if err != nil {
+		return nil, err
+	}
+	if header.Algorithm != alg {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedAlgorithm, header.Algorithm)
+	}
+
+	lastDot := strings.LastIndex(token, ".")
+	signingInput := token[:lastDot]
+	signature, err := base64.RawURLEncoding.DecodeString(token[lastDot+1:])
+	if err != nil {
+		return nil, ErrMalformedToken
+	}
+
+	switch k := key.(type) {
+	case ed25519.PublicKey:
+		if alg != AlgEdDSA || !ed25519.Verify(k, []byte(signingInput), signature) {
+			return nil, ErrInvalidSignature
+		}
+	case *rsa.PublicKey:
+		digest := sha256.Sum256([]byte(signingInput))
+		if alg != AlgRS256 || rsa.VerifyPKCS1v15(k, crypto.SHA256, digest[:], signature) != nil {
+			return nil, ErrInvalidSignature
+		}
+	case *ecdsa.PublicKey:
+		// JWS ECDSA signatures are the fixed size concatenation of r and s (RFC 7518 section 3.4)
+		digest := sha256.Sum256([]byte(signingInput))
+		if alg != AlgES256 || len(signature) != 64 ||
+			!ecdsa.Verify(k, digest[:], new(big.Int).SetBytes(signature[:32]), new(big.Int).SetBytes(signature[32:])) {
+			return nil, ErrInvalidSignature
+		}
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedAlgorithm, key)
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(signingInput[strings.Index(signingInput, ".")+1:])
+	if err != nil {
+		return nil, ErrMalformedToken
+	}
+	var claims map[string]any
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, ErrMalformedToken
+	}
+	return claims, nil
+}
+
+// NumericDate reads a NumericDate claim such as exp, iat or nbf.
+func NumericDate(claims map[string]any, name string) (time.Time, bool) {
+	value, ok := claims[name].(float64)
+	if !ok {
+		return time.Time{}, false
+	}
+	return time.Unix(int64(value), 0), true
+}
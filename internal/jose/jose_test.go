@@ -0,0 +1,144 @@
+package jose
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSignVerify(t *testing.T) {
+	for _, alg := range []string{AlgEdDSA, AlgRS256} {
+		t.Run(alg, func(t *testing.T) {
+			key, err := GenerateKey(alg)
+			if err != nil {
+				t.Fatalf("GenerateKey failed: %v", err)
+			}
+
+			token, err := Sign(alg, "kid-1", key, map[string]any{"sub": "user-1"})
+			if err != nil {
+				t.Fatalf("Sign failed: %v", err)
+			}
+
+			header, err := ParseHeader(token)
+			if err != nil {
+				t.Fatalf("ParseHeader failed: %v", err)
+			}
+			if header.KeyID != "kid-1" || header.Algorithm != alg {
+				t.Fatalf("unexpected header: %+v", header)
+			}
+
+			claims, err := Verify(token, alg, key.Public())
+			if err != nil {
+				t.Fatalf("Verify failed: %v", err)
+			}
+			if claims["sub"] != "user-1" {
+				t.Fatalf("unexpected claims: %v", claims)
+			}
+
+			parts := strings.Split(token, ".")
+			tampered := parts[0] + "." + parts[1] + "x." + parts[2]
+			if _, err := Verify(tampered, alg, key.Public()); err == nil {
+				t.Fatal("expected tampered token to be rejected")
+			}
+
+			other, _ := GenerateKey(alg)
+			if _, err := Verify(token, alg, other.Public()); !errors.Is(err, ErrInvalidSignature) {
+				t.Fatalf("expected signature from another key to be rejected, got %v", err)
+			}
+		})
+	}
+}
+
+func TestVerify_AlgorithmMismatch(t *testing.T) {
+	key, _ := GenerateKey(AlgEdDSA)
+	token, _ := Sign(AlgEdDSA, "kid-1", key, map[string]any{})
+
+	if _, err := Verify(token, AlgRS256, key.Public()); !errors.Is(err, ErrUnsupportedAlgorithm) {
+		t.Fatalf("expected algorithm mismatch to be rejected, got %v", err)
+	}
+}
+
+func TestMarshalKeys(t *testing.T) {
+	key, _ := GenerateKey(AlgEdDSA)
+
+	encodedPrivate, err := MarshalPrivateKey(key)
+	if err != nil {
+		t.Fatalf("MarshalPrivateKey failed: %v", err)
+	}
+	parsedPrivate, err := ParsePrivateKey(encodedPrivate)
+	if err != nil {
+		t.Fatalf("ParsePrivateKey failed: %v", err)
+	}
+
+	encodedPublic, err := MarshalPublicKey(key.Public())
+	if err != nil {
+		t.Fatalf("MarshalPublicKey failed: %v", err)
+	}
+	parsedPublic, err := ParsePublicKey(encodedPublic)
+	if err != nil {
+		t.Fatalf("ParsePublicKey failed: %v", err)
+	}
+
+	token, _ := Sign(AlgEdDSA, "kid-1", parsedPrivate, map[string]any{})
+	if _, err := Verify(token, AlgEdDSA, parsedPublic); err != nil {
+		t.Fatalf("expected round-tripped keys to match, got %v", err)
+	}
+
+	jwk, err := PublicJWK("kid-1", AlgEdDSA, parsedPublic)
+	if err != nil {
+		t.Fatalf("PublicJWK failed: %v", err)
+	}
+	if jwk.KeyType != "OKP" || jwk.Curve != "Ed25519" || jwk.X == "" {
+		t.Fatalf("unexpected JWK: %+v", jwk)
+	}
+}
+
+func TestParseJWK(t *testing.T) {
+	for _, alg := range []string{AlgEdDSA, AlgRS256} {
+		t.Run(alg, func(t *testing.T) {
+			key, _ := GenerateKey(alg)
+			jwk, err := PublicJWK("kid-1", alg, key.Public())
+			if err != nil {
+				t.Fatalf("PublicJWK failed: %v", err)
+			}
+
+			publicKey, err := ParseJWK(jwk)
+			if err != nil {
+				t.Fatalf("ParseJWK failed: %v", err)
+			}
+
+			token, _ := Sign(alg, "kid-1", key, map[string]any{"sub": "user-1"})
+			if _, err := Verify(token, alg, publicKey); err != nil {
+				t.Fatalf("expected token to verify with the parsed jwk, got %v", err)
+			}
+		})
+	}
+}
+
+func TestSignVerify_ES256(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+
+	token, err := Sign(AlgES256, "kid-1", key, map[string]any{"sub": "user-1"})
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+
+	claims, err := Verify(token, AlgES256, key.Public())
+	if err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if claims["sub"] != "user-1" {
+		t.Fatalf("unexpected claims: %v", claims)
+	}
+
+	other, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if _, err := Verify(token, AlgES256, other.Public()); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("expected signature from another key to be rejected, got %v", err)
+	}
+}
@@ -1,21 +1,57 @@
 package middleware
 
 import (
+	"crypto/subtle"
 	"net/http"
 
+	"github.com/GoBetterAuth/go-better-auth/internal/auth"
 	"github.com/GoBetterAuth/go-better-auth/internal/util"
+	"github.com/GoBetterAuth/go-better-auth/models"
 )
 
 // AdminAuth is middleware that checks for a valid admin API key in the request headers.
-func AdminAuth(apiKey string) func(http.Handler) http.Handler {
+// When an admin permission is configured, users whose roles grant it are let through with their session instead,
+// which browsers send implicitly, so those requests must also pass the CSRF check.
+func AdminAuth(apiKey string, config *models.Config, authService *auth.Service) func(http.Handler) http.Handler {
 	return func(h http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			key := r.Header.Get("X-API-KEY")
-			if key != apiKey || apiKey == "" {
+			if apiKey != "" && subtle.ConstantTimeCompare([]byte(key), []byte(apiKey)) == 1 {
+				h.ServeHTTP(w, r)
+				return
+			}
+
+			if config.RBAC.AdminPermission == "" || key != "" {
+				util.JSONResponse(w, http.StatusUnauthorized, map[string]any{"message": "Unauthorized"})
+				return
+			}
+
+			sess, err := getSessionFromRequest(config, authService, r)
+			if err != nil || sess == nil {
 				util.JSONResponse(w, http.StatusUnauthorized, map[string]any{"message": "Unauthorized"})
 				return
 			}
-			h.ServeHTTP(w, r)
+
+			if needsCSRFCheck(config, r) {
+				if err := validateCSRF(config.CSRF, r); err != nil {
+					util.JSONResponse(w, http.StatusForbidden, map[string]any{"message": "invalid CSRF token"})
+					return
+				}
+			}
+
+			allowed, err := authService.RBACService.HasPermission(sess.UserID, config.RBAC.AdminPermission)
+			if err != nil {
+				util.JSONResponse(w, http.StatusInternalServerError, map[string]any{"message": "failed to check permission"})
+				return
+			}
+			if !allowed {
+				util.JSONResponse(w, http.StatusForbidden, map[string]any{"message": "forbidden"})
+				return
+			}
+
+			extendSession(w, r, config, authService, sess)
+
+			h.ServeHTTP(w, r.WithContext(withSession(r.Context(), sess)))
 		})
 	}
 }
@@ -0,0 +1,151 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/GoBetterAuth/go-better-auth/config"
+	"github.com/GoBetterAuth/go-better-auth/internal/auth"
+	"github.com/GoBetterAuth/go-better-auth/internal/services"
+	"github.com/GoBetterAuth/go-better-auth/internal/testutil"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+func TestAdminAuth_APIKey(t *testing.T) {
+	middleware := AdminAuth("admin-key", config.NewConfig(), nil) // authService is nil, the API key is checked first
+
+	handler := middleware(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}),
+	)
+
+	req := httptest.NewRequest("GET", "/admin/users", nil)
+	req.Header.Set("X-API-KEY", "admin-key")
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestAdminAuth_RejectsWithoutAdminPermission(t *testing.T) {
+	tests := []struct {
+		name   string
+		apiKey string
+		header string
+	}{
+		{"wrong key", "admin-key", "other-key"},
+		{"missing key", "admin-key", ""},
+		{"no key configured", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Without an admin permission sessions are never consulted
+			middleware := AdminAuth(tt.apiKey, config.NewConfig(), nil)
+
+			handler := middleware(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					t.Fatal("handler must not be called without a valid API key")
+				}),
+			)
+
+			req := httptest.NewRequest("GET", "/admin/users", nil)
+			if tt.header != "" {
+				req.Header.Set("X-API-KEY", tt.header)
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		})
+	}
+}
+
+func TestAdminAuth_WrongKeyWithAdminPermission(t *testing.T) {
+	cfg := config.NewConfig(config.WithRBAC(models.RBACConfig{AdminPermission: "admin:*"}))
+	middleware := AdminAuth("admin-key", cfg, nil) // a wrong key is rejected before falling back to the session
+
+	handler := middleware(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Fatal("handler must not be called with a wrong API key")
+		}),
+	)
+
+	req := httptest.NewRequest("GET", "/admin/users", nil)
+	req.Header.Set("X-API-KEY", "other-key")
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestAdminAuth_SessionRequiresCSRF(t *testing.T) {
+	cfg := config.NewConfig(
+		config.WithRBAC(models.RBACConfig{AdminPermission: "admin:access"}),
+		config.WithCSRF(models.CSRFConfig{Enabled: true}),
+	)
+	db := testutil.NewDB(t, &models.User{}, &models.Session{}, &models.Role{}, &models.Permission{}, &models.RolePermission{}, &models.UserRole{})
+	authService := &auth.Service{
+		UserService:    services.NewUserServiceImpl(cfg, db),
+		SessionService: services.NewSessionServiceImpl(cfg, db),
+		TokenService:   services.NewTokenServiceImpl(cfg, nil),
+		RBACService:    services.NewRBACServiceImpl(cfg, db),
+	}
+
+	user := &models.User{Email: "admin@example.com"}
+	assert.NoError(t, authService.UserService.CreateUser(user))
+	role := &models.Role{Name: "admin"}
+	assert.NoError(t, authService.RBACService.CreateRole(role))
+	permission := &models.Permission{Name: "admin:access"}
+	assert.NoError(t, authService.RBACService.CreatePermission(permission))
+	assert.NoError(t, authService.RBACService.AddPermissionToRole(role.ID, permission.ID))
+	assert.NoError(t, authService.RBACService.AssignRoleToUser(user.ID, role.ID))
+	_, err := authService.SessionService.CreateSession(user.ID, authService.TokenService.HashToken("session-token"))
+	assert.NoError(t, err)
+
+	handler := AdminAuth("admin-key", cfg, authService)(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}),
+	)
+
+	tests := []struct {
+		name     string
+		method   string
+		csrf     string
+		expected int
+	}{
+		{"safe method", http.MethodGet, "", http.StatusOK},
+		{"missing csrf token", http.MethodPost, "", http.StatusForbidden},
+		{"wrong csrf token", http.MethodPost, "other", http.StatusForbidden},
+		{"matching csrf token", http.MethodPost, "csrf", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/admin/users", nil)
+			req.AddCookie(&http.Cookie{Name: cfg.Session.CookieName, Value: "session-token"})
+			req.AddCookie(&http.Cookie{Name: cfg.CSRF.CookieName, Value: "csrf"})
+			if tt.csrf != "" {
+				req.Header.Set(cfg.CSRF.HeaderName, tt.csrf)
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expected, rec.Code)
+		})
+	}
+
+	// The API key is not a browser credential, so it needs no CSRF token
+	req := httptest.NewRequest(http.MethodPost, "/admin/users", nil)
+	req.Header.Set("X-API-KEY", "admin-key")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
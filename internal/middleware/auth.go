@@ -3,27 +3,95 @@ package middleware
 import (
 	"context"
 	"crypto/subtle"
+	"errors"
+	"log/slog"
 	"net/http"
 	"slices"
 
 	"github.com/GoBetterAuth/go-better-auth/internal/auth"
+	"github.com/GoBetterAuth/go-better-auth/internal/constants"
 	"github.com/GoBetterAuth/go-better-auth/internal/util"
 	"github.com/GoBetterAuth/go-better-auth/models"
 )
 
 type AuthContextKey string
 
-const ContextUserID AuthContextKey = "user_id"
+const (
+	ContextUserID    AuthContextKey = "user_id"
+	ContextSessionID AuthContextKey = "session_id"
+)
 
-// getUserIDFromCookie extracts the user ID from the session cookie.
-// Returns an error if the cookie is missing, invalid, or session is not found.
-func getUserIDFromCookie(authService *auth.Service, cookieName string, r *http.Request) (string, error) {
-	cookie, err := r.Cookie(cookieName)
-	if err != nil || cookie.Value == "" {
-		return "", err
+// getSessionFromRequest looks up the session referenced by the session cookie or bearer token,
+// depending on the configured token sources.
+// Returns an error if the token is missing, invalid, or session is not found.
+func getSessionFromRequest(config *models.Config, authService *auth.Service, r *http.Request) (*models.Session, error) {
+	token, _ := util.GetSessionToken(config, r)
+	if token == "" {
+		return nil, nil
 	}
 
-	sess, err := authService.SessionService.GetSessionByToken(authService.TokenService.HashToken(cookie.Value))
+	sess, err := authService.SessionService.GetSessionByToken(authService.TokenService.HashToken(token))
+	if err != nil || sess == nil {
+		return nil, err
+	}
+
+	if authService.SessionService.IsExpired(sess) {
+		if err := authService.SessionService.DeleteSessionByID(sess.ID); err != nil {
+			slog.Warn("failed to delete expired session", "session_id", sess.ID, "error", err)
+		}
+		return nil, constants.ErrSessionExpired
+	}
+
+	// Banning revokes the user's sessions, checking again covers users banned without going through the admin API
+	user, err := authService.UserService.GetUserByID(sess.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, constants.ErrUserNotFound
+	}
+	if user.IsBanned() {
+		return nil, constants.ErrUserBanned
+	}
+
+	return sess, nil
+}
+
+// extendSession slides the expiry of a session that is older than UpdateAge and reissues the cookie
+// when the session was authenticated through it.
+// Failures are logged but never fail the request as the session is still valid.
+func extendSession(w http.ResponseWriter, r *http.Request, config *models.Config, authService *auth.Service, sess *models.Session) {
+	if !authService.SessionService.ShouldExtend(sess) {
+		return
+	}
+
+	if err := authService.SessionService.ExtendSession(sess); err != nil {
+		slog.Warn("failed to extend session", "session_id", sess.ID, "error", err)
+		return
+	}
+
+	token, source := util.GetSessionToken(config, r)
+	if source != models.SessionTokenSourceCookie {
+		return
+	}
+
+	isSecure, sameSite := util.GetCookieOptions(config)
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     config.Session.CookieName,
+		Value:    token,
+		Path:     "/",
+		HttpOnly: true,
+		MaxAge:   int(config.Session.ExpiresIn.Seconds()),
+		SameSite: sameSite,
+		Secure:   isSecure,
+	})
+}
+
+// getUserIDFromRequest extracts the user ID from the session of the request.
+// Returns an error if the token is missing, invalid, or session is not found.
+func getUserIDFromRequest(config *models.Config, authService *auth.Service, r *http.Request) (string, error) {
+	sess, err := getSessionFromRequest(config, authService, r)
 	if err != nil || sess == nil {
 		return "", err
 	}
@@ -31,6 +99,29 @@ func getUserIDFromCookie(authService *auth.Service, cookieName string, r *http.R
 	return sess.UserID, nil
 }
 
+// withSession stores the user and session IDs of the authenticated request in the context.
+func withSession(ctx context.Context, sess *models.Session) context.Context {
+	ctx = context.WithValue(ctx, ContextUserID, sess.UserID)
+	return context.WithValue(ctx, ContextSessionID, sess.ID)
+}
+
+var errInvalidCSRFToken = errors.New("invalid CSRF token")
+
+// needsCSRFCheck reports whether a request could have been forged by another site. Only requests changing
+// state need checking, and bearer tokens are never sent implicitly by browsers. The check is only skipped
+// when the session really comes from the bearer token, a cookie session sent along with any Authorization
+// header is still checked.
+func needsCSRFCheck(config *models.Config, r *http.Request) bool {
+	if r.Method == http.MethodGet ||
+		r.Method == http.MethodHead ||
+		r.Method == http.MethodOptions {
+		return false
+	}
+
+	_, source := util.GetSessionToken(config, r)
+	return source != models.SessionTokenSourceBearer
+}
+
 // validateCSRF checks the CSRF token from cookie and header.
 // Returns an error if validation fails.
 func validateCSRF(csrfConfig models.CSRFConfig, r *http.Request) error {
@@ -44,38 +135,35 @@ func validateCSRF(csrfConfig models.CSRFConfig, r *http.Request) error {
 	}
 
 	header := r.Header.Get(csrfConfig.HeaderName)
-	if header == "" {
-		return err
-	}
-
-	if subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(header)) != 1 {
-		return err
+	if header == "" || subtle.ConstantTimeCompare([]byte(cookie.Value), []byte(header)) != 1 {
+		return errInvalidCSRFToken
 	}
 
 	return nil
 }
 
-func AuthMiddleware(authService *auth.Service, cookieName string) func(http.Handler) http.Handler {
+func AuthMiddleware(config *models.Config, authService *auth.Service) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			userID, err := getUserIDFromCookie(authService, cookieName, r)
-			if err != nil {
+			sess, err := getSessionFromRequest(config, authService, r)
+			if err != nil || sess == nil {
 				util.JSONResponse(w, http.StatusUnauthorized, map[string]any{"message": "unauthorized"})
 				return
 			}
 
-			ctx := context.WithValue(r.Context(), ContextUserID, userID)
-			next.ServeHTTP(w, r.WithContext(ctx))
+			extendSession(w, r, config, authService, sess)
+
+			next.ServeHTTP(w, r.WithContext(withSession(r.Context(), sess)))
 		})
 	}
 }
 
-func OptionalAuthMiddleware(authService *auth.Service, cookieName string) func(http.Handler) http.Handler {
+func OptionalAuthMiddleware(config *models.Config, authService *auth.Service) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			if userID, err := getUserIDFromCookie(authService, cookieName, r); err == nil {
-				ctx := context.WithValue(r.Context(), ContextUserID, userID)
-				r = r.WithContext(ctx)
+			if sess, err := getSessionFromRequest(config, authService, r); err == nil && sess != nil {
+				extendSession(w, r, config, authService, sess)
+				r = r.WithContext(withSession(r.Context(), sess))
 			}
 			next.ServeHTTP(w, r)
 		})
@@ -114,17 +202,15 @@ func CorsMiddleware(allowedOrigins []string) func(http.Handler) http.Handler {
 	}
 }
 
-func CSRFMiddleware(csrfConfig models.CSRFConfig) func(http.Handler) http.Handler {
+func CSRFMiddleware(config *models.Config) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			if r.Method == http.MethodGet ||
-				r.Method == http.MethodHead ||
-				r.Method == http.MethodOptions {
+			if !needsCSRFCheck(config, r) {
 				next.ServeHTTP(w, r)
 				return
 			}
 
-			if err := validateCSRF(csrfConfig, r); err != nil {
+			if err := validateCSRF(config.CSRF, r); err != nil {
 				util.JSONResponse(w, http.StatusForbidden, map[string]any{"message": "invalid CSRF token"})
 				return
 			}
@@ -135,10 +221,10 @@ func CSRFMiddleware(csrfConfig models.CSRFConfig) func(http.Handler) http.Handle
 }
 
 // RedirectAuthMiddleware redirects unauthenticated users to the specified URL.
-func RedirectAuthMiddleware(authService *auth.Service, cookieName string, redirectURL string, status int) func(http.Handler) http.Handler {
+func RedirectAuthMiddleware(config *models.Config, authService *auth.Service, redirectURL string, status int) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			userID, err := getUserIDFromCookie(authService, cookieName, r)
+			userID, err := getUserIDFromRequest(config, authService, r)
 			if err != nil || userID == "" {
 				http.Redirect(w, r, redirectURL, status)
 				return
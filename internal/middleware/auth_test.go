@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/GoBetterAuth/go-better-auth/config"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+func TestCSRFMiddleware(t *testing.T) {
+	cfg := config.NewConfig(config.WithCSRF(models.CSRFConfig{Enabled: true}))
+
+	tests := []struct {
+		name     string
+		method   string
+		cookies  map[string]string
+		headers  map[string]string
+		expected int
+	}{
+		{
+			name:     "safe method",
+			method:   http.MethodGet,
+			cookies:  map[string]string{cfg.Session.CookieName: "session"},
+			expected: http.StatusOK,
+		},
+		{
+			name:     "cookie session with matching token",
+			method:   http.MethodPost,
+			cookies:  map[string]string{cfg.Session.CookieName: "session", cfg.CSRF.CookieName: "csrf"},
+			headers:  map[string]string{cfg.CSRF.HeaderName: "csrf"},
+			expected: http.StatusOK,
+		},
+		{
+			name:     "cookie session without header",
+			method:   http.MethodPost,
+			cookies:  map[string]string{cfg.Session.CookieName: "session", cfg.CSRF.CookieName: "csrf"},
+			expected: http.StatusForbidden,
+		},
+		{
+			name:     "cookie session with mismatching token",
+			method:   http.MethodPost,
+			cookies:  map[string]string{cfg.Session.CookieName: "session", cfg.CSRF.CookieName: "csrf"},
+			headers:  map[string]string{cfg.CSRF.HeaderName: "other"},
+			expected: http.StatusForbidden,
+		},
+		{
+			name:     "cookie session with a bearer header",
+			method:   http.MethodPost,
+			cookies:  map[string]string{cfg.Session.CookieName: "session"},
+			headers:  map[string]string{"Authorization": "Bearer junk"},
+			expected: http.StatusForbidden,
+		},
+		{
+			name:     "bearer session",
+			method:   http.MethodPost,
+			headers:  map[string]string{"Authorization": "Bearer session"},
+			expected: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := CSRFMiddleware(cfg)(
+				http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					w.WriteHeader(http.StatusOK)
+				}),
+			)
+
+			req := httptest.NewRequest(tt.method, "/sign-out", nil)
+			for name, value := range tt.cookies {
+				req.AddCookie(&http.Cookie{Name: name, Value: value})
+			}
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.expected, rec.Code)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package middleware
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"net/url"
+
+	"github.com/GoBetterAuth/go-better-auth/internal/auth"
+	"github.com/GoBetterAuth/go-better-auth/internal/util"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+// ContextClientID holds the ID of the OAuth client that authenticated the request. It is empty when the
+// admin API key was used instead.
+const ContextClientID AuthContextKey = "client_id"
+
+// ClientAuth is middleware for endpoints called by other services rather than by users. The caller must
+// either present the admin API key or the credentials of a confidential OAuth client, through HTTP basic
+// authentication or the client_id and client_secret form parameters.
+func ClientAuth(apiKey string, authService *auth.Service) func(http.Handler) http.Handler {
+	return func(h http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if key := r.Header.Get("X-API-KEY"); apiKey != "" && subtle.ConstantTimeCompare([]byte(key), []byte(apiKey)) == 1 {
+				ctx := context.WithValue(r.Context(), ContextClientID, "")
+				h.ServeHTTP(w, r.WithContext(ctx))
+				return
+			}
+
+			clientID, clientSecret, ok := getClientCredentials(r)
+			if !ok {
+				w.Header().Set("WWW-Authenticate", `Basic realm="oauth"`)
+				util.JSONResponse(w, http.StatusUnauthorized, models.NewOAuthError("invalid_client", "client authentication required"))
+				return
+			}
+
+			client, err := authService.OIDCProviderService.GetClientByClientID(clientID)
+			if err != nil {
+				util.JSONResponse(w, http.StatusInternalServerError, models.NewOAuthError("server_error", "failed to authenticate client"))
+				return
+			}
+			if client == nil || client.Public || client.ClientSecret == nil ||
+				subtle.ConstantTimeCompare([]byte(authService.TokenService.HashToken(clientSecret)), []byte(*client.ClientSecret)) != 1 {
+				w.Header().Set("WWW-Authenticate", `Basic realm="oauth"`)
+				util.JSONResponse(w, http.StatusUnauthorized, models.NewOAuthError("invalid_client", "invalid client credentials"))
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), ContextClientID, client.ClientID)
+			h.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// getClientCredentials reads the client credentials from the Authorization header or the form body.
+func getClientCredentials(r *http.Request) (string, string, bool) {
+	// client_secret_basic credentials are form encoded before being base64 encoded
+	if clientID, clientSecret, ok := r.BasicAuth(); ok {
+		clientID, _ = url.QueryUnescape(clientID)
+		clientSecret, _ = url.QueryUnescape(clientSecret)
+		return clientID, clientSecret, clientID != "" && clientSecret != ""
+	}
+
+	if err := r.ParseForm(); err != nil {
+		return "", "", false
+	}
+	clientID := r.PostForm.Get("client_id")
+	clientSecret := r.PostForm.Get("client_secret")
+	return clientID, clientSecret, clientID != "" && clientSecret != ""
+}
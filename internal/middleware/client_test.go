@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientAuth_AdminAPIKey(t *testing.T) {
+	middleware := ClientAuth("admin-key", nil) // authService is nil, the admin key is checked first
+
+	handler := middleware(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			clientID, ok := r.Context().Value(ContextClientID).(string)
+			assert.True(t, ok)
+			assert.Empty(t, clientID)
+			w.WriteHeader(http.StatusOK)
+		}),
+	)
+
+	req := httptest.NewRequest("POST", "/introspect", nil)
+	req.Header.Set("X-API-KEY", "admin-key")
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestClientAuth_MissingCredentials(t *testing.T) {
+	middleware := ClientAuth("admin-key", nil)
+
+	handler := middleware(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Fatal("handler must not be called without credentials")
+		}),
+	)
+
+	req := httptest.NewRequest("POST", "/introspect", nil)
+	req.Header.Set("X-API-KEY", "wrong-key")
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Contains(t, rec.Body.String(), "invalid_client")
+	assert.NotEmpty(t, rec.Header().Get("WWW-Authenticate"))
+}
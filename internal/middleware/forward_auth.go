@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/GoBetterAuth/go-better-auth/internal/auth"
+	"github.com/GoBetterAuth/go-better-auth/internal/util"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+// ForwardAuthMiddleware authenticates the requests a reverse proxy forwards to /verify-request, applying
+// the forward auth settings of the upstream host. Anonymous browsers are redirected to the login URL the
+// same way RedirectAuthMiddleware does, other anonymous requests are answered with 401.
+func ForwardAuthMiddleware(config *models.Config, authService *auth.Service) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !config.ForwardAuth.Enabled {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if sess, err := getSessionFromRequest(config, authService, r); err == nil && sess != nil {
+				extendSession(w, r, config, authService, sess)
+				next.ServeHTTP(w, r.WithContext(withSession(r.Context(), sess)))
+				return
+			}
+
+			hostConfig := getForwardAuthHostConfig(config.ForwardAuth, util.GetForwardedHost(r))
+			if hostConfig.Public {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if hostConfig.LoginURL != "" && strings.Contains(r.Header.Get("Accept"), "text/html") {
+				loginURL := hostConfig.LoginURL
+				if callbackURL := util.GetForwardedURL(r); callbackURL != "" {
+					loginURL = util.AppendQueryParam(loginURL, "callback_url", callbackURL)
+				}
+				RedirectAuthMiddleware(config, authService, loginURL, config.ForwardAuth.RedirectStatus)(next).ServeHTTP(w, r)
+				return
+			}
+
+			util.JSONResponse(w, http.StatusUnauthorized, map[string]any{"message": "unauthorized"})
+		})
+	}
+}
+
+// getForwardAuthHostConfig returns the settings of an upstream host, falling back to the global ones.
+func getForwardAuthHostConfig(forwardAuthConfig models.ForwardAuthConfig, host string) models.ForwardAuthHostConfig {
+	hostConfig, ok := forwardAuthConfig.Hosts[host]
+	if !ok {
+		// Hosts are matched without the port when no entry includes it
+		hostConfig = forwardAuthConfig.Hosts[strings.Split(host, ":")[0]]
+	}
+	if hostConfig.LoginURL == "" {
+		hostConfig.LoginURL = forwardAuthConfig.LoginURL
+	}
+	return hostConfig
+}
@@ -0,0 +1,78 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/GoBetterAuth/go-better-auth/config"
+	"github.com/GoBetterAuth/go-better-auth/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func newForwardAuthTestHandler(called *bool) http.Handler {
+	config := config.NewConfig(
+		config.WithForwardAuth(models.ForwardAuthConfig{
+			Enabled:  true,
+			LoginURL: "https://auth.example.com/sign-in",
+			Hosts: map[string]models.ForwardAuthHostConfig{
+				"docs.example.com": {Public: true},
+			},
+		}),
+	)
+
+	// authService is nil, requests without a session token never reach it
+	return ForwardAuthMiddleware(config, nil)(
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*called = true
+			w.WriteHeader(http.StatusOK)
+		}),
+	)
+}
+
+func TestForwardAuthMiddleware_AnonymousAPIRequest(t *testing.T) {
+	called := false
+	handler := newForwardAuthTestHandler(&called)
+
+	req := httptest.NewRequest("GET", "/verify-request", nil)
+	req.Header.Set("X-Forwarded-Host", "app.example.com")
+	req.Header.Set("Accept", "application/json")
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.False(t, called)
+}
+
+func TestForwardAuthMiddleware_AnonymousBrowserIsRedirected(t *testing.T) {
+	called := false
+	handler := newForwardAuthTestHandler(&called)
+
+	req := httptest.NewRequest("GET", "/verify-request", nil)
+	req.Header.Set("X-Forwarded-Proto", "https")
+	req.Header.Set("X-Forwarded-Host", "app.example.com")
+	req.Header.Set("X-Forwarded-Uri", "/dashboard")
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusFound, rec.Code)
+	assert.Equal(t, "https://auth.example.com/sign-in?callback_url=https%3A%2F%2Fapp.example.com%2Fdashboard", rec.Header().Get("Location"))
+	assert.False(t, called)
+}
+
+func TestForwardAuthMiddleware_PublicHost(t *testing.T) {
+	called := false
+	handler := newForwardAuthTestHandler(&called)
+
+	req := httptest.NewRequest("GET", "/verify-request", nil)
+	req.Header.Set("X-Forwarded-Host", "docs.example.com")
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.True(t, called)
+}
@@ -132,13 +132,13 @@ func EndpointHooksMiddleware(config *models.Config, authService *auth.Service) f
 				}
 			}
 
-			cookie, err := r.Cookie(config.Session.CookieName)
-			if err == nil && cookie.Value != "" {
-				session, _ := authService.SessionService.GetSessionByToken(authService.TokenService.HashToken(cookie.Value))
-				if session != nil {
+			if token, _ := util.GetSessionToken(config, r); token != "" {
+				session, _ := authService.SessionService.GetSessionByToken(authService.TokenService.HashToken(token))
+				if session != nil && !authService.SessionService.IsExpired(session) {
 					user, _ := authService.UserService.GetUserByID(session.UserID)
 					if user != nil {
 						hookCtx.User = user
+						hookCtx.Session = session
 					}
 				}
 			}
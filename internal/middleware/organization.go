@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"slices"
+
+	"github.com/GoBetterAuth/go-better-auth/internal/auth"
+	"github.com/GoBetterAuth/go-better-auth/internal/util"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+const (
+	ContextOrganizationID   AuthContextKey = "organization_id"
+	ContextOrganizationRole AuthContextKey = "organization_role"
+)
+
+// RequireOrganizationMember authenticates the request like AuthMiddleware and rejects it with 403 unless the
+// user is a member of the organization, holding one of the given roles when any are given. The organization
+// is read from the {organizationId} path value, falling back to the active organization of the session.
+func RequireOrganizationMember(config *models.Config, authService *auth.Service, roles ...models.OrganizationRole) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			sess, err := getSessionFromRequest(config, authService, r)
+			if err != nil || sess == nil {
+				util.JSONResponse(w, http.StatusUnauthorized, map[string]any{"message": "unauthorized"})
+				return
+			}
+
+			organizationID := r.PathValue("organizationId")
+			if organizationID == "" && sess.ActiveOrganizationID != nil {
+				organizationID = *sess.ActiveOrganizationID
+			}
+			if organizationID == "" {
+				util.JSONResponse(w, http.StatusBadRequest, map[string]any{"message": "no active organization"})
+				return
+			}
+
+			member, err := authService.OrganizationService.GetMember(organizationID, sess.UserID)
+			if err != nil {
+				util.JSONResponse(w, http.StatusInternalServerError, map[string]any{"message": "failed to check organization membership"})
+				return
+			}
+			if member == nil || (len(roles) > 0 && !slices.Contains(roles, member.Role)) {
+				util.JSONResponse(w, http.StatusForbidden, map[string]any{"message": "forbidden"})
+				return
+			}
+
+			extendSession(w, r, config, authService, sess)
+
+			ctx := withSession(r.Context(), sess)
+			ctx = context.WithValue(ctx, ContextOrganizationID, member.OrganizationID)
+			ctx = context.WithValue(ctx, ContextOrganizationRole, member.Role)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
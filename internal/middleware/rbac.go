@@ -0,0 +1,37 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/GoBetterAuth/go-better-auth/internal/auth"
+	"github.com/GoBetterAuth/go-better-auth/internal/util"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+// RequirePermission authenticates the request like AuthMiddleware and rejects it with 403 unless the
+// roles of the user grant the given "resource:action" permission.
+func RequirePermission(config *models.Config, authService *auth.Service, permission string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			sess, err := getSessionFromRequest(config, authService, r)
+			if err != nil || sess == nil {
+				util.JSONResponse(w, http.StatusUnauthorized, map[string]any{"message": "unauthorized"})
+				return
+			}
+
+			allowed, err := authService.RBACService.HasPermission(sess.UserID, permission)
+			if err != nil {
+				util.JSONResponse(w, http.StatusInternalServerError, map[string]any{"message": "failed to check permission"})
+				return
+			}
+			if !allowed {
+				util.JSONResponse(w, http.StatusForbidden, map[string]any{"message": "forbidden"})
+				return
+			}
+
+			extendSession(w, r, config, authService, sess)
+
+			next.ServeHTTP(w, r.WithContext(withSession(r.Context(), sess)))
+		})
+	}
+}
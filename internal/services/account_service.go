@@ -2,11 +2,14 @@ package services
 
 import (
 	"log/slog"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
+	"github.com/GoBetterAuth/go-better-auth/internal/constants"
 	"github.com/GoBetterAuth/go-better-auth/models"
 )
 
@@ -48,10 +51,11 @@ func (s *AccountServiceImpl) CreateAccount(a *models.Account) error {
 	return nil
 }
 
-// GetAccountByUserID retrieves an account by the associated user ID.
-func (s *AccountServiceImpl) GetAccountByUserID(userID string) (*models.Account, error) {
+// GetCredentialAccount retrieves the email and password account of a user.
+// Returns nil if the user only signs in through OAuth2 providers.
+func (s *AccountServiceImpl) GetCredentialAccount(userID string) (*models.Account, error) {
 	var account models.Account
-	if err := s.db.Where("user_id = ?", userID).First(&account).Error; err != nil {
+	if err := s.db.Where("user_id = ? AND provider_id = ?", userID, models.ProviderEmail).First(&account).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
 		}
@@ -60,6 +64,28 @@ func (s *AccountServiceImpl) GetAccountByUserID(userID string) (*models.Account,
 	return &account, nil
 }
 
+// GetAccountByUserIDAndProvider retrieves the account a user linked with a provider.
+// Returns nil if the user has not linked that provider.
+func (s *AccountServiceImpl) GetAccountByUserIDAndProvider(userID string, provider models.ProviderType) (*models.Account, error) {
+	var account models.Account
+	if err := s.db.Where("user_id = ? AND provider_id = ?", userID, provider).First(&account).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &account, nil
+}
+
+// ListAccountsByUserID returns every account of a user, oldest first.
+func (s *AccountServiceImpl) ListAccountsByUserID(userID string) ([]models.Account, error) {
+	var accounts []models.Account
+	if err := s.db.Where("user_id = ?", userID).Order("created_at ASC").Find(&accounts).Error; err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
 // GetAccountByProviderAndAccountID retrieves an account by provider and provider's account ID.
 func (s *AccountServiceImpl) GetAccountByProviderAndAccountID(provider models.ProviderType, accountID string) (*models.Account, error) {
 	var account models.Account
@@ -100,3 +126,32 @@ func (s *AccountServiceImpl) UpdateAccount(account *models.Account) error {
 
 	return nil
 }
+
+// DeleteAccount deletes an account by its ID.
+func (s *AccountServiceImpl) DeleteAccount(ID string) error {
+	return s.db.Where("id = ?", ID).Delete(&models.Account{}).Error
+}
+
+// UnlinkAccount deletes an account of a user unless it is their last one, which would lock them out.
+// The user's accounts stay locked between counting and deleting, so concurrent unlinks cannot both pass the check.
+func (s *AccountServiceImpl) UnlinkAccount(userID string, accountID string) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		var accountIDs []string
+		if err := tx.Model(&models.Account{}).
+			Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("user_id = ?", userID).
+			Pluck("id", &accountIDs).Error; err != nil {
+			return err
+		}
+
+		// Only the user's own accounts can be unlinked
+		if !slices.Contains(accountIDs, accountID) {
+			return constants.ErrAccountNotFound
+		}
+		if len(accountIDs) <= 1 {
+			return constants.ErrLastAccount
+		}
+
+		return tx.Where("id = ? AND user_id = ?", accountID, userID).Delete(&models.Account{}).Error
+	})
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/GoBetterAuth/go-better-auth/config"
+	"github.com/GoBetterAuth/go-better-auth/internal/constants"
+	"github.com/GoBetterAuth/go-better-auth/internal/testutil"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+func createAccounts(t *testing.T, service *AccountServiceImpl, userID string, providers ...models.ProviderType) []string {
+	t.Helper()
+
+	ids := make([]string, 0, len(providers))
+	for _, provider := range providers {
+		account := &models.Account{UserID: userID, AccountID: userID + "-" + string(provider), ProviderID: provider}
+		if err := service.CreateAccount(account); err != nil {
+			t.Fatalf("CreateAccount failed: %v", err)
+		}
+		ids = append(ids, account.ID)
+	}
+	return ids
+}
+
+func TestAccountService_UnlinkAccount(t *testing.T) {
+	service := NewAccountServiceImpl(config.NewConfig(), testutil.NewDB(t, &models.Account{}))
+
+	ids := createAccounts(t, service, "user-1", models.ProviderEmail, models.ProviderGitHub)
+	otherIDs := createAccounts(t, service, "user-2", models.ProviderEmail, models.ProviderGitHub)
+
+	if err := service.UnlinkAccount("user-1", otherIDs[0]); !errors.Is(err, constants.ErrAccountNotFound) {
+		t.Fatalf("expected ErrAccountNotFound for another user's account, got %v", err)
+	}
+
+	if err := service.UnlinkAccount("user-1", ids[1]); err != nil {
+		t.Fatalf("UnlinkAccount failed: %v", err)
+	}
+
+	if err := service.UnlinkAccount("user-1", ids[0]); !errors.Is(err, constants.ErrLastAccount) {
+		t.Fatalf("expected ErrLastAccount, got %v", err)
+	}
+
+	accounts, err := service.ListAccountsByUserID("user-1")
+	if err != nil {
+		t.Fatalf("ListAccountsByUserID failed: %v", err)
+	}
+	if len(accounts) != 1 || accounts[0].ID != ids[0] {
+		t.Fatalf("expected only account %s to remain, got %+v", ids[0], accounts)
+	}
+
+	if accounts, _ := service.ListAccountsByUserID("user-2"); len(accounts) != 2 {
+		t.Fatalf("expected other user's accounts to be untouched, got %d", len(accounts))
+	}
+}
+
+func TestAccountService_UnlinkAccountConcurrently(t *testing.T) {
+	service := NewAccountServiceImpl(config.NewConfig(), testutil.NewDB(t, &models.Account{}))
+
+	ids := createAccounts(t, service, "user-1", models.ProviderEmail, models.ProviderGitHub)
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(ids))
+	for i, id := range ids {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = service.UnlinkAccount("user-1", id)
+		}()
+	}
+	wg.Wait()
+
+	accounts, err := service.ListAccountsByUserID("user-1")
+	if err != nil {
+		t.Fatalf("ListAccountsByUserID failed: %v", err)
+	}
+	if len(accounts) != 1 {
+		t.Fatalf("expected exactly one account to remain, got %d (errors: %v)", len(accounts), errs)
+	}
+}
+
+func TestAccountService_GetCredentialAccount(t *testing.T) {
+	service := NewAccountServiceImpl(config.NewConfig(), testutil.NewDB(t, &models.Account{}))
+
+	// The OAuth2 account is linked first, so it is the first account of the user
+	ids := createAccounts(t, service, "user-1", models.ProviderGitHub, models.ProviderEmail)
+	createAccounts(t, service, "user-2", models.ProviderGitHub)
+
+	account, err := service.GetCredentialAccount("user-1")
+	if err != nil {
+		t.Fatalf("GetCredentialAccount failed: %v", err)
+	}
+	if account == nil || account.ID != ids[1] || account.ProviderID != models.ProviderEmail {
+		t.Fatalf("expected the email account %s, got %+v", ids[1], account)
+	}
+
+	// Users signing in only through OAuth2 providers have no credential account
+	if account, err := service.GetCredentialAccount("user-2"); err != nil || account != nil {
+		t.Fatalf("expected no credential account, got %+v, %v", account, err)
+	}
+}
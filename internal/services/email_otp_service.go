@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"strings"
+
+	"github.com/GoBetterAuth/go-better-auth/internal/constants"
+	"github.com/GoBetterAuth/go-better-auth/internal/util"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+const emailOTPPrefix = "email_otp:"
+
+// EmailOTPServiceImpl stores email one-time codes in secondary storage. Only the hash of a code
+// is stored, keyed by purpose and email so that issuing a new code replaces the previous one.
+type EmailOTPServiceImpl struct {
+	config *models.Config
+}
+
+func NewEmailOTPServiceImpl(config *models.Config) *EmailOTPServiceImpl {
+	return &EmailOTPServiceImpl{config: config}
+}
+
+// CreateOTP issues a new code for the email and purpose and returns the raw code.
+func (s *EmailOTPServiceImpl) CreateOTP(ctx context.Context, email string, otpType models.EmailOTPType) (string, error) {
+	code, err := util.GenerateNumericCode(s.config.EmailOTP.Length)
+	if err != nil {
+		return "", fmt.Errorf("generate code: %w", err)
+	}
+
+	key := s.otpKey(email, otpType)
+	// A new code resets the attempt counter and the consumption guard of the previous one
+	if err := s.config.SecondaryStorage.Storage.Delete(ctx, key+":attempts"); err != nil {
+		return "", fmt.Errorf("reset attempts: %w", err)
+	}
+	if err := s.config.SecondaryStorage.Storage.Delete(ctx, key+consumedSuffix); err != nil {
+		return "", fmt.Errorf("reset consumption: %w", err)
+	}
+
+	ttl := s.config.EmailOTP.ExpiresIn
+	if err := s.config.SecondaryStorage.Storage.Set(ctx, key, util.HashTokenWithSecret(code, s.config.Secret), &ttl); err != nil {
+		return "", fmt.Errorf("store code: %w", err)
+	}
+
+	return code, nil
+}
+
+// VerifyOTP checks the code and counts the attempt. A correct code is consumed, once even under
+// concurrent requests, and the code is invalidated once the maximum number of wrong attempts has
+// been reached.
+func (s *EmailOTPServiceImpl) VerifyOTP(ctx context.Context, email string, otpType models.EmailOTPType, code string) error {
+	if code == "" {
+		return constants.ErrEmailOTPInvalid
+	}
+
+	key := s.otpKey(email, otpType)
+	value, err := s.config.SecondaryStorage.Storage.Get(ctx, key)
+	if err != nil {
+		return fmt.Errorf("get code: %w", err)
+	}
+	hashedCode, ok := value.(string)
+	if !ok || hashedCode == "" {
+		return constants.ErrEmailOTPInvalid
+	}
+
+	ttl := s.config.EmailOTP.ExpiresIn
+	attempts, err := s.config.SecondaryStorage.Storage.Incr(ctx, key+":attempts", &ttl)
+	if err != nil {
+		return fmt.Errorf("count attempts: %w", err)
+	}
+	if attempts > s.config.EmailOTP.MaxAttempts {
+		_ = s.DeleteOTP(ctx, email, otpType)
+		return constants.ErrEmailOTPAttempts
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashedCode), []byte(util.HashTokenWithSecret(code, s.config.Secret))) != 1 {
+		return constants.ErrEmailOTPInvalid
+	}
+
+	claimed, err := claimOnce(ctx, s.config.SecondaryStorage.Storage, key, ttl)
+	if err != nil {
+		return fmt.Errorf("consume code: %w", err)
+	}
+	if !claimed {
+		return constants.ErrEmailOTPInvalid
+	}
+
+	return s.DeleteOTP(ctx, email, otpType)
+}
+
+// DeleteOTP removes the code for the email and purpose.
+func (s *EmailOTPServiceImpl) DeleteOTP(ctx context.Context, email string, otpType models.EmailOTPType) error {
+	key := s.otpKey(email, otpType)
+	if err := s.config.SecondaryStorage.Storage.Delete(ctx, key+":attempts"); err != nil {
+		return err
+	}
+	return s.config.SecondaryStorage.Storage.Delete(ctx, key)
+}
+
+func (s *EmailOTPServiceImpl) otpKey(email string, otpType models.EmailOTPType) string {
+	return emailOTPPrefix + string(otpType) + ":" + util.HashTokenWithSecret(strings.ToLower(email), s.config.Secret)
+}
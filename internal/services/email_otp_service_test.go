@@ -0,0 +1,133 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/GoBetterAuth/go-better-auth/config"
+	"github.com/GoBetterAuth/go-better-auth/internal/constants"
+	"github.com/GoBetterAuth/go-better-auth/models"
+	"github.com/GoBetterAuth/go-better-auth/storage"
+)
+
+func newTestEmailOTPService() *EmailOTPServiceImpl {
+	return newTestEmailOTPServiceWithStorage(storage.NewMemorySecondaryStorage(models.SecondaryStorageMemoryOptions{}), 3)
+}
+
+func newTestEmailOTPServiceWithStorage(secondaryStorage models.SecondaryStorage, maxAttempts int) *EmailOTPServiceImpl {
+	return NewEmailOTPServiceImpl(config.NewConfig(
+		config.WithSecret("test-secret"),
+		config.WithSecondaryStorage(
+			models.SecondaryStorageConfig{
+				Storage: secondaryStorage,
+			},
+		),
+		config.WithEmailOTP(models.EmailOTPConfig{Enabled: true, MaxAttempts: maxAttempts}),
+	))
+}
+
+// slowReadStorage widens the window between reading a code and consuming it.
+type slowReadStorage struct {
+	models.SecondaryStorage
+}
+
+func (s slowReadStorage) Get(ctx context.Context, key string) (any, error) {
+	value, err := s.SecondaryStorage.Get(ctx, key)
+	time.Sleep(20 * time.Millisecond)
+	return value, err
+}
+
+func TestEmailOTPService_VerifyOTP(t *testing.T) {
+	ctx := context.Background()
+	service := newTestEmailOTPService()
+
+	code, err := service.CreateOTP(ctx, "user@example.com", models.EmailOTPTypeSignIn)
+	if err != nil {
+		t.Fatalf("CreateOTP failed: %v", err)
+	}
+	if len(code) != 6 {
+		t.Fatalf("expected a 6 digit code, got %q", code)
+	}
+
+	// Codes are bound to their purpose
+	if err := service.VerifyOTP(ctx, "user@example.com", models.EmailOTPTypePasswordReset, code); !errors.Is(err, constants.ErrEmailOTPInvalid) {
+		t.Fatalf("expected code of another type to be rejected, got %v", err)
+	}
+
+	if err := service.VerifyOTP(ctx, "User@Example.com", models.EmailOTPTypeSignIn, code); err != nil {
+		t.Fatalf("VerifyOTP failed: %v", err)
+	}
+
+	// Codes are single use
+	if err := service.VerifyOTP(ctx, "user@example.com", models.EmailOTPTypeSignIn, code); !errors.Is(err, constants.ErrEmailOTPInvalid) {
+		t.Fatalf("expected used code to be rejected, got %v", err)
+	}
+}
+
+func TestEmailOTPService_Lockout(t *testing.T) {
+	ctx := context.Background()
+	service := newTestEmailOTPService()
+
+	code, err := service.CreateOTP(ctx, "user@example.com", models.EmailOTPTypeSignIn)
+	if err != nil {
+		t.Fatalf("CreateOTP failed: %v", err)
+	}
+
+	wrong := "000000"
+	if code == wrong {
+		wrong = "111111"
+	}
+	for i := range 3 {
+		if err := service.VerifyOTP(ctx, "user@example.com", models.EmailOTPTypeSignIn, wrong); !errors.Is(err, constants.ErrEmailOTPInvalid) {
+			t.Fatalf("attempt %d: expected invalid code, got %v", i+1, err)
+		}
+	}
+
+	// The correct code no longer works once the attempts are exhausted
+	if err := service.VerifyOTP(ctx, "user@example.com", models.EmailOTPTypeSignIn, code); !errors.Is(err, constants.ErrEmailOTPAttempts) {
+		t.Fatalf("expected lockout, got %v", err)
+	}
+	if err := service.VerifyOTP(ctx, "user@example.com", models.EmailOTPTypeSignIn, code); !errors.Is(err, constants.ErrEmailOTPInvalid) {
+		t.Fatalf("expected code to be invalidated after lockout, got %v", err)
+	}
+}
+
+func TestEmailOTPService_VerifyOTPConcurrently(t *testing.T) {
+	ctx := context.Background()
+	service := newTestEmailOTPServiceWithStorage(slowReadStorage{storage.NewMemorySecondaryStorage(models.SecondaryStorageMemoryOptions{})}, 50)
+
+	code, err := service.CreateOTP(ctx, "user@example.com", models.EmailOTPTypeSignIn)
+	if err != nil {
+		t.Fatalf("CreateOTP failed: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	var succeeded atomic.Int32
+	for range 20 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := service.VerifyOTP(ctx, "user@example.com", models.EmailOTPTypeSignIn, code); err == nil {
+				succeeded.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got := succeeded.Load(); got != 1 {
+		t.Fatalf("expected the code to be redeemed once, got %d", got)
+	}
+
+	// A new code for the same email is not blocked by the previous one
+	code, err = service.CreateOTP(ctx, "user@example.com", models.EmailOTPTypeSignIn)
+	if err != nil {
+		t.Fatalf("CreateOTP failed: %v", err)
+	}
+	if err := service.VerifyOTP(ctx, "user@example.com", models.EmailOTPTypeSignIn, code); err != nil {
+		t.Fatalf("VerifyOTP failed: %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+const (
+	sessionCachePrefix = "session_cache:"
+	userCachePrefix    = "user_cache:"
+)
+
+// lookupCache stores JSON encoded records in secondary storage when session caching is enabled.
+// Cache failures are logged and otherwise ignored so that the database remains the source of truth.
+type lookupCache struct {
+	config *models.Config
+}
+
+func (c lookupCache) enabled() bool {
+	return c.config.Session.Cache.Enabled && c.config.SecondaryStorage.Storage != nil
+}
+
+// get decodes the cached value for key into dest and reports whether it was found.
+func (c lookupCache) get(key string, dest any) bool {
+	if !c.enabled() {
+		return false
+	}
+
+	value, err := c.config.SecondaryStorage.Storage.Get(context.Background(), key)
+	if err != nil {
+		slog.Warn("failed to read lookup cache", "key", key, "error", err)
+		return false
+	}
+	data, ok := value.(string)
+	if !ok || data == "" {
+		return false
+	}
+
+	if err := json.Unmarshal([]byte(data), dest); err != nil {
+		slog.Warn("failed to decode lookup cache entry", "key", key, "error", err)
+		return false
+	}
+	return true
+}
+
+func (c lookupCache) set(key string, value any) {
+	if !c.enabled() {
+		return
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		slog.Warn("failed to encode lookup cache entry", "key", key, "error", err)
+		return
+	}
+
+	ttl := c.config.Session.Cache.TTL
+	if err := c.config.SecondaryStorage.Storage.Set(context.Background(), key, string(data), &ttl); err != nil {
+		slog.Warn("failed to write lookup cache", "key", key, "error", err)
+	}
+}
+
+func (c lookupCache) delete(keys ...string) {
+	if !c.enabled() {
+		return
+	}
+
+	for _, key := range keys {
+		if err := c.config.SecondaryStorage.Storage.Delete(context.Background(), key); err != nil {
+			slog.Warn("failed to invalidate lookup cache", "key", key, "error", err)
+		}
+	}
+}
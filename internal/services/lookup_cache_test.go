@@ -0,0 +1,50 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/GoBetterAuth/go-better-auth/config"
+	"github.com/GoBetterAuth/go-better-auth/models"
+	"github.com/GoBetterAuth/go-better-auth/storage"
+)
+
+func newTestLookupCache(enabled bool) lookupCache {
+	return lookupCache{config: config.NewConfig(
+		config.WithSecondaryStorage(
+			models.SecondaryStorageConfig{
+				Storage: storage.NewMemorySecondaryStorage(models.SecondaryStorageMemoryOptions{}),
+			},
+		),
+		config.WithSession(models.SessionConfig{Cache: models.SessionCacheConfig{Enabled: enabled}}),
+	)}
+}
+
+func TestLookupCache_SetGetDelete(t *testing.T) {
+	cache := newTestLookupCache(true)
+	key := sessionCachePrefix + "hashed-token"
+
+	cache.set(key, models.Session{ID: "session-1", UserID: "user-1", Token: "hashed-token"})
+
+	var sess models.Session
+	if !cache.get(key, &sess) {
+		t.Fatal("expected cached session to be found")
+	}
+	if sess.ID != "session-1" || sess.UserID != "user-1" {
+		t.Fatalf("unexpected cached session: %+v", sess)
+	}
+
+	cache.delete(key)
+	if cache.get(key, &models.Session{}) {
+		t.Fatal("expected session to be removed from the cache")
+	}
+}
+
+func TestLookupCache_Disabled(t *testing.T) {
+	cache := newTestLookupCache(false)
+	key := userCachePrefix + "user-1"
+
+	cache.set(key, models.User{ID: "user-1"})
+	if cache.get(key, &models.User{}) {
+		t.Fatal("expected disabled cache to never return entries")
+	}
+}
@@ -0,0 +1,210 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/GoBetterAuth/go-better-auth/internal/util"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+const (
+	oauthGrantPrefix = "oidc_grant:"
+	// oauthGrantsRevokedPrefix marks when the grants of a user were last revoked, grants issued before are rejected
+	oauthGrantsRevokedPrefix = "oidc_grants_revoked:"
+)
+
+// OIDCProviderServiceImpl stores the clients and consents of the OpenID Connect provider in the database,
+// and the short-lived grants issued to them in secondary storage.
+type OIDCProviderServiceImpl struct {
+	config *models.Config
+	db     *gorm.DB
+}
+
+func NewOIDCProviderServiceImpl(config *models.Config, db *gorm.DB) *OIDCProviderServiceImpl {
+	return &OIDCProviderServiceImpl{config: config, db: db}
+}
+
+// CreateClient stores a newly registered client.
+func (s *OIDCProviderServiceImpl) CreateClient(client *models.OAuthClient) error {
+	if client.ID == "" {
+		client.ID = uuid.NewString()
+	}
+	client.CreatedAt = time.Now().UTC()
+	client.UpdatedAt = time.Now().UTC()
+
+	return s.db.Create(client).Error
+}
+
+// GetClientByClientID retrieves a client by its public client ID.
+func (s *OIDCProviderServiceImpl) GetClientByClientID(clientID string) (*models.OAuthClient, error) {
+	var client models.OAuthClient
+	if err := s.db.Where("client_id = ?", clientID).First(&client).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &client, nil
+}
+
+// GetConsent retrieves the scopes a user granted to a client.
+func (s *OIDCProviderServiceImpl) GetConsent(userID string, clientID string) (*models.OAuthConsent, error) {
+	var consent models.OAuthConsent
+	if err := s.db.Where("user_id = ? AND client_id = ?", userID, clientID).First(&consent).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &consent, nil
+}
+
+// SaveConsent records the scopes a user granted to a client, adding to any earlier consent.
+func (s *OIDCProviderServiceImpl) SaveConsent(userID string, clientID string, scopes []string) error {
+	consent, err := s.GetConsent(userID, clientID)
+	if err != nil {
+		return err
+	}
+
+	if consent == nil {
+		return s.db.Create(&models.OAuthConsent{
+			ID:        uuid.NewString(),
+			UserID:    userID,
+			ClientID:  clientID,
+			Scopes:    scopes,
+			CreatedAt: time.Now().UTC(),
+			UpdatedAt: time.Now().UTC(),
+		}).Error
+	}
+
+	for _, scope := range scopes {
+		if !consent.Covers([]string{scope}) {
+			consent.Scopes = append(consent.Scopes, scope)
+		}
+	}
+	consent.UpdatedAt = time.Now().UTC()
+	return s.db.Save(consent).Error
+}
+
+// CreateGrant stores a grant of the given kind until its expiry and returns the opaque token referencing it.
+func (s *OIDCProviderServiceImpl) CreateGrant(ctx context.Context, kind models.OAuthGrantKind, grant *models.OAuthGrant) (string, error) {
+	token, err := util.GenerateToken()
+	if err != nil {
+		return "", fmt.Errorf("generate token: %w", err)
+	}
+
+	grant.IssuedAt = time.Now().UTC()
+	data, err := json.Marshal(grant)
+	if err != nil {
+		return "", fmt.Errorf("encode grant: %w", err)
+	}
+
+	ttl := time.Until(grant.ExpiresAt)
+	if err := s.config.SecondaryStorage.Storage.Set(ctx, s.grantKey(kind, token), string(data), &ttl); err != nil {
+		return "", fmt.Errorf("store grant: %w", err)
+	}
+
+	return token, nil
+}
+
+// GetGrant retrieves the grant referenced by a token. Returns nil if it does not exist or expired.
+func (s *OIDCProviderServiceImpl) GetGrant(ctx context.Context, kind models.OAuthGrantKind, token string) (*models.OAuthGrant, error) {
+	if token == "" {
+		return nil, nil
+	}
+
+	value, err := s.config.SecondaryStorage.Storage.Get(ctx, s.grantKey(kind, token))
+	if err != nil {
+		return nil, fmt.Errorf("get grant: %w", err)
+	}
+	data, ok := value.(string)
+	if !ok || data == "" {
+		return nil, nil
+	}
+
+	var grant models.OAuthGrant
+	if err := json.Unmarshal([]byte(data), &grant); err != nil {
+		return nil, fmt.Errorf("decode grant: %w", err)
+	}
+	if time.Now().UTC().After(grant.ExpiresAt) {
+		return nil, nil
+	}
+
+	revoked, err := s.isRevoked(ctx, &grant)
+	if err != nil || revoked {
+		return nil, err
+	}
+
+	return &grant, nil
+}
+
+// ConsumeGrant retrieves the grant referenced by a token and deletes it so it can only be used once, even by
+// concurrent requests.
+func (s *OIDCProviderServiceImpl) ConsumeGrant(ctx context.Context, kind models.OAuthGrantKind, token string) (*models.OAuthGrant, error) {
+	grant, err := s.GetGrant(ctx, kind, token)
+	if err != nil || grant == nil {
+		return grant, err
+	}
+
+	claimed, err := claimOnce(ctx, s.config.SecondaryStorage.Storage, s.grantKey(kind, token), time.Until(grant.ExpiresAt))
+	if err != nil {
+		return nil, fmt.Errorf("consume grant: %w", err)
+	}
+	if !claimed {
+		return nil, nil
+	}
+
+	if err := s.DeleteGrant(ctx, kind, token); err != nil {
+		return nil, err
+	}
+
+	return grant, nil
+}
+
+// DeleteGrant revokes the grant referenced by a token.
+func (s *OIDCProviderServiceImpl) DeleteGrant(ctx context.Context, kind models.OAuthGrantKind, token string) error {
+	if err := s.config.SecondaryStorage.Storage.Delete(ctx, s.grantKey(kind, token)); err != nil {
+		return fmt.Errorf("delete grant: %w", err)
+	}
+	return nil
+}
+
+// RevokeUserGrants revokes every grant issued to a user so far. Grants are only looked up by token,
+// so the time of the revocation is stored instead, until the longest lived grant would have expired.
+func (s *OIDCProviderServiceImpl) RevokeUserGrants(ctx context.Context, userID string) error {
+	ttl := max(s.config.OIDCProvider.CodeExpiresIn, s.config.OIDCProvider.AccessTokenExpiresIn)
+	revokedAt := strconv.FormatInt(time.Now().UTC().UnixNano(), 10)
+	if err := s.config.SecondaryStorage.Storage.Set(ctx, oauthGrantsRevokedPrefix+userID, revokedAt, &ttl); err != nil {
+		return fmt.Errorf("revoke grants: %w", err)
+	}
+	return nil
+}
+
+// isRevoked reports whether the grants of the user were revoked after the grant was issued.
+func (s *OIDCProviderServiceImpl) isRevoked(ctx context.Context, grant *models.OAuthGrant) (bool, error) {
+	value, err := s.config.SecondaryStorage.Storage.Get(ctx, oauthGrantsRevokedPrefix+grant.UserID)
+	if err != nil {
+		return false, fmt.Errorf("get grant revocation: %w", err)
+	}
+	data, ok := value.(string)
+	if !ok || data == "" {
+		return false, nil
+	}
+
+	revokedAt, err := strconv.ParseInt(data, 10, 64)
+	if err != nil {
+		return false, fmt.Errorf("decode grant revocation: %w", err)
+	}
+	return !grant.IssuedAt.After(time.Unix(0, revokedAt)), nil
+}
+
+func (s *OIDCProviderServiceImpl) grantKey(kind models.OAuthGrantKind, token string) string {
+	return oauthGrantPrefix + string(kind) + ":" + util.HashTokenWithSecret(token, s.config.Secret)
+}
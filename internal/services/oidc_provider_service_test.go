@@ -0,0 +1,110 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/GoBetterAuth/go-better-auth/config"
+	"github.com/GoBetterAuth/go-better-auth/models"
+	"github.com/GoBetterAuth/go-better-auth/storage"
+)
+
+func newTestOIDCProviderService() *OIDCProviderServiceImpl {
+	return NewOIDCProviderServiceImpl(config.NewConfig(
+		config.WithSecondaryStorage(
+			models.SecondaryStorageConfig{
+				Storage: storage.NewMemorySecondaryStorage(models.SecondaryStorageMemoryOptions{}),
+			},
+		),
+	), nil)
+}
+
+func TestOIDCProviderService_ConsumeGrantOnlyOnce(t *testing.T) {
+	service := newTestOIDCProviderService()
+	ctx := context.Background()
+
+	code, err := service.CreateGrant(ctx, models.OAuthGrantKindAuthorizationCode, &models.OAuthGrant{
+		ClientID:  "client-1",
+		UserID:    "user-1",
+		Scopes:    []string{models.ScopeOpenID},
+		ExpiresAt: time.Now().UTC().Add(time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("CreateGrant failed: %v", err)
+	}
+
+	grant, err := service.ConsumeGrant(ctx, models.OAuthGrantKindAuthorizationCode, code)
+	if err != nil {
+		t.Fatalf("ConsumeGrant failed: %v", err)
+	}
+	if grant == nil || grant.ClientID != "client-1" || grant.UserID != "user-1" {
+		t.Fatalf("unexpected grant: %+v", grant)
+	}
+
+	grant, err = service.ConsumeGrant(ctx, models.OAuthGrantKindAuthorizationCode, code)
+	if err != nil {
+		t.Fatalf("ConsumeGrant failed: %v", err)
+	}
+	if grant != nil {
+		t.Fatal("expected authorization code to be usable only once")
+	}
+}
+
+func TestOIDCProviderService_GrantKindsAreSeparate(t *testing.T) {
+	service := newTestOIDCProviderService()
+	ctx := context.Background()
+
+	code, err := service.CreateGrant(ctx, models.OAuthGrantKindAuthorizationCode, &models.OAuthGrant{
+		ClientID:  "client-1",
+		ExpiresAt: time.Now().UTC().Add(time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("CreateGrant failed: %v", err)
+	}
+
+	grant, err := service.GetGrant(ctx, models.OAuthGrantKindAccessToken, code)
+	if err != nil {
+		t.Fatalf("GetGrant failed: %v", err)
+	}
+	if grant != nil {
+		t.Fatal("expected an authorization code not to be accepted as an access token")
+	}
+}
+
+func TestOIDCProviderService_RevokeUserGrants(t *testing.T) {
+	service := newTestOIDCProviderService()
+	ctx := context.Background()
+
+	createGrant := func(userID string) string {
+		t.Helper()
+		token, err := service.CreateGrant(ctx, models.OAuthGrantKindAccessToken, &models.OAuthGrant{
+			ClientID:  "client-1",
+			UserID:    userID,
+			ExpiresAt: time.Now().UTC().Add(time.Hour),
+		})
+		if err != nil {
+			t.Fatalf("CreateGrant failed: %v", err)
+		}
+		return token
+	}
+
+	revoked := createGrant("user-1")
+	other := createGrant("user-2")
+
+	if err := service.RevokeUserGrants(ctx, "user-1"); err != nil {
+		t.Fatalf("RevokeUserGrants failed: %v", err)
+	}
+
+	if grant, err := service.GetGrant(ctx, models.OAuthGrantKindAccessToken, revoked); err != nil || grant != nil {
+		t.Fatalf("expected the grant issued before the revocation to be rejected, got %+v, %v", grant, err)
+	}
+	if grant, _ := service.GetGrant(ctx, models.OAuthGrantKindAccessToken, other); grant == nil {
+		t.Fatal("expected the grants of other users to be kept")
+	}
+
+	// Grants issued after the revocation, such as once a ban is lifted, are valid
+	if grant, _ := service.GetGrant(ctx, models.OAuthGrantKindAccessToken, createGrant("user-1")); grant == nil {
+		t.Fatal("expected a grant issued after the revocation to be accepted")
+	}
+}
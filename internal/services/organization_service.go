@@ -0,0 +1,293 @@
+package services
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/GoBetterAuth/go-better-auth/internal/constants"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+type OrganizationServiceImpl struct {
+	config *models.Config
+	db     *gorm.DB
+	cache  lookupCache
+}
+
+func NewOrganizationServiceImpl(config *models.Config, db *gorm.DB) *OrganizationServiceImpl {
+	return &OrganizationServiceImpl{config: config, db: db, cache: lookupCache{config: config}}
+}
+
+// CreateOrganization stores a new organization with the given user as its owner.
+func (s *OrganizationServiceImpl) CreateOrganization(organization *models.Organization, ownerID string) error {
+	if organization.ID == "" {
+		organization.ID = uuid.NewString()
+	}
+	organization.CreatedAt = time.Now().UTC()
+	organization.UpdatedAt = time.Now().UTC()
+
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(organization).Error; err != nil {
+			return err
+		}
+		return tx.Create(newMember(organization.ID, ownerID, models.OrganizationRoleOwner)).Error
+	})
+}
+
+// DeleteOrganization deletes an organization along with its members and invitations, and clears it from the
+// sessions it is active in.
+func (s *OrganizationServiceImpl) DeleteOrganization(id string) error {
+	var tokens []string
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var err error
+		tokens, err = deleteOrganizations(tx, []string{id})
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		keys = append(keys, sessionCachePrefix+token)
+	}
+	s.cache.delete(keys...)
+	return nil
+}
+
+// GetOrganizationByID retrieves an organization, nil when it does not exist.
+func (s *OrganizationServiceImpl) GetOrganizationByID(id string) (*models.Organization, error) {
+	var organization models.Organization
+	if err := s.db.Where("id = ?", id).First(&organization).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &organization, nil
+}
+
+// GetOrganizationBySlug retrieves an organization by its unique slug, nil when it does not exist.
+func (s *OrganizationServiceImpl) GetOrganizationBySlug(slug string) (*models.Organization, error) {
+	var organization models.Organization
+	if err := s.db.Where("slug = ?", slug).First(&organization).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &organization, nil
+}
+
+// ListUserOrganizations returns the organizations a user is a member of ordered by name.
+func (s *OrganizationServiceImpl) ListUserOrganizations(userID string) ([]models.UserOrganization, error) {
+	var organizations []models.UserOrganization
+	if err := s.db.Model(&models.Organization{}).
+		Select("organizations.*, members.role").
+		Joins("JOIN members ON members.organization_id = organizations.id").
+		Where("members.user_id = ?", userID).
+		Order("organizations.name ASC").
+		Scan(&organizations).Error; err != nil {
+		return nil, err
+	}
+	return organizations, nil
+}
+
+// GetMember retrieves the membership of a user in an organization, nil when they are not a member.
+func (s *OrganizationServiceImpl) GetMember(organizationID string, userID string) (*models.Member, error) {
+	var member models.Member
+	if err := s.db.Where("organization_id = ? AND user_id = ?", organizationID, userID).First(&member).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &member, nil
+}
+
+// ListMembers returns the members of an organization along with their users, oldest first.
+func (s *OrganizationServiceImpl) ListMembers(organizationID string) ([]models.OrganizationMember, error) {
+	var members []models.Member
+	if err := s.db.Where("organization_id = ?", organizationID).Order("created_at ASC").Find(&members).Error; err != nil {
+		return nil, err
+	}
+	if len(members) == 0 {
+		return []models.OrganizationMember{}, nil
+	}
+
+	userIDs := make([]string, 0, len(members))
+	for _, member := range members {
+		userIDs = append(userIDs, member.UserID)
+	}
+	var users []models.User
+	if err := s.db.Where("id IN ?", userIDs).Find(&users).Error; err != nil {
+		return nil, err
+	}
+	usersByID := make(map[string]*models.User, len(users))
+	for i := range users {
+		usersByID[users[i].ID] = &users[i]
+	}
+
+	result := make([]models.OrganizationMember, 0, len(members))
+	for _, member := range members {
+		result = append(result, models.OrganizationMember{Member: member, User: usersByID[member.UserID]})
+	}
+	return result, nil
+}
+
+// CountMembersWithRole counts the members of an organization having the role.
+func (s *OrganizationServiceImpl) CountMembersWithRole(organizationID string, role models.OrganizationRole) (int64, error) {
+	var count int64
+	if err := s.db.Model(&models.Member{}).
+		Where("organization_id = ? AND role = ?", organizationID, role).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+// UpdateMemberRole changes the role of a user within an organization.
+func (s *OrganizationServiceImpl) UpdateMemberRole(organizationID string, userID string, role models.OrganizationRole) error {
+	return s.db.Model(&models.Member{}).
+		Where("organization_id = ? AND user_id = ?", organizationID, userID).
+		Updates(map[string]any{"role": role, "updated_at": time.Now().UTC()}).Error
+}
+
+// RemoveMember removes a user from an organization.
+func (s *OrganizationServiceImpl) RemoveMember(organizationID string, userID string) error {
+	return s.db.Where("organization_id = ? AND user_id = ?", organizationID, userID).Delete(&models.Member{}).Error
+}
+
+// CreateInvitation stores a new pending invitation.
+func (s *OrganizationServiceImpl) CreateInvitation(invitation *models.Invitation) error {
+	if invitation.ID == "" {
+		invitation.ID = uuid.NewString()
+	}
+	invitation.Status = models.InvitationStatusPending
+	invitation.CreatedAt = time.Now().UTC()
+	invitation.UpdatedAt = time.Now().UTC()
+
+	return s.db.Create(invitation).Error
+}
+
+// GetInvitationByToken retrieves an invitation by its hashed token, nil when it does not exist.
+func (s *OrganizationServiceImpl) GetInvitationByToken(token string) (*models.Invitation, error) {
+	var invitation models.Invitation
+	if err := s.db.Where("token = ?", token).First(&invitation).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// GetPendingInvitation retrieves the unexpired pending invitation of an email address to an organization,
+// nil when there is none.
+func (s *OrganizationServiceImpl) GetPendingInvitation(organizationID string, email string) (*models.Invitation, error) {
+	var invitation models.Invitation
+	if err := s.db.
+		Where("organization_id = ? AND LOWER(email) = ? AND status = ? AND expires_at > ?",
+			organizationID, strings.ToLower(email), models.InvitationStatusPending, time.Now().UTC()).
+		First(&invitation).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// ListPendingInvitations returns the unexpired pending invitations of an organization, newest first.
+func (s *OrganizationServiceImpl) ListPendingInvitations(organizationID string) ([]models.Invitation, error) {
+	var invitations []models.Invitation
+	if err := s.db.
+		Where("organization_id = ? AND status = ? AND expires_at > ?", organizationID, models.InvitationStatusPending, time.Now().UTC()).
+		Order("created_at DESC").
+		Find(&invitations).Error; err != nil {
+		return nil, err
+	}
+	return invitations, nil
+}
+
+// AcceptInvitation marks an invitation accepted and adds the user to the organization with the invited role.
+// A user who already is a member keeps their role.
+func (s *OrganizationServiceImpl) AcceptInvitation(invitation *models.Invitation, userID string) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := s.answerInvitation(tx, invitation, models.InvitationStatusAccepted); err != nil {
+			return err
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(newMember(invitation.OrganizationID, userID, invitation.Role)).Error
+	})
+}
+
+// DeclineInvitation marks an invitation declined.
+func (s *OrganizationServiceImpl) DeclineInvitation(invitation *models.Invitation) error {
+	return s.answerInvitation(s.db, invitation, models.InvitationStatusDeclined)
+}
+
+// answerInvitation moves a pending invitation to its final status. Invitations answered concurrently
+// are reported as not found, so that each is only answered once.
+func (s *OrganizationServiceImpl) answerInvitation(db *gorm.DB, invitation *models.Invitation, status models.InvitationStatus) error {
+	now := time.Now().UTC()
+
+	result := db.Model(&models.Invitation{}).
+		Where("id = ? AND status = ?", invitation.ID, models.InvitationStatusPending).
+		Updates(map[string]any{"status": status, "updated_at": now})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return constants.ErrInvitationNotFound
+	}
+
+	invitation.Status = status
+	invitation.UpdatedAt = now
+	return nil
+}
+
+// deleteOrganizations deletes organizations along with their members and invitations, and clears them from
+// the sessions they are active in. Returns the tokens of those sessions, whose cached copies are outdated.
+func deleteOrganizations(tx *gorm.DB, organizationIDs []string) ([]string, error) {
+	if len(organizationIDs) == 0 {
+		return nil, nil
+	}
+
+	var tokens []string
+	if err := tx.Model(&models.Session{}).Where("active_organization_id IN ?", organizationIDs).Pluck("token", &tokens).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Model(&models.Session{}).
+		Where("active_organization_id IN ?", organizationIDs).
+		Update("active_organization_id", nil).Error; err != nil {
+		return nil, err
+	}
+
+	if err := tx.Where("organization_id IN ?", organizationIDs).Delete(&models.Invitation{}).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Where("organization_id IN ?", organizationIDs).Delete(&models.Member{}).Error; err != nil {
+		return nil, err
+	}
+	if err := tx.Where("id IN ?", organizationIDs).Delete(&models.Organization{}).Error; err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+func newMember(organizationID string, userID string, role models.OrganizationRole) *models.Member {
+	now := time.Now().UTC()
+	return &models.Member{
+		ID:             uuid.NewString(),
+		OrganizationID: organizationID,
+		UserID:         userID,
+		Role:           role,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/GoBetterAuth/go-better-auth/internal/constants"
+	"github.com/GoBetterAuth/go-better-auth/internal/util"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+const passkeyChallengePrefix = "passkey_challenge:"
+
+type PasskeyServiceImpl struct {
+	config *models.Config
+	db     *gorm.DB
+}
+
+func NewPasskeyServiceImpl(config *models.Config, db *gorm.DB) *PasskeyServiceImpl {
+	return &PasskeyServiceImpl{config: config, db: db}
+}
+
+// CreatePasskey stores a newly registered passkey.
+func (s *PasskeyServiceImpl) CreatePasskey(passkey *models.Passkey) error {
+	if passkey.ID == "" {
+		passkey.ID = uuid.NewString()
+	}
+	passkey.CreatedAt = time.Now().UTC()
+	passkey.UpdatedAt = time.Now().UTC()
+
+	return s.db.Create(passkey).Error
+}
+
+// GetPasskeyByCredentialID retrieves a passkey by its base64url encoded credential id.
+func (s *PasskeyServiceImpl) GetPasskeyByCredentialID(credentialID string) (*models.Passkey, error) {
+	var passkey models.Passkey
+	if err := s.db.Where("credential_id = ?", credentialID).First(&passkey).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &passkey, nil
+}
+
+// GetPasskeysByUserID retrieves all passkeys registered by a user.
+func (s *PasskeyServiceImpl) GetPasskeysByUserID(userID string) ([]models.Passkey, error) {
+	var passkeys []models.Passkey
+	if err := s.db.Where("user_id = ?", userID).Order("created_at ASC").Find(&passkeys).Error; err != nil {
+		return nil, err
+	}
+	return passkeys, nil
+}
+
+// UpdatePasskey updates an existing passkey, e.g. its signature counter after a sign-in.
+func (s *PasskeyServiceImpl) UpdatePasskey(passkey *models.Passkey) error {
+	passkey.UpdatedAt = time.Now().UTC()
+	return s.db.Save(passkey).Error
+}
+
+// CreateChallenge stores a WebAuthn challenge for the given ceremony in secondary storage and returns it.
+// The user ID may be empty for authentication ceremonies using discoverable credentials.
+func (s *PasskeyServiceImpl) CreateChallenge(ctx context.Context, ceremony string, userID string) (string, error) {
+	challenge, err := util.GenerateToken()
+	if err != nil {
+		return "", fmt.Errorf("generate challenge: %w", err)
+	}
+
+	ttl := s.config.Passkey.Timeout
+	if err := s.config.SecondaryStorage.Storage.Set(ctx, s.challengeKey(ceremony, challenge), userID, &ttl); err != nil {
+		return "", fmt.Errorf("store challenge: %w", err)
+	}
+
+	return challenge, nil
+}
+
+// ConsumeChallenge returns the user ID bound to a challenge and deletes the challenge so it
+// can only be used once, even by concurrent requests.
+func (s *PasskeyServiceImpl) ConsumeChallenge(ctx context.Context, ceremony string, challenge string) (string, error) {
+	if challenge == "" {
+		return "", constants.ErrPasskeyChallengeInvalid
+	}
+
+	key := s.challengeKey(ceremony, challenge)
+	value, err := s.config.SecondaryStorage.Storage.Get(ctx, key)
+	if err != nil {
+		return "", fmt.Errorf("get challenge: %w", err)
+	}
+	userID, ok := value.(string)
+	if !ok {
+		return "", constants.ErrPasskeyChallengeInvalid
+	}
+
+	claimed, err := claimOnce(ctx, s.config.SecondaryStorage.Storage, key, s.config.Passkey.Timeout)
+	if err != nil {
+		return "", fmt.Errorf("consume challenge: %w", err)
+	}
+	if !claimed {
+		return "", constants.ErrPasskeyChallengeInvalid
+	}
+
+	if err := s.config.SecondaryStorage.Storage.Delete(ctx, key); err != nil {
+		return "", fmt.Errorf("delete challenge: %w", err)
+	}
+
+	return userID, nil
+}
+
+func (s *PasskeyServiceImpl) challengeKey(ceremony string, challenge string) string {
+	return passkeyChallengePrefix + ceremony + ":" + util.HashTokenWithSecret(challenge, s.config.Secret)
+}
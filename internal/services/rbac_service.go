@@ -0,0 +1,219 @@
+package services
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/GoBetterAuth/go-better-auth/internal/util"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+type RBACServiceImpl struct {
+	config *models.Config
+	db     *gorm.DB
+}
+
+func NewRBACServiceImpl(config *models.Config, db *gorm.DB) *RBACServiceImpl {
+	return &RBACServiceImpl{config: config, db: db}
+}
+
+// CreateRole stores a new role.
+func (s *RBACServiceImpl) CreateRole(role *models.Role) error {
+	if role.ID == "" {
+		role.ID = uuid.NewString()
+	}
+	role.CreatedAt = time.Now().UTC()
+	role.UpdatedAt = time.Now().UTC()
+
+	return s.db.Create(role).Error
+}
+
+// GetRoleByID retrieves a role, nil when it does not exist.
+func (s *RBACServiceImpl) GetRoleByID(id string) (*models.Role, error) {
+	var role models.Role
+	if err := s.db.Where("id = ?", id).First(&role).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &role, nil
+}
+
+// GetRoleByName retrieves a role by its unique name, nil when it does not exist.
+func (s *RBACServiceImpl) GetRoleByName(name string) (*models.Role, error) {
+	var role models.Role
+	if err := s.db.Where("name = ?", name).First(&role).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &role, nil
+}
+
+// ListRoles returns every role ordered by name.
+func (s *RBACServiceImpl) ListRoles() ([]models.Role, error) {
+	var roles []models.Role
+	if err := s.db.Order("name ASC").Find(&roles).Error; err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+// UpdateRole saves the name and description of a role.
+func (s *RBACServiceImpl) UpdateRole(role *models.Role) error {
+	role.UpdatedAt = time.Now().UTC()
+	return s.db.Save(role).Error
+}
+
+// DeleteRole deletes a role along with its grants and assignments.
+func (s *RBACServiceImpl) DeleteRole(id string) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("role_id = ?", id).Delete(&models.RolePermission{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("role_id = ?", id).Delete(&models.UserRole{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&models.Role{}).Error
+	})
+}
+
+// CreatePermission stores a new permission.
+func (s *RBACServiceImpl) CreatePermission(permission *models.Permission) error {
+	if permission.ID == "" {
+		permission.ID = uuid.NewString()
+	}
+	permission.CreatedAt = time.Now().UTC()
+
+	return s.db.Create(permission).Error
+}
+
+// GetPermissionByID retrieves a permission, nil when it does not exist.
+func (s *RBACServiceImpl) GetPermissionByID(id string) (*models.Permission, error) {
+	var permission models.Permission
+	if err := s.db.Where("id = ?", id).First(&permission).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &permission, nil
+}
+
+// GetPermissionByName retrieves a permission by its unique name, nil when it does not exist.
+func (s *RBACServiceImpl) GetPermissionByName(name string) (*models.Permission, error) {
+	var permission models.Permission
+	if err := s.db.Where("name = ?", name).First(&permission).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &permission, nil
+}
+
+// ListPermissions returns every permission ordered by name.
+func (s *RBACServiceImpl) ListPermissions() ([]models.Permission, error) {
+	var permissions []models.Permission
+	if err := s.db.Order("name ASC").Find(&permissions).Error; err != nil {
+		return nil, err
+	}
+	return permissions, nil
+}
+
+// DeletePermission deletes a permission and revokes it from every role.
+func (s *RBACServiceImpl) DeletePermission(id string) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("permission_id = ?", id).Delete(&models.RolePermission{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("id = ?", id).Delete(&models.Permission{}).Error
+	})
+}
+
+// AddPermissionToRole grants a permission to a role, granting it again is a no-op.
+func (s *RBACServiceImpl) AddPermissionToRole(roleID string, permissionID string) error {
+	return s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.RolePermission{
+		RoleID:       roleID,
+		PermissionID: permissionID,
+		CreatedAt:    time.Now().UTC(),
+	}).Error
+}
+
+// RemovePermissionFromRole revokes a permission from a role.
+func (s *RBACServiceImpl) RemovePermissionFromRole(roleID string, permissionID string) error {
+	return s.db.Where("role_id = ? AND permission_id = ?", roleID, permissionID).Delete(&models.RolePermission{}).Error
+}
+
+// ListRolePermissions returns the permissions granted to a role ordered by name.
+func (s *RBACServiceImpl) ListRolePermissions(roleID string) ([]models.Permission, error) {
+	var permissions []models.Permission
+	if err := s.db.
+		Joins("JOIN role_permissions ON role_permissions.permission_id = permissions.id").
+		Where("role_permissions.role_id = ?", roleID).
+		Order("permissions.name ASC").
+		Find(&permissions).Error; err != nil {
+		return nil, err
+	}
+	return permissions, nil
+}
+
+// AssignRoleToUser assigns a role to a user, assigning it again is a no-op.
+func (s *RBACServiceImpl) AssignRoleToUser(userID string, roleID string) error {
+	return s.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&models.UserRole{
+		UserID:    userID,
+		RoleID:    roleID,
+		CreatedAt: time.Now().UTC(),
+	}).Error
+}
+
+// RemoveRoleFromUser unassigns a role from a user.
+func (s *RBACServiceImpl) RemoveRoleFromUser(userID string, roleID string) error {
+	return s.db.Where("user_id = ? AND role_id = ?", userID, roleID).Delete(&models.UserRole{}).Error
+}
+
+// ListUserRoles returns the roles assigned to a user ordered by name.
+func (s *RBACServiceImpl) ListUserRoles(userID string) ([]models.Role, error) {
+	var roles []models.Role
+	if err := s.db.
+		Joins("JOIN user_roles ON user_roles.role_id = roles.id").
+		Where("user_roles.user_id = ?", userID).
+		Order("roles.name ASC").
+		Find(&roles).Error; err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+// ListUserPermissions returns the names of the permissions granted to a user through their roles.
+func (s *RBACServiceImpl) ListUserPermissions(userID string) ([]string, error) {
+	var names []string
+	if err := s.db.Model(&models.Permission{}).
+		Distinct("permissions.name").
+		Joins("JOIN role_permissions ON role_permissions.permission_id = permissions.id").
+		Joins("JOIN user_roles ON user_roles.role_id = role_permissions.role_id").
+		Where("user_roles.user_id = ?", userID).
+		Order("permissions.name ASC").
+		Pluck("permissions.name", &names).Error; err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+// HasPermission reports whether any role of the user grants the permission, wildcards included.
+func (s *RBACServiceImpl) HasPermission(userID string, permission string) (bool, error) {
+	granted, err := s.ListUserPermissions(userID)
+	if err != nil {
+		return false, err
+	}
+
+	return slices.ContainsFunc(granted, func(name string) bool {
+		return util.PermissionMatches(name, permission)
+	}), nil
+}
@@ -0,0 +1,22 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+// consumedSuffix is appended to the key of a single use record to guard its consumption
+const consumedSuffix = ":consumed"
+
+// claimOnce reports whether the caller is the first to consume the single use record stored under key.
+// Concurrent requests may all read the record before it is deleted. Incr is atomic in the secondary
+// storage, so only the first one to increment the guard wins. The guard lives as long as the record could.
+func claimOnce(ctx context.Context, storage models.SecondaryStorage, key string, ttl time.Duration) (bool, error) {
+	count, err := storage.Incr(ctx, key+consumedSuffix, &ttl)
+	if err != nil {
+		return false, err
+	}
+	return count == 1, nil
+}
@@ -7,16 +7,30 @@ import (
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 
+	"github.com/GoBetterAuth/go-better-auth/internal/constants"
 	"github.com/GoBetterAuth/go-better-auth/models"
 )
 
 type SessionServiceImpl struct {
 	config *models.Config
 	db     *gorm.DB
+	cache  lookupCache
+	// stopCleanup is used to signal the cleanup goroutine to stop.
+	stopCleanup chan struct{}
+	// done signals that the cleanup goroutine has stopped.
+	done chan struct{}
+	// cleanupStarted tracks whether the cleanup goroutine has been started.
+	cleanupStarted bool
 }
 
 func NewSessionServiceImpl(config *models.Config, db *gorm.DB) *SessionServiceImpl {
-	return &SessionServiceImpl{config: config, db: db}
+	return &SessionServiceImpl{
+		config:      config,
+		db:          db,
+		cache:       lookupCache{config: config},
+		stopCleanup: make(chan struct{}),
+		done:        make(chan struct{}),
+	}
 }
 
 // CreateSession creates a new session for a user
@@ -25,19 +39,52 @@ func (s *SessionServiceImpl) CreateSession(userID string, token string) (*models
 		ID:        uuid.NewString(),
 		UserID:    userID,
 		Token:     token,
-		ExpiresAt: time.Now().UTC().Add(7 * 24 * time.Hour),
+		ExpiresAt: time.Now().UTC().Add(s.config.Session.ExpiresIn),
 		CreatedAt: time.Now().UTC(),
 		UpdatedAt: time.Now().UTC(),
 	}
 
+	if err := s.createSession(session); err != nil {
+		return nil, err
+	}
+
+	if err := s.evictOldestSessions(userID); err != nil {
+		slog.Error("failed to evict sessions over the limit", "user_id", userID, "error", err.Error())
+	}
+
+	return session, nil
+}
+
+// CreateImpersonationSession creates a session for a user on behalf of an admin. It expires after
+// ImpersonationExpiresIn, is never extended and does not count towards MaxSessions, so that it cannot
+// sign the user out of their own sessions.
+func (s *SessionServiceImpl) CreateImpersonationSession(userID string, token string, impersonatedBy string) (*models.Session, error) {
+	session := &models.Session{
+		ID:             uuid.NewString(),
+		UserID:         userID,
+		Token:          token,
+		ExpiresAt:      time.Now().UTC().Add(s.config.Session.ImpersonationExpiresIn),
+		ImpersonatedBy: &impersonatedBy,
+		CreatedAt:      time.Now().UTC(),
+		UpdatedAt:      time.Now().UTC(),
+	}
+
+	if err := s.createSession(session); err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+func (s *SessionServiceImpl) createSession(session *models.Session) error {
 	if s.config.DatabaseHooks.Sessions != nil && s.config.DatabaseHooks.Sessions.BeforeCreate != nil {
 		if err := s.config.DatabaseHooks.Sessions.BeforeCreate(session); err != nil {
-			return nil, err
+			return err
 		}
 	}
 
 	if err := s.db.Create(session).Error; err != nil {
-		return nil, err
+		return err
 	}
 
 	if s.config.DatabaseHooks.Sessions != nil && s.config.DatabaseHooks.Sessions.AfterCreate != nil {
@@ -48,13 +95,45 @@ func (s *SessionServiceImpl) CreateSession(userID string, token string) (*models
 		}()
 	}
 
-	return session, nil
+	return nil
+}
+
+// evictOldestSessions deletes the oldest sessions of a user beyond the configured maximum. Impersonation
+// sessions neither count towards it nor get evicted, so the user signing in does not end an admin's support session.
+func (s *SessionServiceImpl) evictOldestSessions(userID string) error {
+	maxSessions := s.config.Session.MaxSessions
+	if maxSessions <= 0 {
+		return nil
+	}
+
+	var evicted []models.Session
+	if err := s.db.Select("id", "token").
+		Where("user_id = ? AND impersonated_by IS NULL", userID).
+		Order("created_at DESC").
+		Offset(maxSessions).
+		Find(&evicted).Error; err != nil {
+		return err
+	}
+	if len(evicted) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(evicted))
+	for _, sess := range evicted {
+		ids = append(ids, sess.ID)
+	}
+	if err := s.db.Where("id IN ?", ids).Delete(&models.Session{}).Error; err != nil {
+		return err
+	}
+
+	s.uncacheSessions(evicted)
+	return nil
 }
 
-// GetSessionByUserID retrieves a session by the associated userID.
+// GetSessionByUserID retrieves the most recently created session of a user.
 func (s *SessionServiceImpl) GetSessionByUserID(userID string) (*models.Session, error) {
 	var sess models.Session
-	if err := s.db.Where("user_id = ?", userID).First(&sess).Error; err != nil {
+	if err := s.db.Where("user_id = ?", userID).Order("created_at DESC").First(&sess).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
 		}
@@ -63,19 +142,247 @@ func (s *SessionServiceImpl) GetSessionByUserID(userID string) (*models.Session,
 	return &sess, nil
 }
 
-// GetSessionByToken retrieves a session by its token.
+// GetSessionByID retrieves a session by its ID.
+func (s *SessionServiceImpl) GetSessionByID(ID string) (*models.Session, error) {
+	var sess models.Session
+	if err := s.db.Where("id = ?", ID).First(&sess).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &sess, nil
+}
+
+// GetSessionByToken retrieves a session by its token, from the lookup cache when enabled.
 func (s *SessionServiceImpl) GetSessionByToken(token string) (*models.Session, error) {
 	var sess models.Session
+	if s.cache.get(sessionCachePrefix+token, &sess) {
+		return &sess, nil
+	}
+
 	if err := s.db.Where("token = ?", token).First(&sess).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
 		}
 		return nil, err
 	}
+
+	s.cache.set(sessionCachePrefix+token, sess)
 	return &sess, nil
 }
 
 // DeleteSessionByID deletes a session by its ID.
 func (s *SessionServiceImpl) DeleteSessionByID(ID string) error {
-	return s.db.Where("id = ?", ID).Delete(&models.Session{}).Error
+	return s.deleteSessions(s.db.Where("id = ?", ID))
+}
+
+// ListSessionsByUserID returns the active sessions of a user, newest first.
+func (s *SessionServiceImpl) ListSessionsByUserID(userID string) ([]models.Session, error) {
+	var sessions []models.Session
+	if err := s.db.
+		Where("user_id = ? AND expires_at > ?", userID, time.Now().UTC()).
+		Order("created_at DESC").
+		Find(&sessions).Error; err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// RevokeSession deletes a session of a user. Sessions belonging to other users are never touched.
+func (s *SessionServiceImpl) RevokeSession(userID string, sessionID string) error {
+	var sess models.Session
+	if err := s.db.Select("id", "token").Where("id = ? AND user_id = ?", sessionID, userID).First(&sess).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return constants.ErrSessionNotFound
+		}
+		return err
+	}
+
+	if err := s.db.Where("id = ?", sess.ID).Delete(&models.Session{}).Error; err != nil {
+		return err
+	}
+
+	s.uncacheSessions([]models.Session{sess})
+	return nil
+}
+
+// RevokeOtherSessions deletes every session of a user except the current one.
+func (s *SessionServiceImpl) RevokeOtherSessions(userID string, currentSessionID string) error {
+	return s.deleteSessions(s.db.Where("user_id = ? AND id <> ?", userID, currentSessionID))
+}
+
+// RevokeUserSessions deletes every session of a user, signing them out everywhere.
+func (s *SessionServiceImpl) RevokeUserSessions(userID string) error {
+	return s.deleteSessions(s.db.Where("user_id = ?", userID))
+}
+
+// InvalidateCachedSessions drops the cached lookups of all sessions of a user,
+// forcing the next request to read them from the database.
+func (s *SessionServiceImpl) InvalidateCachedSessions(userID string) error {
+	if !s.cache.enabled() {
+		return nil
+	}
+
+	var sessions []models.Session
+	if err := s.db.Select("id", "token").Where("user_id = ?", userID).Find(&sessions).Error; err != nil {
+		return err
+	}
+
+	s.uncacheSessions(sessions)
+	s.cache.delete(userCachePrefix + userID)
+	return nil
+}
+
+// deleteSessions deletes the sessions matched by query and drops them from the lookup cache.
+func (s *SessionServiceImpl) deleteSessions(query *gorm.DB) error {
+	if !s.cache.enabled() {
+		return query.Delete(&models.Session{}).Error
+	}
+
+	var sessions []models.Session
+	if err := query.Session(&gorm.Session{}).Select("id", "token").Find(&sessions).Error; err != nil {
+		return err
+	}
+	if len(sessions) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(sessions))
+	for _, sess := range sessions {
+		ids = append(ids, sess.ID)
+	}
+	if err := s.db.Where("id IN ?", ids).Delete(&models.Session{}).Error; err != nil {
+		return err
+	}
+
+	s.uncacheSessions(sessions)
+	return nil
+}
+
+func (s *SessionServiceImpl) uncacheSessions(sessions []models.Session) {
+	keys := make([]string, 0, len(sessions))
+	for _, sess := range sessions {
+		keys = append(keys, sessionCachePrefix+sess.Token)
+	}
+	s.cache.delete(keys...)
+}
+
+// IsExpired reports whether the session is past its expiry time.
+func (s *SessionServiceImpl) IsExpired(session *models.Session) bool {
+	return time.Now().UTC().After(session.ExpiresAt)
+}
+
+// ShouldExtend reports whether the session was last refreshed more than UpdateAge ago.
+func (s *SessionServiceImpl) ShouldExtend(session *models.Session) bool {
+	// Impersonation sessions are time boxed
+	if session.ImpersonatedBy != nil {
+		return false
+	}
+	updateAge := s.config.Session.UpdateAge
+	return updateAge > 0 && time.Since(session.UpdatedAt) >= updateAge
+}
+
+// ExtendSession pushes the expiry of the session to ExpiresIn from now.
+func (s *SessionServiceImpl) ExtendSession(session *models.Session) error {
+	now := time.Now().UTC()
+	session.ExpiresAt = now.Add(s.config.Session.ExpiresIn)
+	session.UpdatedAt = now
+
+	if err := s.db.Model(&models.Session{}).
+		Where("id = ?", session.ID).
+		Updates(map[string]any{"expires_at": session.ExpiresAt, "updated_at": session.UpdatedAt}).Error; err != nil {
+		return err
+	}
+
+	if session.Token != "" {
+		s.cache.set(sessionCachePrefix+session.Token, *session)
+	}
+	return nil
+}
+
+// SetActiveOrganization switches the organization the session works in, nil clears it.
+func (s *SessionServiceImpl) SetActiveOrganization(session *models.Session, organizationID *string) error {
+	if err := s.db.Model(&models.Session{}).
+		Where("id = ?", session.ID).
+		Update("active_organization_id", organizationID).Error; err != nil {
+		return err
+	}
+
+	session.ActiveOrganizationID = organizationID
+	if session.Token != "" {
+		s.cache.set(sessionCachePrefix+session.Token, *session)
+	}
+	return nil
+}
+
+// ClearActiveOrganization clears the active organization of the sessions of a user working in it,
+// as when they leave the organization.
+func (s *SessionServiceImpl) ClearActiveOrganization(userID string, organizationID string) error {
+	var sessions []models.Session
+	if err := s.db.Select("id", "token").
+		Where("user_id = ? AND active_organization_id = ?", userID, organizationID).
+		Find(&sessions).Error; err != nil {
+		return err
+	}
+	if len(sessions) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(sessions))
+	for _, sess := range sessions {
+		ids = append(ids, sess.ID)
+	}
+	if err := s.db.Model(&models.Session{}).Where("id IN ?", ids).Update("active_organization_id", nil).Error; err != nil {
+		return err
+	}
+
+	s.uncacheSessions(sessions)
+	return nil
+}
+
+// DeleteExpiredSessions removes all sessions past their expiry time.
+// Cached entries are left to their TTL as expiry is checked on every lookup.
+func (s *SessionServiceImpl) DeleteExpiredSessions() error {
+	return s.db.Where("expires_at < ?", time.Now().UTC()).Delete(&models.Session{}).Error
+}
+
+// StartCleanup starts the background goroutine that periodically purges expired sessions.
+// It is safe to call this multiple times - subsequent calls will be no-ops until Close is called.
+func (s *SessionServiceImpl) StartCleanup() {
+	if s.cleanupStarted || s.config.Session.CleanupInterval <= 0 {
+		return
+	}
+	s.cleanupStarted = true
+	s.stopCleanup = make(chan struct{})
+	s.done = make(chan struct{})
+	go s.cleanupExpiredSessions(s.config.Session.CleanupInterval)
+}
+
+func (s *SessionServiceImpl) cleanupExpiredSessions(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	defer close(s.done)
+
+	for {
+		select {
+		case <-s.stopCleanup:
+			return
+		case <-ticker.C:
+			if err := s.DeleteExpiredSessions(); err != nil {
+				slog.Error("error cleaning up expired sessions", "error", err.Error())
+			}
+		}
+	}
+}
+
+// Close stops the cleanup goroutine. It is safe to call this multiple times.
+func (s *SessionServiceImpl) Close() error {
+	if !s.cleanupStarted {
+		return nil
+	}
+	s.cleanupStarted = false
+	close(s.stopCleanup)
+	<-s.done
+	return nil
 }
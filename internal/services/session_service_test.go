@@ -0,0 +1,228 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/GoBetterAuth/go-better-auth/config"
+	"github.com/GoBetterAuth/go-better-auth/internal/constants"
+	"github.com/GoBetterAuth/go-better-auth/internal/testutil"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+func newTestSessionService(t *testing.T, sessionConfig models.SessionConfig) *SessionServiceImpl {
+	t.Helper()
+	return NewSessionServiceImpl(config.NewConfig(config.WithSession(sessionConfig)), testutil.NewDB(t, &models.Session{}))
+}
+
+// createSessions creates n sessions for a user, oldest first
+func createSessions(t *testing.T, service *SessionServiceImpl, userID string, n int) []*models.Session {
+	t.Helper()
+
+	sessions := make([]*models.Session, 0, n)
+	for i := range n {
+		session, err := service.CreateSession(userID, fmt.Sprintf("%s-token-%d", userID, i))
+		if err != nil {
+			t.Fatalf("CreateSession failed: %v", err)
+		}
+		sessions = append(sessions, session)
+		// Keep the creation times apart so that the order is deterministic
+		time.Sleep(time.Millisecond)
+	}
+	return sessions
+}
+
+func TestSessionService_MaxSessionsEvictsOldest(t *testing.T) {
+	service := newTestSessionService(t, models.SessionConfig{ExpiresIn: time.Hour, MaxSessions: 2})
+
+	sessions := createSessions(t, service, "user-1", 3)
+	createSessions(t, service, "user-2", 1)
+
+	if session, _ := service.GetSessionByID(sessions[0].ID); session != nil {
+		t.Fatal("expected the oldest session to be evicted")
+	}
+	active, err := service.ListSessionsByUserID("user-1")
+	if err != nil {
+		t.Fatalf("ListSessionsByUserID failed: %v", err)
+	}
+	if len(active) != 2 || active[0].ID != sessions[2].ID || active[1].ID != sessions[1].ID {
+		t.Fatalf("expected the two newest sessions to be kept, got %+v", active)
+	}
+
+	// Other users are not affected
+	if active, _ := service.ListSessionsByUserID("user-2"); len(active) != 1 {
+		t.Fatalf("expected the session of another user to be kept, got %d", len(active))
+	}
+}
+
+func TestSessionService_RevokeSessions(t *testing.T) {
+	service := newTestSessionService(t, models.SessionConfig{ExpiresIn: time.Hour})
+
+	sessions := createSessions(t, service, "user-1", 3)
+	other := createSessions(t, service, "user-2", 1)[0]
+
+	// Sessions of other users cannot be revoked
+	if err := service.RevokeSession("user-1", other.ID); !errors.Is(err, constants.ErrSessionNotFound) {
+		t.Fatalf("expected the session of another user to be not found, got %v", err)
+	}
+	if session, _ := service.GetSessionByID(other.ID); session == nil {
+		t.Fatal("expected the session of another user to be kept")
+	}
+
+	if err := service.RevokeSession("user-1", sessions[0].ID); err != nil {
+		t.Fatalf("RevokeSession failed: %v", err)
+	}
+	if session, _ := service.GetSessionByToken(sessions[0].Token); session != nil {
+		t.Fatal("expected the revoked session to be deleted")
+	}
+
+	if err := service.RevokeOtherSessions("user-1", sessions[2].ID); err != nil {
+		t.Fatalf("RevokeOtherSessions failed: %v", err)
+	}
+	active, _ := service.ListSessionsByUserID("user-1")
+	if len(active) != 1 || active[0].ID != sessions[2].ID {
+		t.Fatalf("expected only the current session to be kept, got %+v", active)
+	}
+
+	if err := service.RevokeUserSessions("user-1"); err != nil {
+		t.Fatalf("RevokeUserSessions failed: %v", err)
+	}
+	if active, _ := service.ListSessionsByUserID("user-1"); len(active) != 0 {
+		t.Fatalf("expected every session to be revoked, got %d", len(active))
+	}
+	if session, _ := service.GetSessionByID(other.ID); session == nil {
+		t.Fatal("expected the session of another user to be kept")
+	}
+}
+
+func TestSessionService_SlidingExpiry(t *testing.T) {
+	service := newTestSessionService(t, models.SessionConfig{ExpiresIn: time.Hour, UpdateAge: 10 * time.Minute})
+
+	session, err := service.CreateSession("user-1", "token-1")
+	if err != nil {
+		t.Fatalf("CreateSession failed: %v", err)
+	}
+	if service.ShouldExtend(session) {
+		t.Fatal("expected a new session not to be extended")
+	}
+
+	// A session last refreshed longer than UpdateAge ago is pushed to ExpiresIn from now
+	session.UpdatedAt = time.Now().UTC().Add(-20 * time.Minute)
+	session.ExpiresAt = time.Now().UTC().Add(40 * time.Minute)
+	if !service.ShouldExtend(session) {
+		t.Fatal("expected a stale session to be extended")
+	}
+	if err := service.ExtendSession(session); err != nil {
+		t.Fatalf("ExtendSession failed: %v", err)
+	}
+
+	stored, err := service.GetSessionByToken("token-1")
+	if err != nil || stored == nil {
+		t.Fatalf("GetSessionByToken failed: %v", err)
+	}
+	if time.Until(stored.ExpiresAt) < 59*time.Minute {
+		t.Fatalf("expected the expiry to be extended to an hour from now, got %s", stored.ExpiresAt)
+	}
+	if service.ShouldExtend(stored) {
+		t.Fatal("expected an extended session not to be extended again")
+	}
+
+	// Impersonation sessions are never extended
+	impersonation, err := service.CreateImpersonationSession("user-1", "token-2", "admin-1")
+	if err != nil {
+		t.Fatalf("CreateImpersonationSession failed: %v", err)
+	}
+	impersonation.UpdatedAt = time.Now().UTC().Add(-20 * time.Minute)
+	if service.ShouldExtend(impersonation) {
+		t.Fatal("expected an impersonation session not to be extended")
+	}
+}
+
+func TestSessionService_DeleteExpiredSessions(t *testing.T) {
+	service := newTestSessionService(t, models.SessionConfig{ExpiresIn: time.Hour})
+
+	active, err := service.CreateSession("user-1", "active")
+	if err != nil {
+		t.Fatalf("CreateSession failed: %v", err)
+	}
+	expired, err := service.CreateSession("user-1", "expired")
+	if err != nil {
+		t.Fatalf("CreateSession failed: %v", err)
+	}
+	if err := service.db.Model(&models.Session{}).Where("id = ?", expired.ID).
+		Update("expires_at", time.Now().UTC().Add(-time.Minute)).Error; err != nil {
+		t.Fatalf("failed to expire session: %v", err)
+	}
+
+	if err := service.DeleteExpiredSessions(); err != nil {
+		t.Fatalf("DeleteExpiredSessions failed: %v", err)
+	}
+
+	if session, _ := service.GetSessionByID(expired.ID); session != nil {
+		t.Fatal("expected the expired session to be purged")
+	}
+	if session, _ := service.GetSessionByID(active.ID); session == nil {
+		t.Fatal("expected the active session to be kept")
+	}
+}
+
+func TestSessionService_CleanupStopsOnClose(t *testing.T) {
+	service := newTestSessionService(t, models.SessionConfig{ExpiresIn: time.Hour, CleanupInterval: 10 * time.Millisecond})
+
+	expired, err := service.CreateSession("user-1", "expired")
+	if err != nil {
+		t.Fatalf("CreateSession failed: %v", err)
+	}
+	if err := service.db.Model(&models.Session{}).Where("id = ?", expired.ID).
+		Update("expires_at", time.Now().UTC().Add(-time.Minute)).Error; err != nil {
+		t.Fatalf("failed to expire session: %v", err)
+	}
+
+	service.StartCleanup()
+	service.StartCleanup()
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		session, err := service.GetSessionByID(expired.ID)
+		if err != nil {
+			t.Fatalf("GetSessionByID failed: %v", err)
+		}
+		if session == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("expected the cleanup goroutine to purge the expired session")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	if err := service.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if err := service.Close(); err != nil {
+		t.Fatalf("expected Close to be idempotent, got %v", err)
+	}
+}
+
+func TestSessionService_MaxSessionsKeepsImpersonationSessions(t *testing.T) {
+	service := newTestSessionService(t, models.SessionConfig{ExpiresIn: time.Hour, ImpersonationExpiresIn: time.Hour, MaxSessions: 1})
+
+	impersonation, err := service.CreateImpersonationSession("user-1", "impersonation-token", "admin-1")
+	if err != nil {
+		t.Fatalf("CreateImpersonationSession failed: %v", err)
+	}
+	time.Sleep(time.Millisecond)
+	sessions := createSessions(t, service, "user-1", 2)
+
+	if session, _ := service.GetSessionByID(impersonation.ID); session == nil {
+		t.Fatal("expected the impersonation session to survive the user signing in")
+	}
+	if session, _ := service.GetSessionByID(sessions[0].ID); session != nil {
+		t.Fatal("expected the oldest regular session to be evicted")
+	}
+	if session, _ := service.GetSessionByID(sessions[1].ID); session == nil {
+		t.Fatal("expected the newest regular session to be kept")
+	}
+}
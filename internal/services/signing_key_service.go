@@ -0,0 +1,141 @@
+package services
+
+import (
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/GoBetterAuth/go-better-auth/internal/jose"
+	"github.com/GoBetterAuth/go-better-auth/internal/util"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+// SigningKeyServiceImpl manages the key pairs used to sign JWT access tokens.
+// Keys are generated lazily and rotated once they are older than JWT.KeyRotationInterval.
+type SigningKeyServiceImpl struct {
+	config *models.Config
+	db     *gorm.DB
+	// mu prevents concurrent requests from generating several keys at once
+	mu sync.Mutex
+}
+
+func NewSigningKeyServiceImpl(config *models.Config, db *gorm.DB) *SigningKeyServiceImpl {
+	return &SigningKeyServiceImpl{config: config, db: db}
+}
+
+// GetActiveSigningKey returns the key new tokens are signed with, generating one when there is none,
+// it is due for rotation or the configured algorithm changed.
+func (s *SigningKeyServiceImpl) GetActiveSigningKey() (*models.SigningKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var key models.SigningKey
+	err := s.db.Where("expires_at IS NULL").Order("created_at DESC").First(&key).Error
+	if err != nil && err != gorm.ErrRecordNotFound {
+		return nil, err
+	}
+	if err == nil && !s.shouldRotate(&key) {
+		return &key, nil
+	}
+
+	return s.rotate()
+}
+
+// GetSigningKeyByID retrieves a key by its ID, the "kid" of the tokens it signed.
+func (s *SigningKeyServiceImpl) GetSigningKeyByID(id string) (*models.SigningKey, error) {
+	var key models.SigningKey
+	if err := s.db.Where("id = ?", id).First(&key).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &key, nil
+}
+
+// ListVerificationKeys returns the active key along with rotated keys whose tokens may still be valid.
+func (s *SigningKeyServiceImpl) ListVerificationKeys() ([]models.SigningKey, error) {
+	var keys []models.SigningKey
+	if err := s.db.Where("expires_at IS NULL OR expires_at > ?", time.Now().UTC()).
+		Order("created_at DESC").
+		Find(&keys).Error; err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// IsExpired reports whether a rotated key may no longer be used to verify tokens.
+func (s *SigningKeyServiceImpl) IsExpired(key *models.SigningKey) bool {
+	return key.ExpiresAt != nil && time.Now().UTC().After(*key.ExpiresAt)
+}
+
+func (s *SigningKeyServiceImpl) shouldRotate(key *models.SigningKey) bool {
+	if key.Algorithm != s.config.JWT.Algorithm {
+		return true
+	}
+	interval := s.config.JWT.KeyRotationInterval
+	return interval > 0 && time.Since(key.CreatedAt) >= interval
+}
+
+// tokenLifetime is the longest lifetime of the tokens signed with the keys, JWT access tokens or the ID
+// tokens of the OIDC provider.
+func (s *SigningKeyServiceImpl) tokenLifetime() time.Duration {
+	return max(s.config.JWT.ExpiresIn, s.config.OIDCProvider.AccessTokenExpiresIn)
+}
+
+// rotate generates a new signing key and retires the current ones. Retired keys stay
+// published until the tokens they signed have expired.
+func (s *SigningKeyServiceImpl) rotate() (*models.SigningKey, error) {
+	alg := s.config.JWT.Algorithm
+	if !jose.IsSupportedAlgorithm(alg) {
+		return nil, fmt.Errorf("%w: %s", jose.ErrUnsupportedAlgorithm, alg)
+	}
+	if s.config.Secret == "" {
+		return nil, fmt.Errorf("secret is required for signing key encryption")
+	}
+
+	privateKey, err := jose.GenerateKey(alg)
+	if err != nil {
+		return nil, fmt.Errorf("generate signing key: %w", err)
+	}
+	encodedPrivateKey, err := jose.MarshalPrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	encryptedPrivateKey, err := util.EncryptToken(encodedPrivateKey, s.config.Secret)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt signing key: %w", err)
+	}
+	publicKey, err := jose.MarshalPublicKey(privateKey.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	key := &models.SigningKey{
+		ID:         uuid.NewString(),
+		Algorithm:  alg,
+		PublicKey:  publicKey,
+		PrivateKey: encryptedPrivateKey,
+		CreatedAt:  now,
+	}
+
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.SigningKey{}).
+			Where("expires_at IS NULL").
+			Update("expires_at", now.Add(s.tokenLifetime())).Error; err != nil {
+			return err
+		}
+		return tx.Create(key).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Info("generated new JWT signing key", "kid", key.ID, "algorithm", alg)
+	return key, nil
+}
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/GoBetterAuth/go-better-auth/internal/jose"
+	"github.com/GoBetterAuth/go-better-auth/internal/testutil"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+func TestSigningKeyService_RotatedKeysOutliveOIDCTokens(t *testing.T) {
+	config := &models.Config{
+		Secret:       "test_secret",
+		JWT:          models.JWTConfig{Algorithm: jose.AlgEdDSA, ExpiresIn: 15 * time.Minute, KeyRotationInterval: time.Hour},
+		OIDCProvider: models.OIDCProviderConfig{AccessTokenExpiresIn: 2 * time.Hour},
+	}
+	service := NewSigningKeyServiceImpl(config, testutil.NewDB(t, &models.SigningKey{}))
+
+	retired, err := service.GetActiveSigningKey()
+	if err != nil {
+		t.Fatalf("GetActiveSigningKey failed: %v", err)
+	}
+
+	// The key is due for rotation on the next lookup
+	config.JWT.KeyRotationInterval = time.Nanosecond
+	active, err := service.GetActiveSigningKey()
+	if err != nil {
+		t.Fatalf("GetActiveSigningKey failed: %v", err)
+	}
+	if active.ID == retired.ID {
+		t.Fatal("expected the key to be rotated")
+	}
+
+	retired, err = service.GetSigningKeyByID(retired.ID)
+	if err != nil || retired == nil {
+		t.Fatalf("expected the retired key to be kept, got %+v, %v", retired, err)
+	}
+	// ID tokens and OIDC access tokens live longer than JWT access tokens here
+	if retired.ExpiresAt == nil || retired.ExpiresAt.Before(time.Now().Add(time.Hour+55*time.Minute)) {
+		t.Fatalf("expected the retired key to stay valid for the OIDC token lifetime, got %v", retired.ExpiresAt)
+	}
+
+	keys, err := service.ListVerificationKeys()
+	if err != nil {
+		t.Fatalf("ListVerificationKeys failed: %v", err)
+	}
+	if len(keys) != 2 {
+		t.Fatalf("expected both keys to be published, got %d", len(keys))
+	}
+}
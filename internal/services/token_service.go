@@ -2,21 +2,28 @@ package services
 
 import (
 	"fmt"
+	"time"
 
+	"github.com/GoBetterAuth/go-better-auth/internal/constants"
+	"github.com/GoBetterAuth/go-better-auth/internal/jose"
 	"github.com/GoBetterAuth/go-better-auth/internal/util"
 	"github.com/GoBetterAuth/go-better-auth/models"
 )
 
 // TokenServiceImpl manages token operations using the application secret.
-// This service uses Config.Secret for signing, encryption, and hashing tokens.
+// This service uses Config.Secret for signing, encryption, and hashing tokens,
+// and the signing keys for JWTs.
 type TokenServiceImpl struct {
-	config *models.Config
+	config      *models.Config
+	signingKeys models.SigningKeyService
 }
 
 // NewTokenServiceImpl creates a new TokenServiceImpl with the provided config.
-func NewTokenServiceImpl(config *models.Config) *TokenServiceImpl {
+// signingKeys may be nil when JWTs are not used.
+func NewTokenServiceImpl(config *models.Config, signingKeys models.SigningKeyService) *TokenServiceImpl {
 	return &TokenServiceImpl{
-		config: config,
+		config:      config,
+		signingKeys: signingKeys,
 	}
 }
 
@@ -77,3 +84,112 @@ func (ts *TokenServiceImpl) DecryptToken(encryptedToken string) (string, error)
 
 	return token, nil
 }
+
+// SignJWT signs the claims with the active signing key. The iss, aud, iat and exp claims
+// default to the JWT config when they are not set.
+func (ts *TokenServiceImpl) SignJWT(claims map[string]any) (string, error) {
+	if ts.signingKeys == nil {
+		return "", fmt.Errorf("signing keys are not configured")
+	}
+
+	key, err := ts.signingKeys.GetActiveSigningKey()
+	if err != nil {
+		return "", fmt.Errorf("get signing key: %w", err)
+	}
+	encodedPrivateKey, err := ts.DecryptToken(key.PrivateKey)
+	if err != nil {
+		return "", fmt.Errorf("decrypt signing key: %w", err)
+	}
+	privateKey, err := jose.ParsePrivateKey(encodedPrivateKey)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now().UTC()
+	payload := map[string]any{
+		"iss": ts.jwtIssuer(),
+		"aud": ts.jwtAudience(),
+		"iat": now.Unix(),
+		"exp": now.Add(ts.config.JWT.ExpiresIn).Unix(),
+	}
+	for name, value := range claims {
+		payload[name] = value
+	}
+
+	return jose.Sign(key.Algorithm, key.ID, privateKey, payload)
+}
+
+// VerifyJWT checks the signature, issuer, audience and validity period of a JWT issued by SignJWT
+// and returns its claims. ID tokens share the signing keys and issuer but are meant for a client, so the
+// audience keeps them from passing as access tokens.
+func (ts *TokenServiceImpl) VerifyJWT(token string) (map[string]any, error) {
+	if ts.signingKeys == nil {
+		return nil, fmt.Errorf("signing keys are not configured")
+	}
+
+	header, err := jose.ParseHeader(token)
+	if err != nil {
+		return nil, constants.ErrInvalidToken
+	}
+	key, err := ts.signingKeys.GetSigningKeyByID(header.KeyID)
+	if err != nil {
+		return nil, fmt.Errorf("get signing key: %w", err)
+	}
+	if key == nil || ts.signingKeys.IsExpired(key) {
+		return nil, constants.ErrInvalidToken
+	}
+	publicKey, err := jose.ParsePublicKey(key.PublicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := jose.Verify(token, key.Algorithm, publicKey)
+	if err != nil {
+		return nil, constants.ErrInvalidToken
+	}
+
+	now := time.Now()
+	if exp, ok := jose.NumericDate(claims, "exp"); !ok || !now.Before(exp) {
+		return nil, constants.ErrTokenExpired
+	}
+	if nbf, ok := jose.NumericDate(claims, "nbf"); ok && now.Before(nbf) {
+		return nil, constants.ErrInvalidToken
+	}
+	if iss, _ := claims["iss"].(string); iss != ts.jwtIssuer() {
+		return nil, constants.ErrInvalidToken
+	}
+	if !hasAudience(claims, ts.jwtAudience()) {
+		return nil, constants.ErrInvalidToken
+	}
+
+	return claims, nil
+}
+
+func (ts *TokenServiceImpl) jwtIssuer() string {
+	if ts.config.JWT.Issuer != "" {
+		return ts.config.JWT.Issuer
+	}
+	return ts.config.BaseURL
+}
+
+func (ts *TokenServiceImpl) jwtAudience() string {
+	if ts.config.JWT.Audience != "" {
+		return ts.config.JWT.Audience
+	}
+	return ts.config.BaseURL
+}
+
+// hasAudience reports whether the "aud" claim, a string or an array of strings, contains audience.
+func hasAudience(claims map[string]any, audience string) bool {
+	switch aud := claims["aud"].(type) {
+	case string:
+		return aud == audience
+	case []any:
+		for _, value := range aud {
+			if value == audience {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -1,8 +1,13 @@
 package services
 
 import (
+	"errors"
 	"testing"
+	"time"
 
+	"github.com/GoBetterAuth/go-better-auth/internal/constants"
+	"github.com/GoBetterAuth/go-better-auth/internal/jose"
+	"github.com/GoBetterAuth/go-better-auth/internal/util"
 	"github.com/GoBetterAuth/go-better-auth/models"
 )
 
@@ -11,7 +16,7 @@ func TestTokenServiceGenerateToken(t *testing.T) {
 	config := &models.Config{
 		Secret: "test_secret",
 	}
-	ts := NewTokenServiceImpl(config)
+	ts := NewTokenServiceImpl(config, nil)
 
 	token, err := ts.GenerateToken()
 	if err != nil {
@@ -29,7 +34,7 @@ func TestTokenServiceHashToken(t *testing.T) {
 	config := &models.Config{
 		Secret: secret,
 	}
-	ts := NewTokenServiceImpl(config)
+	ts := NewTokenServiceImpl(config, nil)
 
 	token := "test_token"
 	hash1 := ts.HashToken(token)
@@ -48,7 +53,7 @@ func TestTokenServiceHashToken(t *testing.T) {
 	differentConfig := &models.Config{
 		Secret: "different_secret",
 	}
-	differentTS := NewTokenServiceImpl(differentConfig)
+	differentTS := NewTokenServiceImpl(differentConfig, nil)
 	hash3 := differentTS.HashToken(token)
 
 	if hash1 == hash3 {
@@ -62,7 +67,7 @@ func TestTokenServiceEncryptedToken(t *testing.T) {
 	config := &models.Config{
 		Secret: secret,
 	}
-	ts := NewTokenServiceImpl(config)
+	ts := NewTokenServiceImpl(config, nil)
 
 	// Generate encrypted token
 	encrypted, err := ts.GenerateEncryptedToken()
@@ -88,7 +93,7 @@ func TestTokenServiceEncryptedToken(t *testing.T) {
 	wrongConfig := &models.Config{
 		Secret: "wrong_secret",
 	}
-	wrongTS := NewTokenServiceImpl(wrongConfig)
+	wrongTS := NewTokenServiceImpl(wrongConfig, nil)
 	_, err = wrongTS.DecryptToken(encrypted)
 	if err == nil {
 		t.Fatal("DecryptToken should fail with wrong secret")
@@ -100,7 +105,7 @@ func TestTokenServiceNoSecret(t *testing.T) {
 	config := &models.Config{
 		Secret: "",
 	}
-	ts := NewTokenServiceImpl(config)
+	ts := NewTokenServiceImpl(config, nil)
 
 	// EncryptedToken should fail without secret
 	_, err := ts.GenerateEncryptedToken()
@@ -114,3 +119,80 @@ func TestTokenServiceNoSecret(t *testing.T) {
 		t.Fatal("DecryptToken should fail without secret")
 	}
 }
+
+// stubSigningKeyService serves a single in-memory signing key
+type stubSigningKeyService struct {
+	key *models.SigningKey
+}
+
+func (s *stubSigningKeyService) GetActiveSigningKey() (*models.SigningKey, error) {
+	return s.key, nil
+}
+
+func (s *stubSigningKeyService) GetSigningKeyByID(id string) (*models.SigningKey, error) {
+	if id != s.key.ID {
+		return nil, nil
+	}
+	return s.key, nil
+}
+
+func (s *stubSigningKeyService) ListVerificationKeys() ([]models.SigningKey, error) {
+	return []models.SigningKey{*s.key}, nil
+}
+
+func (s *stubSigningKeyService) IsExpired(key *models.SigningKey) bool {
+	return false
+}
+
+// TestTokenServiceSignVerifyJWT verifies JWTs round trip and expire
+func TestTokenServiceSignVerifyJWT(t *testing.T) {
+	config := &models.Config{
+		Secret:  "test_secret",
+		BaseURL: "https://auth.example.com",
+		JWT:     models.JWTConfig{Algorithm: jose.AlgEdDSA, ExpiresIn: 15 * time.Minute},
+	}
+
+	privateKey, _ := jose.GenerateKey(jose.AlgEdDSA)
+	encodedPrivateKey, _ := jose.MarshalPrivateKey(privateKey)
+	encryptedPrivateKey, _ := util.EncryptToken(encodedPrivateKey, config.Secret)
+	publicKey, _ := jose.MarshalPublicKey(privateKey.Public())
+	ts := NewTokenServiceImpl(config, &stubSigningKeyService{key: &models.SigningKey{
+		ID:         "kid-1",
+		Algorithm:  jose.AlgEdDSA,
+		PublicKey:  publicKey,
+		PrivateKey: encryptedPrivateKey,
+	}})
+
+	token, err := ts.SignJWT(map[string]any{"sub": "user-1"})
+	if err != nil {
+		t.Fatalf("SignJWT failed: %v", err)
+	}
+
+	claims, err := ts.VerifyJWT(token)
+	if err != nil {
+		t.Fatalf("VerifyJWT failed: %v", err)
+	}
+	if claims["sub"] != "user-1" || claims["iss"] != config.BaseURL {
+		t.Fatalf("unexpected claims: %v", claims)
+	}
+
+	expired, _ := ts.SignJWT(map[string]any{"sub": "user-1", "exp": time.Now().Add(-time.Minute).Unix()})
+	if _, err := ts.VerifyJWT(expired); !errors.Is(err, constants.ErrTokenExpired) {
+		t.Fatalf("expected expired token to be rejected, got %v", err)
+	}
+
+	// ID tokens are signed with the same keys and issuer, for a client
+	idToken, _ := ts.SignJWT(map[string]any{"sub": "user-1", "aud": "client-1", "azp": "client-1"})
+	if _, err := ts.VerifyJWT(idToken); !errors.Is(err, constants.ErrInvalidToken) {
+		t.Fatalf("expected an ID token to be rejected, got %v", err)
+	}
+	multiple, _ := ts.SignJWT(map[string]any{"sub": "user-1", "aud": []string{"client-1", config.BaseURL}})
+	if _, err := ts.VerifyJWT(multiple); err != nil {
+		t.Fatalf("expected a token listing the audience to be accepted, got %v", err)
+	}
+
+	config.JWT.Issuer = "https://other.example.com"
+	if _, err := ts.VerifyJWT(token); !errors.Is(err, constants.ErrInvalidToken) {
+		t.Fatalf("expected token from another issuer to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,202 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"github.com/GoBetterAuth/go-better-auth/internal/constants"
+	"github.com/GoBetterAuth/go-better-auth/internal/util"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+const (
+	twoFactorChallengePrefix = "two_factor_challenge:"
+	// maxTwoFactorChallengeAttempts limits how many codes can be tried against a single challenge.
+	maxTwoFactorChallengeAttempts = 5
+)
+
+type TwoFactorServiceImpl struct {
+	config *models.Config
+	db     *gorm.DB
+}
+
+func NewTwoFactorServiceImpl(config *models.Config, db *gorm.DB) *TwoFactorServiceImpl {
+	return &TwoFactorServiceImpl{config: config, db: db}
+}
+
+// CreateTwoFactor creates a new two factor record for a user.
+func (s *TwoFactorServiceImpl) CreateTwoFactor(twoFactor *models.TwoFactor) error {
+	if twoFactor.ID == "" {
+		twoFactor.ID = uuid.NewString()
+	}
+	twoFactor.CreatedAt = time.Now().UTC()
+	twoFactor.UpdatedAt = time.Now().UTC()
+
+	return s.db.Create(twoFactor).Error
+}
+
+// GetTwoFactorByUserID retrieves the two factor record of a user.
+func (s *TwoFactorServiceImpl) GetTwoFactorByUserID(userID string) (*models.TwoFactor, error) {
+	var twoFactor models.TwoFactor
+	if err := s.db.Where("user_id = ?", userID).First(&twoFactor).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &twoFactor, nil
+}
+
+// UpdateTwoFactor updates an existing two factor record.
+func (s *TwoFactorServiceImpl) UpdateTwoFactor(twoFactor *models.TwoFactor) error {
+	twoFactor.UpdatedAt = time.Now().UTC()
+	return s.db.Save(twoFactor).Error
+}
+
+// DeleteTwoFactorByUserID deletes the two factor record of a user along with their backup codes.
+func (s *TwoFactorServiceImpl) DeleteTwoFactorByUserID(userID string) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.TwoFactorBackupCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Where("user_id = ?", userID).Delete(&models.TwoFactor{}).Error
+	})
+}
+
+// UseTimeStep records the TOTP time step of an accepted code. It reports false if a code of the
+// same or a later step has already been accepted. The conditional update guarantees each code
+// can only be used once, even by concurrent requests.
+func (s *TwoFactorServiceImpl) UseTimeStep(userID string, step int64) (bool, error) {
+	result := s.db.Model(&models.TwoFactor{}).
+		Where("user_id = ? AND last_used_step < ?", userID, step).
+		Update("last_used_step", step)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// IsEnabledForUser reports whether two factor authentication is enabled globally and
+// the user has a verified factor, i.e. whether sign-in must go through a challenge.
+func (s *TwoFactorServiceImpl) IsEnabledForUser(userID string) (bool, error) {
+	if !s.config.TwoFactor.Enabled {
+		return false, nil
+	}
+
+	twoFactor, err := s.GetTwoFactorByUserID(userID)
+	if err != nil {
+		return false, err
+	}
+
+	return twoFactor != nil && twoFactor.Enabled, nil
+}
+
+// CreateChallenge stores a short-lived pending sign-in for the user in secondary storage
+// and returns the raw challenge token. Only the hashed token is used as the storage key.
+func (s *TwoFactorServiceImpl) CreateChallenge(ctx context.Context, userID string) (string, error) {
+	token, err := util.GenerateToken()
+	if err != nil {
+		return "", fmt.Errorf("generate challenge token: %w", err)
+	}
+
+	ttl := s.config.TwoFactor.ChallengeExpiresIn
+	if err := s.config.SecondaryStorage.Storage.Set(ctx, s.challengeKey(token), userID, &ttl); err != nil {
+		return "", fmt.Errorf("store challenge: %w", err)
+	}
+
+	return token, nil
+}
+
+// VerifyChallenge returns the user ID for a pending challenge and counts the attempt.
+// The challenge is rejected once the maximum number of attempts has been reached.
+func (s *TwoFactorServiceImpl) VerifyChallenge(ctx context.Context, challengeToken string) (string, error) {
+	if challengeToken == "" {
+		return "", constants.ErrTwoFactorChallengeInvalid
+	}
+
+	key := s.challengeKey(challengeToken)
+	value, err := s.config.SecondaryStorage.Storage.Get(ctx, key)
+	if err != nil {
+		return "", fmt.Errorf("get challenge: %w", err)
+	}
+	userID, ok := value.(string)
+	if !ok || userID == "" {
+		return "", constants.ErrTwoFactorChallengeInvalid
+	}
+
+	ttl := s.config.TwoFactor.ChallengeExpiresIn
+	attempts, err := s.config.SecondaryStorage.Storage.Incr(ctx, key+":attempts", &ttl)
+	if err != nil {
+		return "", fmt.Errorf("count challenge attempts: %w", err)
+	}
+	if attempts > maxTwoFactorChallengeAttempts {
+		_ = s.DeleteChallenge(ctx, challengeToken)
+		return "", constants.ErrTwoFactorChallengeAttempts
+	}
+
+	return userID, nil
+}
+
+// DeleteChallenge removes a pending challenge so it cannot be used again.
+func (s *TwoFactorServiceImpl) DeleteChallenge(ctx context.Context, challengeToken string) error {
+	key := s.challengeKey(challengeToken)
+	if err := s.config.SecondaryStorage.Storage.Delete(ctx, key+":attempts"); err != nil {
+		return err
+	}
+	return s.config.SecondaryStorage.Storage.Delete(ctx, key)
+}
+
+// ReplaceBackupCodes removes all existing backup codes of a user and stores the given hashed codes.
+func (s *TwoFactorServiceImpl) ReplaceBackupCodes(userID string, hashedCodes []string) error {
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.TwoFactorBackupCode{}).Error; err != nil {
+			return err
+		}
+		if len(hashedCodes) == 0 {
+			return nil
+		}
+
+		now := time.Now().UTC()
+		backupCodes := make([]models.TwoFactorBackupCode, 0, len(hashedCodes))
+		for _, hashedCode := range hashedCodes {
+			backupCodes = append(backupCodes, models.TwoFactorBackupCode{
+				ID:        uuid.NewString(),
+				UserID:    userID,
+				Code:      hashedCode,
+				CreatedAt: now,
+			})
+		}
+		return tx.Create(&backupCodes).Error
+	})
+}
+
+// UseBackupCode marks an unused backup code as used. It reports false if the code does not exist
+// or has already been used. The conditional update guarantees a code can only be consumed once.
+func (s *TwoFactorServiceImpl) UseBackupCode(userID string, hashedCode string) (bool, error) {
+	result := s.db.Model(&models.TwoFactorBackupCode{}).
+		Where("user_id = ? AND code = ? AND used_at IS NULL", userID, hashedCode).
+		Update("used_at", time.Now().UTC())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// CountBackupCodes returns the number of unused backup codes of a user.
+func (s *TwoFactorServiceImpl) CountBackupCodes(userID string) (int, error) {
+	var count int64
+	if err := s.db.Model(&models.TwoFactorBackupCode{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Count(&count).Error; err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+func (s *TwoFactorServiceImpl) challengeKey(challengeToken string) string {
+	return twoFactorChallengePrefix + util.HashTokenWithSecret(challengeToken, s.config.Secret)
+}
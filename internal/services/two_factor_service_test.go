@@ -0,0 +1,48 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/GoBetterAuth/go-better-auth/config"
+	"github.com/GoBetterAuth/go-better-auth/internal/testutil"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+func TestTwoFactorService_UseTimeStep(t *testing.T) {
+	service := NewTwoFactorServiceImpl(config.NewConfig(), testutil.NewDB(t, &models.TwoFactor{}))
+
+	if err := service.CreateTwoFactor(&models.TwoFactor{UserID: "user-1", Secret: "secret", Enabled: true}); err != nil {
+		t.Fatalf("CreateTwoFactor failed: %v", err)
+	}
+
+	used, err := service.UseTimeStep("user-1", 100)
+	if err != nil {
+		t.Fatalf("UseTimeStep failed: %v", err)
+	}
+	if !used {
+		t.Fatal("expected first code of a time step to be accepted")
+	}
+
+	// Replays of the same step and codes of earlier steps within the skew window are rejected
+	for _, step := range []int64{100, 99} {
+		used, err := service.UseTimeStep("user-1", step)
+		if err != nil {
+			t.Fatalf("UseTimeStep failed: %v", err)
+		}
+		if used {
+			t.Fatalf("expected time step %d to be rejected", step)
+		}
+	}
+
+	if used, _ := service.UseTimeStep("user-1", 101); !used {
+		t.Fatal("expected code of a later time step to be accepted")
+	}
+
+	twoFactor, err := service.GetTwoFactorByUserID("user-1")
+	if err != nil || twoFactor == nil {
+		t.Fatalf("GetTwoFactorByUserID failed: %v", err)
+	}
+	if twoFactor.LastUsedStep != 101 {
+		t.Fatalf("expected last used step 101, got %d", twoFactor.LastUsedStep)
+	}
+}
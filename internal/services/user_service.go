@@ -2,6 +2,7 @@ package services
 
 import (
 	"log/slog"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -13,10 +14,11 @@ import (
 type UserServiceImpl struct {
 	config *models.Config
 	db     *gorm.DB
+	cache  lookupCache
 }
 
 func NewUserServiceImpl(config *models.Config, db *gorm.DB) *UserServiceImpl {
-	return &UserServiceImpl{config: config, db: db}
+	return &UserServiceImpl{config: config, db: db, cache: lookupCache{config: config}}
 }
 
 // CreateUser creates a new user in the database.
@@ -46,15 +48,21 @@ func (s *UserServiceImpl) CreateUser(user *models.User) error {
 	return nil
 }
 
-// GetUserByID retrieves a user by their ID.
+// GetUserByID retrieves a user by their ID, from the lookup cache when enabled.
 func (s *UserServiceImpl) GetUserByID(id string) (*models.User, error) {
 	var user models.User
+	if s.cache.get(userCachePrefix+id, &user) {
+		return &user, nil
+	}
+
 	if err := s.db.First(&user, "id = ?", id).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
 		}
 		return nil, err
 	}
+
+	s.cache.set(userCachePrefix+id, user)
 	return &user, nil
 }
 
@@ -84,6 +92,8 @@ func (s *UserServiceImpl) UpdateUser(user *models.User) error {
 		return err
 	}
 
+	s.cache.delete(userCachePrefix + user.ID)
+
 	if s.config.DatabaseHooks.Users != nil && s.config.DatabaseHooks.Users.AfterUpdate != nil {
 		go func() {
 			if err := s.config.DatabaseHooks.Users.AfterUpdate(*user); err != nil {
@@ -94,3 +104,91 @@ func (s *UserServiceImpl) UpdateUser(user *models.User) error {
 
 	return nil
 }
+
+// ListUsers returns a page of the users matching query, newest first, along with the number of matching users.
+func (s *UserServiceImpl) ListUsers(query models.UserListQuery) ([]models.User, int64, error) {
+	db := s.db.Model(&models.User{})
+	if query.Search != "" {
+		pattern := "%" + strings.ToLower(query.Search) + "%"
+		db = db.Where("LOWER(name) LIKE ? OR LOWER(email) LIKE ?", pattern, pattern)
+	}
+	if query.EmailVerified != nil {
+		db = db.Where("email_verified = ?", *query.EmailVerified)
+	}
+	if query.Banned != nil {
+		// Expired bans no longer count
+		active := "banned = ? AND (ban_expires_at IS NULL OR ban_expires_at > ?)"
+		if *query.Banned {
+			db = db.Where(active, true, time.Now().UTC())
+		} else {
+			db = db.Not(active, true, time.Now().UTC())
+		}
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var users []models.User
+	if err := db.Order("created_at DESC").Limit(query.Limit).Offset(query.Offset).Find(&users).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return users, total, nil
+}
+
+// DeleteUser deletes a user along with everything they own, including the organizations they are the
+// only owner of, which would otherwise be left without anyone to manage them. Foreign keys are not
+// enforced by every database, so the related rows are deleted explicitly.
+func (s *UserServiceImpl) DeleteUser(id string) error {
+	var sessionTokens []string
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		otherOwners := tx.Model(&models.Member{}).Select("organization_id").Where("role = ? AND user_id <> ?", models.OrganizationRoleOwner, id)
+		var ownedOrganizationIDs []string
+		if err := tx.Model(&models.Member{}).
+			Where("user_id = ? AND role = ?", id, models.OrganizationRoleOwner).
+			Where("organization_id NOT IN (?)", otherOwners).
+			Pluck("organization_id", &ownedOrganizationIDs).Error; err != nil {
+			return err
+		}
+		tokens, err := deleteOrganizations(tx, ownedOrganizationIDs)
+		if err != nil {
+			return err
+		}
+
+		// The tokens are needed to drop the deleted sessions from the lookup cache
+		var userTokens []string
+		if err := tx.Model(&models.Session{}).Where("user_id = ?", id).Pluck("token", &userTokens).Error; err != nil {
+			return err
+		}
+		sessionTokens = append(tokens, userTokens...)
+
+		for _, model := range []any{
+			&models.Session{},
+			&models.Account{},
+			&models.Verification{},
+			&models.TwoFactor{},
+			&models.TwoFactorBackupCode{},
+			&models.Passkey{},
+			&models.OAuthConsent{},
+			&models.UserRole{},
+			&models.Member{},
+		} {
+			if err := tx.Where("user_id = ?", id).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Where("id = ?", id).Delete(&models.User{}).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(sessionTokens)+1)
+	for _, token := range sessionTokens {
+		keys = append(keys, sessionCachePrefix+token)
+	}
+	s.cache.delete(append(keys, userCachePrefix+id)...)
+	return nil
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/GoBetterAuth/go-better-auth/config"
+	"github.com/GoBetterAuth/go-better-auth/internal/testutil"
+	"github.com/GoBetterAuth/go-better-auth/models"
+	"github.com/GoBetterAuth/go-better-auth/storage"
+)
+
+func newTestUserDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	return testutil.NewDB(t,
+		&models.User{}, &models.Session{}, &models.Account{}, &models.Verification{}, &models.TwoFactor{},
+		&models.TwoFactorBackupCode{}, &models.Passkey{}, &models.OAuthConsent{}, &models.UserRole{},
+		&models.Organization{}, &models.Member{}, &models.Invitation{},
+	)
+}
+
+func createUser(t *testing.T, service *UserServiceImpl, email string) *models.User {
+	t.Helper()
+
+	user := &models.User{Email: email}
+	if err := service.CreateUser(user); err != nil {
+		t.Fatalf("CreateUser failed: %v", err)
+	}
+	return user
+}
+
+func TestUserService_DeleteUserUncachesSessions(t *testing.T) {
+	db := newTestUserDB(t)
+	cfg := config.NewConfig(
+		config.WithSecondaryStorage(models.SecondaryStorageConfig{
+			Storage: storage.NewMemorySecondaryStorage(models.SecondaryStorageMemoryOptions{}),
+		}),
+		config.WithSession(models.SessionConfig{ExpiresIn: time.Hour, Cache: models.SessionCacheConfig{Enabled: true}}),
+	)
+	userService := NewUserServiceImpl(cfg, db)
+	sessionService := NewSessionServiceImpl(cfg, db)
+
+	user := createUser(t, userService, "user@example.com")
+	session, err := sessionService.CreateSession(user.ID, "token-1")
+	if err != nil {
+		t.Fatalf("CreateSession failed: %v", err)
+	}
+	// Looking the session up caches it
+	if cached, _ := sessionService.GetSessionByToken(session.Token); cached == nil {
+		t.Fatal("expected the session to be found")
+	}
+
+	if err := userService.DeleteUser(user.ID); err != nil {
+		t.Fatalf("DeleteUser failed: %v", err)
+	}
+
+	if cached, err := sessionService.GetSessionByToken(session.Token); err != nil || cached != nil {
+		t.Fatalf("expected the session of a deleted user to be gone, got %+v, %v", cached, err)
+	}
+	if deleted, err := userService.GetUserByID(user.ID); err != nil || deleted != nil {
+		t.Fatalf("expected the user to be deleted, got %+v, %v", deleted, err)
+	}
+}
+
+func TestUserService_DeleteUserDeletesSolelyOwnedOrganizations(t *testing.T) {
+	db := newTestUserDB(t)
+	cfg := config.NewConfig()
+	userService := NewUserServiceImpl(cfg, db)
+	sessionService := NewSessionServiceImpl(cfg, db)
+	organizationService := NewOrganizationServiceImpl(cfg, db)
+
+	owner := createUser(t, userService, "owner@example.com")
+	coOwner := createUser(t, userService, "co-owner@example.com")
+	member := createUser(t, userService, "member@example.com")
+
+	owned := &models.Organization{Name: "Acme", Slug: "acme"}
+	if err := organizationService.CreateOrganization(owned, owner.ID); err != nil {
+		t.Fatalf("CreateOrganization failed: %v", err)
+	}
+	shared := &models.Organization{Name: "Globex", Slug: "globex"}
+	if err := organizationService.CreateOrganization(shared, owner.ID); err != nil {
+		t.Fatalf("CreateOrganization failed: %v", err)
+	}
+	for _, m := range []*models.Member{
+		newMember(owned.ID, member.ID, models.OrganizationRoleMember),
+		newMember(shared.ID, coOwner.ID, models.OrganizationRoleOwner),
+	} {
+		if err := db.Create(m).Error; err != nil {
+			t.Fatalf("failed to add member: %v", err)
+		}
+	}
+	invitation := &models.Invitation{OrganizationID: owned.ID, Email: "invitee@example.com", Role: models.OrganizationRoleMember, Token: "token", ExpiresAt: time.Now().Add(time.Hour)}
+	if err := organizationService.CreateInvitation(invitation); err != nil {
+		t.Fatalf("CreateInvitation failed: %v", err)
+	}
+	memberSession, err := sessionService.CreateSession(member.ID, "member-token")
+	if err != nil {
+		t.Fatalf("CreateSession failed: %v", err)
+	}
+	if err := sessionService.SetActiveOrganization(memberSession, &owned.ID); err != nil {
+		t.Fatalf("SetActiveOrganization failed: %v", err)
+	}
+
+	if err := userService.DeleteUser(owner.ID); err != nil {
+		t.Fatalf("DeleteUser failed: %v", err)
+	}
+
+	// The organization the user was the only owner of goes along with its members and invitations
+	if organization, _ := organizationService.GetOrganizationByID(owned.ID); organization != nil {
+		t.Error("expected the solely owned organization to be deleted")
+	}
+	if m, _ := organizationService.GetMember(owned.ID, member.ID); m != nil {
+		t.Error("expected the members of the deleted organization to be removed")
+	}
+	if found, _ := organizationService.GetInvitationByToken(invitation.Token); found != nil {
+		t.Error("expected the invitations of the deleted organization to be removed")
+	}
+	if session, _ := sessionService.GetSessionByToken(memberSession.Token); session == nil || session.ActiveOrganizationID != nil {
+		t.Errorf("expected the deleted organization to be cleared from the member's session, got %+v", session)
+	}
+
+	// The organization another owner remains in is kept
+	if organization, _ := organizationService.GetOrganizationByID(shared.ID); organization == nil {
+		t.Error("expected the organization with another owner to be kept")
+	}
+	if m, _ := organizationService.GetMember(shared.ID, owner.ID); m != nil {
+		t.Error("expected the membership of the deleted user to be removed")
+	}
+}
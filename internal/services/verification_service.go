@@ -26,7 +26,10 @@ func (s *VerificationServiceImpl) CreateVerification(v *models.Verification) err
 	now := time.Now().UTC()
 	v.CreatedAt = now
 	v.UpdatedAt = now
-	v.ExpiresAt = now.Add(time.Hour)
+	// Callers set the expiry of their own kind of verification, e.g. the shorter lived magic links
+	if v.ExpiresAt.IsZero() {
+		v.ExpiresAt = now.Add(time.Hour)
+	}
 
 	if s.config.DatabaseHooks.Verifications != nil && s.config.DatabaseHooks.Verifications.BeforeCreate != nil {
 		if err := s.config.DatabaseHooks.Verifications.BeforeCreate(v); err != nil {
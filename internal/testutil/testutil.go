@@ -0,0 +1,42 @@
+// Package testutil holds helpers shared by the tests of services and use cases.
+package testutil
+
+import (
+	"io"
+	"log/slog"
+	"testing"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+// NewDB opens an in-memory SQLite database with the tables of the given models.
+func NewDB(t testing.TB, tables ...any) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("failed to open test database: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("failed to get test database: %v", err)
+	}
+	// Every connection to an in-memory database sees a database of its own
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	if err := db.AutoMigrate(tables...); err != nil {
+		t.Fatalf("failed to migrate test database: %v", err)
+	}
+
+	return db
+}
+
+// NewLogger returns a logger that discards everything.
+func NewLogger() models.Logger {
+	return slog.New(slog.NewTextHandler(io.Discard, nil))
+}
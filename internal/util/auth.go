@@ -27,6 +27,63 @@ func GetCookieOptions(cfg *models.Config) (isSecure bool, sameSite http.SameSite
 	return isSecure, sameSite
 }
 
+// GetBearerToken returns the token of an "Authorization: Bearer <token>" header, if any.
+func GetBearerToken(r *http.Request) string {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(token)
+}
+
+// GetSessionToken returns the raw session token of the request, read from the first
+// configured token source that carries one, along with that source.
+func GetSessionToken(cfg *models.Config, r *http.Request) (string, models.SessionTokenSource) {
+	for _, source := range cfg.Session.TokenSources {
+		switch source {
+		case models.SessionTokenSourceCookie:
+			if cookie, err := r.Cookie(cfg.Session.CookieName); err == nil && cookie.Value != "" {
+				return cookie.Value, source
+			}
+		case models.SessionTokenSourceBearer:
+			if token := GetBearerToken(r); token != "" {
+				return token, source
+			}
+		}
+	}
+	return "", ""
+}
+
+// GetForwardedHost returns the host of the request a reverse proxy is asking about, falling back to the
+// host of the request itself.
+func GetForwardedHost(r *http.Request) string {
+	if host := r.Header.Get("X-Forwarded-Host"); host != "" {
+		return strings.TrimSpace(strings.Split(host, ",")[0])
+	}
+	return r.Host
+}
+
+// GetForwardedURL rebuilds the URL of the request a reverse proxy is asking about, from either the
+// X-Original-URL header (nginx) or the X-Forwarded-Proto, X-Forwarded-Host and X-Forwarded-Uri headers (Traefik).
+// Returns an empty string if the proxy did not send enough information.
+func GetForwardedURL(r *http.Request) string {
+	if originalURL := r.Header.Get("X-Original-URL"); originalURL != "" {
+		return originalURL
+	}
+
+	uri := r.Header.Get("X-Forwarded-Uri")
+	host := r.Header.Get("X-Forwarded-Host")
+	if uri == "" || host == "" {
+		return ""
+	}
+
+	proto := r.Header.Get("X-Forwarded-Proto")
+	if proto == "" {
+		proto = "https"
+	}
+	return proto + "://" + strings.TrimSpace(strings.Split(host, ",")[0]) + uri
+}
+
 func AppendQueryParam(originalURL string, key string, value string) string {
 	URL, err := url.Parse(originalURL)
 	if err != nil {
@@ -39,8 +96,8 @@ func AppendQueryParam(originalURL string, key string, value string) string {
 }
 
 func IsTrustedRedirect(target string, trusted []string) bool {
-	// Allow only safe relative paths
-	if strings.HasPrefix(target, "/") && !strings.HasPrefix(target, "//") {
+	// Allow only safe relative paths. Browsers treat a backslash like a slash, so "/\host" is protocol relative too
+	if strings.HasPrefix(target, "/") && !strings.HasPrefix(target, "//") && !strings.HasPrefix(target, "/\\") {
 		return true
 	}
 
@@ -0,0 +1,98 @@
+package util
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+func TestGetBearerToken(t *testing.T) {
+	tests := map[string]string{
+		"Bearer abc123": "abc123",
+		"bearer abc123": "abc123",
+		"Basic abc123":  "",
+		"Bearer":        "",
+		"":              "",
+	}
+
+	for header, expected := range tests {
+		req := CreateMockRequest(http.MethodGet, "/me", nil, nil, map[string]string{"Authorization": header})
+		if got := GetBearerToken(req); got != expected {
+			t.Errorf("header %q: expected %q, got %q", header, expected, got)
+		}
+	}
+}
+
+func TestGetSessionToken(t *testing.T) {
+	cfg := &models.Config{
+		Session: models.SessionConfig{
+			CookieName:   "session",
+			TokenSources: []models.SessionTokenSource{models.SessionTokenSourceCookie, models.SessionTokenSourceBearer},
+		},
+	}
+
+	req := CreateMockRequest(http.MethodGet, "/me", nil, nil, map[string]string{"Authorization": "Bearer from-header"})
+	req.AddCookie(&http.Cookie{Name: "session", Value: "from-cookie"})
+
+	token, source := GetSessionToken(cfg, req)
+	if token != "from-cookie" || source != models.SessionTokenSourceCookie {
+		t.Errorf("expected the cookie to take precedence, got %q from %q", token, source)
+	}
+
+	cfg.Session.TokenSources = []models.SessionTokenSource{models.SessionTokenSourceBearer}
+	token, source = GetSessionToken(cfg, req)
+	if token != "from-header" || source != models.SessionTokenSourceBearer {
+		t.Errorf("expected the bearer token, got %q from %q", token, source)
+	}
+
+	cfg.Session.TokenSources = []models.SessionTokenSource{models.SessionTokenSourceCookie}
+	req = CreateMockRequest(http.MethodGet, "/me", nil, nil, map[string]string{"Authorization": "Bearer from-header"})
+	if token, _ := GetSessionToken(cfg, req); token != "" {
+		t.Errorf("expected bearer tokens to be ignored when not allowed, got %q", token)
+	}
+}
+
+func TestGetForwardedURL(t *testing.T) {
+	req := CreateMockRequest(http.MethodGet, "/verify-request", nil, nil, map[string]string{
+		"X-Forwarded-Proto": "http",
+		"X-Forwarded-Host":  "app.example.com",
+		"X-Forwarded-Uri":   "/dashboard?tab=1",
+	})
+	if got := GetForwardedURL(req); got != "http://app.example.com/dashboard?tab=1" {
+		t.Errorf("expected the Traefik headers to be used, got %q", got)
+	}
+	if got := GetForwardedHost(req); got != "app.example.com" {
+		t.Errorf("expected forwarded host, got %q", got)
+	}
+
+	req = CreateMockRequest(http.MethodGet, "/verify-request", nil, nil, map[string]string{
+		"X-Original-URL": "https://legacy.example.com/admin",
+	})
+	if got := GetForwardedURL(req); got != "https://legacy.example.com/admin" {
+		t.Errorf("expected the nginx header to be used, got %q", got)
+	}
+
+	req = CreateMockRequest(http.MethodGet, "/verify-request", nil, nil, nil)
+	if got := GetForwardedURL(req); got != "" {
+		t.Errorf("expected no URL without proxy headers, got %q", got)
+	}
+}
+
+func TestIsTrustedRedirect(t *testing.T) {
+	trusted := []string{"https://app.example.com"}
+	tests := map[string]bool{
+		"/dashboard":                        true,
+		"https://app.example.com/dashboard": true,
+		"https://evil.example.com/":         false,
+		"//evil.example.com":                false,
+		"/\\evil.example.com":               false,
+		"javascript:alert(1)":               false,
+	}
+
+	for target, expected := range tests {
+		if got := IsTrustedRedirect(target, trusted); got != expected {
+			t.Errorf("target %q: expected %v, got %v", target, expected, got)
+		}
+	}
+}
@@ -24,7 +24,10 @@ func PreserveNonSerializableFieldsOnConfig(target, source *models.Config) {
 	target.EmailPassword.Password.Hash = source.EmailPassword.Password.Hash
 	target.EmailPassword.Password.Verify = source.EmailPassword.Password.Verify
 	target.EmailVerification.SendVerificationEmail = source.EmailVerification.SendVerificationEmail
+	target.MagicLink.SendMagicLinkEmail = source.MagicLink.SendMagicLinkEmail
+	target.EmailOTP.SendEmailOTP = source.EmailOTP.SendEmailOTP
 	target.User.ChangeEmail.SendEmailChangeVerificationEmail = source.User.ChangeEmail.SendEmailChangeVerificationEmail
+	target.JWT.DefinePayload = source.JWT.DefinePayload
 }
 
 // RequiresRestart checks if the configuration changes require a server restart.
@@ -2,6 +2,7 @@ package util
 
 import (
 	"fmt"
+	"html"
 
 	"github.com/GoBetterAuth/go-better-auth/models"
 )
@@ -98,3 +99,101 @@ func CreateEmailChangeVerificationBody(user models.User, newEmail string, verifi
 </html>
 `, user.Name, newEmail, verificationURL)
 }
+
+// CreateMagicLinkEmailBody creates the HTML body for a magic link sign in email
+func CreateMagicLinkEmailBody(email string, magicLinkURL string) string {
+	return fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <style>
+        body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
+        .container { max-width: 600px; margin: 0 auto; padding: 20px; background: #f9f9f9; border-radius: 5px; }
+        .button { display: inline-block; padding: 12px 24px; background: #007bff; color: white; text-decoration: none; border-radius: 5px; margin-top: 15px; }
+        .footer { margin-top: 20px; padding-top: 20px; border-top: 1px solid #ddd; font-size: 0.9em; color: #666; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <h2>Sign In</h2>
+        <p>Hello %s,</p>
+        <p>Click the button below to sign in. The link can only be used once:</p>
+        <a href="%s" class="button">Sign In</a>
+        <p>If you didn't request this link, you can safely ignore this email.</p>
+        <div class="footer">
+            <p>Best regards,<br>The GoBetterAuth Team</p>
+        </div>
+    </div>
+</body>
+</html>
+`, email, magicLinkURL)
+}
+
+// CreateEmailOTPEmailBody creates the HTML body for an email containing a one-time code
+func CreateEmailOTPEmailBody(otp string, otpType models.EmailOTPType) string {
+	heading := "Your Sign In Code"
+	switch otpType {
+	case models.EmailOTPTypeEmailVerification:
+		heading = "Your Email Verification Code"
+	case models.EmailOTPTypePasswordReset:
+		heading = "Your Password Reset Code"
+	}
+
+	return fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <style>
+        body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
+        .container { max-width: 600px; margin: 0 auto; padding: 20px; background: #f9f9f9; border-radius: 5px; }
+        .code { display: inline-block; padding: 12px 24px; background: #fff; border: 1px solid #ddd; border-radius: 5px; font-size: 1.6em; letter-spacing: 6px; font-weight: bold; margin-top: 15px; }
+        .footer { margin-top: 20px; padding-top: 20px; border-top: 1px solid #ddd; font-size: 0.9em; color: #666; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <h2>%s</h2>
+        <p>Enter the following code to continue. It can only be used once:</p>
+        <div class="code">%s</div>
+        <p>If you didn't request this code, you can safely ignore this email.</p>
+        <div class="footer">
+            <p>Best regards,<br>The GoBetterAuth Team</p>
+        </div>
+    </div>
+</body>
+</html>
+`, heading, otp)
+}
+
+// CreateInvitationEmailBody creates the HTML body for an organization invitation email. The organization
+// and inviter names are chosen by users, so they are escaped.
+func CreateInvitationEmailBody(organization models.Organization, inviter models.User, acceptURL string, declineURL string) string {
+	return fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <style>
+        body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
+        .container { max-width: 600px; margin: 0 auto; padding: 20px; background: #f9f9f9; border-radius: 5px; }
+        .button { display: inline-block; padding: 12px 24px; background: #007bff; color: white; text-decoration: none; border-radius: 5px; margin-top: 15px; }
+        .footer { margin-top: 20px; padding-top: 20px; border-top: 1px solid #ddd; font-size: 0.9em; color: #666; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <h2>Join %s</h2>
+        <p>Hello,</p>
+        <p>%s has invited you to join %s. Click the button below to accept the invitation:</p>
+        <a href="%s" class="button">Accept Invitation</a>
+        <p>If you don't want to join, you can <a href="%s">decline the invitation</a> or safely ignore this email.</p>
+        <div class="footer">
+            <p>Best regards,<br>The GoBetterAuth Team</p>
+        </div>
+    </div>
+</body>
+</html>
+`, html.EscapeString(organization.Name), html.EscapeString(inviter.Name), html.EscapeString(organization.Name), acceptURL, declineURL)
+}
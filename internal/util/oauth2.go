@@ -15,12 +15,12 @@ func GeneratePKCE() (verifier string, challenge string, err error) {
 		return "", "", fmt.Errorf("generate verifier: %w", err)
 	}
 
-	// Calculate S256 challenge
-	// challenge = BASE64URL-ENCODE(SHA256(ASCII(code_verifier)))
-	h := sha256.New()
-	h.Write([]byte(verifier))
-	sum := h.Sum(nil)
-	challenge = base64.RawURLEncoding.EncodeToString(sum)
+	return verifier, PKCEChallengeS256(verifier), nil
+}
 
-	return verifier, challenge, nil
+// PKCEChallengeS256 derives the S256 code challenge of a verifier:
+// BASE64URL-ENCODE(SHA256(ASCII(code_verifier)))
+func PKCEChallengeS256(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
 }
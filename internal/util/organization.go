@@ -0,0 +1,66 @@
+package util
+
+import (
+	"net/url"
+	"strings"
+)
+
+const maxSlugLength = 64
+
+// BuildInvitationURL builds the link to the invitation page to accept or decline an organization invitation,
+// action being "accept" or "decline".
+func BuildInvitationURL(invitationPage string, action string, token string, callbackURL *string) string {
+	url, err := url.Parse(invitationPage)
+	if err != nil {
+		return invitationPage
+	}
+	q := url.Query()
+	q.Set("token", token)
+	q.Set("action", action)
+
+	if callbackURL != nil && *callbackURL != "" {
+		q.Set("callback_url", *callbackURL)
+	}
+
+	url.RawQuery = q.Encode()
+
+	return url.String()
+}
+
+// Slugify derives a URL friendly slug from a name, lowercasing it and joining its words with dashes.
+// Characters other than ASCII letters and digits are dropped.
+func Slugify(name string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			if dash && b.Len() > 0 {
+				b.WriteByte('-')
+			}
+			dash = false
+			b.WriteRune(r)
+		case r == ' ' || r == '-' || r == '_' || r == '.':
+			dash = true
+		}
+	}
+
+	slug := b.String()
+	if len(slug) > maxSlugLength {
+		slug = strings.TrimRight(slug[:maxSlugLength], "-")
+	}
+	return slug
+}
+
+// IsValidSlug reports whether slug is made of lowercase letters, digits and single dashes between them.
+func IsValidSlug(slug string) bool {
+	if slug == "" || len(slug) > maxSlugLength || slug[0] == '-' || slug[len(slug)-1] == '-' || strings.Contains(slug, "--") {
+		return false
+	}
+	for _, r := range slug {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,59 @@
+package util
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildInvitationURL(t *testing.T) {
+	callback := "https://app.com/welcome"
+
+	tests := []struct {
+		name        string
+		action      string
+		callbackURL *string
+		expected    string
+	}{
+		{"accept", "accept", nil, "https://app.com/invitations?action=accept&token=abc123"},
+		{"decline with callback", "decline", &callback, "https://app.com/invitations?action=decline&callback_url=https%3A%2F%2Fapp.com%2Fwelcome&token=abc123"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := BuildInvitationURL("https://app.com/invitations", tt.action, "abc123", tt.callbackURL)
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"Acme", "acme"},
+		{"Acme Corp.", "acme-corp"},
+		{"  Acme -- Corp  ", "acme-corp"},
+		{"Team_42", "team-42"},
+		{"Café Crème", "caf-crme"},
+		{"!!!", ""},
+		{strings.Repeat("a", 70), strings.Repeat("a", 64)},
+	}
+	for _, tt := range tests {
+		if result := Slugify(tt.name); result != tt.expected {
+			t.Errorf("Slugify(%q): expected %q, got %q", tt.name, tt.expected, result)
+		}
+		if tt.expected != "" && !IsValidSlug(tt.expected) {
+			t.Errorf("expected %q to be a valid slug", tt.expected)
+		}
+	}
+}
+
+func TestIsValidSlug(t *testing.T) {
+	for _, slug := range []string{"", "-acme", "acme-", "ac--me", "Acme", "acme corp", strings.Repeat("a", 65)} {
+		if IsValidSlug(slug) {
+			t.Errorf("expected %q to be an invalid slug", slug)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package util
+
+import "strings"
+
+// IsValidPermission reports whether name has the "resource:action" form, or is the "*" wildcard.
+func IsValidPermission(name string) bool {
+	if name == "*" {
+		return true
+	}
+	resource, action, ok := strings.Cut(name, ":")
+	return ok && resource != "" && action != "" && resource != "*" &&
+		!strings.ContainsAny(name, " \t\n") && !strings.Contains(action, ":")
+}
+
+// PermissionMatches reports whether the granted permission covers the required one. "*" covers every
+// permission and "resource:*" every action on the resource.
+func PermissionMatches(granted string, required string) bool {
+	if granted == "*" || granted == required {
+		return true
+	}
+	resource, action, ok := strings.Cut(granted, ":")
+	if !ok || action != "*" {
+		return false
+	}
+	requiredResource, _, ok := strings.Cut(required, ":")
+	return ok && requiredResource == resource
+}
@@ -0,0 +1,45 @@
+package util
+
+import "testing"
+
+func TestIsValidPermission(t *testing.T) {
+	tests := map[string]bool{
+		"posts:read":    true,
+		"posts:*":       true,
+		"*":             true,
+		"posts":         false,
+		":read":         false,
+		"posts:":        false,
+		"*:read":        false,
+		"posts:read:1":  false,
+		"posts: read":   false,
+		"billing:admin": true,
+	}
+
+	for name, expected := range tests {
+		if got := IsValidPermission(name); got != expected {
+			t.Errorf("%q: expected %v, got %v", name, expected, got)
+		}
+	}
+}
+
+func TestPermissionMatches(t *testing.T) {
+	tests := []struct {
+		granted  string
+		required string
+		expected bool
+	}{
+		{"posts:read", "posts:read", true},
+		{"posts:read", "posts:delete", false},
+		{"posts:*", "posts:delete", true},
+		{"posts:*", "comments:delete", false},
+		{"*", "comments:delete", true},
+		{"posts", "posts:read", false},
+	}
+
+	for _, tt := range tests {
+		if got := PermissionMatches(tt.granted, tt.required); got != tt.expected {
+			t.Errorf("%q covers %q: expected %v, got %v", tt.granted, tt.required, tt.expected, got)
+		}
+	}
+}
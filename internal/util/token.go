@@ -9,6 +9,8 @@ import (
 	"encoding/hex"
 	"fmt"
 	"io"
+	"math/big"
+	"strings"
 )
 
 // DefaultTokenBytes is the recommended default length (in bytes) for generated tokens.
@@ -53,6 +55,24 @@ func GenerateToken() (string, error) {
 	return GenerateRandomTokenBase64URL(DefaultTokenBytes)
 }
 
+// GenerateNumericCode returns a uniformly distributed random code of the given number of digits,
+// suitable for one-time codes sent by email.
+func GenerateNumericCode(digits int) (string, error) {
+	if digits <= 0 {
+		return "", fmt.Errorf("invalid length %d", digits)
+	}
+	max := big.NewInt(10)
+	var sb strings.Builder
+	for range digits {
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", fmt.Errorf("read random digit: %w", err)
+		}
+		sb.WriteByte(byte('0' + n.Int64()))
+	}
+	return sb.String(), nil
+}
+
 // EncryptToken encrypts the token using AES-256-GCM with the provided secret.
 // Returns the base64-encoded encrypted token.
 func EncryptToken(token string, secret string) (string, error) {
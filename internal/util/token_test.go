@@ -113,3 +113,26 @@ func TestGenerateRandomBytes(t *testing.T) {
 		t.Fatal("GenerateRandomBytes(-1) should return error")
 	}
 }
+
+// TestGenerateNumericCode verifies codes have the requested length and only contain digits
+func TestGenerateNumericCode(t *testing.T) {
+	code, err := GenerateNumericCode(6)
+	if err != nil {
+		t.Fatalf("GenerateNumericCode(6) failed: %v", err)
+	}
+
+	if len(code) != 6 {
+		t.Fatalf("Expected 6 digits, got %q", code)
+	}
+
+	for _, c := range code {
+		if c < '0' || c > '9' {
+			t.Fatalf("Expected only digits, got %q", code)
+		}
+	}
+
+	_, err = GenerateNumericCode(0)
+	if err == nil {
+		t.Fatal("GenerateNumericCode(0) should return error")
+	}
+}
@@ -0,0 +1,141 @@
+package util
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultTOTPSecretBytes is the length (in bytes) of generated TOTP secrets.
+// 20 bytes matches the HMAC-SHA1 block recommendation in RFC 4226.
+const DefaultTOTPSecretBytes = 20
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateTOTPSecret returns a new random base32-encoded (unpadded) TOTP secret.
+func GenerateTOTPSecret() (string, error) {
+	b, err := GenerateRandomBytes(DefaultTOTPSecretBytes)
+	if err != nil {
+		return "", err
+	}
+	return totpEncoding.EncodeToString(b), nil
+}
+
+// GenerateTOTPCode returns the RFC 6238 code for the given base32 secret at time t.
+func GenerateTOTPCode(secret string, t time.Time, digits int, period time.Duration) (string, error) {
+	if period < time.Second {
+		return "", fmt.Errorf("invalid period %s", period)
+	}
+	counter := uint64(t.Unix() / int64(period.Seconds()))
+	return generateHOTPCode(secret, counter, digits)
+}
+
+// ValidateTOTPCode checks the code against the secret at time t, allowing up to skew
+// periods of clock drift in either direction. It returns the time step the code matched,
+// which callers store to reject replays of the same code (RFC 6238 section 5.2).
+// The comparison is constant time.
+func ValidateTOTPCode(secret string, code string, t time.Time, digits int, period time.Duration, skew int) (int64, bool) {
+	code = strings.TrimSpace(code)
+	if len(code) != digits || period < time.Second {
+		return 0, false
+	}
+
+	counter := t.Unix() / int64(period.Seconds())
+	step := int64(-1)
+	for i := -skew; i <= skew; i++ {
+		if counter+int64(i) < 0 {
+			continue
+		}
+		expected, err := generateHOTPCode(secret, uint64(counter+int64(i)), digits)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			step = counter + int64(i)
+		}
+	}
+
+	return step, step >= 0
+}
+
+// BuildTOTPURI builds an otpauth:// key URI that authenticator apps can import,
+// usually rendered as a QR code by the client.
+func BuildTOTPURI(issuer string, accountName string, secret string, digits int, period time.Duration) string {
+	label := url.PathEscape(issuer + ":" + accountName)
+	q := url.Values{}
+	q.Set("secret", secret)
+	q.Set("issuer", issuer)
+	q.Set("algorithm", "SHA1")
+	q.Set("digits", fmt.Sprintf("%d", digits))
+	q.Set("period", fmt.Sprintf("%d", int(period.Seconds())))
+	return "otpauth://totp/" + label + "?" + q.Encode()
+}
+
+// generateHOTPCode implements the RFC 4226 HOTP algorithm with HMAC-SHA1.
+func generateHOTPCode(secret string, counter uint64, digits int) (string, error) {
+	if digits < 6 || digits > 8 {
+		return "", fmt.Errorf("invalid digits %d", digits)
+	}
+
+	key, err := totpEncoding.DecodeString(strings.ToUpper(strings.TrimRight(secret, "=")))
+	if err != nil {
+		return "", fmt.Errorf("decode secret: %w", err)
+	}
+
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], counter)
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	mod := uint32(math.Pow10(digits))
+
+	return fmt.Sprintf("%0*d", digits, value%mod), nil
+}
+
+// backupCodeAlphabet omits easily confused characters (0/O, 1/I). It has exactly 32
+// characters so that mapping random bytes onto it introduces no modulo bias.
+const backupCodeAlphabet = "23456789ABCDEFGHJKLMNPQRSTUVWXYZ"
+
+// GenerateBackupCodes returns n random recovery codes formatted as XXXXX-XXXXX.
+func GenerateBackupCodes(n int) ([]string, error) {
+	codes := make([]string, 0, n)
+	for range n {
+		b, err := GenerateRandomBytes(10)
+		if err != nil {
+			return nil, err
+		}
+		var sb strings.Builder
+		for i, v := range b {
+			if i == 5 {
+				sb.WriteByte('-')
+			}
+			sb.WriteByte(backupCodeAlphabet[int(v)%len(backupCodeAlphabet)])
+		}
+		codes = append(codes, sb.String())
+	}
+	return codes, nil
+}
+
+// NormalizeBackupCode uppercases a user-supplied recovery code and strips separators and
+// whitespace so that codes can be compared regardless of how they were typed.
+func NormalizeBackupCode(code string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToUpper(code) {
+		if r == '-' || r == ' ' || r == '\t' {
+			continue
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
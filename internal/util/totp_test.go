@@ -0,0 +1,120 @@
+package util
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfc6238Secret is the SHA1 test key from RFC 6238 Appendix B.
+var rfc6238Secret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+// TestGenerateTOTPCode verifies codes against the RFC 6238 SHA1 test vectors
+func TestGenerateTOTPCode(t *testing.T) {
+	tests := []struct {
+		unix int64
+		code string
+	}{
+		{59, "94287082"},
+		{1111111109, "07081804"},
+		{1111111111, "14050471"},
+		{1234567890, "89005924"},
+		{2000000000, "69279037"},
+	}
+
+	for _, tt := range tests {
+		code, err := GenerateTOTPCode(rfc6238Secret, time.Unix(tt.unix, 0), 8, 30*time.Second)
+		if err != nil {
+			t.Fatalf("GenerateTOTPCode failed: %v", err)
+		}
+		if code != tt.code {
+			t.Fatalf("unexpected code at %d: expected %s, got %s", tt.unix, tt.code, code)
+		}
+	}
+}
+
+// TestValidateTOTPCode verifies validation with and without clock skew
+func TestValidateTOTPCode(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		t.Fatalf("GenerateTOTPSecret failed: %v", err)
+	}
+
+	now := time.Now()
+	code, err := GenerateTOTPCode(secret, now, 6, 30*time.Second)
+	if err != nil {
+		t.Fatalf("GenerateTOTPCode failed: %v", err)
+	}
+
+	step, ok := ValidateTOTPCode(secret, code, now, 6, 30*time.Second, 0)
+	if !ok {
+		t.Fatal("expected current code to be valid")
+	}
+	if step != now.Unix()/30 {
+		t.Fatalf("expected current time step %d, got %d", now.Unix()/30, step)
+	}
+
+	// A code from the previous period is only accepted with skew
+	previous, _ := GenerateTOTPCode(secret, now.Add(-30*time.Second), 6, 30*time.Second)
+	if _, ok := ValidateTOTPCode(secret, previous, now, 6, 30*time.Second, 0); previous != code && ok {
+		t.Fatal("expected previous code to be rejected without skew")
+	}
+	step, ok = ValidateTOTPCode(secret, previous, now, 6, 30*time.Second, 1)
+	if !ok {
+		t.Fatal("expected previous code to be accepted with skew")
+	}
+	if previous != code && step != now.Unix()/30-1 {
+		t.Fatalf("expected previous time step %d, got %d", now.Unix()/30-1, step)
+	}
+
+	if _, ok := ValidateTOTPCode(secret, "12345", now, 6, 30*time.Second, 1); ok {
+		t.Fatal("expected code with wrong length to be rejected")
+	}
+	if _, ok := ValidateTOTPCode(secret, code, now, 6, 500*time.Millisecond, 1); ok {
+		t.Fatal("expected period below one second to be rejected")
+	}
+}
+
+// TestBuildTOTPURI verifies the otpauth URI contains the expected parameters
+func TestBuildTOTPURI(t *testing.T) {
+	uri := BuildTOTPURI("GoBetterAuth", "user@example.com", "JBSWY3DPEHPK3PXP", 6, 30*time.Second)
+
+	if !strings.HasPrefix(uri, "otpauth://totp/GoBetterAuth:user@example.com?") {
+		t.Fatalf("unexpected URI prefix: %s", uri)
+	}
+	for _, param := range []string{"secret=JBSWY3DPEHPK3PXP", "issuer=GoBetterAuth", "digits=6", "period=30"} {
+		if !strings.Contains(uri, param) {
+			t.Fatalf("expected URI to contain %s: %s", param, uri)
+		}
+	}
+}
+
+// TestGenerateBackupCodes verifies the number, format and uniqueness of recovery codes
+func TestGenerateBackupCodes(t *testing.T) {
+	codes, err := GenerateBackupCodes(10)
+	if err != nil {
+		t.Fatalf("GenerateBackupCodes failed: %v", err)
+	}
+	if len(codes) != 10 {
+		t.Fatalf("expected 10 codes, got %d", len(codes))
+	}
+
+	seen := make(map[string]bool)
+	for _, code := range codes {
+		if len(code) != 11 || code[5] != '-' {
+			t.Fatalf("unexpected code format: %s", code)
+		}
+		if seen[code] {
+			t.Fatalf("duplicate code generated: %s", code)
+		}
+		seen[code] = true
+	}
+}
+
+// TestNormalizeBackupCode verifies that formatting differences are ignored
+func TestNormalizeBackupCode(t *testing.T) {
+	if got := NormalizeBackupCode(" abcde-fghjk "); got != "ABCDEFGHJK" {
+		t.Fatalf("unexpected normalized code: %s", got)
+	}
+}
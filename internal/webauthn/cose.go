@@ -0,0 +1,144 @@
+package webauthn
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// COSE algorithm identifiers (https://www.iana.org/assignments/cose/cose.xhtml#algorithms)
+const (
+	AlgES256 int64 = -7
+	AlgEdDSA int64 = -8
+	AlgRS256 int64 = -257
+)
+
+// SupportedAlgorithms lists the algorithms accepted for new credentials, in order of preference.
+var SupportedAlgorithms = []int64{AlgES256, AlgEdDSA, AlgRS256}
+
+// COSE key parameters (RFC 9053)
+const (
+	coseKeyType    int64 = 1
+	coseKeyAlg     int64 = 3
+	coseKeyCrv     int64 = -1
+	coseKeyX       int64 = -2
+	coseKeyY       int64 = -3
+	coseKeyRSAN    int64 = -1
+	coseKeyRSAE    int64 = -2
+	coseKtyOKP     int64 = 1
+	coseKtyEC2     int64 = 2
+	coseKtyRSA     int64 = 3
+	coseCrvP256    int64 = 1
+	coseCrvEd25519 int64 = 6
+)
+
+// minRSAKeyBits rejects weak RSA credential keys.
+const minRSAKeyBits = 2048
+
+// PublicKey is a credential public key decoded from its COSE representation.
+type PublicKey struct {
+	Algorithm int64
+	key       crypto.PublicKey
+}
+
+// ParsePublicKey decodes a COSE_Key as found in the attested credential data.
+func ParsePublicKey(coseKey []byte) (*PublicKey, error) {
+	decoded, _, err := decodeCBOR(coseKey)
+	if err != nil {
+		return nil, err
+	}
+	m, ok := decoded.(map[any]any)
+	if !ok {
+		return nil, errors.New("cose: key is not a map")
+	}
+
+	kty, ok := m[coseKeyType].(int64)
+	if !ok {
+		return nil, errors.New("cose: missing key type")
+	}
+	alg, ok := m[coseKeyAlg].(int64)
+	if !ok {
+		return nil, errors.New("cose: missing algorithm")
+	}
+
+	switch alg {
+	case AlgES256:
+		if kty != coseKtyEC2 {
+			return nil, errors.New("cose: ES256 requires an EC2 key")
+		}
+		if crv, _ := m[coseKeyCrv].(int64); crv != coseCrvP256 {
+			return nil, errors.New("cose: ES256 requires the P-256 curve")
+		}
+		x, okX := m[coseKeyX].([]byte)
+		y, okY := m[coseKeyY].([]byte)
+		if !okX || !okY || len(x) != 32 || len(y) != 32 {
+			return nil, errors.New("cose: invalid EC2 coordinates")
+		}
+		point := append([]byte{0x04}, x...)
+		point = append(point, y...)
+		key, err := ecdsa.ParseUncompressedPublicKey(elliptic.P256(), point)
+		if err != nil {
+			return nil, fmt.Errorf("cose: %w", err)
+		}
+		return &PublicKey{Algorithm: alg, key: key}, nil
+	case AlgEdDSA:
+		if kty != coseKtyOKP {
+			return nil, errors.New("cose: EdDSA requires an OKP key")
+		}
+		if crv, _ := m[coseKeyCrv].(int64); crv != coseCrvEd25519 {
+			return nil, errors.New("cose: EdDSA requires the Ed25519 curve")
+		}
+		x, ok := m[coseKeyX].([]byte)
+		if !ok || len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("cose: invalid Ed25519 key")
+		}
+		return &PublicKey{Algorithm: alg, key: ed25519.PublicKey(x)}, nil
+	case AlgRS256:
+		if kty != coseKtyRSA {
+			return nil, errors.New("cose: RS256 requires an RSA key")
+		}
+		n, okN := m[coseKeyRSAN].([]byte)
+		e, okE := m[coseKeyRSAE].([]byte)
+		if !okN || !okE || len(e) == 0 || len(e) > 4 {
+			return nil, errors.New("cose: invalid RSA key")
+		}
+		exponent := 0
+		for _, b := range e {
+			exponent = exponent<<8 | int(b)
+		}
+		key := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: exponent}
+		if key.N.BitLen() < minRSAKeyBits {
+			return nil, errors.New("cose: RSA key is too small")
+		}
+		return &PublicKey{Algorithm: alg, key: key}, nil
+	}
+
+	return nil, fmt.Errorf("cose: unsupported algorithm %d", alg)
+}
+
+// Verify checks the signature over data using the key's algorithm.
+func (k *PublicKey) Verify(data []byte, signature []byte) error {
+	switch key := k.key.(type) {
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(data)
+		if !ecdsa.VerifyASN1(key, digest[:], signature) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	case ed25519.PublicKey:
+		if !ed25519.Verify(key, data, signature) {
+			return errors.New("invalid signature")
+		}
+		return nil
+	case *rsa.PublicKey:
+		digest := sha256.Sum256(data)
+		return rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature)
+	}
+	return errors.New("unsupported key type")
+}
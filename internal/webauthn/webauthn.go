@@ -0,0 +1,275 @@
+// Package webauthn implements the relying party side of WebAuthn registration and
+// authentication ceremonies (https://www.w3.org/TR/webauthn-2/) for passkeys.
+//
+// Credentials are registered with attestation conveyance "none", so attestation statements
+// are not verified; the credential public key is trusted on first use.
+package webauthn
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+const (
+	ClientDataTypeCreate = "webauthn.create"
+	ClientDataTypeGet    = "webauthn.get"
+)
+
+// Authenticator data flags
+const (
+	FlagUserPresent            byte = 0x01
+	FlagUserVerified           byte = 0x04
+	FlagBackupEligible         byte = 0x08
+	FlagBackedUp               byte = 0x10
+	FlagAttestedCredentialData byte = 0x40
+	FlagExtensionData          byte = 0x80
+)
+
+// minAuthenticatorDataLength covers the RP ID hash, flags and signature counter.
+const minAuthenticatorDataLength = 37
+
+var ErrVerificationFailed = errors.New("webauthn verification failed")
+
+// Base64URL is the encoding used for all binary values exchanged with the browser.
+var Base64URL = base64.RawURLEncoding
+
+// CollectedClientData is the JSON structure signed by the authenticator.
+type CollectedClientData struct {
+	Type        string `json:"type"`
+	Challenge   string `json:"challenge"`
+	Origin      string `json:"origin"`
+	CrossOrigin bool   `json:"crossOrigin,omitempty"`
+}
+
+// ParseClientData decodes the clientDataJSON sent by the browser.
+func ParseClientData(clientDataJSON []byte) (*CollectedClientData, error) {
+	var clientData CollectedClientData
+	if err := json.Unmarshal(clientDataJSON, &clientData); err != nil {
+		return nil, fmt.Errorf("%w: invalid client data: %w", ErrVerificationFailed, err)
+	}
+	return &clientData, nil
+}
+
+// AuthenticatorData is the parsed binary authenticator data.
+type AuthenticatorData struct {
+	RPIDHash  []byte
+	Flags     byte
+	SignCount uint32
+	// Only present when FlagAttestedCredentialData is set
+	AAGUID              []byte
+	CredentialID        []byte
+	CredentialPublicKey []byte
+}
+
+func (a *AuthenticatorData) HasFlag(flag byte) bool {
+	return a.Flags&flag != 0
+}
+
+// ParseAuthenticatorData decodes authenticator data as defined in WebAuthn section 6.1.
+func ParseAuthenticatorData(data []byte) (*AuthenticatorData, error) {
+	if len(data) < minAuthenticatorDataLength {
+		return nil, fmt.Errorf("%w: authenticator data is too short", ErrVerificationFailed)
+	}
+
+	authData := &AuthenticatorData{
+		RPIDHash:  data[:32],
+		Flags:     data[32],
+		SignCount: binary.BigEndian.Uint32(data[33:37]),
+	}
+	rest := data[minAuthenticatorDataLength:]
+
+	if authData.HasFlag(FlagAttestedCredentialData) {
+		if len(rest) < 18 {
+			return nil, fmt.Errorf("%w: attested credential data is too short", ErrVerificationFailed)
+		}
+		authData.AAGUID = rest[:16]
+		credentialIDLength := int(binary.BigEndian.Uint16(rest[16:18]))
+		rest = rest[18:]
+		if credentialIDLength > 1023 || len(rest) < credentialIDLength {
+			return nil, fmt.Errorf("%w: invalid credential id length", ErrVerificationFailed)
+		}
+		authData.CredentialID = rest[:credentialIDLength]
+		rest = rest[credentialIDLength:]
+
+		_, n, err := decodeCBOR(rest)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid credential public key: %w", ErrVerificationFailed, err)
+		}
+		authData.CredentialPublicKey = rest[:n]
+		rest = rest[n:]
+	}
+
+	if authData.HasFlag(FlagExtensionData) {
+		_, n, err := decodeCBOR(rest)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid extension data: %w", ErrVerificationFailed, err)
+		}
+		rest = rest[n:]
+	}
+
+	if len(rest) != 0 {
+		return nil, fmt.Errorf("%w: trailing bytes in authenticator data", ErrVerificationFailed)
+	}
+
+	return authData, nil
+}
+
+// RelyingParty describes the expected relying party for a ceremony.
+type RelyingParty struct {
+	ID      string
+	Origins []string
+	// RequireUserVerification rejects responses without the UV flag
+	RequireUserVerification bool
+}
+
+// Credential is a newly registered credential.
+type Credential struct {
+	ID        []byte
+	PublicKey []byte
+	Algorithm int64
+	SignCount uint32
+	AAGUID    string
+	BackedUp  bool
+}
+
+// VerifyRegistration validates the response of navigator.credentials.create() and returns the new credential.
+func VerifyRegistration(rp RelyingParty, challenge string, clientDataJSON []byte, attestationObject []byte) (*Credential, error) {
+	if err := verifyClientData(rp, ClientDataTypeCreate, challenge, clientDataJSON); err != nil {
+		return nil, err
+	}
+
+	decoded, _, err := decodeCBOR(attestationObject)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid attestation object: %w", ErrVerificationFailed, err)
+	}
+	attestation, ok := decoded.(map[any]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: invalid attestation object", ErrVerificationFailed)
+	}
+	if _, ok := attestation["fmt"].(string); !ok {
+		return nil, fmt.Errorf("%w: missing attestation format", ErrVerificationFailed)
+	}
+	rawAuthData, ok := attestation["authData"].([]byte)
+	if !ok {
+		return nil, fmt.Errorf("%w: missing authenticator data", ErrVerificationFailed)
+	}
+
+	authData, err := ParseAuthenticatorData(rawAuthData)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyAuthenticatorData(rp, authData); err != nil {
+		return nil, err
+	}
+	if !authData.HasFlag(FlagAttestedCredentialData) {
+		return nil, fmt.Errorf("%w: missing attested credential data", ErrVerificationFailed)
+	}
+
+	publicKey, err := ParsePublicKey(authData.CredentialPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrVerificationFailed, err)
+	}
+	if !slices.Contains(SupportedAlgorithms, publicKey.Algorithm) {
+		return nil, fmt.Errorf("%w: unsupported algorithm", ErrVerificationFailed)
+	}
+
+	return &Credential{
+		ID:        bytes.Clone(authData.CredentialID),
+		PublicKey: bytes.Clone(authData.CredentialPublicKey),
+		Algorithm: publicKey.Algorithm,
+		SignCount: authData.SignCount,
+		AAGUID:    hex.EncodeToString(authData.AAGUID),
+		BackedUp:  authData.HasFlag(FlagBackedUp),
+	}, nil
+}
+
+// AssertionResult holds the authenticator state reported by a successful assertion.
+type AssertionResult struct {
+	SignCount uint32
+	BackedUp  bool
+}
+
+// VerifyAssertion validates the response of navigator.credentials.get() against a stored credential.
+// storedSignCount is used to detect cloned authenticators.
+func VerifyAssertion(
+	rp RelyingParty,
+	challenge string,
+	credentialPublicKey []byte,
+	storedSignCount uint32,
+	clientDataJSON []byte,
+	authenticatorData []byte,
+	signature []byte,
+) (*AssertionResult, error) {
+	if err := verifyClientData(rp, ClientDataTypeGet, challenge, clientDataJSON); err != nil {
+		return nil, err
+	}
+
+	authData, err := ParseAuthenticatorData(authenticatorData)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifyAuthenticatorData(rp, authData); err != nil {
+		return nil, err
+	}
+
+	publicKey, err := ParsePublicKey(credentialPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrVerificationFailed, err)
+	}
+
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signedData := append(bytes.Clone(authenticatorData), clientDataHash[:]...)
+	if err := publicKey.Verify(signedData, signature); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrVerificationFailed, err)
+	}
+
+	// A counter that does not increase signals a possibly cloned authenticator.
+	// Authenticators that do not implement counters always report zero.
+	if (authData.SignCount != 0 || storedSignCount != 0) && authData.SignCount <= storedSignCount {
+		return nil, fmt.Errorf("%w: signature counter did not increase", ErrVerificationFailed)
+	}
+
+	return &AssertionResult{
+		SignCount: authData.SignCount,
+		BackedUp:  authData.HasFlag(FlagBackedUp),
+	}, nil
+}
+
+func verifyClientData(rp RelyingParty, expectedType string, challenge string, clientDataJSON []byte) error {
+	clientData, err := ParseClientData(clientDataJSON)
+	if err != nil {
+		return err
+	}
+	if clientData.Type != expectedType {
+		return fmt.Errorf("%w: unexpected client data type %q", ErrVerificationFailed, clientData.Type)
+	}
+	if challenge == "" || subtle.ConstantTimeCompare([]byte(clientData.Challenge), []byte(challenge)) != 1 {
+		return fmt.Errorf("%w: challenge mismatch", ErrVerificationFailed)
+	}
+	if !slices.Contains(rp.Origins, clientData.Origin) {
+		return fmt.Errorf("%w: untrusted origin %q", ErrVerificationFailed, clientData.Origin)
+	}
+	return nil
+}
+
+func verifyAuthenticatorData(rp RelyingParty, authData *AuthenticatorData) error {
+	rpIDHash := sha256.Sum256([]byte(rp.ID))
+	if subtle.ConstantTimeCompare(authData.RPIDHash, rpIDHash[:]) != 1 {
+		return fmt.Errorf("%w: relying party id mismatch", ErrVerificationFailed)
+	}
+	if !authData.HasFlag(FlagUserPresent) {
+		return fmt.Errorf("%w: user not present", ErrVerificationFailed)
+	}
+	if rp.RequireUserVerification && !authData.HasFlag(FlagUserVerified) {
+		return fmt.Errorf("%w: user not verified", ErrVerificationFailed)
+	}
+	return nil
+}
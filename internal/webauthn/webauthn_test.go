@@ -0,0 +1,299 @@
+package webauthn
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+const (
+	testRPID      = "example.com"
+	testOrigin    = "https://example.com"
+	testChallenge = "dGVzdC1jaGFsbGVuZ2U"
+)
+
+var testRP = RelyingParty{ID: testRPID, Origins: []string{testOrigin}}
+
+// ---------------------------------
+// Software authenticator fixtures
+// ---------------------------------
+
+type cborPair struct {
+	key   any
+	value any
+}
+
+// cborMap keeps insertion order so that encoded fixtures are deterministic
+type cborMap []cborPair
+
+func encodeCBORHead(major byte, n uint64) []byte {
+	switch {
+	case n < 24:
+		return []byte{major<<5 | byte(n)}
+	case n <= 0xff:
+		return []byte{major<<5 | 24, byte(n)}
+	case n <= 0xffff:
+		return binary.BigEndian.AppendUint16([]byte{major<<5 | 25}, uint16(n))
+	default:
+		return binary.BigEndian.AppendUint32([]byte{major<<5 | 26}, uint32(n))
+	}
+}
+
+func encodeCBOR(v any) []byte {
+	switch value := v.(type) {
+	case int:
+		if value < 0 {
+			return encodeCBORHead(1, uint64(-1-value))
+		}
+		return encodeCBORHead(0, uint64(value))
+	case int64:
+		return encodeCBOR(int(value))
+	case []byte:
+		return append(encodeCBORHead(2, uint64(len(value))), value...)
+	case string:
+		return append(encodeCBORHead(3, uint64(len(value))), value...)
+	case cborMap:
+		out := encodeCBORHead(5, uint64(len(value)))
+		for _, pair := range value {
+			out = append(out, encodeCBOR(pair.key)...)
+			out = append(out, encodeCBOR(pair.value)...)
+		}
+		return out
+	}
+	panic("unsupported fixture type")
+}
+
+func ecdsaCOSEKey(key *ecdsa.PublicKey) []byte {
+	point, _ := key.Bytes()
+	return encodeCBOR(cborMap{
+		{1, 2},
+		{3, -7},
+		{-1, 1},
+		{-2, point[1:33]},
+		{-3, point[33:]},
+	})
+}
+
+func ed25519COSEKey(key ed25519.PublicKey) []byte {
+	return encodeCBOR(cborMap{
+		{1, 1},
+		{3, -8},
+		{-1, 6},
+		{-2, []byte(key)},
+	})
+}
+
+func buildAuthenticatorData(rpID string, flags byte, signCount uint32, credentialID []byte, coseKey []byte) []byte {
+	rpIDHash := sha256.Sum256([]byte(rpID))
+	data := append([]byte{}, rpIDHash[:]...)
+	data = append(data, flags)
+	data = binary.BigEndian.AppendUint32(data, signCount)
+	if credentialID != nil {
+		data = append(data, make([]byte, 16)...)
+		data = binary.BigEndian.AppendUint16(data, uint16(len(credentialID)))
+		data = append(data, credentialID...)
+		data = append(data, coseKey...)
+	}
+	return data
+}
+
+func buildClientData(t *testing.T, clientDataType string, challenge string, origin string) []byte {
+	t.Helper()
+	data, err := json.Marshal(CollectedClientData{Type: clientDataType, Challenge: challenge, Origin: origin})
+	if err != nil {
+		t.Fatalf("failed to marshal client data: %v", err)
+	}
+	return data
+}
+
+func buildAttestationObject(authData []byte) []byte {
+	return encodeCBOR(cborMap{
+		{"fmt", "none"},
+		{"attStmt", cborMap{}},
+		{"authData", authData},
+	})
+}
+
+func signAssertion(t *testing.T, sign func([]byte) ([]byte, error), authData []byte, clientDataJSON []byte) []byte {
+	t.Helper()
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	signature, err := sign(append(append([]byte{}, authData...), clientDataHash[:]...))
+	if err != nil {
+		t.Fatalf("failed to sign assertion: %v", err)
+	}
+	return signature
+}
+
+// ---------------------------------
+// Tests
+// ---------------------------------
+
+// TestRegistrationAndAssertion_ES256 runs a full ceremony with a software P-256 authenticator
+func TestRegistrationAndAssertion_ES256(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	credentialID := []byte("credential-es256")
+
+	authData := buildAuthenticatorData(testRPID, FlagUserPresent|FlagUserVerified|FlagAttestedCredentialData, 0, credentialID, ecdsaCOSEKey(&privateKey.PublicKey))
+	clientData := buildClientData(t, ClientDataTypeCreate, testChallenge, testOrigin)
+
+	credential, err := VerifyRegistration(testRP, testChallenge, clientData, buildAttestationObject(authData))
+	if err != nil {
+		t.Fatalf("VerifyRegistration failed: %v", err)
+	}
+	if string(credential.ID) != string(credentialID) {
+		t.Fatalf("unexpected credential id: %s", credential.ID)
+	}
+	if credential.Algorithm != AlgES256 {
+		t.Fatalf("unexpected algorithm: %d", credential.Algorithm)
+	}
+
+	sign := func(data []byte) ([]byte, error) {
+		digest := sha256.Sum256(data)
+		return ecdsa.SignASN1(rand.Reader, privateKey, digest[:])
+	}
+
+	assertionAuthData := buildAuthenticatorData(testRPID, FlagUserPresent|FlagUserVerified, 1, nil, nil)
+	assertionClientData := buildClientData(t, ClientDataTypeGet, testChallenge, testOrigin)
+	signature := signAssertion(t, sign, assertionAuthData, assertionClientData)
+
+	result, err := VerifyAssertion(testRP, testChallenge, credential.PublicKey, credential.SignCount, assertionClientData, assertionAuthData, signature)
+	if err != nil {
+		t.Fatalf("VerifyAssertion failed: %v", err)
+	}
+	if result.SignCount != 1 {
+		t.Fatalf("unexpected sign count: %d", result.SignCount)
+	}
+
+	// Replaying the same counter must be rejected
+	if _, err := VerifyAssertion(testRP, testChallenge, credential.PublicKey, result.SignCount, assertionClientData, assertionAuthData, signature); err == nil {
+		t.Fatal("expected replayed sign count to be rejected")
+	}
+}
+
+// TestAssertion_Ed25519 verifies assertions signed with an Ed25519 credential
+func TestAssertion_Ed25519(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	authData := buildAuthenticatorData(testRPID, FlagUserPresent, 0, nil, nil)
+	clientData := buildClientData(t, ClientDataTypeGet, testChallenge, testOrigin)
+	sign := func(data []byte) ([]byte, error) {
+		return ed25519.Sign(privateKey, data), nil
+	}
+	signature := signAssertion(t, sign, authData, clientData)
+
+	if _, err := VerifyAssertion(testRP, testChallenge, ed25519COSEKey(publicKey), 0, clientData, authData, signature); err != nil {
+		t.Fatalf("VerifyAssertion failed: %v", err)
+	}
+
+	// Tampering with the authenticator data invalidates the signature
+	tampered := append([]byte{}, authData...)
+	tampered[32] |= FlagUserVerified
+	if _, err := VerifyAssertion(testRP, testChallenge, ed25519COSEKey(publicKey), 0, clientData, tampered, signature); err == nil {
+		t.Fatal("expected tampered authenticator data to be rejected")
+	}
+}
+
+// TestVerifyRegistration_Rejections verifies that mismatched ceremony parameters are rejected
+func TestVerifyRegistration_Rejections(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	coseKey := ecdsaCOSEKey(&privateKey.PublicKey)
+	validAuthData := buildAuthenticatorData(testRPID, FlagUserPresent|FlagAttestedCredentialData, 0, []byte("id"), coseKey)
+
+	tests := []struct {
+		name       string
+		rp         RelyingParty
+		clientData []byte
+		authData   []byte
+	}{
+		{
+			name:       "wrong challenge",
+			rp:         testRP,
+			clientData: buildClientData(t, ClientDataTypeCreate, "other", testOrigin),
+			authData:   validAuthData,
+		},
+		{
+			name:       "wrong origin",
+			rp:         testRP,
+			clientData: buildClientData(t, ClientDataTypeCreate, testChallenge, "https://evil.com"),
+			authData:   validAuthData,
+		},
+		{
+			name:       "wrong type",
+			rp:         testRP,
+			clientData: buildClientData(t, ClientDataTypeGet, testChallenge, testOrigin),
+			authData:   validAuthData,
+		},
+		{
+			name:       "wrong rp id",
+			rp:         testRP,
+			clientData: buildClientData(t, ClientDataTypeCreate, testChallenge, testOrigin),
+			authData:   buildAuthenticatorData("evil.com", FlagUserPresent|FlagAttestedCredentialData, 0, []byte("id"), coseKey),
+		},
+		{
+			name:       "user not present",
+			rp:         testRP,
+			clientData: buildClientData(t, ClientDataTypeCreate, testChallenge, testOrigin),
+			authData:   buildAuthenticatorData(testRPID, FlagAttestedCredentialData, 0, []byte("id"), coseKey),
+		},
+		{
+			name:       "user verification required",
+			rp:         RelyingParty{ID: testRPID, Origins: []string{testOrigin}, RequireUserVerification: true},
+			clientData: buildClientData(t, ClientDataTypeCreate, testChallenge, testOrigin),
+			authData:   validAuthData,
+		},
+		{
+			name:       "truncated authenticator data",
+			rp:         testRP,
+			clientData: buildClientData(t, ClientDataTypeCreate, testChallenge, testOrigin),
+			authData:   validAuthData[:len(validAuthData)-4],
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := VerifyRegistration(tt.rp, testChallenge, tt.clientData, buildAttestationObject(tt.authData))
+			if !errors.Is(err, ErrVerificationFailed) {
+				t.Fatalf("expected verification failure, got %v", err)
+			}
+		})
+	}
+}
+
+// TestDecodeCBOR verifies decoding of nested structures and truncated input
+func TestDecodeCBOR(t *testing.T) {
+	encoded := encodeCBOR(cborMap{{"a", -300}, {1, []byte{1, 2, 3}}, {"nested", cborMap{{"b", "c"}}}})
+
+	decoded, n, err := decodeCBOR(encoded)
+	if err != nil {
+		t.Fatalf("decodeCBOR failed: %v", err)
+	}
+	if n != len(encoded) {
+		t.Fatalf("expected %d bytes consumed, got %d", len(encoded), n)
+	}
+	m := decoded.(map[any]any)
+	if m["a"] != int64(-300) {
+		t.Fatalf("unexpected negative integer: %v", m["a"])
+	}
+	if nested := m["nested"].(map[any]any); nested["b"] != "c" {
+		t.Fatalf("unexpected nested value: %v", nested)
+	}
+
+	if _, _, err := decodeCBOR(encoded[:len(encoded)-1]); err == nil {
+		t.Fatal("expected truncated input to fail")
+	}
+}
@@ -0,0 +1,14 @@
+-- ---------------------------
+-- TWO FACTORS (TOTP secrets for two factor authentication)
+-- ---------------------------
+
+CREATE TABLE IF NOT EXISTS two_factors (
+  id CHAR(36) PRIMARY KEY,
+  user_id CHAR(36) UNIQUE NOT NULL,
+  secret TEXT NOT NULL,
+  enabled BOOLEAN NOT NULL DEFAULT FALSE,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  CONSTRAINT fk_two_factors_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+  INDEX idx_two_factors_user_id (user_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,13 @@
+-- ---------------------------
+-- TWO FACTOR BACKUP CODES (hashed single-use recovery codes)
+-- ---------------------------
+
+CREATE TABLE IF NOT EXISTS two_factor_backup_codes (
+  id CHAR(36) PRIMARY KEY,
+  user_id CHAR(36) NOT NULL,
+  code VARCHAR(255) UNIQUE NOT NULL,
+  used_at TIMESTAMP NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT fk_two_factor_backup_codes_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+  INDEX idx_two_factor_backup_codes_user_id (user_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
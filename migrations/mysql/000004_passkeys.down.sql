@@ -0,0 +1,2 @@
+-- Rollback passkeys schema
+DROP TABLE IF EXISTS passkeys;
@@ -0,0 +1,21 @@
+-- ---------------------------
+-- PASSKEYS (WebAuthn credentials for passwordless sign-in)
+-- ---------------------------
+
+CREATE TABLE IF NOT EXISTS passkeys (
+  id CHAR(36) PRIMARY KEY,
+  user_id CHAR(36) NOT NULL,
+  name VARCHAR(255) NOT NULL,
+  credential_id VARCHAR(512) UNIQUE NOT NULL,
+  public_key BLOB NOT NULL,
+  algorithm BIGINT NOT NULL,
+  sign_count BIGINT UNSIGNED NOT NULL DEFAULT 0,
+  aaguid VARCHAR(32),
+  transports VARCHAR(255),
+  backed_up BOOLEAN NOT NULL DEFAULT FALSE,
+  last_used_at TIMESTAMP NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  CONSTRAINT fk_passkeys_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE,
+  INDEX idx_passkeys_user_id (user_id)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,13 @@
+-- ---------------------------
+-- SIGNING KEYS (JWT signing key pairs, published through the JWKS)
+-- ---------------------------
+
+CREATE TABLE IF NOT EXISTS signing_keys (
+  id CHAR(36) PRIMARY KEY,
+  algorithm VARCHAR(32) NOT NULL,
+  public_key TEXT NOT NULL,
+  private_key TEXT NOT NULL,
+  expires_at TIMESTAMP NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  INDEX idx_signing_keys_expires_at (expires_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
@@ -0,0 +1,3 @@
+-- Rollback OIDC provider schema
+DROP TABLE IF EXISTS oauth_consents;
+DROP TABLE IF EXISTS oauth_clients;
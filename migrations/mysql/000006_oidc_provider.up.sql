@@ -0,0 +1,28 @@
+-- ---------------------------
+-- OIDC PROVIDER (registered clients and the consents users granted them)
+-- ---------------------------
+
+CREATE TABLE IF NOT EXISTS oauth_clients (
+  id CHAR(36) PRIMARY KEY,
+  client_id VARCHAR(255) UNIQUE NOT NULL,
+  client_secret VARCHAR(255) NULL,
+  name VARCHAR(255) NOT NULL,
+  redirect_uris TEXT NOT NULL,
+  scopes TEXT NULL,
+  public BOOLEAN NOT NULL DEFAULT FALSE,
+  skip_consent BOOLEAN NOT NULL DEFAULT FALSE,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
+
+CREATE TABLE IF NOT EXISTS oauth_consents (
+  id CHAR(36) PRIMARY KEY,
+  user_id CHAR(36) NOT NULL,
+  client_id VARCHAR(255) NOT NULL,
+  scopes TEXT NULL,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  INDEX idx_oauth_consents_user_id (user_id),
+  INDEX idx_oauth_consents_client_id (client_id),
+  CONSTRAINT fk_oauth_consents_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci;
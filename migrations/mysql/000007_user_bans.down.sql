@@ -0,0 +1,5 @@
+-- Rollback user bans schema
+ALTER TABLE users
+  DROP COLUMN ban_expires_at,
+  DROP COLUMN ban_reason,
+  DROP COLUMN banned;
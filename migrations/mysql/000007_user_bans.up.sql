@@ -0,0 +1,8 @@
+-- ---------------------------
+-- USER BANS (locks users out, optionally until an expiry)
+-- ---------------------------
+
+ALTER TABLE users
+  ADD COLUMN banned BOOLEAN NOT NULL DEFAULT FALSE,
+  ADD COLUMN ban_reason TEXT NULL,
+  ADD COLUMN ban_expires_at TIMESTAMP NULL;
@@ -0,0 +1,2 @@
+-- Rollback session impersonation schema
+ALTER TABLE sessions DROP COLUMN impersonated_by;
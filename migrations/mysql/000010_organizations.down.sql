@@ -0,0 +1,5 @@
+-- Rollback organizations schema
+ALTER TABLE sessions DROP COLUMN active_organization_id;
+DROP TABLE IF EXISTS invitations;
+DROP TABLE IF EXISTS members;
+DROP TABLE IF EXISTS organizations;
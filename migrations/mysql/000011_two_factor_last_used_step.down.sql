@@ -0,0 +1,2 @@
+-- Rollback two factor last used step schema
+ALTER TABLE two_factors DROP COLUMN last_used_step;
@@ -0,0 +1,2 @@
+-- Rollback two factor schema for PostgreSQL
+DROP TABLE IF EXISTS two_factors;
@@ -0,0 +1,21 @@
+-- ---------------------------
+-- TWO FACTORS (TOTP secrets for two factor authentication)
+-- ---------------------------
+
+CREATE TABLE IF NOT EXISTS two_factors (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id UUID UNIQUE NOT NULL,
+  secret TEXT NOT NULL,
+  enabled BOOLEAN NOT NULL DEFAULT FALSE,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  CONSTRAINT fk_two_factors_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_two_factors_user_id ON two_factors(user_id);
+
+DROP TRIGGER IF EXISTS update_two_factors_updated_at ON two_factors;
+CREATE TRIGGER update_two_factors_updated_at
+  BEFORE UPDATE ON two_factors
+  FOR EACH ROW
+  EXECUTE FUNCTION update_updated_at_column();
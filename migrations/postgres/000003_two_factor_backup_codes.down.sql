@@ -0,0 +1,2 @@
+-- Rollback two factor backup codes schema for PostgreSQL
+DROP TABLE IF EXISTS two_factor_backup_codes;
@@ -0,0 +1,14 @@
+-- ---------------------------
+-- TWO FACTOR BACKUP CODES (hashed single-use recovery codes)
+-- ---------------------------
+
+CREATE TABLE IF NOT EXISTS two_factor_backup_codes (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id UUID NOT NULL,
+  code VARCHAR(255) UNIQUE NOT NULL,
+  used_at TIMESTAMP,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  CONSTRAINT fk_two_factor_backup_codes_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_two_factor_backup_codes_user_id ON two_factor_backup_codes(user_id);
@@ -0,0 +1,2 @@
+-- Rollback passkeys schema for PostgreSQL
+DROP TABLE IF EXISTS passkeys;
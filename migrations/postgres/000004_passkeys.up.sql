@@ -0,0 +1,28 @@
+-- ---------------------------
+-- PASSKEYS (WebAuthn credentials for passwordless sign-in)
+-- ---------------------------
+
+CREATE TABLE IF NOT EXISTS passkeys (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id UUID NOT NULL,
+  name VARCHAR(255) NOT NULL,
+  credential_id VARCHAR(512) UNIQUE NOT NULL,
+  public_key BYTEA NOT NULL,
+  algorithm BIGINT NOT NULL,
+  sign_count BIGINT NOT NULL DEFAULT 0,
+  aaguid VARCHAR(32),
+  transports VARCHAR(255),
+  backed_up BOOLEAN NOT NULL DEFAULT FALSE,
+  last_used_at TIMESTAMP,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  CONSTRAINT fk_passkeys_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_passkeys_user_id ON passkeys(user_id);
+
+DROP TRIGGER IF EXISTS update_passkeys_updated_at ON passkeys;
+CREATE TRIGGER update_passkeys_updated_at
+  BEFORE UPDATE ON passkeys
+  FOR EACH ROW
+  EXECUTE FUNCTION update_updated_at_column();
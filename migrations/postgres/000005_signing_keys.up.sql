@@ -0,0 +1,14 @@
+-- ---------------------------
+-- SIGNING KEYS (JWT signing key pairs, published through the JWKS)
+-- ---------------------------
+
+CREATE TABLE IF NOT EXISTS signing_keys (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  algorithm VARCHAR(32) NOT NULL,
+  public_key TEXT NOT NULL,
+  private_key TEXT NOT NULL,
+  expires_at TIMESTAMP,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_signing_keys_expires_at ON signing_keys(expires_at);
@@ -0,0 +1,29 @@
+-- ---------------------------
+-- OIDC PROVIDER (registered clients and the consents users granted them)
+-- ---------------------------
+
+CREATE TABLE IF NOT EXISTS oauth_clients (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  client_id VARCHAR(255) UNIQUE NOT NULL,
+  client_secret VARCHAR(255),
+  name VARCHAR(255) NOT NULL,
+  redirect_uris TEXT NOT NULL,
+  scopes TEXT,
+  public BOOLEAN NOT NULL DEFAULT FALSE,
+  skip_consent BOOLEAN NOT NULL DEFAULT FALSE,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS oauth_consents (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id UUID NOT NULL,
+  client_id VARCHAR(255) NOT NULL,
+  scopes TEXT,
+  created_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+  CONSTRAINT fk_oauth_consents_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_oauth_consents_user_id ON oauth_consents(user_id);
+CREATE INDEX IF NOT EXISTS idx_oauth_consents_client_id ON oauth_consents(client_id);
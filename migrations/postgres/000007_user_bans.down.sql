@@ -0,0 +1,4 @@
+-- Rollback user bans schema for PostgreSQL
+ALTER TABLE users DROP COLUMN IF EXISTS ban_expires_at;
+ALTER TABLE users DROP COLUMN IF EXISTS ban_reason;
+ALTER TABLE users DROP COLUMN IF EXISTS banned;
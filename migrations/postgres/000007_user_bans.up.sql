@@ -0,0 +1,7 @@
+-- ---------------------------
+-- USER BANS (locks users out, optionally until an expiry)
+-- ---------------------------
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS banned BOOLEAN NOT NULL DEFAULT FALSE;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS ban_reason TEXT;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS ban_expires_at TIMESTAMP;
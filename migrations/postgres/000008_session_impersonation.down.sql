@@ -0,0 +1,2 @@
+-- Rollback session impersonation schema for PostgreSQL
+ALTER TABLE sessions DROP COLUMN IF EXISTS impersonated_by;
@@ -0,0 +1,5 @@
+-- Rollback RBAC schema for PostgreSQL
+DROP TABLE IF EXISTS user_roles;
+DROP TABLE IF EXISTS role_permissions;
+DROP TABLE IF EXISTS permissions;
+DROP TABLE IF EXISTS roles;
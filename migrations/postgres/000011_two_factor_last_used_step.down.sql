@@ -0,0 +1,2 @@
+-- Rollback two factor last used step schema for PostgreSQL
+ALTER TABLE two_factors DROP COLUMN IF EXISTS last_used_step;
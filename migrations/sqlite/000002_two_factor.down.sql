@@ -0,0 +1,2 @@
+-- Rollback two factor schema
+DROP TABLE IF EXISTS two_factors;
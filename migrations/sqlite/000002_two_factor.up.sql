@@ -0,0 +1,15 @@
+-- ---------------------------
+-- TWO FACTORS (TOTP secrets for two factor authentication)
+-- ---------------------------
+
+CREATE TABLE IF NOT EXISTS two_factors (
+  id VARCHAR(255) PRIMARY KEY,
+  user_id VARCHAR(255) UNIQUE NOT NULL,
+  secret TEXT NOT NULL,
+  enabled BOOLEAN NOT NULL DEFAULT FALSE,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_two_factors_user_id ON two_factors(user_id);
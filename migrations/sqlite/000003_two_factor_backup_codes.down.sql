@@ -0,0 +1,2 @@
+-- Rollback two factor backup codes schema
+DROP TABLE IF EXISTS two_factor_backup_codes;
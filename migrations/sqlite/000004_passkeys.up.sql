@@ -0,0 +1,22 @@
+-- ---------------------------
+-- PASSKEYS (WebAuthn credentials for passwordless sign-in)
+-- ---------------------------
+
+CREATE TABLE IF NOT EXISTS passkeys (
+  id VARCHAR(255) PRIMARY KEY,
+  user_id VARCHAR(255) NOT NULL,
+  name VARCHAR(255) NOT NULL,
+  credential_id VARCHAR(512) UNIQUE NOT NULL,
+  public_key BLOB NOT NULL,
+  algorithm INTEGER NOT NULL,
+  sign_count INTEGER NOT NULL DEFAULT 0,
+  aaguid VARCHAR(32),
+  transports VARCHAR(255),
+  backed_up BOOLEAN NOT NULL DEFAULT FALSE,
+  last_used_at TIMESTAMP,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_passkeys_user_id ON passkeys(user_id);
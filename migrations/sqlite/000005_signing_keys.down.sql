@@ -0,0 +1,2 @@
+-- Rollback signing keys schema
+DROP TABLE IF EXISTS signing_keys;
@@ -0,0 +1,29 @@
+-- ---------------------------
+-- OIDC PROVIDER (registered clients and the consents users granted them)
+-- ---------------------------
+
+CREATE TABLE IF NOT EXISTS oauth_clients (
+  id VARCHAR(255) PRIMARY KEY,
+  client_id VARCHAR(255) UNIQUE NOT NULL,
+  client_secret VARCHAR(255),
+  name VARCHAR(255) NOT NULL,
+  redirect_uris TEXT NOT NULL,
+  scopes TEXT,
+  public BOOLEAN NOT NULL DEFAULT FALSE,
+  skip_consent BOOLEAN NOT NULL DEFAULT FALSE,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS oauth_consents (
+  id VARCHAR(255) PRIMARY KEY,
+  user_id VARCHAR(255) NOT NULL,
+  client_id VARCHAR(255) NOT NULL,
+  scopes TEXT,
+  created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_oauth_consents_user_id ON oauth_consents(user_id);
+CREATE INDEX IF NOT EXISTS idx_oauth_consents_client_id ON oauth_consents(client_id);
@@ -0,0 +1,4 @@
+-- Rollback user bans schema
+ALTER TABLE users DROP COLUMN ban_expires_at;
+ALTER TABLE users DROP COLUMN ban_reason;
+ALTER TABLE users DROP COLUMN banned;
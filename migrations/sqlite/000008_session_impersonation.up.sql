@@ -0,0 +1,5 @@
+-- ---------------------------
+-- SESSION IMPERSONATION (sessions admins create to act as a user)
+-- ---------------------------
+
+ALTER TABLE sessions ADD COLUMN impersonated_by VARCHAR(255);
@@ -0,0 +1,5 @@
+-- ---------------------------
+-- TWO FACTORS (time step of the last accepted TOTP code, to reject replays)
+-- ---------------------------
+
+ALTER TABLE two_factors ADD COLUMN last_used_step INTEGER NOT NULL DEFAULT 0;
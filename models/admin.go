@@ -0,0 +1,57 @@
+package models
+
+import "time"
+
+// UserListQuery filters and paginates the users listed by administrators
+type UserListQuery struct {
+	// Search matches part of the name or email
+	Search        string
+	EmailVerified *bool
+	Banned        *bool
+	Limit         int
+	Offset        int
+}
+
+// UserList is a page of users, newest first
+type UserList struct {
+	Users  []User `json:"users"`
+	Total  int64  `json:"total"`
+	Limit  int    `json:"limit"`
+	Offset int    `json:"offset"`
+}
+
+// AdminCreateUserRequest describes a user created by an administrator. Users created without a password
+// sign in through another method, or after resetting their password.
+type AdminCreateUserRequest struct {
+	Name          string  `json:"name" validate:"required"`
+	Email         string  `json:"email" validate:"required,email"`
+	Password      *string `json:"password,omitempty"`
+	EmailVerified bool    `json:"email_verified"`
+	Image         *string `json:"image,omitempty"`
+}
+
+// AdminUpdateUserRequest holds the fields of a user to change, nil fields are left untouched
+type AdminUpdateUserRequest struct {
+	Name          *string `json:"name,omitempty"`
+	Email         *string `json:"email,omitempty" validate:"omitempty,email"`
+	EmailVerified *bool   `json:"email_verified,omitempty"`
+	Image         *string `json:"image,omitempty"`
+}
+
+// AdminBanUserRequest describes a ban, without an expiry the user stays banned until unbanned
+type AdminBanUserRequest struct {
+	Reason    *string    `json:"reason,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// ImpersonationResult holds the session an admin acts as a user with
+type ImpersonationResult struct {
+	Token   string   `json:"token"`
+	User    *User    `json:"user"`
+	Session *Session `json:"session"`
+}
+
+// StopImpersonationResult holds the admin's own session token when it is still valid, empty otherwise
+type StopImpersonationResult struct {
+	Token string `json:"token,omitempty"`
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"context"
 	"net/http"
 	"time"
 
@@ -109,6 +110,36 @@ type EmailVerificationConfig struct {
 	SendVerificationEmail func(user User, url string, token string) error `json:"-" toml:"-"`
 }
 
+// =======================
+// Magic Link Config
+// =======================
+
+type MagicLinkConfig struct {
+	Enabled   bool          `json:"enabled" toml:"enabled"`
+	ExpiresIn time.Duration `json:"expires_in" toml:"expires_in"`
+	// DisableSignUp prevents magic links from creating new users.
+	DisableSignUp bool `json:"disable_sign_up" toml:"disable_sign_up"`
+	// Library mode only
+	SendMagicLinkEmail func(email string, url string, token string) error `json:"-" toml:"-"`
+}
+
+// =======================
+// Email OTP Config
+// =======================
+
+type EmailOTPConfig struct {
+	Enabled bool `json:"enabled" toml:"enabled"`
+	// Length is the number of digits in a code.
+	Length    int           `json:"length" toml:"length"`
+	ExpiresIn time.Duration `json:"expires_in" toml:"expires_in"`
+	// MaxAttempts is the number of wrong codes allowed before the code is invalidated.
+	MaxAttempts int `json:"max_attempts" toml:"max_attempts"`
+	// DisableSignUp prevents sign in codes from creating new users.
+	DisableSignUp bool `json:"disable_sign_up" toml:"disable_sign_up"`
+	// Library mode only
+	SendEmailOTP func(email string, otp string, otpType EmailOTPType) error `json:"-" toml:"-"`
+}
+
 // =======================
 // User Config
 // =======================
@@ -127,10 +158,155 @@ type UserConfig struct {
 // Session Config
 // =======================
 
+// SessionCacheConfig caches session and user lookups in secondary storage to avoid
+// a database round trip on every authenticated request.
+type SessionCacheConfig struct {
+	Enabled bool          `json:"enabled" toml:"enabled"`
+	TTL     time.Duration `json:"ttl" toml:"ttl"`
+}
+
+// SessionTokenSource is a place a session token is read from on incoming requests.
+type SessionTokenSource string
+
+const (
+	SessionTokenSourceCookie SessionTokenSource = "cookie"
+	// SessionTokenSourceBearer reads the token from an "Authorization: Bearer <token>" header.
+	SessionTokenSourceBearer SessionTokenSource = "bearer"
+)
+
 type SessionConfig struct {
 	CookieName string        `json:"cookie_name" toml:"cookie_name"`
 	ExpiresIn  time.Duration `json:"expires_in" toml:"expires_in"`
 	UpdateAge  time.Duration `json:"update_age" toml:"update_age"`
+	// MaxSessions caps the number of concurrent sessions per user, evicting the oldest. 0 means unlimited.
+	MaxSessions int `json:"max_sessions" toml:"max_sessions"`
+	// CleanupInterval controls how often expired sessions are purged from the database.
+	CleanupInterval time.Duration `json:"cleanup_interval" toml:"cleanup_interval"`
+	// Cache stores session and user lookups in secondary storage. Disabled by default.
+	Cache SessionCacheConfig `json:"cache" toml:"cache"`
+	// TokenSources lists where session tokens are accepted from, checked in order.
+	TokenSources []SessionTokenSource `json:"token_sources" toml:"token_sources"`
+	// ImpersonationExpiresIn is the lifetime of the sessions admins create to act as a user.
+	ImpersonationExpiresIn time.Duration `json:"impersonation_expires_in" toml:"impersonation_expires_in"`
+}
+
+// =======================
+// Two Factor Config
+// =======================
+
+type TwoFactorConfig struct {
+	Enabled bool   `json:"enabled" toml:"enabled"`
+	Issuer  string `json:"issuer" toml:"issuer"`
+	Digits  int    `json:"digits" toml:"digits"`
+	// Period is the TOTP time step, usually 30 seconds. It must be at least one second.
+	Period time.Duration `json:"period" toml:"period" validate:"omitempty,min=1s"`
+	// Skew is the number of periods of clock drift allowed in either direction.
+	Skew int `json:"skew" toml:"skew"`
+	// ChallengeExpiresIn controls how long a pending two-factor sign-in stays valid.
+	ChallengeExpiresIn time.Duration `json:"challenge_expires_in" toml:"challenge_expires_in"`
+	// BackupCodesCount is the number of single-use recovery codes issued at a time.
+	BackupCodesCount int `json:"backup_codes_count" toml:"backup_codes_count"`
+}
+
+// =======================
+// Passkey Config
+// =======================
+
+type PasskeyConfig struct {
+	Enabled bool `json:"enabled" toml:"enabled"`
+	// RPID is the WebAuthn relying party id, usually the registrable domain. Defaults to the host of BaseURL.
+	RPID string `json:"rp_id" toml:"rp_id"`
+	// RPName is shown by authenticators during registration. Defaults to AppName.
+	RPName string `json:"rp_name" toml:"rp_name"`
+	// Origins allowed to perform ceremonies. Defaults to the origin of BaseURL plus the trusted origins.
+	Origins []string      `json:"origins" toml:"origins"`
+	Timeout time.Duration `json:"timeout" toml:"timeout"`
+	// UserVerification is one of "required", "preferred" or "discouraged".
+	UserVerification string `json:"user_verification" toml:"user_verification"`
+}
+
+// =======================
+// JWT Config
+// =======================
+
+type JWTConfig struct {
+	Enabled bool `json:"enabled" toml:"enabled"`
+	// Algorithm used to sign tokens, either "EdDSA" (Ed25519) or "RS256".
+	Algorithm string `json:"algorithm" toml:"algorithm"`
+	// Issuer is the "iss" claim of issued tokens. Defaults to BaseURL.
+	Issuer string `json:"issuer" toml:"issuer"`
+	// Audience is the "aud" claim of issued tokens. Defaults to BaseURL.
+	Audience  string        `json:"audience" toml:"audience"`
+	ExpiresIn time.Duration `json:"expires_in" toml:"expires_in"`
+	// KeyRotationInterval controls how often a new signing key is generated.
+	KeyRotationInterval time.Duration `json:"key_rotation_interval" toml:"key_rotation_interval"`
+	// Library mode only. DefinePayload returns the claims of a token issued for a session,
+	// merged over the default sub, sid, email, email_verified and name claims.
+	DefinePayload func(ctx context.Context, user *User, session *Session) (map[string]any, error) `json:"-" toml:"-"`
+}
+
+// =======================
+// OIDC Provider Config
+// =======================
+
+// OIDCProviderConfig turns the server into an OpenID Connect identity provider for other applications.
+// ID tokens are signed with the JWT signing keys.
+type OIDCProviderConfig struct {
+	Enabled bool `json:"enabled" toml:"enabled"`
+	// LoginPage is where signed out users are sent. It receives a callback_url to return to once signed in.
+	LoginPage string `json:"login_page" toml:"login_page"`
+	// ConsentPage is where users approve a client. It receives consent_code, client_id and scope,
+	// and posts the decision to /oidc/consent.
+	ConsentPage string `json:"consent_page" toml:"consent_page"`
+	// Scopes supported by the provider.
+	Scopes               []string      `json:"scopes" toml:"scopes"`
+	CodeExpiresIn        time.Duration `json:"code_expires_in" toml:"code_expires_in"`
+	AccessTokenExpiresIn time.Duration `json:"access_token_expires_in" toml:"access_token_expires_in"`
+}
+
+// =======================
+// Forward Auth Config
+// =======================
+
+// ForwardAuthConfig lets a reverse proxy (nginx auth_request, Traefik ForwardAuth) gate upstream
+// applications on the session through the /verify-request endpoint.
+type ForwardAuthConfig struct {
+	Enabled bool `json:"enabled" toml:"enabled"`
+	// LoginURL is where browsers are redirected when they are not signed in. It receives a callback_url
+	// pointing back at the original request. When empty, anonymous requests are answered with 401.
+	LoginURL       string `json:"login_url" toml:"login_url"`
+	RedirectStatus int    `json:"redirect_status" toml:"redirect_status"`
+	// Hosts overrides the settings above per upstream host, matched against the X-Forwarded-Host header.
+	Hosts map[string]ForwardAuthHostConfig `json:"hosts" toml:"hosts"`
+}
+
+// =======================
+// Organization Config
+// =======================
+
+type OrganizationConfig struct {
+	Enabled bool `json:"enabled" toml:"enabled"`
+	// InvitationExpiresIn is how long invitations can be accepted.
+	InvitationExpiresIn time.Duration `json:"invitation_expires_in" toml:"invitation_expires_in"`
+	// InvitationPage is where the emailed links lead. It receives token, action ("accept" or "decline") and
+	// callback_url, and posts the answer to /organizations/invitations/{action} once the invitee is signed in.
+	InvitationPage string `json:"invitation_page" toml:"invitation_page"`
+	// Library mode only
+	SendInvitationEmail func(invitation Invitation, organization Organization, inviter User, acceptURL string, declineURL string) error `json:"-" toml:"-"`
+}
+
+// RBACConfig configures role-based access control.
+type RBACConfig struct {
+	// AdminPermission lets users whose roles grant it use the admin API with their session, along with
+	// the admin API key. When empty, only the API key is accepted.
+	AdminPermission string `json:"admin_permission" toml:"admin_permission"`
+}
+
+type ForwardAuthHostConfig struct {
+	// LoginURL replaces the global login URL for this host.
+	LoginURL string `json:"login_url" toml:"login_url"`
+	// Public lets anonymous requests through. Signed in users still get the user headers.
+	Public bool `json:"public" toml:"public"`
 }
 
 // =======================
@@ -158,12 +334,55 @@ type OAuth2ProviderConfig struct {
 	AuthURL     string `json:"auth_url" toml:"auth_url"`
 	TokenURL    string `json:"token_url" toml:"token_url"`
 	UserInfoURL string `json:"user_info_url" toml:"user_info_url"`
+	// Type "oidc" discovers the endpoints from Issuer and verifies ID tokens against the issuer's JWKS.
+	// Other providers that are not built in are generic OAuth2 providers configured through the URLs above.
+	Type   string `json:"type" toml:"type"`
+	Issuer string `json:"issuer" toml:"issuer"`
+	// PKCE makes generic providers use PKCE. OIDC providers use it whenever the issuer supports S256.
+	PKCE bool `json:"pkce" toml:"pkce"`
+	// ClaimPaths maps the claims of generic and OIDC providers to the user info.
+	ClaimPaths OAuth2ClaimPaths `json:"claim_paths" toml:"claim_paths"`
+	// Audiences are the client IDs of native apps, accepted besides ClientID as audience of the ID tokens
+	// they sign in with.
+	Audiences []string `json:"audiences" toml:"audiences"`
+	// Apple signs client secrets with a private key instead of using a static secret
+	TeamID     string `json:"team_id" toml:"team_id"`
+	KeyID      string `json:"key_id" toml:"key_id"`
+	PrivateKey string `json:"private_key" toml:"private_key"`
+	// TenantID restricts Microsoft sign in to a tenant. Defaults to "common".
+	TenantID string `json:"tenant_id" toml:"tenant_id"`
+	// BaseURL points GitLab to a self-hosted instance. Defaults to https://gitlab.com.
+	BaseURL string `json:"base_url" toml:"base_url"`
+}
+
+// OAuth2ClaimPaths locate user info in provider claims. Nested claims use dot separated paths such as
+// "profile.email". Empty paths use the standard OpenID Connect claims.
+type OAuth2ClaimPaths struct {
+	ID            string `json:"id" toml:"id"`
+	Email         string `json:"email" toml:"email"`
+	EmailVerified string `json:"email_verified" toml:"email_verified"`
+	Name          string `json:"name" toml:"name"`
+	Picture       string `json:"picture" toml:"picture"`
 }
 
 type SocialProvidersConfig struct {
 	Providers map[string]OAuth2ProviderConfig `json:"providers" toml:"providers"`
 }
 
+// =======================
+// Account Linking Config
+// =======================
+
+// AccountLinkingConfig lets users connect several OAuth2 providers to the same user.
+type AccountLinkingConfig struct {
+	Enabled bool `json:"enabled" toml:"enabled"`
+	// TrustedProviders are linked automatically on sign in when they report a verified email matching an
+	// existing user. Sign ins through other providers fail with an account linking required error instead.
+	TrustedProviders []string `json:"trusted_providers" toml:"trusted_providers"`
+	// AllowDifferentEmails allows linking a provider account whose email differs from the user's.
+	AllowDifferentEmails bool `json:"allow_different_emails" toml:"allow_different_emails"`
+}
+
 // =======================
 // Trusted Origins Config
 // =======================
@@ -201,13 +420,15 @@ type RateLimitConfig struct {
 // =======================
 
 type EndpointHookContext struct {
-	Path            string
-	Method          string
-	Body            map[string]any
-	Headers         map[string][]string
-	Query           map[string][]string
-	Request         *http.Request
-	User            *User
+	Path    string
+	Method  string
+	Body    map[string]any
+	Headers map[string][]string
+	Query   map[string][]string
+	Request *http.Request
+	User    *User
+	// Session is the session of the request, its ImpersonatedBy is set when an admin acts as the user
+	Session         *Session
 	ResponseStatus  int
 	ResponseHeaders map[string][]string
 	ResponseBody    []byte
@@ -267,6 +488,9 @@ type EventHooksConfig struct {
 	OnEmailVerified   func(user User)
 	OnPasswordChanged func(user User)
 	OnEmailChanged    func(user User)
+	OnBackupCodeUsed  func(user User)
+	OnUserBanned      func(user User)
+	OnUserUnbanned    func(user User)
 }
 
 // =======================
@@ -285,6 +509,9 @@ type WebhooksConfig struct {
 	OnEmailVerified   *WebhookConfig `json:"on_email_verified" toml:"on_email_verified"`
 	OnPasswordChanged *WebhookConfig `json:"on_password_changed" toml:"on_password_changed"`
 	OnEmailChanged    *WebhookConfig `json:"on_email_changed" toml:"on_email_changed"`
+	OnBackupCodeUsed  *WebhookConfig `json:"on_backup_code_used" toml:"on_backup_code_used"`
+	OnUserBanned      *WebhookConfig `json:"on_user_banned" toml:"on_user_banned"`
+	OnUserUnbanned    *WebhookConfig `json:"on_user_unbanned" toml:"on_user_unbanned"`
 }
 
 // =======================
@@ -322,10 +549,20 @@ type Config struct {
 	SecondaryStorage  SecondaryStorageConfig  `json:"secondary_storage" toml:"secondary_storage"`
 	EmailPassword     EmailPasswordConfig     `json:"email_password" toml:"email_password"`
 	EmailVerification EmailVerificationConfig `json:"email_verification" toml:"email_verification"`
+	MagicLink         MagicLinkConfig         `json:"magic_link" toml:"magic_link"`
+	EmailOTP          EmailOTPConfig          `json:"email_otp" toml:"email_otp"`
 	User              UserConfig              `json:"user" toml:"user"`
 	Session           SessionConfig           `json:"session" toml:"session"`
+	TwoFactor         TwoFactorConfig         `json:"two_factor" toml:"two_factor"`
+	Passkey           PasskeyConfig           `json:"passkey" toml:"passkey"`
+	JWT               JWTConfig               `json:"jwt" toml:"jwt"`
+	OIDCProvider      OIDCProviderConfig      `json:"oidc_provider" toml:"oidc_provider"`
+	ForwardAuth       ForwardAuthConfig       `json:"forward_auth" toml:"forward_auth"`
 	CSRF              CSRFConfig              `json:"csrf" toml:"csrf"`
+	RBAC              RBACConfig              `json:"rbac" toml:"rbac"`
+	Organization      OrganizationConfig      `json:"organization" toml:"organization"`
 	SocialProviders   SocialProvidersConfig   `json:"social_providers" toml:"social_providers"`
+	AccountLinking    AccountLinkingConfig    `json:"account_linking" toml:"account_linking"`
 	TrustedOrigins    TrustedOriginsConfig    `json:"trusted_origins" toml:"trusted_origins"`
 	RateLimit         RateLimitConfig         `json:"rate_limit" toml:"rate_limit"`
 	EndpointHooks     EndpointHooksConfig     `json:"-" toml:"-"`
@@ -1,10 +1,20 @@
 package models
 
-// SignInResult represents the result of a sign-in operation
+import "time"
+
+// SignInResult represents the result of a sign-in operation.
+// When TwoFactorRequired is set no session has been created yet and the ChallengeToken
+// must be exchanged for one through the two factor verification flow.
 type SignInResult struct {
-	Token     string  `json:"token"`
-	User      *User   `json:"user"`
-	CSRFToken *string `json:"csrf_token,omitempty"`
+	Token             string  `json:"token"`
+	User              *User   `json:"user"`
+	CSRFToken         *string `json:"csrf_token,omitempty"`
+	TwoFactorRequired bool    `json:"two_factor_required,omitempty"`
+	ChallengeToken    *string `json:"challenge_token,omitempty"`
+	// AccountLinked is set when the OAuth2 callback linked an account to the signed in user rather than signing in
+	AccountLinked bool `json:"account_linked,omitempty"`
+	// RedirectTo is the validated target the OAuth2 flow was started with
+	RedirectTo string `json:"redirect_to,omitempty"`
 }
 
 // SignUpResult represents the result of a sign-up operation
@@ -23,6 +33,11 @@ type SignOutResult struct {
 type VerifyEmailResult struct {
 	Message string `json:"message"`
 	User    *User  `json:"user,omitempty"`
+	// Set when the verification signs the user in, as magic links do
+	Token             string  `json:"token,omitempty"`
+	CSRFToken         *string `json:"csrf_token,omitempty"`
+	TwoFactorRequired bool    `json:"two_factor_required,omitempty"`
+	ChallengeToken    *string `json:"challenge_token,omitempty"`
 }
 
 // PasswordResetRequestResult represents the result of a password reset request
@@ -46,7 +61,48 @@ type EmailChangeResult struct {
 	User    *User  `json:"user,omitempty"`
 }
 
+// TwoFactorEnrollResult contains the secret and key URI used to set up an authenticator app,
+// along with the recovery codes. The codes are only ever returned once.
+type TwoFactorEnrollResult struct {
+	Secret      string   `json:"secret"`
+	URI         string   `json:"uri"`
+	BackupCodes []string `json:"backup_codes"`
+}
+
 type MeResult struct {
-	User    *User    `json:"user"`
-	Session *Session `json:"session"`
+	User                 *User    `json:"user"`
+	Session              *Session `json:"session"`
+	TwoFactorEnabled     bool     `json:"two_factor_enabled"`
+	BackupCodesRemaining int      `json:"backup_codes_remaining"`
+	// ImpersonatedBy is the ID of the admin acting as the user, set only in impersonation sessions
+	ImpersonatedBy *string `json:"impersonated_by,omitempty"`
+	// Roles and Permissions are the names of the roles assigned to the user and of the permissions they grant
+	Roles       []string `json:"roles"`
+	Permissions []string `json:"permissions"`
+}
+
+// TokenResult contains a signed JWT access token
+type TokenResult struct {
+	Token string `json:"token"`
+}
+
+// ActiveSession describes a signed in device without exposing the session token
+type ActiveSession struct {
+	ID        string    `json:"id"`
+	IPAddress *string   `json:"ip_address,omitempty"`
+	UserAgent *string   `json:"user_agent,omitempty"`
+	ExpiresAt time.Time `json:"expires_at"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	Current   bool      `json:"current"`
+}
+
+// LinkedAccount describes an account the user can sign in with, without exposing its tokens or password
+type LinkedAccount struct {
+	ID         string       `json:"id"`
+	ProviderID ProviderType `json:"provider_id"`
+	AccountID  string       `json:"account_id"`
+	Scope      *string      `json:"scope,omitempty"`
+	CreatedAt  time.Time    `json:"created_at"`
+	UpdatedAt  time.Time    `json:"updated_at"`
 }
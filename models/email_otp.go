@@ -0,0 +1,18 @@
+package models
+
+// EmailOTPType is the purpose an email one-time code was issued for. A code can only be used for its own purpose.
+type EmailOTPType string
+
+const (
+	EmailOTPTypeSignIn            EmailOTPType = "sign_in"
+	EmailOTPTypeEmailVerification EmailOTPType = "email_verification"
+	EmailOTPTypePasswordReset     EmailOTPType = "password_reset"
+)
+
+func (t EmailOTPType) IsValid() bool {
+	switch t {
+	case EmailOTPTypeSignIn, EmailOTPTypeEmailVerification, EmailOTPTypePasswordReset:
+		return true
+	}
+	return false
+}
@@ -13,6 +13,13 @@ const (
 	EventEmailVerified   = "user.email_verified"
 	EventPasswordChanged = "user.password_changed"
 	EventEmailChanged    = "user.email_changed"
+	EventBackupCodeUsed  = "user.backup_code_used"
+	EventUserBanned      = "user.banned"
+	EventUserUnbanned    = "user.unbanned"
+	EventSessionRevoked  = "session.revoked"
+	// Impersonation events are published for auditing whenever an admin starts or stops acting as a user
+	EventImpersonationStarted = "session.impersonation_started"
+	EventImpersonationStopped = "session.impersonation_stopped"
 )
 
 // Event represents data to be published or received via the EventBus
@@ -24,6 +31,21 @@ type Event struct {
 	Metadata  map[string]string `json:"metadata"`
 }
 
+// SessionRevokedPayload is the payload of EventSessionRevoked.
+type SessionRevokedPayload struct {
+	SessionID string    `json:"session_id"`
+	UserID    string    `json:"user_id"`
+	RevokedAt time.Time `json:"revoked_at"`
+}
+
+// ImpersonationPayload is the payload of EventImpersonationStarted and EventImpersonationStopped.
+type ImpersonationPayload struct {
+	SessionID      string    `json:"session_id"`
+	UserID         string    `json:"user_id"`
+	ImpersonatedBy string    `json:"impersonated_by"`
+	Timestamp      time.Time `json:"timestamp"`
+}
+
 // Message represents a message in the pub/sub system.
 type Message struct {
 	UUID     string
@@ -0,0 +1,20 @@
+package models
+
+// Token type hints defined by RFC 7009 and RFC 7662
+const (
+	TokenTypeHintAccessToken = "access_token"
+	TokenTypeHintSession     = "session_token"
+)
+
+// IntrospectionResult is a token introspection response as defined by RFC 7662 section 2.2.
+// Inactive tokens only ever carry Active set to false.
+type IntrospectionResult struct {
+	Active    bool   `json:"active"`
+	Subject   string `json:"sub,omitempty"`
+	ClientID  string `json:"client_id,omitempty"`
+	Scope     string `json:"scope,omitempty"`
+	TokenType string `json:"token_type,omitempty"`
+	SessionID string `json:"sid,omitempty"`
+	ExpiresAt int64  `json:"exp,omitempty"`
+	IssuedAt  int64  `json:"iat,omitempty"`
+}
@@ -0,0 +1,35 @@
+package models
+
+import "time"
+
+// SigningKey is an asymmetric key pair used to sign JWT access tokens.
+// The ID doubles as the "kid" of the tokens and JWKS entries.
+type SigningKey struct {
+	ID        string `json:"id" gorm:"primaryKey"`
+	Algorithm string `json:"algorithm"`
+	// PublicKey is the base64 PKIX encoded public key
+	PublicKey string `json:"public_key"`
+	// PrivateKey is the PKCS #8 encoded private key, encrypted with the application secret
+	PrivateKey string `json:"-"`
+	// ExpiresAt is set once the key has been rotated out. It stays published in the JWKS
+	// until then so tokens it signed can still be verified.
+	ExpiresAt *time.Time `json:"expires_at"`
+	CreatedAt time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// JSONWebKey is the public part of a signing key as published in the JWKS (RFC 7517).
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use,omitempty"`
+	Algorithm string `json:"alg"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	Y         string `json:"y,omitempty"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+}
+
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
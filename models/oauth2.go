@@ -1,5 +1,7 @@
 package models
 
+import "time"
+
 type OAuth2UserInfo struct {
 	ID       string
 	Email    string
@@ -9,9 +11,35 @@ type OAuth2UserInfo struct {
 	Raw      map[string]any
 }
 
+// OAuth2LoginOptions customize an OAuth2 login or link flow
+type OAuth2LoginOptions struct {
+	// RedirectTo is where the callback sends the browser once done. It must be a relative path or
+	// belong to a trusted origin.
+	RedirectTo string
+	// Scopes are requested from the provider in addition to the configured ones
+	Scopes []string
+}
+
 // OAuth2LoginResult contains the information needed for the OAuth2 login flow
 type OAuth2LoginResult struct {
-	AuthURL  string  // The authorization URL to redirect to
-	State    string  // CSRF protection state
-	Verifier *string // PKCE code verifier (if PKCE is required)
+	AuthURL string // The authorization URL to redirect to
+	State   string // CSRF protection state
+}
+
+// OAuth2State is the record of an OAuth2 flow in progress. It is kept in secondary storage under its
+// state and consumed by the callback, so that each flow completes at most once.
+type OAuth2State struct {
+	Provider   string   `json:"provider"`
+	Verifier   string   `json:"verifier,omitempty"`
+	Nonce      string   `json:"nonce,omitempty"`
+	RedirectTo string   `json:"redirect_to,omitempty"`
+	LinkUserID string   `json:"link_user_id,omitempty"`
+	Scopes     []string `json:"scopes,omitempty"`
+}
+
+// OAuth2AccessToken is a valid access token of a linked account, used to call the provider's APIs on behalf of the user
+type OAuth2AccessToken struct {
+	AccessToken string     `json:"access_token"`
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	Scopes      []string   `json:"scopes"`
 }
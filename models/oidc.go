@@ -0,0 +1,170 @@
+package models
+
+import (
+	"slices"
+	"time"
+)
+
+// OIDC scopes understood by the provider
+const (
+	ScopeOpenID  = "openid"
+	ScopeProfile = "profile"
+	ScopeEmail   = "email"
+)
+
+// OAuthClient is an application that signs its users in through the OpenID Connect provider.
+type OAuthClient struct {
+	ID       string `json:"id" gorm:"primaryKey"`
+	ClientID string `json:"client_id" gorm:"uniqueIndex;size:255"`
+	// ClientSecret is the hashed secret of confidential clients. Public clients have none and must use PKCE.
+	ClientSecret *string  `json:"-"`
+	Name         string   `json:"name"`
+	RedirectURIs []string `json:"redirect_uris" gorm:"serializer:json"`
+	// Scopes the client may request. Empty allows every supported scope.
+	Scopes []string `json:"scopes" gorm:"serializer:json"`
+	Public bool     `json:"public"`
+	// SkipConsent signs users into trusted first party clients without asking them first.
+	SkipConsent bool      `json:"skip_consent"`
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// HasRedirectURI reports whether uri exactly matches one of the registered redirect URIs.
+func (c *OAuthClient) HasRedirectURI(uri string) bool {
+	return slices.Contains(c.RedirectURIs, uri)
+}
+
+// OAuthConsent records the scopes a user granted to a client.
+type OAuthConsent struct {
+	ID        string    `json:"id" gorm:"primaryKey"`
+	UserID    string    `json:"user_id" gorm:"index"`
+	ClientID  string    `json:"client_id" gorm:"index"`
+	Scopes    []string  `json:"scopes" gorm:"serializer:json"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// Covers reports whether every scope was already granted.
+func (c *OAuthConsent) Covers(scopes []string) bool {
+	for _, scope := range scopes {
+		if !slices.Contains(c.Scopes, scope) {
+			return false
+		}
+	}
+	return true
+}
+
+// OAuthGrantKind tells apart the grants kept in secondary storage.
+type OAuthGrantKind string
+
+const (
+	OAuthGrantKindConsent           OAuthGrantKind = "consent"
+	OAuthGrantKindAuthorizationCode OAuthGrantKind = "code"
+	OAuthGrantKindAccessToken       OAuthGrantKind = "access_token"
+)
+
+// OAuthGrant is an authorization given by a user to a client. It backs pending consent requests,
+// authorization codes and access tokens, which are all kept in secondary storage.
+type OAuthGrant struct {
+	ClientID            string    `json:"client_id"`
+	UserID              string    `json:"user_id"`
+	SessionID           string    `json:"session_id"`
+	RedirectURI         string    `json:"redirect_uri"`
+	Scopes              []string  `json:"scopes"`
+	State               string    `json:"state,omitempty"`
+	Nonce               string    `json:"nonce,omitempty"`
+	CodeChallenge       string    `json:"code_challenge,omitempty"`
+	CodeChallengeMethod string    `json:"code_challenge_method,omitempty"`
+	AuthTime            time.Time `json:"auth_time"`
+	IssuedAt            time.Time `json:"issued_at"`
+	ExpiresAt           time.Time `json:"expires_at"`
+}
+
+// OAuthError is an error response defined by RFC 6749 section 5.2.
+type OAuthError struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description,omitempty"`
+}
+
+func (e *OAuthError) Error() string {
+	if e.Description == "" {
+		return e.Code
+	}
+	return e.Code + ": " + e.Description
+}
+
+// NewOAuthError creates an OAuth error with one of the RFC 6749 error codes.
+func NewOAuthError(code string, description string) *OAuthError {
+	return &OAuthError{Code: code, Description: description}
+}
+
+// OAuthClientRegistration describes a client to register with the OpenID Connect provider.
+type OAuthClientRegistration struct {
+	Name         string   `json:"name" validate:"required"`
+	RedirectURIs []string `json:"redirect_uris" validate:"required,min=1,dive,url"`
+	Scopes       []string `json:"scopes,omitempty"`
+	Public       bool     `json:"public"`
+	SkipConsent  bool     `json:"skip_consent"`
+}
+
+// OAuthClientRegistrationResult contains a newly registered client.
+// The client secret of confidential clients is only ever returned once.
+type OAuthClientRegistrationResult struct {
+	Client       *OAuthClient `json:"client"`
+	ClientSecret string       `json:"client_secret,omitempty"`
+}
+
+// OIDCAuthorizeRequest holds the parameters of an authorization request.
+type OIDCAuthorizeRequest struct {
+	ResponseType        string
+	ClientID            string
+	RedirectURI         string
+	Scope               string
+	State               string
+	Nonce               string
+	CodeChallenge       string
+	CodeChallengeMethod string
+}
+
+// OIDCAuthorizeResult tells the browser where to go next: back to the client, to the login page
+// or to the consent page.
+type OIDCAuthorizeResult struct {
+	RedirectURL string `json:"redirect_url"`
+}
+
+// OIDCTokenRequest holds the parameters of a token request. The client credentials come from
+// either HTTP basic authentication or the request body.
+type OIDCTokenRequest struct {
+	GrantType    string
+	Code         string
+	RedirectURI  string
+	ClientID     string
+	ClientSecret string
+	CodeVerifier string
+}
+
+// OIDCTokenResponse is a successful token response.
+type OIDCTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+	IDToken     string `json:"id_token,omitempty"`
+	Scope       string `json:"scope"`
+}
+
+// OIDCDiscoveryDocument is served from /.well-known/openid-configuration.
+type OIDCDiscoveryDocument struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	UserInfoEndpoint                  string   `json:"userinfo_endpoint"`
+	JWKSURI                           string   `json:"jwks_uri"`
+	ScopesSupported                   []string `json:"scopes_supported"`
+	ResponseTypesSupported            []string `json:"response_types_supported"`
+	GrantTypesSupported               []string `json:"grant_types_supported"`
+	SubjectTypesSupported             []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported  []string `json:"id_token_signing_alg_values_supported"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported"`
+	ClaimsSupported                   []string `json:"claims_supported"`
+}
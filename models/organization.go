@@ -0,0 +1,102 @@
+package models
+
+import "time"
+
+// OrganizationRole is the role of a member within an organization.
+type OrganizationRole string
+
+const (
+	// OrganizationRoleOwner manages the organization and its members, an organization keeps at least one
+	OrganizationRoleOwner OrganizationRole = "owner"
+	// OrganizationRoleAdmin manages the members and invitations of the organization
+	OrganizationRoleAdmin  OrganizationRole = "admin"
+	OrganizationRoleMember OrganizationRole = "member"
+)
+
+// Organization is a team of users, such as the customer account of a B2B application.
+type Organization struct {
+	ID        string    `json:"id" gorm:"primaryKey"`
+	Name      string    `json:"name"`
+	Slug      string    `json:"slug" gorm:"uniqueIndex"`
+	Logo      *string   `json:"logo,omitempty"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// Member is the membership of a user in an organization.
+type Member struct {
+	ID             string           `json:"id" gorm:"primaryKey"`
+	OrganizationID string           `json:"organization_id" gorm:"uniqueIndex:idx_members_organization_user"`
+	UserID         string           `json:"user_id" gorm:"uniqueIndex:idx_members_organization_user;index"`
+	Role           OrganizationRole `json:"role"`
+	CreatedAt      time.Time        `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time        `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+type InvitationStatus string
+
+const (
+	InvitationStatusPending  InvitationStatus = "pending"
+	InvitationStatusAccepted InvitationStatus = "accepted"
+	InvitationStatusDeclined InvitationStatus = "declined"
+)
+
+// Invitation invites an email address to join an organization. The token is emailed to the invitee and
+// only its hash is stored.
+type Invitation struct {
+	ID             string           `json:"id" gorm:"primaryKey"`
+	OrganizationID string           `json:"organization_id" gorm:"index"`
+	Email          string           `json:"email" gorm:"index"`
+	Role           OrganizationRole `json:"role"`
+	Status         InvitationStatus `json:"status"`
+	InviterID      string           `json:"inviter_id"`
+	Token          string           `json:"-" gorm:"uniqueIndex"`
+	ExpiresAt      time.Time        `json:"expires_at"`
+	CreatedAt      time.Time        `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt      time.Time        `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// UserOrganization is an organization along with the role of the user in it
+type UserOrganization struct {
+	Organization
+	Role OrganizationRole `json:"role"`
+}
+
+// OrganizationMember is a member along with their user
+type OrganizationMember struct {
+	Member
+	User *User `json:"user"`
+}
+
+type CreateOrganizationRequest struct {
+	Name string `json:"name" validate:"required"`
+	// Slug is derived from the name when empty
+	Slug string  `json:"slug,omitempty" validate:"omitempty,max=64"`
+	Logo *string `json:"logo,omitempty"`
+}
+
+type SetActiveOrganizationRequest struct {
+	// OrganizationID is the organization to switch to, nil clears the active organization
+	OrganizationID *string `json:"organization_id"`
+}
+
+type UpdateMemberRequest struct {
+	Role OrganizationRole `json:"role" validate:"required,oneof=owner admin member"`
+}
+
+type CreateInvitationRequest struct {
+	Email       string           `json:"email" validate:"required,email"`
+	Role        OrganizationRole `json:"role" validate:"required,oneof=admin member"`
+	CallbackURL *string          `json:"callback_url,omitempty"`
+}
+
+// AnswerInvitationRequest accepts or declines the invitation of an emailed token
+type AnswerInvitationRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// InvitationResult is the outcome of answering an invitation
+type InvitationResult struct {
+	Invitation   *Invitation   `json:"invitation"`
+	Organization *Organization `json:"organization"`
+}
@@ -0,0 +1,103 @@
+package models
+
+import "time"
+
+// Passkey is a WebAuthn credential registered by a user for passwordless sign-in.
+type Passkey struct {
+	ID     string `json:"id" gorm:"primaryKey"`
+	UserID string `json:"user_id" gorm:"index"`
+	Name   string `json:"name"`
+	// CredentialID is the base64url encoded credential id chosen by the authenticator
+	CredentialID string `json:"credential_id" gorm:"uniqueIndex;size:512"`
+	// PublicKey is the COSE encoded credential public key
+	PublicKey  []byte     `json:"-"`
+	Algorithm  int64      `json:"algorithm"`
+	SignCount  uint32     `json:"sign_count"`
+	AAGUID     string     `json:"aaguid"`
+	Transports string     `json:"transports"`
+	BackedUp   bool       `json:"backed_up"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt  time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// The passkey types below mirror the WebAuthn JSON serialization (camelCase, base64url values)
+// so they can be passed directly to PublicKeyCredential.parseCreationOptionsFromJSON and
+// parseRequestOptionsFromJSON in the browser.
+
+type PasskeyRelyingParty struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type PasskeyUserEntity struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	DisplayName string `json:"displayName"`
+}
+
+type PasskeyCredentialParameter struct {
+	Type string `json:"type"`
+	Alg  int64  `json:"alg"`
+}
+
+type PasskeyCredentialDescriptor struct {
+	Type       string   `json:"type"`
+	ID         string   `json:"id"`
+	Transports []string `json:"transports,omitempty"`
+}
+
+type PasskeyAuthenticatorSelection struct {
+	ResidentKey      string `json:"residentKey"`
+	UserVerification string `json:"userVerification"`
+}
+
+// PasskeyRegistrationOptions are the options for navigator.credentials.create()
+type PasskeyRegistrationOptions struct {
+	Challenge              string                        `json:"challenge"`
+	RP                     PasskeyRelyingParty           `json:"rp"`
+	User                   PasskeyUserEntity             `json:"user"`
+	PubKeyCredParams       []PasskeyCredentialParameter  `json:"pubKeyCredParams"`
+	Timeout                int64                         `json:"timeout"`
+	Attestation            string                        `json:"attestation"`
+	ExcludeCredentials     []PasskeyCredentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection PasskeyAuthenticatorSelection `json:"authenticatorSelection"`
+}
+
+// PasskeyAuthenticationOptions are the options for navigator.credentials.get()
+type PasskeyAuthenticationOptions struct {
+	Challenge        string                        `json:"challenge"`
+	RPID             string                        `json:"rpId"`
+	Timeout          int64                         `json:"timeout"`
+	UserVerification string                        `json:"userVerification"`
+	AllowCredentials []PasskeyCredentialDescriptor `json:"allowCredentials"`
+}
+
+type PasskeyAttestationResponse struct {
+	ClientDataJSON    string   `json:"clientDataJSON" validate:"required"`
+	AttestationObject string   `json:"attestationObject" validate:"required"`
+	Transports        []string `json:"transports,omitempty"`
+}
+
+// PasskeyRegistrationCredential is the credential returned by navigator.credentials.create()
+type PasskeyRegistrationCredential struct {
+	ID       string                     `json:"id" validate:"required"`
+	RawID    string                     `json:"rawId"`
+	Type     string                     `json:"type"`
+	Response PasskeyAttestationResponse `json:"response"`
+}
+
+type PasskeyAssertionResponse struct {
+	ClientDataJSON    string  `json:"clientDataJSON" validate:"required"`
+	AuthenticatorData string  `json:"authenticatorData" validate:"required"`
+	Signature         string  `json:"signature" validate:"required"`
+	UserHandle        *string `json:"userHandle,omitempty"`
+}
+
+// PasskeyAuthenticationCredential is the credential returned by navigator.credentials.get()
+type PasskeyAuthenticationCredential struct {
+	ID       string                   `json:"id" validate:"required"`
+	RawID    string                   `json:"rawId"`
+	Type     string                   `json:"type"`
+	Response PasskeyAssertionResponse `json:"response"`
+}
@@ -2,9 +2,15 @@ package models
 
 type ProviderType string
 
+// OAuth2ProviderTypeOIDC is the type of OAuth2 providers configured from an OpenID Connect issuer.
+const OAuth2ProviderTypeOIDC = "oidc"
+
 const (
-	ProviderEmail   ProviderType = "email"
-	ProviderDiscord ProviderType = "discord"
-	ProviderGitHub  ProviderType = "github"
-	ProviderGoogle  ProviderType = "google"
+	ProviderEmail     ProviderType = "email"
+	ProviderApple     ProviderType = "apple"
+	ProviderDiscord   ProviderType = "discord"
+	ProviderGitHub    ProviderType = "github"
+	ProviderGitLab    ProviderType = "gitlab"
+	ProviderGoogle    ProviderType = "google"
+	ProviderMicrosoft ProviderType = "microsoft"
 )
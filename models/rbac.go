@@ -0,0 +1,57 @@
+package models
+
+import "time"
+
+// Role groups permissions that are granted to the users it is assigned to.
+type Role struct {
+	ID          string    `json:"id" gorm:"primaryKey"`
+	Name        string    `json:"name" gorm:"uniqueIndex"`
+	Description *string   `json:"description,omitempty"`
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt   time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// Permission is a "resource:action" string, such as "posts:delete". A "*" action grants every action
+// on the resource and a "*" permission grants everything.
+type Permission struct {
+	ID          string    `json:"id" gorm:"primaryKey"`
+	Name        string    `json:"name" gorm:"uniqueIndex"`
+	Description *string   `json:"description,omitempty"`
+	CreatedAt   time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// RolePermission grants a permission to a role.
+type RolePermission struct {
+	RoleID       string    `json:"role_id" gorm:"primaryKey"`
+	PermissionID string    `json:"permission_id" gorm:"primaryKey;index"`
+	CreatedAt    time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// UserRole assigns a role to a user.
+type UserRole struct {
+	UserID    string    `json:"user_id" gorm:"primaryKey"`
+	RoleID    string    `json:"role_id" gorm:"primaryKey;index"`
+	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
+}
+
+// RoleWithPermissions is a role along with the permissions it grants
+type RoleWithPermissions struct {
+	Role
+	Permissions []Permission `json:"permissions"`
+}
+
+type CreateRoleRequest struct {
+	Name        string  `json:"name" validate:"required"`
+	Description *string `json:"description,omitempty"`
+}
+
+// UpdateRoleRequest holds the fields of a role to change, nil fields are left untouched
+type UpdateRoleRequest struct {
+	Name        *string `json:"name,omitempty" validate:"omitempty,min=1"`
+	Description *string `json:"description,omitempty"`
+}
+
+type CreatePermissionRequest struct {
+	Name        string  `json:"name" validate:"required"`
+	Description *string `json:"description,omitempty"`
+}
@@ -10,20 +10,110 @@ type UserService interface {
 	GetUserByID(id string) (*User, error)
 	GetUserByEmail(email string) (*User, error)
 	UpdateUser(user *User) error
+	ListUsers(query UserListQuery) ([]User, int64, error)
+	DeleteUser(id string) error
 }
 
 type AccountService interface {
 	CreateAccount(account *Account) error
-	GetAccountByUserID(userID string) (*Account, error)
+	ListAccountsByUserID(userID string) ([]Account, error)
+	GetCredentialAccount(userID string) (*Account, error)
+	GetAccountByUserIDAndProvider(userID string, provider ProviderType) (*Account, error)
 	GetAccountByProviderAndAccountID(provider ProviderType, accountID string) (*Account, error)
 	UpdateAccount(account *Account) error
+	DeleteAccount(ID string) error
+	UnlinkAccount(userID string, accountID string) error
 }
 
 type SessionService interface {
 	CreateSession(userID string, token string) (*Session, error)
+	CreateImpersonationSession(userID string, token string, impersonatedBy string) (*Session, error)
 	GetSessionByUserID(userID string) (*Session, error)
+	GetSessionByID(ID string) (*Session, error)
 	GetSessionByToken(token string) (*Session, error)
 	DeleteSessionByID(ID string) error
+	ListSessionsByUserID(userID string) ([]Session, error)
+	RevokeSession(userID string, sessionID string) error
+	RevokeOtherSessions(userID string, currentSessionID string) error
+	RevokeUserSessions(userID string) error
+	InvalidateCachedSessions(userID string) error
+	IsExpired(session *Session) bool
+	ShouldExtend(session *Session) bool
+	ExtendSession(session *Session) error
+	SetActiveOrganization(session *Session, organizationID *string) error
+	ClearActiveOrganization(userID string, organizationID string) error
+	DeleteExpiredSessions() error
+}
+
+type RBACService interface {
+	CreateRole(role *Role) error
+	GetRoleByID(id string) (*Role, error)
+	GetRoleByName(name string) (*Role, error)
+	ListRoles() ([]Role, error)
+	UpdateRole(role *Role) error
+	DeleteRole(id string) error
+	CreatePermission(permission *Permission) error
+	GetPermissionByID(id string) (*Permission, error)
+	GetPermissionByName(name string) (*Permission, error)
+	ListPermissions() ([]Permission, error)
+	DeletePermission(id string) error
+	AddPermissionToRole(roleID string, permissionID string) error
+	RemovePermissionFromRole(roleID string, permissionID string) error
+	ListRolePermissions(roleID string) ([]Permission, error)
+	AssignRoleToUser(userID string, roleID string) error
+	RemoveRoleFromUser(userID string, roleID string) error
+	ListUserRoles(userID string) ([]Role, error)
+	ListUserPermissions(userID string) ([]string, error)
+	HasPermission(userID string, permission string) (bool, error)
+}
+
+type OrganizationService interface {
+	CreateOrganization(organization *Organization, ownerID string) error
+	DeleteOrganization(id string) error
+	GetOrganizationByID(id string) (*Organization, error)
+	GetOrganizationBySlug(slug string) (*Organization, error)
+	ListUserOrganizations(userID string) ([]UserOrganization, error)
+	GetMember(organizationID string, userID string) (*Member, error)
+	ListMembers(organizationID string) ([]OrganizationMember, error)
+	CountMembersWithRole(organizationID string, role OrganizationRole) (int64, error)
+	UpdateMemberRole(organizationID string, userID string, role OrganizationRole) error
+	RemoveMember(organizationID string, userID string) error
+	CreateInvitation(invitation *Invitation) error
+	GetInvitationByToken(token string) (*Invitation, error)
+	GetPendingInvitation(organizationID string, email string) (*Invitation, error)
+	ListPendingInvitations(organizationID string) ([]Invitation, error)
+	AcceptInvitation(invitation *Invitation, userID string) error
+	DeclineInvitation(invitation *Invitation) error
+}
+
+type TwoFactorService interface {
+	CreateTwoFactor(twoFactor *TwoFactor) error
+	GetTwoFactorByUserID(userID string) (*TwoFactor, error)
+	UpdateTwoFactor(twoFactor *TwoFactor) error
+	DeleteTwoFactorByUserID(userID string) error
+	UseTimeStep(userID string, step int64) (bool, error)
+	IsEnabledForUser(userID string) (bool, error)
+	CreateChallenge(ctx context.Context, userID string) (string, error)
+	VerifyChallenge(ctx context.Context, challengeToken string) (string, error)
+	DeleteChallenge(ctx context.Context, challengeToken string) error
+	ReplaceBackupCodes(userID string, hashedCodes []string) error
+	UseBackupCode(userID string, hashedCode string) (bool, error)
+	CountBackupCodes(userID string) (int, error)
+}
+
+type PasskeyService interface {
+	CreatePasskey(passkey *Passkey) error
+	GetPasskeyByCredentialID(credentialID string) (*Passkey, error)
+	GetPasskeysByUserID(userID string) ([]Passkey, error)
+	UpdatePasskey(passkey *Passkey) error
+	CreateChallenge(ctx context.Context, ceremony string, userID string) (string, error)
+	ConsumeChallenge(ctx context.Context, ceremony string, challenge string) (string, error)
+}
+
+type EmailOTPService interface {
+	CreateOTP(ctx context.Context, email string, otpType EmailOTPType) (string, error)
+	VerifyOTP(ctx context.Context, email string, otpType EmailOTPType, code string) error
+	DeleteOTP(ctx context.Context, email string, otpType EmailOTPType) error
 }
 
 type VerificationService interface {
@@ -44,6 +134,27 @@ type TokenService interface {
 	GenerateEncryptedToken() (string, error)
 	EncryptToken(token string) (string, error)
 	DecryptToken(encryptedToken string) (string, error)
+	SignJWT(claims map[string]any) (string, error)
+	VerifyJWT(token string) (map[string]any, error)
+}
+
+type OIDCProviderService interface {
+	CreateClient(client *OAuthClient) error
+	GetClientByClientID(clientID string) (*OAuthClient, error)
+	GetConsent(userID string, clientID string) (*OAuthConsent, error)
+	SaveConsent(userID string, clientID string, scopes []string) error
+	CreateGrant(ctx context.Context, kind OAuthGrantKind, grant *OAuthGrant) (string, error)
+	GetGrant(ctx context.Context, kind OAuthGrantKind, token string) (*OAuthGrant, error)
+	ConsumeGrant(ctx context.Context, kind OAuthGrantKind, token string) (*OAuthGrant, error)
+	DeleteGrant(ctx context.Context, kind OAuthGrantKind, token string) error
+	RevokeUserGrants(ctx context.Context, userID string) error
+}
+
+type SigningKeyService interface {
+	GetActiveSigningKey() (*SigningKey, error)
+	GetSigningKeyByID(id string) (*SigningKey, error)
+	ListVerificationKeys() ([]SigningKey, error)
+	IsExpired(key *SigningKey) bool
 }
 
 type RateLimitService interface {
@@ -62,6 +173,12 @@ type EventEmitter interface {
 	OnEmailVerified(user User)
 	OnPasswordChanged(user User)
 	OnEmailChanged(user User)
+	OnBackupCodeUsed(user User)
+	OnUserBanned(user User)
+	OnUserUnbanned(user User)
+	OnSessionRevoked(session Session)
+	OnImpersonationStarted(session Session)
+	OnImpersonationStopped(session Session)
 }
 
 // AuthServices groups all service interfaces related to authentication
@@ -69,11 +186,18 @@ type AuthServices struct {
 	Users         UserService
 	Accounts      AccountService
 	Sessions      SessionService
+	TwoFactors    TwoFactorService
+	Passkeys      PasskeyService
+	EmailOTPs     EmailOTPService
 	Verifications VerificationService
 	Passwords     PasswordService
 	Tokens        TokenService
+	SigningKeys   SigningKeyService
+	OIDCProvider  OIDCProviderService
 	RateLimits    RateLimitService
 	Mailers       MailerService
+	RBAC          RBACService
+	Organizations OrganizationService
 }
 
 // AuthApi defines the interface for the authentication API
@@ -82,22 +206,101 @@ type AuthApi interface {
 	SignUpWithEmailAndPassword(ctx context.Context, name string, email string, password string, callbackURL *string) (*SignUpResult, error)
 	SignInWithEmailAndPassword(ctx context.Context, email string, password string, callbackURL *string) (*SignInResult, error)
 	SignOut(ctx context.Context, sessionToken string) error
+	ListSessions(ctx context.Context, userID string, currentSessionID string) ([]ActiveSession, error)
+	RevokeSession(ctx context.Context, userID string, sessionID string) error
+	RevokeOtherSessions(ctx context.Context, userID string, currentSessionID string) error
 	VerifyEmail(ctx context.Context, rawToken string) (*VerifyEmailResult, error)
 	SendEmailVerification(ctx context.Context, userID string, callbackURL *string) error
 	ResetPassword(ctx context.Context, email string, callbackURL *string) error
+	SendMagicLink(ctx context.Context, email string, callbackURL *string) error
+	SendEmailOTP(ctx context.Context, email string, otpType EmailOTPType) error
+	SignInWithEmailOTP(ctx context.Context, email string, otp string) (*SignInResult, error)
+	VerifyEmailWithOTP(ctx context.Context, email string, otp string) (*VerifyEmailResult, error)
+	ResetPasswordWithOTP(ctx context.Context, email string, otp string, newPassword string) error
 	ChangePassword(ctx context.Context, rawToken string, newPassword string) error
 	EmailChange(ctx context.Context, userID string, newEmail string, callbackURL *string) error
-	GetMe(ctx context.Context, userID string) (*MeResult, error)
-	PrepareOAuth2Login(ctx context.Context, providerName string) (*OAuth2LoginResult, error)
-	SignInWithOAuth2(ctx context.Context, providerName string, code string, state string, verifier *string) (*SignInResult, error)
+	GetMe(ctx context.Context, userID string, sessionID string) (*MeResult, error)
+	PrepareOAuth2Login(ctx context.Context, providerName string, options OAuth2LoginOptions) (*OAuth2LoginResult, error)
+	SignInWithOAuth2(ctx context.Context, providerName string, code string, state string) (*SignInResult, error)
+	SignInWithIDToken(ctx context.Context, providerName string, idToken string, nonce string) (*SignInResult, error)
+	EnrollTwoFactor(ctx context.Context, userID string) (*TwoFactorEnrollResult, error)
+	EnableTwoFactor(ctx context.Context, userID string, code string) error
+	DisableTwoFactor(ctx context.Context, userID string, code string) error
+	VerifyTwoFactor(ctx context.Context, challengeToken string, code string) (*SignInResult, error)
+	RegenerateBackupCodes(ctx context.Context, userID string) ([]string, error)
+	BeginPasskeyRegistration(ctx context.Context, userID string) (*PasskeyRegistrationOptions, error)
+	FinishPasskeyRegistration(ctx context.Context, userID string, name string, credential PasskeyRegistrationCredential) (*Passkey, error)
+	BeginPasskeyAuthentication(ctx context.Context, email *string) (*PasskeyAuthenticationOptions, error)
+	FinishPasskeyAuthentication(ctx context.Context, credential PasskeyAuthenticationCredential) (*SignInResult, error)
+	GetJWKS(ctx context.Context) (*JSONWebKeySet, error)
+	IssueToken(ctx context.Context, userID string, sessionID string) (*TokenResult, error)
+	RegisterOAuthClient(ctx context.Context, registration OAuthClientRegistration) (*OAuthClientRegistrationResult, error)
+	AuthorizeOIDC(ctx context.Context, userID string, sessionID string, request OIDCAuthorizeRequest) (*OIDCAuthorizeResult, error)
+	ConsentOIDC(ctx context.Context, userID string, consentCode string, accept bool) (*OIDCAuthorizeResult, error)
+	ExchangeOIDCToken(ctx context.Context, request OIDCTokenRequest) (*OIDCTokenResponse, error)
+	GetOIDCUserInfo(ctx context.Context, accessToken string) (map[string]any, error)
+	IntrospectToken(ctx context.Context, token string, tokenTypeHint string) (*IntrospectionResult, error)
+	RevokeToken(ctx context.Context, token string, tokenTypeHint string) error
+	VerifyForwardedRequest(ctx context.Context, userID string) (*User, error)
+	PrepareOAuth2Link(ctx context.Context, providerName string, userID string, options OAuth2LoginOptions) (*OAuth2LoginResult, error)
+	PrepareOAuth2Reauthorize(ctx context.Context, providerName string, userID string, options OAuth2LoginOptions) (*OAuth2LoginResult, error)
+	GetAccessToken(ctx context.Context, userID string, providerName string) (*OAuth2AccessToken, error)
+	ListAccounts(ctx context.Context, userID string) ([]LinkedAccount, error)
+	UnlinkAccount(ctx context.Context, userID string, accountID string) error
+	AdminListUsers(ctx context.Context, query UserListQuery) (*UserList, error)
+	AdminGetUser(ctx context.Context, userID string) (*User, error)
+	AdminCreateUser(ctx context.Context, request AdminCreateUserRequest) (*User, error)
+	AdminUpdateUser(ctx context.Context, userID string, request AdminUpdateUserRequest) (*User, error)
+	AdminDeleteUser(ctx context.Context, userID string) error
+	AdminVerifyUserEmail(ctx context.Context, userID string) (*User, error)
+	AdminSendPasswordReset(ctx context.Context, userID string, callbackURL *string) error
+	AdminListUserSessions(ctx context.Context, userID string) ([]ActiveSession, error)
+	AdminRevokeUserSession(ctx context.Context, userID string, sessionID string) error
+	AdminRevokeUserSessions(ctx context.Context, userID string) error
+	AdminBanUser(ctx context.Context, userID string, request AdminBanUserRequest) (*User, error)
+	AdminUnbanUser(ctx context.Context, userID string) (*User, error)
+	AdminImpersonateUser(ctx context.Context, adminSessionID string, userID string) (*ImpersonationResult, error)
+	AdminStopImpersonating(ctx context.Context, sessionID string, adminSessionToken string) (*StopImpersonationResult, error)
+	AdminListRoles(ctx context.Context) ([]Role, error)
+	AdminGetRole(ctx context.Context, roleID string) (*RoleWithPermissions, error)
+	AdminCreateRole(ctx context.Context, request CreateRoleRequest) (*Role, error)
+	AdminUpdateRole(ctx context.Context, roleID string, request UpdateRoleRequest) (*Role, error)
+	AdminDeleteRole(ctx context.Context, roleID string) error
+	AdminListPermissions(ctx context.Context) ([]Permission, error)
+	AdminCreatePermission(ctx context.Context, request CreatePermissionRequest) (*Permission, error)
+	AdminDeletePermission(ctx context.Context, permissionID string) error
+	AdminAddRolePermission(ctx context.Context, roleID string, permissionID string) error
+	AdminRemoveRolePermission(ctx context.Context, roleID string, permissionID string) error
+	AdminListUserRoles(ctx context.Context, userID string) ([]Role, error)
+	AdminAssignUserRole(ctx context.Context, userID string, roleID string) error
+	AdminRemoveUserRole(ctx context.Context, userID string, roleID string) error
+	HasPermission(ctx context.Context, userID string, permission string) (bool, error)
+	CreateOrganization(ctx context.Context, userID string, sessionID string, request CreateOrganizationRequest) (*UserOrganization, error)
+	ListOrganizations(ctx context.Context, userID string) ([]UserOrganization, error)
+	SetActiveOrganization(ctx context.Context, userID string, sessionID string, organizationID *string) (*Session, error)
+	DeleteOrganization(ctx context.Context, userID string, organizationID string) error
+	ListOrganizationMembers(ctx context.Context, userID string, organizationID string) ([]OrganizationMember, error)
+	UpdateOrganizationMemberRole(ctx context.Context, userID string, organizationID string, memberUserID string, role OrganizationRole) (*Member, error)
+	RemoveOrganizationMember(ctx context.Context, userID string, organizationID string, memberUserID string) error
+	CreateOrganizationInvitation(ctx context.Context, inviterID string, organizationID string, request CreateInvitationRequest) (*Invitation, error)
+	ListOrganizationInvitations(ctx context.Context, userID string, organizationID string) ([]Invitation, error)
+	AcceptOrganizationInvitation(ctx context.Context, userID string, token string) (*InvitationResult, error)
+	DeclineOrganizationInvitation(ctx context.Context, userID string, token string) (*InvitationResult, error)
 }
 
 type ApiMiddleware struct {
 	AdminAuth     func() func(http.Handler) http.Handler
+	ClientAuth    func() func(http.Handler) http.Handler
+	ForwardAuth   func() func(http.Handler) http.Handler
 	Auth          func() func(http.Handler) http.Handler
 	OptionalAuth  func() func(http.Handler) http.Handler
 	CorsAuth      func() func(http.Handler) http.Handler
 	CSRF          func() func(http.Handler) http.Handler
 	RateLimit     func() func(http.Handler) http.Handler
 	EndpointHooks func() func(http.Handler) http.Handler
+	// RequirePermission authenticates the request and rejects it unless the roles of the user grant the "resource:action" permission
+	RequirePermission func(permission string) func(http.Handler) http.Handler
+	// RequireOrganizationMember authenticates the request and rejects it unless the user is a member of the
+	// {organizationId} of the path or else the active organization, with one of the roles when any are given
+	RequireOrganizationMember func(roles ...OrganizationRole) func(http.Handler) http.Handler
 }
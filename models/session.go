@@ -9,6 +9,10 @@ type Session struct {
 	ExpiresAt time.Time `json:"expires_at"`
 	IPAddress *string   `json:"ip_address,omitempty"`
 	UserAgent *string   `json:"user_agent,omitempty"`
-	CreatedAt time.Time `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	// ImpersonatedBy is the ID of the admin acting as the user through this session
+	ImpersonatedBy *string `json:"impersonated_by,omitempty"`
+	// ActiveOrganizationID is the organization the user is currently working in
+	ActiveOrganizationID *string   `json:"active_organization_id,omitempty"`
+	CreatedAt            time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt            time.Time `json:"updated_at" gorm:"autoUpdateTime"`
 }
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// TwoFactor stores a user's TOTP factor. The secret is encrypted at rest with the application secret.
+// LastUsedStep is the time step of the last accepted code, codes of that or an earlier step are rejected
+// so that a code cannot be replayed within the skew window.
+type TwoFactor struct {
+	ID           string    `json:"id" gorm:"primaryKey"`
+	UserID       string    `json:"user_id" gorm:"uniqueIndex"`
+	Secret       string    `json:"-"`
+	Enabled      bool      `json:"enabled"`
+	LastUsedStep int64     `json:"-"`
+	CreatedAt    time.Time `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt    time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// TwoFactorBackupCode is a single-use recovery code. Only the hash of the code is stored.
+type TwoFactorBackupCode struct {
+	ID        string     `json:"id" gorm:"primaryKey"`
+	UserID    string     `json:"user_id" gorm:"index"`
+	Code      string     `json:"-" gorm:"uniqueIndex"`
+	UsedAt    *time.Time `json:"used_at"`
+	CreatedAt time.Time  `json:"created_at" gorm:"autoCreateTime"`
+}
@@ -3,11 +3,19 @@ package models
 import "time"
 
 type User struct {
-	ID            string    `json:"id" gorm:"primaryKey"`
-	Name          string    `json:"name"`
-	Email         string    `json:"email" gorm:"uniqueIndex"`
-	EmailVerified bool      `json:"email_verified"`
-	Image         *string   `json:"image,omitempty"`
-	CreatedAt     time.Time `json:"created_at" gorm:"autoCreateTime"`
-	UpdatedAt     time.Time `json:"updated_at" gorm:"autoUpdateTime"`
+	ID            string     `json:"id" gorm:"primaryKey"`
+	Name          string     `json:"name"`
+	Email         string     `json:"email" gorm:"uniqueIndex"`
+	EmailVerified bool       `json:"email_verified"`
+	Image         *string    `json:"image,omitempty"`
+	Banned        bool       `json:"banned"`
+	BanReason     *string    `json:"ban_reason,omitempty"`
+	BanExpiresAt  *time.Time `json:"ban_expires_at,omitempty"`
+	CreatedAt     time.Time  `json:"created_at" gorm:"autoCreateTime"`
+	UpdatedAt     time.Time  `json:"updated_at" gorm:"autoUpdateTime"`
+}
+
+// IsBanned reports whether the user is locked out. A ban with an expiry is lifted once it passes.
+func (u *User) IsBanned() bool {
+	return u.Banned && (u.BanExpiresAt == nil || time.Now().Before(*u.BanExpiresAt))
 }
@@ -8,6 +8,7 @@ const (
 	TypeEmailVerification VerificationType = "email_verification"
 	TypePasswordReset     VerificationType = "password_reset"
 	TypeEmailChange       VerificationType = "email_change"
+	TypeMagicLink         VerificationType = "magic_link"
 )
 
 type Verification struct {
@@ -0,0 +1,177 @@
+package oauth2providers
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/GoBetterAuth/go-better-auth/internal/jose"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+const (
+	appleIssuer = "https://appleid.apple.com"
+	// appleClientSecretExpiresIn is the lifetime of the generated client secrets, Apple accepts up to six months
+	appleClientSecretExpiresIn = time.Hour
+)
+
+// appleDiscovery is fixed so that building the provider does not need to reach Apple.
+var appleDiscovery = models.OIDCDiscoveryDocument{
+	Issuer:                appleIssuer,
+	AuthorizationEndpoint: appleIssuer + "/auth/authorize",
+	TokenEndpoint:         appleIssuer + "/auth/token",
+	JWKSURI:               appleIssuer + "/auth/keys",
+}
+
+// AppleProvider implements Sign in with Apple. Apple has no user info endpoint, users are read from
+// the ID token, and the client secret is a JWT signed with the private key of the developer account.
+type AppleProvider struct {
+	config     *models.OAuth2ProviderConfig
+	privateKey *ecdsa.PrivateKey
+	verifier   *OIDCProvider
+}
+
+func NewAppleProvider(config *models.OAuth2ProviderConfig) (*AppleProvider, error) {
+	if envClientID := os.Getenv("APPLE_CLIENT_ID"); envClientID != "" {
+		config.ClientID = envClientID
+	}
+	if envTeamID := os.Getenv("APPLE_TEAM_ID"); envTeamID != "" {
+		config.TeamID = envTeamID
+	}
+	if envKeyID := os.Getenv("APPLE_KEY_ID"); envKeyID != "" {
+		config.KeyID = envKeyID
+	}
+	if envPrivateKey := os.Getenv("APPLE_PRIVATE_KEY"); envPrivateKey != "" {
+		config.PrivateKey = envPrivateKey
+	}
+
+	if config.TeamID == "" || config.KeyID == "" {
+		return nil, errors.New("apple provider requires a team ID and key ID")
+	}
+	privateKey, err := parseApplePrivateKey(config.PrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	provider := &AppleProvider{
+		config:     config,
+		privateKey: privateKey,
+		verifier:   &OIDCProvider{name: "apple", config: config, discovery: &appleDiscovery},
+	}
+
+	// A key that cannot sign client secrets would only surface as failed sign-ins, so it is rejected up front
+	if _, err := provider.clientSecret(); err != nil {
+		return nil, fmt.Errorf("sign apple client secret: %w", err)
+	}
+
+	return provider, nil
+}
+
+func (p *AppleProvider) GetName() string {
+	return "apple"
+}
+
+func (p *AppleProvider) GetConfig() *oauth2.Config {
+	// The key was checked when the provider was built, so signing only fails if the system
+	// runs out of randomness. The empty secret is then rejected by Apple as an invalid client.
+	clientSecret, _ := p.clientSecret()
+	return p.oauth2Config(clientSecret)
+}
+
+func (p *AppleProvider) oauth2Config(clientSecret string) *oauth2.Config {
+	var scopes []string
+	if len(p.config.Scopes) > 0 {
+		scopes = p.config.Scopes
+	} else {
+		scopes = []string{
+			"name",
+			"email",
+		}
+	}
+
+	return &oauth2.Config{
+		ClientID:     p.config.ClientID,
+		ClientSecret: clientSecret,
+		RedirectURL:  p.config.RedirectURL,
+		Scopes:       scopes,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:   appleDiscovery.AuthorizationEndpoint,
+			TokenURL:  appleDiscovery.TokenEndpoint,
+			AuthStyle: oauth2.AuthStyleInParams,
+		},
+	}
+}
+
+func (p *AppleProvider) RequiresPKCE() bool {
+	return false
+}
+
+func (p *AppleProvider) GetAuthURL(state string, opts ...oauth2.AuthCodeOption) string {
+	// Apple requires the callback to be a form post whenever the name or email scope is requested
+	opts = append(opts, oauth2.SetAuthURLParam("response_mode", "form_post"))
+	return p.oauth2Config("").AuthCodeURL(state, opts...)
+}
+
+func (p *AppleProvider) Exchange(ctx context.Context, code string, opts ...oauth2.AuthCodeOption) (*oauth2.Token, error) {
+	clientSecret, err := p.clientSecret()
+	if err != nil {
+		return nil, fmt.Errorf("sign apple client secret: %w", err)
+	}
+
+	return p.oauth2Config(clientSecret).Exchange(ctx, code, opts...)
+}
+
+func (p *AppleProvider) GetUserInfo(ctx context.Context, token *oauth2.Token) (*models.OAuth2UserInfo, error) {
+	return nil, errors.New("apple has no user info endpoint, users are read from the id token")
+}
+
+// VerifyIDToken verifies an ID token issued by Apple. Apple only includes the user's name in the
+// first callback, not in the ID token, so users signing up with Apple start without a name.
+func (p *AppleProvider) VerifyIDToken(ctx context.Context, rawIDToken string, nonce string) (*models.OAuth2UserInfo, error) {
+	return p.verifier.VerifyIDToken(ctx, rawIDToken, nonce)
+}
+
+// clientSecret signs the JWT Apple expects as client secret.
+func (p *AppleProvider) clientSecret() (string, error) {
+	now := time.Now()
+	return jose.Sign(jose.AlgES256, p.config.KeyID, p.privateKey, map[string]any{
+		"iss": p.config.TeamID,
+		"sub": p.config.ClientID,
+		"aud": appleIssuer,
+		"iat": now.Unix(),
+		"exp": now.Add(appleClientSecretExpiresIn).Unix(),
+	})
+}
+
+// parseApplePrivateKey decodes the .p8 key downloaded from the Apple developer account. Environment
+// variables often hold it with escaped newlines.
+func parseApplePrivateKey(encoded string) (*ecdsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(strings.ReplaceAll(encoded, `\n`, "\n")))
+	if block == nil {
+		return nil, errors.New("apple private key is not PEM encoded")
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parse apple private key: %w", err)
+	}
+	ecdsaKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("apple private key must be an ECDSA key, got %T", key)
+	}
+	// Client secrets are signed with ES256, which needs a P-256 key
+	if ecdsaKey.Curve != elliptic.P256() {
+		return nil, fmt.Errorf("apple private key must use the P-256 curve, got %s", ecdsaKey.Curve.Params().Name)
+	}
+
+	return ecdsaKey, nil
+}
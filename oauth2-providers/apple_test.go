@@ -0,0 +1,83 @@
+package oauth2providers
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"strings"
+	"testing"
+
+	"github.com/GoBetterAuth/go-better-auth/internal/jose"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+func TestAppleProvider_ClientSecret(t *testing.T) {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	der, _ := x509.MarshalPKCS8PrivateKey(key)
+	encoded := string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+
+	provider, err := NewAppleProvider(&models.OAuth2ProviderConfig{
+		ClientID: "com.example.web",
+		TeamID:   "team-1",
+		KeyID:    "key-1",
+		// Keys read from environment variables often have escaped newlines
+		PrivateKey: strings.ReplaceAll(encoded, "\n", `\n`),
+	})
+	if err != nil {
+		t.Fatalf("NewAppleProvider failed: %v", err)
+	}
+
+	clientSecret := provider.GetConfig().ClientSecret
+	header, err := jose.ParseHeader(clientSecret)
+	if err != nil {
+		t.Fatalf("ParseHeader failed: %v", err)
+	}
+	if header.Algorithm != jose.AlgES256 || header.KeyID != "key-1" {
+		t.Fatalf("unexpected header: %+v", header)
+	}
+
+	claims, err := jose.Verify(clientSecret, jose.AlgES256, key.Public())
+	if err != nil {
+		t.Fatalf("Verify failed: %v", err)
+	}
+	if claims["iss"] != "team-1" || claims["sub"] != "com.example.web" || claims["aud"] != appleIssuer {
+		t.Fatalf("unexpected claims: %v", claims)
+	}
+
+	if !strings.Contains(provider.GetAuthURL("state"), "response_mode=form_post") {
+		t.Fatal("expected apple to use form_post callbacks")
+	}
+}
+
+func TestNewAppleProvider_InvalidKey(t *testing.T) {
+	_, err := NewAppleProvider(&models.OAuth2ProviderConfig{TeamID: "team-1", KeyID: "key-1", PrivateKey: "not a key"})
+	if err == nil {
+		t.Fatal("expected an invalid private key to be rejected")
+	}
+
+	// ES256 client secrets cannot be signed with keys of other curves
+	key, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	der, _ := x509.MarshalPKCS8PrivateKey(key)
+	_, err = NewAppleProvider(&models.OAuth2ProviderConfig{
+		TeamID:     "team-1",
+		KeyID:      "key-1",
+		PrivateKey: string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})),
+	})
+	if err == nil {
+		t.Fatal("expected a P-384 private key to be rejected")
+	}
+}
+
+func TestProviderEndpoints(t *testing.T) {
+	microsoft := NewMicrosoftProvider(&models.OAuth2ProviderConfig{TenantID: "contoso.onmicrosoft.com"})
+	if got := microsoft.GetConfig().Endpoint.TokenURL; got != "https://login.microsoftonline.com/contoso.onmicrosoft.com/oauth2/v2.0/token" {
+		t.Fatalf("unexpected microsoft token URL: %s", got)
+	}
+
+	gitlab := NewGitLabProvider(&models.OAuth2ProviderConfig{BaseURL: "https://gitlab.example.com/"})
+	if got := gitlab.GetConfig().Endpoint.AuthURL; got != "https://gitlab.example.com/oauth/authorize" {
+		t.Fatalf("unexpected gitlab auth URL: %s", got)
+	}
+}
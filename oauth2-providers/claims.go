@@ -0,0 +1,83 @@
+package oauth2providers
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+// defaultClaimPaths are the standard OpenID Connect claims.
+var defaultClaimPaths = models.OAuth2ClaimPaths{
+	ID:            "sub",
+	Email:         "email",
+	EmailVerified: "email_verified",
+	Name:          "name",
+	Picture:       "picture",
+}
+
+// lookupClaim returns the claim at a dot separated path.
+func lookupClaim(claims map[string]any, path string) (any, bool) {
+	var value any = claims
+	for _, key := range strings.Split(path, ".") {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if value, ok = object[key]; !ok {
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// claimString returns the claim at path as a string. Numeric IDs are formatted without exponent.
+func claimString(claims map[string]any, path string) string {
+	value, ok := lookupClaim(claims, path)
+	if !ok {
+		return ""
+	}
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return fmt.Sprintf("%.0f", v)
+	default:
+		return ""
+	}
+}
+
+// claimBool returns the claim at path as a boolean. Some providers send booleans as strings.
+func claimBool(claims map[string]any, path string) bool {
+	value, ok := lookupClaim(claims, path)
+	if !ok {
+		return false
+	}
+	switch v := value.(type) {
+	case bool:
+		return v
+	case string:
+		return v == "true"
+	default:
+		return false
+	}
+}
+
+// mapClaims builds the user info from claims, using the configured paths and the standard claims otherwise.
+func mapClaims(claims map[string]any, paths models.OAuth2ClaimPaths) *models.OAuth2UserInfo {
+	pathOr := func(path string, fallback string) string {
+		if path != "" {
+			return path
+		}
+		return fallback
+	}
+
+	return &models.OAuth2UserInfo{
+		ID:       claimString(claims, pathOr(paths.ID, defaultClaimPaths.ID)),
+		Email:    claimString(claims, pathOr(paths.Email, defaultClaimPaths.Email)),
+		Verified: claimBool(claims, pathOr(paths.EmailVerified, defaultClaimPaths.EmailVerified)),
+		Name:     claimString(claims, pathOr(paths.Name, defaultClaimPaths.Name)),
+		Picture:  claimString(claims, pathOr(paths.Picture, defaultClaimPaths.Picture)),
+		Raw:      claims,
+	}
+}
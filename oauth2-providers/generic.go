@@ -42,7 +42,7 @@ func (p *GenericProvider) GetConfig() *oauth2.Config {
 }
 
 func (p *GenericProvider) RequiresPKCE() bool {
-	return false // Default to false for generic providers, can be made configurable if needed
+	return p.config.PKCE
 }
 
 func (p *GenericProvider) GetAuthURL(state string, opts ...oauth2.AuthCodeOption) string {
@@ -79,29 +79,16 @@ func (p *GenericProvider) GetUserInfo(ctx context.Context, token *oauth2.Token)
 		return nil, err
 	}
 
-	// Try to extract common fields
-	userInfo := &models.OAuth2UserInfo{
-		Raw: data,
-	}
-
-	if id, ok := data["id"].(string); ok {
-		userInfo.ID = id
-	} else if id, ok := data["sub"].(string); ok {
-		userInfo.ID = id
-	}
+	userInfo := mapClaims(data, p.config.ClaimPaths)
 
-	if email, ok := data["email"].(string); ok {
-		userInfo.Email = email
+	// Without configured paths, also try the fields common to non OpenID Connect providers
+	if p.config.ClaimPaths.ID == "" {
+		if id := claimString(data, "id"); id != "" {
+			userInfo.ID = id
+		}
 	}
-
-	if name, ok := data["name"].(string); ok {
-		userInfo.Name = name
-	}
-
-	if picture, ok := data["picture"].(string); ok {
-		userInfo.Picture = picture
-	} else if avatar, ok := data["avatar_url"].(string); ok {
-		userInfo.Picture = avatar
+	if p.config.ClaimPaths.Picture == "" && userInfo.Picture == "" {
+		userInfo.Picture = claimString(data, "avatar_url")
 	}
 
 	return userInfo, nil
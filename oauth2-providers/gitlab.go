@@ -0,0 +1,127 @@
+package oauth2providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"golang.org/x/oauth2"
+
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+type GitLabUser struct {
+	ID          int     `json:"id"`
+	Username    string  `json:"username"`
+	Name        string  `json:"name"`
+	Email       string  `json:"email"`
+	AvatarURL   string  `json:"avatar_url"`
+	ConfirmedAt *string `json:"confirmed_at"`
+}
+
+type GitLabProvider struct {
+	config *models.OAuth2ProviderConfig
+}
+
+func NewGitLabProvider(config *models.OAuth2ProviderConfig) *GitLabProvider {
+	if envClientID := os.Getenv("GITLAB_CLIENT_ID"); envClientID != "" {
+		config.ClientID = envClientID
+	}
+	if envClientSecret := os.Getenv("GITLAB_CLIENT_SECRET"); envClientSecret != "" {
+		config.ClientSecret = envClientSecret
+	}
+	if envBaseURL := os.Getenv("GITLAB_BASE_URL"); envBaseURL != "" {
+		config.BaseURL = envBaseURL
+	}
+	return &GitLabProvider{config: config}
+}
+
+func (p *GitLabProvider) GetName() string {
+	return "gitlab"
+}
+
+func (p *GitLabProvider) GetConfig() *oauth2.Config {
+	var scopes []string
+	if len(p.config.Scopes) > 0 {
+		scopes = p.config.Scopes
+	} else {
+		scopes = []string{
+			"read_user",
+		}
+	}
+
+	return &oauth2.Config{
+		ClientID:     p.config.ClientID,
+		ClientSecret: p.config.ClientSecret,
+		RedirectURL:  p.config.RedirectURL,
+		Scopes:       scopes,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  p.baseURL() + "/oauth/authorize",
+			TokenURL: p.baseURL() + "/oauth/token",
+		},
+	}
+}
+
+func (p *GitLabProvider) RequiresPKCE() bool {
+	return true
+}
+
+func (p *GitLabProvider) GetAuthURL(state string, opts ...oauth2.AuthCodeOption) string {
+	return p.GetConfig().AuthCodeURL(state, opts...)
+}
+
+func (p *GitLabProvider) Exchange(ctx context.Context, code string, opts ...oauth2.AuthCodeOption) (*oauth2.Token, error) {
+	return p.GetConfig().Exchange(ctx, code, opts...)
+}
+
+func (p *GitLabProvider) GetUserInfo(ctx context.Context, token *oauth2.Token) (*models.OAuth2UserInfo, error) {
+	client := oauth2.NewClient(ctx, oauth2.StaticTokenSource(token))
+	resp, err := client.Get(p.baseURL() + "/api/v4/user")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("gitlab user info returned status: %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var gitlabUser GitLabUser
+	if err := json.Unmarshal(body, &gitlabUser); err != nil {
+		return nil, err
+	}
+
+	var raw map[string]any
+	_ = json.Unmarshal(body, &raw)
+
+	name := gitlabUser.Name
+	if name == "" {
+		name = gitlabUser.Username
+	}
+
+	return &models.OAuth2UserInfo{
+		ID:       fmt.Sprintf("%d", gitlabUser.ID),
+		Email:    gitlabUser.Email,
+		Name:     name,
+		Picture:  gitlabUser.AvatarURL,
+		Verified: gitlabUser.ConfirmedAt != nil && *gitlabUser.ConfirmedAt != "",
+		Raw:      raw,
+	}, nil
+}
+
+// baseURL returns the URL of the GitLab instance, gitlab.com unless self-hosted.
+func (p *GitLabProvider) baseURL() string {
+	if p.config.BaseURL == "" {
+		return "https://gitlab.com"
+	}
+	return strings.TrimSuffix(p.config.BaseURL, "/")
+}
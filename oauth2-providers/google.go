@@ -21,6 +21,14 @@ type GoogleUser struct {
 	Picture       string `json:"picture"`
 }
 
+// googleDiscovery is fixed so that building the provider does not need to reach Google.
+var googleDiscovery = models.OIDCDiscoveryDocument{
+	Issuer:                "https://accounts.google.com",
+	AuthorizationEndpoint: "https://accounts.google.com/o/oauth2/auth",
+	TokenEndpoint:         "https://oauth2.googleapis.com/token",
+	JWKSURI:               "https://www.googleapis.com/oauth2/v3/certs",
+}
+
 type GoogleProvider struct {
 	config *models.OAuth2ProviderConfig
 }
@@ -109,3 +117,15 @@ func (p *GoogleProvider) GetUserInfo(ctx context.Context, token *oauth2.Token) (
 		Raw:      raw,
 	}, nil
 }
+
+// VerifyIDToken verifies an ID token issued by Google, such as those of Google One Tap and the
+// Android and iOS sign in SDKs.
+func (p *GoogleProvider) VerifyIDToken(ctx context.Context, rawIDToken string, nonce string) (*models.OAuth2UserInfo, error) {
+	verifier := &OIDCProvider{
+		name:          "google",
+		config:        p.config,
+		discovery:     &googleDiscovery,
+		issuerAliases: []string{"accounts.google.com"},
+	}
+	return verifier.VerifyIDToken(ctx, rawIDToken, nonce)
+}
@@ -0,0 +1,166 @@
+package oauth2providers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/google/uuid"
+	"golang.org/x/oauth2"
+
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+const (
+	microsoftLoginURL = "https://login.microsoftonline.com/"
+	// microsoftConsumersTenantID is the tenant of all personal Microsoft accounts
+	microsoftConsumersTenantID = "9188040d-6c67-4c5b-b112-36a304b66dad"
+)
+
+// MicrosoftProvider signs users in with Microsoft Entra ID accounts.
+type MicrosoftProvider struct {
+	config *models.OAuth2ProviderConfig
+}
+
+func NewMicrosoftProvider(config *models.OAuth2ProviderConfig) *MicrosoftProvider {
+	if envClientID := os.Getenv("MICROSOFT_CLIENT_ID"); envClientID != "" {
+		config.ClientID = envClientID
+	}
+	if envClientSecret := os.Getenv("MICROSOFT_CLIENT_SECRET"); envClientSecret != "" {
+		config.ClientSecret = envClientSecret
+	}
+	if envTenantID := os.Getenv("MICROSOFT_TENANT_ID"); envTenantID != "" {
+		config.TenantID = envTenantID
+	}
+	return &MicrosoftProvider{config: config}
+}
+
+func (p *MicrosoftProvider) GetName() string {
+	return "microsoft"
+}
+
+func (p *MicrosoftProvider) GetConfig() *oauth2.Config {
+	var scopes []string
+	if len(p.config.Scopes) > 0 {
+		scopes = p.config.Scopes
+	} else {
+		scopes = []string{
+			"openid",
+			"email",
+			"profile",
+		}
+	}
+
+	tenant := p.tenant()
+
+	return &oauth2.Config{
+		ClientID:     p.config.ClientID,
+		ClientSecret: p.config.ClientSecret,
+		RedirectURL:  p.config.RedirectURL,
+		Scopes:       scopes,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  microsoftLoginURL + tenant + "/oauth2/v2.0/authorize",
+			TokenURL: microsoftLoginURL + tenant + "/oauth2/v2.0/token",
+		},
+	}
+}
+
+// tenant returns the tenant users sign in to. "common" accepts work, school and personal accounts,
+// "organizations" and "consumers" only one kind, and a tenant ID or domain only the accounts of that tenant.
+func (p *MicrosoftProvider) tenant() string {
+	if p.config.TenantID == "" {
+		return "common"
+	}
+	return p.config.TenantID
+}
+
+func (p *MicrosoftProvider) RequiresPKCE() bool {
+	return true
+}
+
+func (p *MicrosoftProvider) GetAuthURL(state string, opts ...oauth2.AuthCodeOption) string {
+	return p.GetConfig().AuthCodeURL(state, opts...)
+}
+
+func (p *MicrosoftProvider) Exchange(ctx context.Context, code string, opts ...oauth2.AuthCodeOption) (*oauth2.Token, error) {
+	return p.GetConfig().Exchange(ctx, code, opts...)
+}
+
+func (p *MicrosoftProvider) GetUserInfo(ctx context.Context, token *oauth2.Token) (*models.OAuth2UserInfo, error) {
+	client := oauth2.NewClient(ctx, oauth2.StaticTokenSource(token))
+	resp, err := client.Get("https://graph.microsoft.com/oidc/userinfo")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("microsoft user info returned status: %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var data map[string]any
+	if err := json.Unmarshal(body, &data); err != nil {
+		return nil, err
+	}
+
+	userInfo := mapClaims(data, models.OAuth2ClaimPaths{})
+	// Tenant administrators can set any email on their accounts, so Entra ID emails are never
+	// treated as verified. The picture claim is a Graph URL that needs an access token to load.
+	userInfo.Verified = false
+	userInfo.Picture = ""
+
+	return userInfo, nil
+}
+
+// VerifyIDToken verifies an ID token issued by Microsoft Entra ID. ID tokens of the "common" and
+// "organizations" tenants are issued by the tenant of each user, which the tid claim names.
+func (p *MicrosoftProvider) VerifyIDToken(ctx context.Context, rawIDToken string, nonce string) (*models.OAuth2UserInfo, error) {
+	tenant := p.tenant()
+
+	var issuer string
+	switch tenant {
+	case "common", "organizations":
+		issuer = microsoftLoginURL + "{tenantid}/v2.0"
+	case "consumers":
+		issuer = microsoftLoginURL + microsoftConsumersTenantID + "/v2.0"
+	default:
+		// The issuer of a tenant domain is only known by its ID, which the domain cannot be checked against here
+		if _, err := uuid.Parse(tenant); err != nil {
+			return nil, fmt.Errorf("microsoft id tokens can only be verified with a tenant ID, not %q", tenant)
+		}
+		issuer = microsoftLoginURL + tenant + "/v2.0"
+	}
+
+	verifier := &OIDCProvider{
+		name:   "microsoft",
+		config: p.config,
+		discovery: &models.OIDCDiscoveryDocument{
+			Issuer:  issuer,
+			JWKSURI: microsoftLoginURL + tenant + "/discovery/v2.0/keys",
+		},
+	}
+	userInfo, err := verifier.VerifyIDToken(ctx, rawIDToken, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	// "organizations" only accepts work and school accounts
+	if tenant == "organizations" && userInfo.Raw["tid"] == microsoftConsumersTenantID {
+		return nil, errors.New("personal microsoft accounts are not accepted")
+	}
+
+	// As with the user info endpoint, Entra ID emails are never treated as verified
+	userInfo.Verified = false
+	userInfo.Picture = ""
+
+	return userInfo, nil
+}
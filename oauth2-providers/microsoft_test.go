@@ -0,0 +1,89 @@
+package oauth2providers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/GoBetterAuth/go-better-auth/internal/jose"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+func TestMicrosoftProvider_VerifyIDToken(t *testing.T) {
+	key, err := jose.GenerateKey(jose.AlgRS256)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+	jwk, err := jose.PublicJWK("kid-1", jose.AlgRS256, key.Public())
+	if err != nil {
+		t.Fatalf("PublicJWK failed: %v", err)
+	}
+
+	// Seed the key sets so that the test does not reach Microsoft
+	const tenantID = "72f988bf-86f1-41af-91ab-2d7cd011db47"
+	oidcCache.mu.Lock()
+	for _, tenant := range []string{"common", "organizations", tenantID} {
+		oidcCache.keySets[microsoftLoginURL+tenant+"/discovery/v2.0/keys"] = cachedKeySet{
+			keys:      map[string]models.JSONWebKey{"kid-1": jwk},
+			fetchedAt: time.Now(),
+		}
+	}
+	oidcCache.mu.Unlock()
+
+	sign := func(tid string, iss string) string {
+		token, err := jose.Sign(jose.AlgRS256, "kid-1", key, map[string]any{
+			"iss":            iss,
+			"tid":            tid,
+			"aud":            "client-1",
+			"sub":            "user-1",
+			"exp":            time.Now().Add(time.Hour).Unix(),
+			"iat":            time.Now().Unix(),
+			"nonce":          "nonce-1",
+			"email":          "user@example.com",
+			"email_verified": true,
+		})
+		if err != nil {
+			t.Fatalf("Sign failed: %v", err)
+		}
+		return token
+	}
+	issuer := func(tid string) string { return microsoftLoginURL + tid + "/v2.0" }
+
+	tests := []struct {
+		name    string
+		tenant  string
+		token   string
+		wantErr bool
+	}{
+		{"common accepts any tenant", "", sign(tenantID, issuer(tenantID)), false},
+		{"common accepts personal accounts", "common", sign(microsoftConsumersTenantID, issuer(microsoftConsumersTenantID)), false},
+		{"issuer must match the tenant claim", "common", sign(tenantID, issuer(microsoftConsumersTenantID)), true},
+		{"organizations rejects personal accounts", "organizations", sign(microsoftConsumersTenantID, issuer(microsoftConsumersTenantID)), true},
+		{"tenant ID accepts its own tokens", tenantID, sign(tenantID, issuer(tenantID)), false},
+		{"tenant ID rejects other tenants", tenantID, sign(microsoftConsumersTenantID, issuer(microsoftConsumersTenantID)), true},
+		{"tenant domain cannot be verified", "contoso.onmicrosoft.com", sign(tenantID, issuer(tenantID)), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider := NewMicrosoftProvider(&models.OAuth2ProviderConfig{ClientID: "client-1", TenantID: tt.tenant})
+
+			userInfo, err := provider.VerifyIDToken(context.Background(), tt.token, "nonce-1")
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected the id token to be rejected")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("VerifyIDToken failed: %v", err)
+			}
+			if userInfo.ID != "user-1" || userInfo.Email != "user@example.com" {
+				t.Fatalf("unexpected user info: %+v", userInfo)
+			}
+			if userInfo.Verified {
+				t.Fatal("expected microsoft emails to never be treated as verified")
+			}
+		})
+	}
+}
@@ -7,10 +7,14 @@ import (
 	"github.com/GoBetterAuth/go-better-auth/models"
 )
 
+// OAuth2ProviderRegistry holds the providers built from the config. They are built once at startup and
+// again whenever the config changes, never on lookup.
 type OAuth2ProviderRegistry struct {
 	config    *models.Config
 	mu        sync.RWMutex
 	providers map[string]OAuth2Provider
+	// refreshMu serializes rebuilds, which may reach the network, without blocking lookups
+	refreshMu sync.Mutex
 }
 
 func NewOAuth2ProviderRegistry(config *models.Config) *OAuth2ProviderRegistry {
@@ -27,16 +31,35 @@ func (r *OAuth2ProviderRegistry) Register(provider OAuth2Provider) {
 }
 
 func (r *OAuth2ProviderRegistry) Get(name string) (OAuth2Provider, error) {
-	r.RefreshOAuth2Providers()
-
 	r.mu.RLock()
-	defer r.mu.RUnlock()
-
 	provider, ok := r.providers[name]
-	if !ok {
+	r.mu.RUnlock()
+	if ok {
+		return provider, nil
+	}
+
+	// A provider whose issuer was unreachable when the registry was built is retried on lookup.
+	// Discovery failures are cached with a backoff, so this does not reach the issuer on every request.
+	providerConfig, ok := r.config.SocialProviders.Providers[name]
+	if !ok || !providerConfig.Enabled {
 		return nil, fmt.Errorf("provider %s not found", name)
 	}
 
+	provider, err := buildProvider(name, providerConfig)
+	if err != nil {
+		return nil, fmt.Errorf("provider %s is not available: %w", name, err)
+	}
+	if provider == nil {
+		return nil, fmt.Errorf("provider %s not found", name)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if existing, ok := r.providers[provider.GetName()]; ok {
+		return existing, nil
+	}
+	r.providers[provider.GetName()] = provider
+
 	return provider, nil
 }
 
@@ -46,33 +69,71 @@ func (r *OAuth2ProviderRegistry) Clear() {
 	r.providers = make(map[string]OAuth2Provider)
 }
 
+// RefreshOAuth2Providers rebuilds the providers from the config. It must be called again after the config changes.
+// The providers are built without holding the lock, as OIDC providers may need to fetch their discovery document.
 func (r *OAuth2ProviderRegistry) RefreshOAuth2Providers() {
-	r.mu.Lock()
-	defer r.mu.Unlock()
-
-	r.providers = make(map[string]OAuth2Provider)
+	r.refreshMu.Lock()
+	defer r.refreshMu.Unlock()
 
+	providers := make(map[string]OAuth2Provider)
 	for name, providerConfig := range r.config.SocialProviders.Providers {
 		if !providerConfig.Enabled {
 			continue
 		}
 
-		var provider OAuth2Provider
-		switch name {
-		case "google":
-			provider = NewGoogleProvider(&providerConfig)
-		case "github":
-			provider = NewGitHubProvider(&providerConfig)
-		case "discord":
-			provider = NewDiscordProvider(&providerConfig)
-		default:
-			if providerConfig.AuthURL != "" && providerConfig.TokenURL != "" {
-				provider = NewGenericProvider(name, &providerConfig)
-			}
+		provider, err := buildProvider(name, providerConfig)
+		if err != nil {
+			r.logConfigurationError(name, err)
+			continue
 		}
-
 		if provider != nil {
-			r.providers[provider.GetName()] = provider
+			providers[provider.GetName()] = provider
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.providers = providers
+}
+
+// buildProvider returns the provider configured under name, or nil if the config does not describe one.
+func buildProvider(name string, providerConfig models.OAuth2ProviderConfig) (OAuth2Provider, error) {
+	switch name {
+	case "google":
+		return NewGoogleProvider(&providerConfig), nil
+	case "github":
+		return NewGitHubProvider(&providerConfig), nil
+	case "discord":
+		return NewDiscordProvider(&providerConfig), nil
+	case "gitlab":
+		return NewGitLabProvider(&providerConfig), nil
+	case "microsoft":
+		return NewMicrosoftProvider(&providerConfig), nil
+	case "apple":
+		appleProvider, err := NewAppleProvider(&providerConfig)
+		if err != nil {
+			return nil, err
+		}
+		return appleProvider, nil
+	default:
+		if providerConfig.Type == models.OAuth2ProviderTypeOIDC {
+			oidcProvider, err := NewOIDCProvider(name, &providerConfig)
+			if err != nil {
+				return nil, err
+			}
+			return oidcProvider, nil
 		}
+		if providerConfig.AuthURL != "" && providerConfig.TokenURL != "" {
+			return NewGenericProvider(name, &providerConfig), nil
+		}
+		return nil, nil
+	}
+}
+
+// logConfigurationError reports a provider that could not be built. A misconfigured provider or
+// unreachable issuer only disables that provider.
+func (r *OAuth2ProviderRegistry) logConfigurationError(name string, err error) {
+	if r.config.Logger.Logger != nil {
+		r.config.Logger.Logger.Error("failed to configure oauth2 provider", "provider", name, "error", err)
 	}
 }
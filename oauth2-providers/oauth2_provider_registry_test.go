@@ -0,0 +1,93 @@
+package oauth2providers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+func TestOAuth2ProviderRegistry_BuildsProvidersOnRefresh(t *testing.T) {
+	issuer := newTestIssuer(t)
+	config := &models.Config{
+		SocialProviders: models.SocialProvidersConfig{
+			Providers: map[string]models.OAuth2ProviderConfig{
+				"github": {Enabled: true, ClientID: "client-1"},
+				"corp":   {Enabled: true, Type: models.OAuth2ProviderTypeOIDC, Issuer: issuer.server.URL, ClientID: "client-1"},
+			},
+		},
+	}
+
+	registry := NewOAuth2ProviderRegistry(config)
+	registry.RefreshOAuth2Providers()
+
+	github, err := registry.Get("github")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if _, err := registry.Get("corp"); err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+
+	// Lookups return the providers built by the last refresh instead of rebuilding them
+	if again, _ := registry.Get("github"); again != github {
+		t.Fatal("expected lookups to reuse the built provider")
+	}
+
+	config.SocialProviders.Providers["github"] = models.OAuth2ProviderConfig{Enabled: false}
+	if _, err := registry.Get("github"); err != nil {
+		t.Fatal("expected the provider to stay available until the registry is refreshed")
+	}
+	registry.RefreshOAuth2Providers()
+	if _, err := registry.Get("github"); err == nil {
+		t.Fatal("expected a disabled provider to be removed on refresh")
+	}
+	if _, err := registry.Get("unknown"); err == nil {
+		t.Fatal("expected an unknown provider to be rejected")
+	}
+}
+
+func TestOAuth2ProviderRegistry_RetriesUnavailableIssuerWithBackoff(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+
+	config := &models.Config{
+		SocialProviders: models.SocialProvidersConfig{
+			Providers: map[string]models.OAuth2ProviderConfig{
+				"corp": {Enabled: true, Type: models.OAuth2ProviderTypeOIDC, Issuer: server.URL, ClientID: "client-1"},
+			},
+		},
+	}
+
+	registry := NewOAuth2ProviderRegistry(config)
+	registry.RefreshOAuth2Providers()
+
+	for range 3 {
+		if _, err := registry.Get("corp"); err == nil {
+			t.Fatal("expected a provider with an unreachable issuer to be unavailable")
+		}
+	}
+	if got := requests.Load(); got != 1 {
+		t.Fatalf("expected the failed discovery to be cached, got %d requests", got)
+	}
+
+	// Once the backoff has passed the issuer is tried again on lookup
+	oidcCache.mu.Lock()
+	cached := oidcCache.discovery[server.URL]
+	cached.retryAt = cached.retryAt.Add(-oidcDiscoveryMaxBackoff)
+	oidcCache.discovery[server.URL] = cached
+	oidcCache.mu.Unlock()
+
+	if _, err := registry.Get("corp"); err == nil {
+		t.Fatal("expected a provider with an unreachable issuer to be unavailable")
+	}
+	if got := requests.Load(); got != 2 {
+		t.Fatalf("expected the issuer to be retried after the backoff, got %d requests", got)
+	}
+}
@@ -0,0 +1,354 @@
+package oauth2providers
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/GoBetterAuth/go-better-auth/internal/jose"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+const (
+	// oidcCacheTTL is how long discovery documents and key sets are reused before being fetched again
+	oidcCacheTTL = time.Hour
+	// oidcKeyRefreshInterval limits how often an unknown key ID makes the key set be fetched again
+	oidcKeyRefreshInterval = time.Minute
+	// oidcClockSkew is the leeway given to the time based claims of ID tokens
+	oidcClockSkew = time.Minute
+	// oidcDiscoveryBackoff is how long a failed discovery is remembered before the issuer is tried again,
+	// doubling with each consecutive failure up to oidcDiscoveryMaxBackoff
+	oidcDiscoveryBackoff    = 5 * time.Second
+	oidcDiscoveryMaxBackoff = 5 * time.Minute
+)
+
+var oidcHTTPClient = &http.Client{Timeout: 10 * time.Second}
+
+// Discovery documents and key sets are cached per issuer for the lifetime of the process, so that rebuilding
+// the providers on a config change or retrying an unavailable one only reaches issuers when needed.
+var oidcCache = struct {
+	mu        sync.Mutex
+	discovery map[string]cachedDiscovery
+	keySets   map[string]cachedKeySet
+}{
+	discovery: make(map[string]cachedDiscovery),
+	keySets:   make(map[string]cachedKeySet),
+}
+
+type cachedDiscovery struct {
+	document  *models.OIDCDiscoveryDocument
+	fetchedAt time.Time
+	// err is the last failed fetch, returned until retryAt unless an earlier document can be served instead
+	err      error
+	failures int
+	retryAt  time.Time
+}
+
+type cachedKeySet struct {
+	keys      map[string]models.JSONWebKey
+	fetchedAt time.Time
+}
+
+// OIDCProvider is an OpenID Connect provider configured from its issuer's discovery document.
+type OIDCProvider struct {
+	name      string
+	config    *models.OAuth2ProviderConfig
+	discovery *models.OIDCDiscoveryDocument
+	// issuerAliases are other iss values the issuer is known to use in its ID tokens
+	issuerAliases []string
+}
+
+// NewOIDCProvider discovers the endpoints of the configured issuer.
+func NewOIDCProvider(name string, config *models.OAuth2ProviderConfig) (*OIDCProvider, error) {
+	discovery, err := discover(config.Issuer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OIDCProvider{
+		name:      name,
+		config:    config,
+		discovery: discovery,
+	}, nil
+}
+
+func (p *OIDCProvider) GetName() string {
+	return p.name
+}
+
+func (p *OIDCProvider) GetConfig() *oauth2.Config {
+	scopes := p.config.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{models.ScopeOpenID, models.ScopeEmail, models.ScopeProfile}
+	}
+
+	return &oauth2.Config{
+		ClientID:     p.config.ClientID,
+		ClientSecret: p.config.ClientSecret,
+		RedirectURL:  p.config.RedirectURL,
+		Scopes:       scopes,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  p.discovery.AuthorizationEndpoint,
+			TokenURL: p.discovery.TokenEndpoint,
+		},
+	}
+}
+
+func (p *OIDCProvider) RequiresPKCE() bool {
+	return p.config.PKCE || slices.Contains(p.discovery.CodeChallengeMethodsSupported, "S256")
+}
+
+func (p *OIDCProvider) GetAuthURL(state string, opts ...oauth2.AuthCodeOption) string {
+	return p.GetConfig().AuthCodeURL(state, opts...)
+}
+
+func (p *OIDCProvider) Exchange(ctx context.Context, code string, opts ...oauth2.AuthCodeOption) (*oauth2.Token, error) {
+	return p.GetConfig().Exchange(ctx, code, opts...)
+}
+
+func (p *OIDCProvider) GetUserInfo(ctx context.Context, token *oauth2.Token) (*models.OAuth2UserInfo, error) {
+	if p.discovery.UserInfoEndpoint == "" {
+		return nil, fmt.Errorf("issuer %s has no user info endpoint", p.config.Issuer)
+	}
+
+	client := oauth2.NewClient(ctx, oauth2.StaticTokenSource(token))
+	resp, err := client.Get(p.discovery.UserInfoEndpoint)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("oidc user info returned status: %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var data map[string]any
+	if err := json.Unmarshal(body, &data); err != nil {
+		return nil, err
+	}
+
+	return mapClaims(data, p.config.ClaimPaths), nil
+}
+
+// VerifyIDToken checks the signature of an ID token against the issuer's JWKS, then its issuer,
+// audience, expiry and nonce, and returns the user info read from its claims.
+func (p *OIDCProvider) VerifyIDToken(ctx context.Context, rawIDToken string, nonce string) (*models.OAuth2UserInfo, error) {
+	header, err := jose.ParseHeader(rawIDToken)
+	if err != nil {
+		return nil, err
+	}
+	// Symmetric algorithms would let anyone holding the client secret forge tokens
+	if header.Algorithm != jose.AlgRS256 && header.Algorithm != jose.AlgES256 && header.Algorithm != jose.AlgEdDSA {
+		return nil, fmt.Errorf("%w: %s", jose.ErrUnsupportedAlgorithm, header.Algorithm)
+	}
+
+	key, err := p.getKey(header.KeyID, header.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := jose.Verify(rawIDToken, header.Algorithm, key)
+	if err != nil {
+		return nil, err
+	}
+
+	// Multi-tenant issuers, such as Microsoft's "common" one, publish their issuer with a tenant placeholder
+	expectedIssuer := p.discovery.Issuer
+	if tenantID, ok := claims["tid"].(string); ok && tenantID != "" {
+		expectedIssuer = strings.ReplaceAll(expectedIssuer, "{tenantid}", tenantID)
+	}
+	if iss, _ := claims["iss"].(string); iss != expectedIssuer && !slices.Contains(p.issuerAliases, iss) {
+		return nil, fmt.Errorf("unexpected id token issuer %q", iss)
+	}
+
+	// Native apps have client IDs of their own, which are accepted along with the configured one
+	clientIDs := append([]string{p.config.ClientID}, p.config.Audiences...)
+	audiences := claimAudiences(claims)
+	if !slices.ContainsFunc(audiences, func(aud string) bool { return slices.Contains(clientIDs, aud) }) {
+		return nil, errors.New("id token was not issued for this client")
+	}
+	if azp, ok := claims["azp"].(string); ok && !slices.Contains(clientIDs, azp) {
+		return nil, fmt.Errorf("id token was authorized for another party %q", azp)
+	}
+	if len(audiences) > 1 && claims["azp"] == nil {
+		return nil, errors.New("id token with multiple audiences has no authorized party")
+	}
+
+	now := time.Now()
+	exp, ok := jose.NumericDate(claims, "exp")
+	if !ok || now.After(exp.Add(oidcClockSkew)) {
+		return nil, errors.New("id token expired")
+	}
+	if iat, ok := jose.NumericDate(claims, "iat"); ok && iat.After(now.Add(oidcClockSkew)) {
+		return nil, errors.New("id token issued in the future")
+	}
+
+	if tokenNonce, _ := claims["nonce"].(string); tokenNonce != nonce {
+		return nil, errors.New("id token nonce mismatch")
+	}
+
+	return mapClaims(claims, p.config.ClaimPaths), nil
+}
+
+// getKey returns the issuer's public key with the given ID. The key set is fetched again when the ID is
+// unknown, as issuers rotate their keys.
+func (p *OIDCProvider) getKey(keyID string, alg string) (crypto.PublicKey, error) {
+	jwk, err := findKey(p.discovery.JWKSURI, keyID, false)
+	if errors.Is(err, errKeyNotFound) {
+		jwk, err = findKey(p.discovery.JWKSURI, keyID, true)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if jwk.Algorithm != "" && jwk.Algorithm != alg {
+		return nil, fmt.Errorf("%w: key %q is not used with %s", jose.ErrUnsupportedAlgorithm, keyID, alg)
+	}
+
+	return jose.ParseJWK(jwk)
+}
+
+var errKeyNotFound = errors.New("id token signing key not found")
+
+// findKey looks a key up in the cached key set. Without a key ID the set must hold a single key.
+func findKey(jwksURI string, keyID string, refresh bool) (models.JSONWebKey, error) {
+	oidcCache.mu.Lock()
+	cached, ok := oidcCache.keySets[jwksURI]
+	oidcCache.mu.Unlock()
+
+	stale := !ok || time.Since(cached.fetchedAt) > oidcCacheTTL
+	if stale || (refresh && time.Since(cached.fetchedAt) > oidcKeyRefreshInterval) {
+		var keySet models.JSONWebKeySet
+		if err := fetchJSON(jwksURI, &keySet); err != nil {
+			return models.JSONWebKey{}, fmt.Errorf("fetch jwks: %w", err)
+		}
+
+		cached = cachedKeySet{keys: make(map[string]models.JSONWebKey), fetchedAt: time.Now()}
+		for _, key := range keySet.Keys {
+			if key.Use == "" || key.Use == "sig" {
+				cached.keys[key.KeyID] = key
+			}
+		}
+
+		oidcCache.mu.Lock()
+		oidcCache.keySets[jwksURI] = cached
+		oidcCache.mu.Unlock()
+	}
+
+	if keyID == "" && len(cached.keys) == 1 {
+		for _, key := range cached.keys {
+			return key, nil
+		}
+	}
+	key, ok := cached.keys[keyID]
+	if !ok {
+		return models.JSONWebKey{}, fmt.Errorf("%w: %q", errKeyNotFound, keyID)
+	}
+	return key, nil
+}
+
+// discover returns the cached discovery document of an issuer, fetching it when missing or stale.
+// Failed fetches are cached with an exponential backoff. A stale document keeps being served while its
+// issuer is unreachable.
+func discover(issuer string) (*models.OIDCDiscoveryDocument, error) {
+	if issuer == "" {
+		return nil, errors.New("oidc provider requires an issuer")
+	}
+
+	oidcCache.mu.Lock()
+	cached := oidcCache.discovery[issuer]
+	oidcCache.mu.Unlock()
+
+	now := time.Now()
+	if cached.document != nil && now.Sub(cached.fetchedAt) < oidcCacheTTL {
+		return cached.document, nil
+	}
+	if cached.err != nil && now.Before(cached.retryAt) {
+		if cached.document != nil {
+			return cached.document, nil
+		}
+		return nil, cached.err
+	}
+
+	document, err := fetchDiscovery(issuer)
+	if err != nil {
+		cached.err = err
+		// Capped so that the shifted backoff cannot overflow
+		cached.failures = min(cached.failures+1, 10)
+		cached.retryAt = now.Add(min(oidcDiscoveryBackoff<<(cached.failures-1), oidcDiscoveryMaxBackoff))
+	} else {
+		cached = cachedDiscovery{document: document, fetchedAt: now}
+	}
+
+	oidcCache.mu.Lock()
+	oidcCache.discovery[issuer] = cached
+	oidcCache.mu.Unlock()
+
+	if err != nil && cached.document == nil {
+		return nil, err
+	}
+	return cached.document, nil
+}
+
+func fetchDiscovery(issuer string) (*models.OIDCDiscoveryDocument, error) {
+	var document models.OIDCDiscoveryDocument
+	if err := fetchJSON(strings.TrimSuffix(issuer, "/")+"/.well-known/openid-configuration", &document); err != nil {
+		return nil, fmt.Errorf("fetch discovery document of %s: %w", issuer, err)
+	}
+
+	// The issuer must match exactly to prevent one issuer from impersonating another (OpenID Connect Discovery section 4.3)
+	if strings.TrimSuffix(document.Issuer, "/") != strings.TrimSuffix(issuer, "/") {
+		return nil, fmt.Errorf("discovery document issuer %q does not match %q", document.Issuer, issuer)
+	}
+	if document.AuthorizationEndpoint == "" || document.TokenEndpoint == "" || document.JWKSURI == "" {
+		return nil, fmt.Errorf("discovery document of %s is missing required endpoints", issuer)
+	}
+
+	return &document, nil
+}
+
+func fetchJSON(url string, target any) error {
+	resp, err := oidcHTTPClient.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status: %d", resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(target)
+}
+
+// claimAudiences returns the aud claim, which is either a single string or an array of strings.
+func claimAudiences(claims map[string]any) []string {
+	switch aud := claims["aud"].(type) {
+	case string:
+		return []string{aud}
+	case []any:
+		audiences := make([]string, 0, len(aud))
+		for _, value := range aud {
+			if s, ok := value.(string); ok {
+				audiences = append(audiences, s)
+			}
+		}
+		return audiences
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,175 @@
+package oauth2providers
+
+import (
+	"context"
+	"crypto"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/GoBetterAuth/go-better-auth/internal/jose"
+	"github.com/GoBetterAuth/go-better-auth/models"
+)
+
+type testIssuer struct {
+	server *httptest.Server
+	key    crypto.Signer
+}
+
+func newTestIssuer(t *testing.T) *testIssuer {
+	t.Helper()
+
+	key, err := jose.GenerateKey(jose.AlgRS256)
+	if err != nil {
+		t.Fatalf("GenerateKey failed: %v", err)
+	}
+	jwk, err := jose.PublicJWK("kid-1", jose.AlgRS256, key.Public())
+	if err != nil {
+		t.Fatalf("PublicJWK failed: %v", err)
+	}
+
+	issuer := &testIssuer{key: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(models.OIDCDiscoveryDocument{
+			Issuer:                        issuer.server.URL,
+			AuthorizationEndpoint:         issuer.server.URL + "/authorize",
+			TokenEndpoint:                 issuer.server.URL + "/token",
+			UserInfoEndpoint:              issuer.server.URL + "/userinfo",
+			JWKSURI:                       issuer.server.URL + "/jwks",
+			CodeChallengeMethodsSupported: []string{"S256"},
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(models.JSONWebKeySet{Keys: []models.JSONWebKey{jwk}})
+	})
+	issuer.server = httptest.NewServer(mux)
+	t.Cleanup(issuer.server.Close)
+
+	return issuer
+}
+
+func (i *testIssuer) sign(t *testing.T, key crypto.Signer, claims map[string]any) string {
+	t.Helper()
+
+	token, err := jose.Sign(jose.AlgRS256, "kid-1", key, claims)
+	if err != nil {
+		t.Fatalf("Sign failed: %v", err)
+	}
+	return token
+}
+
+func (i *testIssuer) claims(overrides map[string]any) map[string]any {
+	claims := map[string]any{
+		"iss":            i.server.URL,
+		"aud":            "client-1",
+		"sub":            "user-1",
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"iat":            time.Now().Unix(),
+		"nonce":          "nonce-1",
+		"email":          "user@example.com",
+		"email_verified": true,
+		"name":           "User",
+	}
+	for name, value := range overrides {
+		claims[name] = value
+	}
+	return claims
+}
+
+func TestOIDCProvider_Discovery(t *testing.T) {
+	issuer := newTestIssuer(t)
+
+	provider, err := NewOIDCProvider("test", &models.OAuth2ProviderConfig{Issuer: issuer.server.URL, ClientID: "client-1"})
+	if err != nil {
+		t.Fatalf("NewOIDCProvider failed: %v", err)
+	}
+
+	config := provider.GetConfig()
+	if config.Endpoint.AuthURL != issuer.server.URL+"/authorize" || config.Endpoint.TokenURL != issuer.server.URL+"/token" {
+		t.Fatalf("unexpected endpoints: %+v", config.Endpoint)
+	}
+	if len(config.Scopes) != 3 {
+		t.Fatalf("expected default scopes, got %v", config.Scopes)
+	}
+	if !provider.RequiresPKCE() {
+		t.Fatal("expected PKCE to be used when the issuer supports S256")
+	}
+
+	if _, err := NewOIDCProvider("test", &models.OAuth2ProviderConfig{Issuer: issuer.server.URL + "/other"}); err == nil {
+		t.Fatal("expected an issuer without discovery document to be rejected")
+	}
+}
+
+func TestOIDCProvider_VerifyIDToken(t *testing.T) {
+	issuer := newTestIssuer(t)
+	otherKey, _ := jose.GenerateKey(jose.AlgRS256)
+
+	provider, err := NewOIDCProvider("test", &models.OAuth2ProviderConfig{Issuer: issuer.server.URL, ClientID: "client-1"})
+	if err != nil {
+		t.Fatalf("NewOIDCProvider failed: %v", err)
+	}
+
+	userInfo, err := provider.VerifyIDToken(context.Background(), issuer.sign(t, issuer.key, issuer.claims(nil)), "nonce-1")
+	if err != nil {
+		t.Fatalf("VerifyIDToken failed: %v", err)
+	}
+	if userInfo.ID != "user-1" || userInfo.Email != "user@example.com" || !userInfo.Verified || userInfo.Name != "User" {
+		t.Fatalf("unexpected user info: %+v", userInfo)
+	}
+
+	nativeProvider, _ := NewOIDCProvider("test", &models.OAuth2ProviderConfig{
+		Issuer:    issuer.server.URL,
+		ClientID:  "client-1",
+		Audiences: []string{"android-client"},
+	})
+	nativeToken := issuer.sign(t, issuer.key, issuer.claims(map[string]any{"aud": "android-client", "nonce": nil}))
+	if _, err := nativeProvider.VerifyIDToken(context.Background(), nativeToken, ""); err != nil {
+		t.Fatalf("expected id token of a native app to be accepted, got %v", err)
+	}
+	if _, err := provider.VerifyIDToken(context.Background(), nativeToken, ""); err == nil {
+		t.Fatal("expected id token of an unknown native app to be rejected")
+	}
+
+	tests := []struct {
+		name  string
+		token string
+		nonce string
+	}{
+		{"wrong nonce", issuer.sign(t, issuer.key, issuer.claims(nil)), "nonce-2"},
+		{"wrong audience", issuer.sign(t, issuer.key, issuer.claims(map[string]any{"aud": "client-2"})), "nonce-1"},
+		{"multiple audiences without azp", issuer.sign(t, issuer.key, issuer.claims(map[string]any{"aud": []string{"client-1", "client-2"}})), "nonce-1"},
+		{"wrong issuer", issuer.sign(t, issuer.key, issuer.claims(map[string]any{"iss": "https://other.example.com"})), "nonce-1"},
+		{"expired", issuer.sign(t, issuer.key, issuer.claims(map[string]any{"exp": time.Now().Add(-time.Hour).Unix()})), "nonce-1"},
+		{"foreign key", issuer.sign(t, otherKey, issuer.claims(nil)), "nonce-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := provider.VerifyIDToken(context.Background(), tt.token, tt.nonce); err == nil {
+				t.Fatal("expected id token to be rejected")
+			}
+		})
+	}
+}
+
+func TestMapClaims_Paths(t *testing.T) {
+	claims := map[string]any{
+		"user_id": float64(12345),
+		"profile": map[string]any{
+			"mail":     "user@example.com",
+			"verified": "true",
+		},
+		"name": "User",
+	}
+
+	userInfo := mapClaims(claims, models.OAuth2ClaimPaths{
+		ID:            "user_id",
+		Email:         "profile.mail",
+		EmailVerified: "profile.verified",
+	})
+	if userInfo.ID != "12345" || userInfo.Email != "user@example.com" || !userInfo.Verified || userInfo.Name != "User" {
+		t.Fatalf("unexpected user info: %+v", userInfo)
+	}
+}
@@ -17,3 +17,8 @@ type OAuth2Provider interface {
 	Exchange(ctx context.Context, code string, opts ...oauth2.AuthCodeOption) (*oauth2.Token, error)
 	GetUserInfo(ctx context.Context, token *oauth2.Token) (*models.OAuth2UserInfo, error)
 }
+
+// IDTokenVerifier is implemented by OpenID Connect providers, which sign users in from verified ID tokens.
+type IDTokenVerifier interface {
+	VerifyIDToken(ctx context.Context, rawIDToken string, nonce string) (*models.OAuth2UserInfo, error)
+}